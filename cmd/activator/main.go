@@ -37,6 +37,7 @@ import (
 	"knative.dev/serving/pkg/activator"
 	"knative.dev/serving/pkg/http/handler"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 
@@ -84,6 +85,13 @@ type config struct {
 	// TODO: run loadtests using these flags to determine optimal default values.
 	MaxIdleProxyConns        int `split_words:"true" default:"1000"`
 	MaxIdleProxyConnsPerHost int `split_words:"true" default:"100"`
+
+	// NodeName is used to look up the topology zone of this activator.
+	NodeName string `split_words:"true"`
+	// ZoneSpillThreshold is the fraction of the capacity of the pods in the zone
+	// of this activator that may be in use before requests are sent to the pods
+	// in the other zones. Zero disables zone-aware load balancing.
+	ZoneSpillThreshold float64 `split_words:"true" default:"0"`
 }
 
 func main() {
@@ -173,7 +181,16 @@ func main() {
 	}
 
 	// Start throttler.
-	throttler := activatornet.NewThrottler(ctx, env.PodIP)
+	var throttlerOpts []activatornet.ThrottlerOption
+	if env.ZoneSpillThreshold > 0 {
+		zone, err := nodeZone(ctx, env.NodeName)
+		if err != nil {
+			logger.Fatalw("Failed to determine the zone of this activator", zap.Error(err))
+		}
+		logger.Infof("Zone-aware load balancing is enabled for zone %q with spill threshold %v", zone, env.ZoneSpillThreshold)
+		throttlerOpts = append(throttlerOpts, activatornet.WithZoneAwareLB(zone, env.ZoneSpillThreshold))
+	}
+	throttler := activatornet.NewThrottler(ctx, env.PodIP, throttlerOpts...)
 	go throttler.Run(ctx, transport, networkConfig.EnableMeshPodAddressability, networkConfig.MeshCompatibilityMode)
 
 	oct := tracing.NewOpenCensusTracer(tracing.WithExporterFull(networking.ActivatorServiceName, env.PodIP, logger))
@@ -262,10 +279,14 @@ func main() {
 		logger.Fatalw("Failed to start configuration manager", zap.Error(err))
 	}
 
+	// The debug endpoints are unauthenticated, so they are only served on
+	// localhost and reached through `kubectl port-forward`.
+	adminAddr := "localhost:" + strconv.Itoa(networking.ActivatorAdminPort)
 	servers := map[string]*http.Server{
 		"http1":   pkgnet.NewServer(":"+strconv.Itoa(networking.BackendHTTPPort), ah),
 		"h2c":     pkgnet.NewServer(":"+strconv.Itoa(networking.BackendHTTP2Port), ah),
 		"profile": profiling.NewServer(profilingHandler),
+		"admin":   pkgnet.NewServer(adminAddr, adminHandler(throttler, logger)),
 	}
 
 	errCh := make(chan error, len(servers))
@@ -316,6 +337,22 @@ func main() {
 	logger.Info("Servers shutdown.")
 }
 
+// nodeZone returns the topology zone of the given node.
+func nodeZone(ctx context.Context, nodeName string) (string, error) {
+	if nodeName == "" {
+		return "", errors.New("NODE_NAME must be set for zone-aware load balancing")
+	}
+	node, err := kubeclient.Get(ctx).CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch node %s: %w", nodeName, err)
+	}
+	zone := node.Labels[corev1.LabelTopologyZone]
+	if zone == "" {
+		return "", fmt.Errorf("node %s has no %s label", nodeName, corev1.LabelTopologyZone)
+	}
+	return zone, nil
+}
+
 func newHealthCheck(sigCtx context.Context, logger *zap.SugaredLogger, statSink *websocket.ManagedConnection) func() error {
 	once := sync.Once{}
 	return func() error {
@@ -339,3 +376,12 @@ func flush(logger *zap.SugaredLogger) {
 	os.Stderr.Sync()
 	metrics.FlushExporter()
 }
+
+func adminHandler(throttler *activatornet.Throttler, logger *zap.SugaredLogger) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle(activatorhandler.ThrottlerStatePath, &activatorhandler.ThrottlerStateHandler{
+		Throttler: throttler,
+		Logger:    logger,
+	})
+	return mux
+}
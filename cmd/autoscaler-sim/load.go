@@ -0,0 +1,184 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	asmetrics "knative.dev/serving/pkg/autoscaler/metrics"
+)
+
+// statSource produces the stats fed into the simulated autoscaler.
+type statSource interface {
+	// start is the time the first stats are produced at.
+	start() time.Time
+	// end is the time the last stats are produced at.
+	end() time.Time
+	// stats returns the stats produced in the second ending at now.
+	stats(now time.Time) []asmetrics.Stat
+}
+
+// loadPoint is the request rate at an offset from the start of a profile.
+type loadPoint struct {
+	at  time.Duration
+	rps float64
+}
+
+// loadProfile is a piecewise linear request rate over time.
+type loadProfile []loadPoint
+
+// parseLoadProfile parses a profile of the form `0s=0,1m=100,5m=100,6m=0`,
+// i.e. a comma separated list of offsets from the start and the request rate
+// at that offset. The rate is interpolated linearly between the points and
+// stays constant after the last one.
+func parseLoadProfile(s string) (loadProfile, error) {
+	var p loadProfile
+	for _, part := range strings.Split(s, ",") {
+		at, rate, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid load point %q, want offset=rps", part)
+		}
+		d, err := time.ParseDuration(at)
+		if err != nil {
+			return nil, fmt.Errorf("invalid offset in load point %q: %w", part, err)
+		}
+		rps, err := strconv.ParseFloat(rate, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate in load point %q: %w", part, err)
+		}
+		if d < 0 || rps < 0 {
+			return nil, fmt.Errorf("load point %q must not be negative", part)
+		}
+		if len(p) > 0 && d <= p[len(p)-1].at {
+			return nil, fmt.Errorf("load point %q must come after %v", part, p[len(p)-1].at)
+		}
+		p = append(p, loadPoint{at: d, rps: rps})
+	}
+	return p, nil
+}
+
+// rateAt returns the request rate at offset d.
+func (p loadProfile) rateAt(d time.Duration) float64 {
+	i := sort.Search(len(p), func(i int) bool { return p[i].at > d })
+	switch {
+	case i == 0:
+		return p[0].rps
+	case i == len(p):
+		return p[len(p)-1].rps
+	}
+	from, to := p[i-1], p[i]
+	return from.rps + (to.rps-from.rps)*float64(d-from.at)/float64(to.at-from.at)
+}
+
+// syntheticSource produces the stats of a load profile, as reported by a
+// single pod. The concurrency follows from the request rate and latency.
+type syntheticSource struct {
+	profile loadProfile
+	latency time.Duration
+	begin   time.Time
+}
+
+func (s *syntheticSource) start() time.Time {
+	return s.begin
+}
+
+func (s *syntheticSource) end() time.Time {
+	return s.begin.Add(s.profile[len(s.profile)-1].at)
+}
+
+func (s *syntheticSource) stats(now time.Time) []asmetrics.Stat {
+	rps := s.profile.rateAt(now.Sub(s.begin))
+	return []asmetrics.Stat{{
+		PodName:                   "synthetic",
+		AverageConcurrentRequests: rps * s.latency.Seconds(),
+		RequestCount:              rps,
+		Timestamp:                 now.Unix(),
+	}}
+}
+
+// replaySource produces the stats of a recording, at the times they were
+// recorded at.
+type replaySource struct {
+	recorded []asmetrics.Stat
+	next     int
+}
+
+// newReplaySource reads the stats of the revision rev from the recording in r.
+// If rev is empty, the recording must contain the stats of a single revision.
+func newReplaySource(r io.Reader, rev types.NamespacedName) (*replaySource, types.NamespacedName, error) {
+	src := &replaySource{}
+	single := rev.Name == ""
+	reader := asmetrics.NewWireStatMessagesReader(r)
+	for {
+		wsms, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, rev, err
+		}
+		for _, wsm := range wsms.Messages {
+			if wsm == nil || wsm.Stat == nil {
+				continue
+			}
+			sm := wsm.ToStatMessage()
+			if rev.Name == "" {
+				rev = sm.Key
+			}
+			if sm.Key != rev {
+				if single {
+					return nil, rev, fmt.Errorf("recording contains stats of several revisions, e.g. %s and %s", rev, sm.Key)
+				}
+				continue
+			}
+			if sm.Stat.Timestamp == 0 {
+				return nil, rev, fmt.Errorf("stat of pod %q has no timestamp", sm.Stat.PodName)
+			}
+			src.recorded = append(src.recorded, sm.Stat)
+		}
+	}
+	if len(src.recorded) == 0 {
+		return nil, rev, fmt.Errorf("recording contains no stats for revision %q", rev)
+	}
+	sort.SliceStable(src.recorded, func(i, j int) bool {
+		return src.recorded[i].Timestamp < src.recorded[j].Timestamp
+	})
+	return src, rev, nil
+}
+
+func (s *replaySource) start() time.Time {
+	return time.Unix(s.recorded[0].Timestamp, 0)
+}
+
+func (s *replaySource) end() time.Time {
+	return time.Unix(s.recorded[len(s.recorded)-1].Timestamp, 0)
+}
+
+func (s *replaySource) stats(now time.Time) []asmetrics.Stat {
+	from := s.next
+	for s.next < len(s.recorded) && s.recorded[s.next].Timestamp <= now.Unix() {
+		s.next++
+	}
+	return s.recorded[from:s.next]
+}
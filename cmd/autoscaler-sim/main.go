@@ -0,0 +1,262 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// The autoscaler-sim command simulates the autoscaling of a revision offline.
+// It drives the autoscaler with either a recording of the stats of a revision
+// or a synthetic load profile, and prints the resulting scale over time.
+//
+// Examples:
+//
+//	autoscaler-sim -load=0s=0,1m=500,10m=500,11m=0 -request-latency=200ms \
+//	  -config=config-autoscaler.yaml -annotation=autoscaling.knative.dev/target=50
+//
+//	autoscaler-sim -replay=stats.rec -revision=default/hello-00001 -format=json
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
+
+	"knative.dev/pkg/logging"
+	"knative.dev/serving/pkg/apis/autoscaling"
+	autoscalingv1alpha1 "knative.dev/serving/pkg/apis/autoscaling/v1alpha1"
+	asconfig "knative.dev/serving/pkg/autoscaler/config"
+	"knative.dev/serving/pkg/autoscaler/config/autoscalerconfig"
+)
+
+// annotations is a flag.Value collecting repeated key=value flags.
+type annotations map[string]string
+
+func (a annotations) String() string {
+	parts := make([]string, 0, len(a))
+	for k, v := range a {
+		parts = append(parts, k+"="+v)
+	}
+	return strings.Join(parts, ",")
+}
+
+func (a annotations) Set(s string) error {
+	k, v, ok := strings.Cut(s, "=")
+	if !ok || k == "" {
+		return fmt.Errorf("invalid annotation %q, want key=value", s)
+	}
+	a[k] = v
+	return nil
+}
+
+type options struct {
+	configPath           string
+	annotations          annotations
+	containerConcurrency int64
+	replayPath           string
+	revision             string
+	load                 string
+	requestLatency       time.Duration
+	duration             time.Duration
+	podStartupDelay      time.Duration
+	format               string
+	summaryOnly          bool
+	verbose              bool
+}
+
+func main() {
+	opts := options{annotations: annotations{}}
+	flag.StringVar(&opts.configPath, "config", "", "Path to a config-autoscaler ConfigMap. The defaults are used if unset.")
+	flag.Var(opts.annotations, "annotation", "An autoscaling annotation of the revision, as key=value. May be repeated.")
+	flag.Int64Var(&opts.containerConcurrency, "container-concurrency", 0, "The containerConcurrency of the revision.")
+	flag.StringVar(&opts.replayPath, "replay", "", "Path to a recording of stats, as length-delimited WireStatMessages.")
+	flag.StringVar(&opts.revision, "revision", "", "The namespace/name of the revision to replay the stats of. "+
+		"Required if the recording contains the stats of several revisions.")
+	flag.StringVar(&opts.load, "load", "", "A synthetic load profile in requests per second, e.g. 0s=0,1m=100,5m=100,6m=0. "+
+		"The rate is interpolated linearly between the points.")
+	flag.DurationVar(&opts.requestLatency, "request-latency", time.Second, "The latency of the requests of the synthetic load, "+
+		"which determines the concurrency for a request rate.")
+	flag.DurationVar(&opts.duration, "duration", 0, "How long to simulate for. Defaults to the end of the load profile "+
+		"or recording plus the stable window.")
+	flag.DurationVar(&opts.podStartupDelay, "pod-startup-delay", 5*time.Second, "How long new pods take to become ready.")
+	flag.StringVar(&opts.format, "format", formatCSV, "The output format, csv or json. "+
+		"With csv the samples are printed to stdout and the summary to stderr.")
+	flag.BoolVar(&opts.summaryOnly, "summary-only", false, "Only print the summary.")
+	flag.BoolVar(&opts.verbose, "v", false, "Log the decisions of the autoscaler to stderr.")
+	flag.Parse()
+
+	if err := run(context.Background(), opts, os.Stdout, os.Stderr); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(ctx context.Context, opts options, stdout, stderr io.Writer) error {
+	if opts.format != formatCSV && opts.format != formatJSON {
+		return fmt.Errorf("unknown format %q, want %s or %s", opts.format, formatCSV, formatJSON)
+	}
+
+	config, err := loadConfig(opts.configPath)
+	if err != nil {
+		return err
+	}
+
+	source, rev, err := makeSource(opts)
+	if err != nil {
+		return err
+	}
+
+	pa, err := makePodAutoscaler(ctx, rev, opts, config)
+	if err != nil {
+		return err
+	}
+
+	duration := opts.duration
+	if duration == 0 {
+		duration = source.end().Sub(source.start()) + config.StableWindow
+		if w, ok := pa.Window(); ok {
+			duration = source.end().Sub(source.start()) + w
+		}
+	}
+
+	logger := zap.NewNop().Sugar()
+	if opts.verbose {
+		logger, _ = logging.NewLogger("", "debug")
+	}
+
+	sim := &simulation{
+		pa:              pa,
+		config:          config,
+		source:          source,
+		duration:        duration,
+		podStartupDelay: opts.podStartupDelay,
+		logger:          logger,
+	}
+	samples, err := sim.run(ctx)
+	if err != nil {
+		return err
+	}
+	sum := summarize(samples)
+
+	switch {
+	case opts.format == formatJSON:
+		if opts.summaryOnly {
+			samples = nil
+		}
+		return writeJSON(stdout, samples, sum)
+	case opts.summaryOnly:
+		return writeSummary(stdout, sum)
+	default:
+		if err := writeSamples(stdout, samples); err != nil {
+			return err
+		}
+		return writeSummary(stderr, sum)
+	}
+}
+
+// loadConfig reads the config-autoscaler ConfigMap at path.
+func loadConfig(path string) (*autoscalerconfig.Config, error) {
+	if path == "" {
+		return asconfig.NewConfigFromMap(nil)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+	cm := &corev1.ConfigMap{}
+	if err := yaml.Unmarshal(b, cm); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+	config, err := asconfig.NewConfigFromConfigMap(cm)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// makeSource creates the source of stats from either the recording or the
+// load profile in opts, and returns the revision to simulate.
+func makeSource(opts options) (statSource, types.NamespacedName, error) {
+	var rev types.NamespacedName
+	if opts.revision != "" {
+		ns, name, ok := strings.Cut(opts.revision, "/")
+		if !ok || ns == "" || name == "" {
+			return nil, rev, fmt.Errorf("invalid revision %q, want namespace/name", opts.revision)
+		}
+		rev = types.NamespacedName{Namespace: ns, Name: name}
+	}
+
+	switch {
+	case opts.replayPath != "" && opts.load != "":
+		return nil, rev, errors.New("only one of -replay and -load can be set")
+	case opts.replayPath != "":
+		f, err := os.Open(opts.replayPath)
+		if err != nil {
+			return nil, rev, fmt.Errorf("failed to open recording: %w", err)
+		}
+		defer f.Close()
+		src, rev, err := newReplaySource(f, rev)
+		if err != nil {
+			return nil, rev, fmt.Errorf("failed to read recording %s: %w", opts.replayPath, err)
+		}
+		return src, rev, nil
+	case opts.load != "":
+		profile, err := parseLoadProfile(opts.load)
+		if err != nil {
+			return nil, rev, err
+		}
+		if rev.Name == "" {
+			rev = types.NamespacedName{Namespace: "default", Name: "simulated"}
+		}
+		return &syntheticSource{
+			profile: profile,
+			latency: opts.requestLatency,
+			begin:   time.Unix(0, 0),
+		}, rev, nil
+	default:
+		return nil, rev, errors.New("one of -replay and -load must be set")
+	}
+}
+
+// makePodAutoscaler returns the PodAutoscaler of the simulated revision.
+func makePodAutoscaler(ctx context.Context, rev types.NamespacedName, opts options,
+	config *autoscalerconfig.Config) (*autoscalingv1alpha1.PodAutoscaler, error) {
+	if err := autoscaling.ValidateAnnotations(ctx, config, opts.annotations); err != nil {
+		return nil, fmt.Errorf("invalid annotations: %w", err)
+	}
+	pa := &autoscalingv1alpha1.PodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   rev.Namespace,
+			Name:        rev.Name,
+			Annotations: opts.annotations,
+		},
+		Spec: autoscalingv1alpha1.PodAutoscalerSpec{
+			ContainerConcurrency: opts.containerConcurrency,
+			Reachability:         autoscalingv1alpha1.ReachabilityReachable,
+		},
+	}
+	if class := pa.Class(); class != autoscaling.KPA {
+		return nil, fmt.Errorf("only the %s class can be simulated, got %s", autoscaling.KPA, class)
+	}
+	return pa, nil
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+const (
+	formatCSV  = "csv"
+	formatJSON = "json"
+)
+
+var csvHeader = []string{
+	"time", "valid", "observed_stable", "observed_panic", "panic_mode",
+	"excess_burst_capacity", "ready_pods", "desired_pods",
+}
+
+// writeSamples writes the samples as CSV, one row per sample.
+func writeSamples(w io.Writer, samples []sample) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	formatFloat := func(f float64) string { return strconv.FormatFloat(f, 'f', -1, 64) }
+	for _, s := range samples {
+		if err := cw.Write([]string{
+			formatFloat(s.Time),
+			strconv.FormatBool(s.Valid),
+			formatFloat(s.ObservedStableValue),
+			formatFloat(s.ObservedPanicValue),
+			strconv.FormatBool(s.PanicMode),
+			strconv.Itoa(int(s.ExcessBurstCapacity)),
+			strconv.Itoa(s.ReadyPods),
+			strconv.Itoa(int(s.DesiredPods)),
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeSummary writes the summary as a list of `key: value` lines.
+func writeSummary(w io.Writer, sum summary) error {
+	_, err := fmt.Fprintf(w, `duration: %.0fs
+max desired pods: %d
+mean desired pods: %.2f
+mean ready pods: %.2f
+ready pod seconds: %.0f
+scale ups: %d
+scale downs: %d
+panic: %.0fs
+activator in path: %.0fs
+`, sum.DurationSeconds, sum.MaxDesiredPods, sum.MeanDesiredPods, sum.MeanReadyPods,
+		sum.ReadyPodSeconds, sum.ScaleUps, sum.ScaleDowns, sum.PanicSeconds, sum.ActivatorSeconds)
+	return err
+}
+
+// writeJSON writes the samples and the summary as a single JSON document.
+func writeJSON(w io.Writer, samples []sample, sum summary) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		Samples []sample `json:"samples"`
+		Summary summary  `json:"summary"`
+	}{samples, sum})
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
+	clocktest "k8s.io/utils/clock/testing"
+
+	autoscalingv1alpha1 "knative.dev/serving/pkg/apis/autoscaling/v1alpha1"
+	asconfig "knative.dev/serving/pkg/autoscaler/config"
+	"knative.dev/serving/pkg/autoscaler/config/autoscalerconfig"
+	asmetrics "knative.dev/serving/pkg/autoscaler/metrics"
+	"knative.dev/serving/pkg/autoscaler/scaling"
+	kparesources "knative.dev/serving/pkg/reconciler/autoscaling/kpa/resources"
+	aresources "knative.dev/serving/pkg/reconciler/autoscaling/resources"
+)
+
+// scaleTickInterval is how often the autoscaler is evaluated. It matches the
+// interval the MultiScaler uses.
+const scaleTickInterval = 2 * time.Second
+
+// simulation drives the autoscaler of a single revision with stats from
+// source, on a fake clock.
+type simulation struct {
+	pa     *autoscalingv1alpha1.PodAutoscaler
+	config *autoscalerconfig.Config
+	source statSource
+
+	// duration is how long to simulate for, from the start of source.
+	duration time.Duration
+	// podStartupDelay is how long new pods take to become ready.
+	podStartupDelay time.Duration
+
+	logger *zap.SugaredLogger
+}
+
+// sample is the outcome of a single evaluation of the autoscaler.
+type sample struct {
+	// Time is the number of seconds since the start of the simulation.
+	Time                float64 `json:"time"`
+	Valid               bool    `json:"valid"`
+	ObservedStableValue float64 `json:"observedStableValue"`
+	ObservedPanicValue  float64 `json:"observedPanicValue"`
+	PanicMode           bool    `json:"panicMode"`
+	ExcessBurstCapacity int32   `json:"excessBurstCapacity"`
+	ReadyPods           int     `json:"readyPods"`
+	// DesiredPods is the scale after applying the scale bounds. It is kept
+	// from the previous evaluation if this one is not Valid.
+	DesiredPods int32 `json:"desiredPods"`
+}
+
+// summary condenses the samples of a simulation.
+type summary struct {
+	DurationSeconds float64 `json:"durationSeconds"`
+	MaxDesiredPods  int32   `json:"maxDesiredPods"`
+	MeanDesiredPods float64 `json:"meanDesiredPods"`
+	MeanReadyPods   float64 `json:"meanReadyPods"`
+	ReadyPodSeconds float64 `json:"readyPodSeconds"`
+	ScaleUps        int     `json:"scaleUps"`
+	ScaleDowns      int     `json:"scaleDowns"`
+	PanicSeconds    float64 `json:"panicSeconds"`
+	// ActivatorSeconds is the time the excess burst capacity was negative,
+	// i.e. the activator would be in the request path.
+	ActivatorSeconds float64 `json:"activatorSeconds"`
+}
+
+// run runs the simulation and returns the sample of each evaluation of the
+// autoscaler.
+func (s *simulation) run(ctx context.Context) ([]sample, error) {
+	key := types.NamespacedName{Namespace: s.pa.Namespace, Name: s.pa.Name}
+	start := s.source.start()
+	clk := clocktest.NewFakeClock(start)
+
+	// There is nothing to scrape, the stats are recorded directly. The
+	// scrape target is set nonetheless so the collector treats the absence of
+	// stats like it does in the cluster. The collector runs on the fake clock,
+	// so that nothing depends on the real time.
+	collector := asmetrics.NewMetricCollectorWithClock(
+		func(*autoscalingv1alpha1.Metric, *zap.SugaredLogger) (asmetrics.StatsScraper, error) {
+			return nil, nil
+		}, clk, s.logger)
+	if err := collector.CreateOrUpdate(aresources.MakeMetric(s.pa, "simulated", s.config)); err != nil {
+		return nil, fmt.Errorf("failed to create metric collection: %w", err)
+	}
+	defer collector.Delete(key.Namespace, key.Name)
+
+	decider := kparesources.MakeDecider(s.pa, s.config)
+	// The pods of the initial scale are ready from the start.
+	pods := &simulatedPods{clock: clk}
+	desired := s.bound(decider.Spec.InitialScale)
+	pods.scale(int(desired))
+	pods.startupDelay = s.podStartupDelay
+
+	scaler := scaling.New(ctx, key.Namespace, key.Name, collector, pods, &decider.Spec)
+	explainer, _ := scaler.(scaling.Explainer)
+
+	var samples []sample
+	for elapsed := time.Duration(0); elapsed < s.duration; elapsed += asconfig.BucketSize {
+		now := start.Add(elapsed)
+		clk.SetTime(now)
+		for _, stat := range s.source.stats(now) {
+			collector.Record(key, now, stat)
+		}
+		if elapsed%scaleTickInterval != 0 {
+			continue
+		}
+
+		ready, _ := pods.ReadyCount()
+		sr := scaler.Scale(s.logger, now)
+		smpl := sample{
+			Time:      elapsed.Seconds(),
+			Valid:     sr.ScaleValid,
+			ReadyPods: ready,
+		}
+		if sr.ScaleValid {
+			desired = s.bound(sr.DesiredPodCount)
+			pods.scale(int(desired))
+			smpl.ExcessBurstCapacity = sr.ExcessBurstCapacity
+		}
+		if explainer != nil {
+			if exps := explainer.Explanations(); len(exps) > 0 && exps[len(exps)-1].Valid {
+				e := exps[len(exps)-1]
+				smpl.ObservedStableValue, smpl.ObservedPanicValue = e.ObservedStableValue, e.ObservedPanicValue
+				smpl.PanicMode = e.PanicMode
+			}
+		}
+		smpl.DesiredPods = desired
+		samples = append(samples, smpl)
+	}
+	return samples, nil
+}
+
+// bound applies the scale bounds of the revision to the autoscaler's
+// decision, like the PodAutoscaler reconciler does. The scale to zero grace
+// period is not simulated.
+func (s *simulation) bound(want int32) int32 {
+	if want == 0 && !s.config.EnableScaleToZero {
+		want = 1
+	}
+	min, max := s.pa.ScaleBounds(s.config)
+	if want < min {
+		want = min
+	}
+	if max > 0 && want > max {
+		want = max
+	}
+	return want
+}
+
+// summarize condenses the samples of a simulation.
+func summarize(samples []sample) summary {
+	var sum summary
+	if len(samples) == 0 {
+		return sum
+	}
+	tick := scaleTickInterval.Seconds()
+	var desiredTotal, readyTotal float64
+	for i, s := range samples {
+		if s.DesiredPods > sum.MaxDesiredPods {
+			sum.MaxDesiredPods = s.DesiredPods
+		}
+		desiredTotal += float64(s.DesiredPods)
+		readyTotal += float64(s.ReadyPods)
+		if s.PanicMode {
+			sum.PanicSeconds += tick
+		}
+		if s.Valid && s.ExcessBurstCapacity < 0 {
+			sum.ActivatorSeconds += tick
+		}
+		if i > 0 {
+			switch prev := samples[i-1].DesiredPods; {
+			case s.DesiredPods > prev:
+				sum.ScaleUps++
+			case s.DesiredPods < prev:
+				sum.ScaleDowns++
+			}
+		}
+	}
+	// Each sample stands for the tick following it.
+	sum.DurationSeconds = float64(len(samples)) * tick
+	sum.MeanDesiredPods = desiredTotal / float64(len(samples))
+	sum.MeanReadyPods = readyTotal / float64(len(samples))
+	sum.ReadyPodSeconds = readyTotal * tick
+	return sum
+}
+
+// simulatedPods is the set of pods of the simulated revision. Pods become
+// ready a fixed delay after they are created.
+type simulatedPods struct {
+	clock        clock.PassiveClock
+	startupDelay time.Duration
+	// readyAt holds the time each pod becomes ready at, in creation order.
+	readyAt []time.Time
+}
+
+// ReadyCount implements resources.EndpointsCounter.
+func (p *simulatedPods) ReadyCount() (int, error) {
+	now, ready := p.clock.Now(), 0
+	for _, t := range p.readyAt {
+		if !t.After(now) {
+			ready++
+		}
+	}
+	return ready, nil
+}
+
+// NotReadyCount implements resources.EndpointsCounter.
+func (p *simulatedPods) NotReadyCount() (int, error) {
+	ready, _ := p.ReadyCount()
+	return len(p.readyAt) - ready, nil
+}
+
+// scale creates or removes pods to have want of them. The newest pods, which
+// are the least likely to be ready, are removed first.
+func (p *simulatedPods) scale(want int) {
+	now := p.clock.Now()
+	for len(p.readyAt) < want {
+		p.readyAt = append(p.readyAt, now.Add(p.startupDelay))
+	}
+	p.readyAt = p.readyAt[:want]
+}
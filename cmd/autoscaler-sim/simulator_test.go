@@ -0,0 +1,349 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/types"
+	clocktest "k8s.io/utils/clock/testing"
+
+	asmetrics "knative.dev/serving/pkg/autoscaler/metrics"
+)
+
+func TestParseLoadProfile(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile string
+		want    loadProfile
+		wantErr bool
+	}{{
+		name:    "single point",
+		profile: "0s=10",
+		want:    loadProfile{{0, 10}},
+	}, {
+		name:    "ramp",
+		profile: "0s=0, 1m=100,2m=0",
+		want:    loadProfile{{0, 0}, {time.Minute, 100}, {2 * time.Minute, 0}},
+	}, {
+		name:    "missing rate",
+		profile: "0s",
+		wantErr: true,
+	}, {
+		name:    "bad offset",
+		profile: "soon=10",
+		wantErr: true,
+	}, {
+		name:    "negative rate",
+		profile: "0s=-1",
+		wantErr: true,
+	}, {
+		name:    "out of order",
+		profile: "1m=10,30s=10",
+		wantErr: true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := parseLoadProfile(test.profile)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("parseLoadProfile() = %v, wantErr: %v", err, test.wantErr)
+			}
+			if !cmp.Equal(got, test.want, cmp.AllowUnexported(loadPoint{})) {
+				t.Error("Profile (-want, +got):", cmp.Diff(test.want, got, cmp.AllowUnexported(loadPoint{})))
+			}
+		})
+	}
+}
+
+func TestLoadProfileRateAt(t *testing.T) {
+	p := loadProfile{{10 * time.Second, 0}, {20 * time.Second, 100}, {40 * time.Second, 50}}
+	for d, want := range map[time.Duration]float64{
+		0:                0,
+		10 * time.Second: 0,
+		15 * time.Second: 50,
+		20 * time.Second: 100,
+		30 * time.Second: 75,
+		time.Hour:        50,
+	} {
+		if got := p.rateAt(d); got != want {
+			t.Errorf("rateAt(%v) = %v, want: %v", d, got, want)
+		}
+	}
+}
+
+func TestReplaySource(t *testing.T) {
+	rev1 := types.NamespacedName{Namespace: "ns", Name: "rev-1"}
+	rev2 := types.NamespacedName{Namespace: "ns", Name: "rev-2"}
+	recording := func(sms ...asmetrics.StatMessage) *bytes.Buffer {
+		var buf bytes.Buffer
+		wsms := asmetrics.ToWireStatMessages(sms)
+		if err := asmetrics.WriteWireStatMessages(&buf, &wsms); err != nil {
+			t.Fatal("WriteWireStatMessages() =", err)
+		}
+		return &buf
+	}
+
+	src, rev, err := newReplaySource(recording(
+		asmetrics.StatMessage{Key: rev1, Stat: asmetrics.Stat{PodName: "b", Timestamp: 102}},
+		asmetrics.StatMessage{Key: rev2, Stat: asmetrics.Stat{PodName: "x", Timestamp: 100}},
+		asmetrics.StatMessage{Key: rev1, Stat: asmetrics.Stat{PodName: "a", Timestamp: 100}},
+	), rev1)
+	if err != nil {
+		t.Fatal("newReplaySource() =", err)
+	}
+	if rev != rev1 {
+		t.Errorf("Revision = %v, want: %v", rev, rev1)
+	}
+	if got, want := src.start(), time.Unix(100, 0); !got.Equal(want) {
+		t.Errorf("start() = %v, want: %v", got, want)
+	}
+	if got, want := src.end(), time.Unix(102, 0); !got.Equal(want) {
+		t.Errorf("end() = %v, want: %v", got, want)
+	}
+	for i, want := range []string{"a", "", "b", ""} {
+		var got []string
+		for _, s := range src.stats(time.Unix(100+int64(i), 0)) {
+			got = append(got, s.PodName)
+		}
+		if got := strings.Join(got, ","); got != want {
+			t.Errorf("stats(%d) = %q, want: %q", 100+i, got, want)
+		}
+	}
+
+	if _, _, err := newReplaySource(recording(
+		asmetrics.StatMessage{Key: rev1, Stat: asmetrics.Stat{Timestamp: 100}},
+		asmetrics.StatMessage{Key: rev2, Stat: asmetrics.Stat{Timestamp: 100}},
+	), types.NamespacedName{}); err == nil {
+		t.Error("newReplaySource() with several revisions succeeded, want an error")
+	}
+	if _, _, err := newReplaySource(recording(
+		asmetrics.StatMessage{Key: rev1, Stat: asmetrics.Stat{PodName: "a"}},
+	), rev1); err == nil {
+		t.Error("newReplaySource() without timestamps succeeded, want an error")
+	}
+}
+
+func TestSimulatedPods(t *testing.T) {
+	now := time.Unix(0, 0)
+	clk := clocktest.NewFakePassiveClock(now)
+	pods := &simulatedPods{clock: clk, startupDelay: 10 * time.Second}
+
+	pods.scale(3)
+	expectPods(t, pods, 0, 3)
+	clk.SetTime(now.Add(5 * time.Second))
+	pods.scale(5)
+	expectPods(t, pods, 0, 5)
+	clk.SetTime(now.Add(10 * time.Second))
+	expectPods(t, pods, 3, 2)
+	pods.scale(2)
+	expectPods(t, pods, 2, 0)
+}
+
+func expectPods(t *testing.T, pods *simulatedPods, wantReady, wantNotReady int) {
+	t.Helper()
+	if got, _ := pods.ReadyCount(); got != wantReady {
+		t.Errorf("ReadyCount() = %d, want: %d", got, wantReady)
+	}
+	if got, _ := pods.NotReadyCount(); got != wantNotReady {
+		t.Errorf("NotReadyCount() = %d, want: %d", got, wantNotReady)
+	}
+}
+
+func TestRunSynthetic(t *testing.T) {
+	opts := options{
+		annotations: annotations{
+			"autoscaling.knative.dev/target": "10",
+			"autoscaling.knative.dev/window": "6s",
+		},
+		// 100 requests per second at 500ms are a concurrency of 50,
+		// which needs 8 pods at the default utilization of 70%.
+		load:            "0s=100,30s=100,31s=0",
+		requestLatency:  500 * time.Millisecond,
+		podStartupDelay: 4 * time.Second,
+		duration:        time.Minute,
+		format:          formatJSON,
+	}
+
+	var stdout, stderr bytes.Buffer
+	if err := run(context.Background(), opts, &stdout, &stderr); err != nil {
+		t.Fatal("run() =", err)
+	}
+	var got struct {
+		Samples []sample `json:"samples"`
+		Summary summary  `json:"summary"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+		t.Fatal("Failed to decode output:", err)
+	}
+
+	if got, want := len(got.Samples), 30; got != want {
+		t.Errorf("len(Samples) = %d, want: %d", got, want)
+	}
+	if got, want := got.Summary.MaxDesiredPods, int32(8); got != want {
+		t.Errorf("MaxDesiredPods = %d, want: %d", got, want)
+	}
+	if first := got.Samples[0]; first.ReadyPods != 1 || first.DesiredPods != 8 || !first.PanicMode {
+		t.Errorf("Samples[0] = %+v, want 1 ready pod, 8 desired pods and panic mode", first)
+	}
+	if last := got.Samples[len(got.Samples)-1]; last.DesiredPods != 0 {
+		t.Errorf("Samples[last].DesiredPods = %d, want: 0", last.DesiredPods)
+	}
+	if got.Summary.ScaleDowns == 0 || got.Summary.DurationSeconds != 60 {
+		t.Errorf("Summary = %+v, want scale downs over 60s", got.Summary)
+	}
+}
+
+func TestRunCSV(t *testing.T) {
+	config := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(config, []byte(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config-autoscaler
+data:
+  enable-scale-to-zero: "false"
+  stable-window: "6s"
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	opts := options{
+		configPath:     config,
+		annotations:    annotations{},
+		load:           "0s=0",
+		requestLatency: time.Second,
+		format:         formatCSV,
+	}
+
+	var stdout, stderr bytes.Buffer
+	if err := run(context.Background(), opts, &stdout, &stderr); err != nil {
+		t.Fatal("run() =", err)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if got, want := lines[0], strings.Join(csvHeader, ","); got != want {
+		t.Errorf("Header = %q, want: %q", got, want)
+	}
+	// Scale to zero is disabled, hence the revision stays at one pod.
+	if got, want := lines[len(lines)-1], "4,true,0,0,false,-111,1,1"; got != want {
+		t.Errorf("Last row = %q, want: %q", got, want)
+	}
+	if !strings.Contains(stderr.String(), "max desired pods: 1") {
+		t.Errorf("Summary = %q, want to contain the max desired pods", stderr.String())
+	}
+}
+
+func TestRunReplayDeterministic(t *testing.T) {
+	// Two pods report a concurrency ramping up to 40 over a minute.
+	rev := types.NamespacedName{Namespace: "ns", Name: "rev"}
+	var sms []asmetrics.StatMessage
+	for i := 0; i < 60; i++ {
+		for _, pod := range []string{"a", "b"} {
+			sms = append(sms, asmetrics.StatMessage{Key: rev, Stat: asmetrics.Stat{
+				PodName:                   pod,
+				AverageConcurrentRequests: float64(i) / 3,
+				RequestCount:              float64(i),
+				Timestamp:                 int64(1000 + i),
+			}})
+		}
+	}
+	var buf bytes.Buffer
+	wsms := asmetrics.ToWireStatMessages(sms)
+	if err := asmetrics.WriteWireStatMessages(&buf, &wsms); err != nil {
+		t.Fatal("WriteWireStatMessages() =", err)
+	}
+	recording := filepath.Join(t.TempDir(), "stats.rec")
+	if err := os.WriteFile(recording, buf.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := options{
+		annotations: annotations{
+			"autoscaling.knative.dev/target": "10",
+			"autoscaling.knative.dev/window": "6s",
+		},
+		replayPath:      recording,
+		podStartupDelay: 4 * time.Second,
+		format:          formatJSON,
+	}
+	replay := func() string {
+		var stdout, stderr bytes.Buffer
+		if err := run(context.Background(), opts, &stdout, &stderr); err != nil {
+			t.Fatal("run() =", err)
+		}
+		return stdout.String()
+	}
+
+	first := replay()
+	var got struct {
+		Summary summary `json:"summary"`
+	}
+	if err := json.Unmarshal([]byte(first), &got); err != nil {
+		t.Fatal("Failed to decode output:", err)
+	}
+	if got.Summary.MaxDesiredPods < 2 {
+		t.Fatalf("Summary = %+v, want the revision to scale up", got.Summary)
+	}
+	if second := replay(); second != first {
+		t.Error("Replaying the same recording twice differs (-first, +second):", cmp.Diff(first, second))
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		opts options
+	}{{
+		name: "no source",
+		opts: options{annotations: annotations{}, format: formatCSV},
+	}, {
+		name: "both sources",
+		opts: options{annotations: annotations{}, format: formatCSV, load: "0s=1", replayPath: "stats.rec"},
+	}, {
+		name: "unknown format",
+		opts: options{annotations: annotations{}, format: "yaml", load: "0s=1"},
+	}, {
+		name: "invalid annotation",
+		opts: options{annotations: annotations{"autoscaling.knative.dev/target": "-1"}, format: formatCSV, load: "0s=1"},
+	}, {
+		name: "hpa class",
+		opts: options{
+			annotations: annotations{"autoscaling.knative.dev/class": "hpa.autoscaling.knative.dev"},
+			format:      formatCSV,
+			load:        "0s=1",
+		},
+	}, {
+		name: "invalid revision",
+		opts: options{annotations: annotations{}, format: formatCSV, load: "0s=1", revision: "rev"},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if err := run(context.Background(), test.opts, &stdout, &stderr); err == nil {
+				t.Error("run() succeeded, want an error")
+			}
+		})
+	}
+}
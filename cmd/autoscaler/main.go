@@ -23,12 +23,16 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/kelseyhightower/envconfig"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/rest"
@@ -39,11 +43,13 @@ import (
 	filteredinformerfactory "knative.dev/pkg/client/injection/kube/informers/factory/filtered"
 	configmap "knative.dev/pkg/configmap/informer"
 	"knative.dev/pkg/controller"
+	"knative.dev/pkg/hash"
 	"knative.dev/pkg/injection"
 	"knative.dev/pkg/injection/sharedmain"
 	"knative.dev/pkg/leaderelection"
 	"knative.dev/pkg/logging"
 	"knative.dev/pkg/metrics"
+	pkgnet "knative.dev/pkg/network"
 	"knative.dev/pkg/profiling"
 	"knative.dev/pkg/signals"
 	"knative.dev/pkg/system"
@@ -51,9 +57,12 @@ import (
 	autoscalingv1alpha1 "knative.dev/serving/pkg/apis/autoscaling/v1alpha1"
 	"knative.dev/serving/pkg/apis/serving"
 	"knative.dev/serving/pkg/autoscaler/bucket"
+	"knative.dev/serving/pkg/autoscaler/checkpoint"
+	asconfig "knative.dev/serving/pkg/autoscaler/config"
 	asmetrics "knative.dev/serving/pkg/autoscaler/metrics"
 	"knative.dev/serving/pkg/autoscaler/scaling"
 	"knative.dev/serving/pkg/autoscaler/statforwarder"
+	"knative.dev/serving/pkg/autoscaler/statrecorder"
 	"knative.dev/serving/pkg/autoscaler/statserver"
 	smetrics "knative.dev/serving/pkg/metrics"
 	"knative.dev/serving/pkg/reconciler/autoscaling/kpa"
@@ -63,11 +72,40 @@ import (
 
 const (
 	statsServerAddr = ":8080"
+	// The debug endpoints are unauthenticated, so they are only served on
+	// localhost and reached through `kubectl port-forward`.
+	adminServerAddr = "localhost:8081"
 	statsBufferLen  = 1000
 	component       = "autoscaler"
 	controllerNum   = 2
+
+	// autoscalerSelector selects the pods of the autoscaler, which forward
+	// the stats to the owners of the buckets of their revisions.
+	autoscalerSelector = "app=" + component
 )
 
+type config struct {
+	// StatRecordingPath enables recording the received and scraped stats to
+	// the given file, for replaying them offline.
+	StatRecordingPath string `split_words:"true"`
+	// StatRecordingMaxFileSize is the size in bytes at which the recording
+	// is rotated and StatRecordingMaxFiles the number of files kept.
+	StatRecordingMaxFileSize int64 `split_words:"true" default:"104857600"`
+	StatRecordingMaxFiles    int   `split_words:"true" default:"5"`
+	// StatRecordingNamespaces and StatRecordingRevisions limit the recording
+	// to the revisions in the given namespaces and to the given
+	// namespace/name revisions.
+	StatRecordingNamespaces []string `split_words:"true"`
+	StatRecordingRevisions  []string `split_words:"true"`
+
+	// CheckpointInterval is how often the metric windows and the autoscaler
+	// state of the owned revisions are checkpointed, to be restored after a
+	// restart or a change of the bucket leader. Every interval, each bucket
+	// leader GETs and UPDATEs a ConfigMap of up to 900KiB per bucket, so
+	// checkpointing is opt-in: zero, the default, disables it.
+	CheckpointInterval time.Duration `split_words:"true"`
+}
+
 func main() {
 	// Set up signals so we handle the first shutdown signal gracefully.
 	ctx := signals.NewContext()
@@ -77,6 +115,11 @@ func main() {
 
 	cfg := injection.ParseAndGetRESTConfigOrDie()
 
+	var env config
+	if err := envconfig.Process("", &env); err != nil {
+		log.Fatal("Failed to process env: ", err)
+	}
+
 	log.Printf("Registering %d clients", len(injection.Default.GetClients()))
 	log.Printf("Registering %d informer factories", len(injection.Default.GetInformerFactories()))
 	log.Printf("Registering %d informers", len(injection.Default.GetInformers()))
@@ -86,7 +129,7 @@ func main() {
 	// Adjust our client's rate limits based on the number of controller's we are running.
 	cfg.QPS = controllerNum * rest.DefaultQPS
 	cfg.Burst = controllerNum * rest.DefaultBurst
-	ctx = filteredinformerfactory.WithSelectors(ctx, serving.RevisionUID)
+	ctx = filteredinformerfactory.WithSelectors(ctx, serving.RevisionUID, autoscalerSelector)
 	ctx, informers := injection.Default.SetupInformers(ctx, cfg)
 
 	kubeClient := kubeclient.Get(ctx)
@@ -138,6 +181,25 @@ func main() {
 	collector := asmetrics.NewMetricCollector(
 		statsScraperFactoryFunc(podLister, networkConfig.EnableMeshPodAddressability, networkConfig.MeshCompatibilityMode), logger)
 
+	// External metrics are only read from the sources the operator allowed.
+	cmw.Watch(asconfig.ConfigName, func(cm *corev1.ConfigMap) {
+		asCfg, err := asconfig.NewConfigFromConfigMap(cm)
+		if err != nil {
+			logger.Errorw("Failed to parse the autoscaler config", zap.Error(err))
+			return
+		}
+		collector.SetAllowedMetricSources(asCfg.AllowedMetricSources)
+	})
+
+	recorder, err := statRecorder(env, logger)
+	if err != nil {
+		logger.Fatalw("Failed to set up stat recording", zap.Error(err))
+	}
+	if recorder != nil {
+		defer recorder.Close()
+		collector.SetStatRecorder(recorder)
+	}
+
 	// Set up scalers.
 	multiScaler := scaling.NewMultiScaler(ctx.Done(),
 		uniScalerFactoryFunc(podLister, collector), logger)
@@ -158,7 +220,12 @@ func main() {
 	}
 
 	// accept is the func to call when this pod owns the Revision for this StatMessage.
+	// The pushed stats are recorded here rather than when they are received,
+	// so that the stats forwarded to the owner are only recorded once.
 	accept := func(sm asmetrics.StatMessage) {
+		if recorder != nil {
+			recorder.Record(sm)
+		}
 		collector.Record(sm.Key, time.Unix(sm.Stat.Timestamp, 0), sm.Stat)
 		multiScaler.Poke(sm.Key, sm.Stat)
 	}
@@ -169,10 +236,14 @@ func main() {
 	// since they will be sharing an elector
 	var electorCtx context.Context
 
-	var f *statforwarder.Forwarder
-	if b, bs, err := leaderelection.NewStatefulSetBucketAndSet(int(cc.Buckets)); err == nil {
+	var (
+		f  *statforwarder.Forwarder
+		bs *hash.BucketSet
+	)
+	if b, ssbs, err := leaderelection.NewStatefulSetBucketAndSet(int(cc.Buckets)); err == nil {
 		logger.Info("Running with StatefulSet leader election")
 		electorCtx = leaderelection.WithStatefulSetElectorBuilder(ctx, cc, b)
+		bs = ssbs
 		f = statforwarder.New(ctx, bs)
 		if err := statforwarder.StatefulSetBasedProcessor(ctx, f, accept); err != nil {
 			logger.Fatalw("Failed to set up statefulset processors", zap.Error(err))
@@ -180,19 +251,33 @@ func main() {
 	} else {
 		logger.Info("Running with Standard leader election")
 		electorCtx = leaderelection.WithStandardLeaderElectorBuilder(ctx, kubeClient, cc)
-		f = statforwarder.New(ctx, bucket.AutoscalerBucketSet(cc.Buckets))
+		bs = bucket.AutoscalerBucketSet(cc.Buckets)
+		f = statforwarder.New(ctx, bs)
 		if err := statforwarder.LeaseBasedProcessor(ctx, f, accept); err != nil {
 			logger.Fatalw("Failed to set up lease tracking", zap.Error(err))
 		}
 	}
 
+	// Restore the collections and autoscalers of the revisions taken over
+	// from the checkpoints saved by their previous owner.
+	var checkpoints *checkpoint.Store
+	if env.CheckpointInterval > 0 {
+		checkpoints = checkpoint.New(kubeClient, system.Namespace(), bs, f.IsBucketOwner,
+			collector, multiScaler, env.CheckpointInterval, logger)
+		collector.SetCheckpointSource(checkpoints)
+		multiScaler.SetCheckpointSource(checkpoints)
+	}
+
 	elector, err := setupSharedElector(electorCtx, controllers)
 	if err != nil {
 		logger.Fatalw("Failed to setup elector", zap.Error(err))
 	}
 
 	// Set up a statserver.
-	statsServer := statserver.New(statsServerAddr, statsCh, logger, f.IsBucketOwner)
+	// The stats pushed by the queue-proxies are only accepted from the pods of
+	// their revisions, or forwarded by the other autoscalers.
+	statsServer := statserver.New(statsServerAddr, statsCh, logger, f.IsBucketOwner,
+		statserver.PodSenderFilter(podLister, filteredpodinformer.Get(ctx, autoscalerSelector).Lister()))
 	defer f.Cancel()
 
 	go func() {
@@ -206,6 +291,7 @@ func main() {
 	}()
 
 	profilingServer := profiling.NewServer(profilingHandler)
+	adminServer := pkgnet.NewServer(adminServerAddr, adminHandler(multiScaler, logger))
 
 	eg, egCtx := errgroup.WithContext(ctx)
 	eg.Go(func() error {
@@ -214,9 +300,16 @@ func main() {
 	})
 	eg.Go(statsServer.ListenAndServe)
 	eg.Go(profilingServer.ListenAndServe)
+	eg.Go(adminServer.ListenAndServe)
 	eg.Go(func() error {
 		return controller.StartAll(egCtx, controllers...)
 	})
+	if checkpoints != nil {
+		eg.Go(func() error {
+			checkpoints.Run(egCtx)
+			return nil
+		})
+	}
 
 	// This will block until either a signal arrives or one of the grouped functions
 	// returns an error.
@@ -224,6 +317,7 @@ func main() {
 
 	statsServer.Shutdown(5 * time.Second)
 	profilingServer.Shutdown(context.Background())
+	adminServer.Shutdown(context.Background())
 	// Don't forward ErrServerClosed as that indicates we're already shutting down.
 	if err := eg.Wait(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 		logger.Errorw("Error while running server", zap.Error(err))
@@ -268,6 +362,39 @@ func statsScraperFactoryFunc(podLister corev1listers.PodLister, usePassthroughLb
 	}
 }
 
+func adminHandler(multiScaler *scaling.MultiScaler, logger *zap.SugaredLogger) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle(scaling.ExplanationsPath, &scaling.ExplanationHandler{
+		Lister: multiScaler,
+		Logger: logger,
+	})
+	return mux
+}
+
+// statRecorder returns the recorder configured by env, or nil if recording is
+// not enabled.
+func statRecorder(env config, logger *zap.SugaredLogger) (*statrecorder.Recorder, error) {
+	if env.StatRecordingPath == "" {
+		return nil, nil
+	}
+	revisions := make([]types.NamespacedName, 0, len(env.StatRecordingRevisions))
+	for _, r := range env.StatRecordingRevisions {
+		ns, name, ok := strings.Cut(r, "/")
+		if !ok || ns == "" || name == "" {
+			return nil, fmt.Errorf("invalid revision %q, want namespace/name", r)
+		}
+		revisions = append(revisions, types.NamespacedName{Namespace: ns, Name: name})
+	}
+	logger.Infof("Recording stats to %s", env.StatRecordingPath)
+	return statrecorder.New(statrecorder.Config{
+		Path:        env.StatRecordingPath,
+		MaxFileSize: env.StatRecordingMaxFileSize,
+		MaxFiles:    env.StatRecordingMaxFiles,
+		Namespaces:  env.StatRecordingNamespaces,
+		Revisions:   revisions,
+	}, logger)
+}
+
 func flush(logger *zap.SugaredLogger) {
 	logger.Sync()
 	metrics.FlushExporter()
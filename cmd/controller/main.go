@@ -36,6 +36,7 @@ import (
 	filteredFactory "knative.dev/pkg/client/injection/kube/informers/factory/filtered"
 	"knative.dev/pkg/injection"
 	"knative.dev/pkg/injection/sharedmain"
+	"knative.dev/serving/pkg/apis/serving"
 	"knative.dev/serving/pkg/networking"
 )
 
@@ -61,6 +62,8 @@ func main() {
 		"The amount of time to give each reconciliation of a resource to complete before its context is canceled.")
 
 	labelName := networking.ServingCertName + secretLabelNamePostfix
-	ctx := filteredFactory.WithSelectors(signals.NewContext(), labelName)
+	// The Configuration controller only watches the ConfigMaps and Secrets
+	// labeled for their changes to be rolled out.
+	ctx := filteredFactory.WithSelectors(signals.NewContext(), labelName, serving.RolloutOnConfigChangeLabelKey)
 	sharedmain.MainWithContext(ctx, "controller", ctors...)
 }
@@ -20,6 +20,7 @@ import (
 	"context"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	namespaceinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/namespace"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/injection/sharedmain"
@@ -37,6 +38,7 @@ import (
 	// resource validation types
 	net "knative.dev/networking/pkg/apis/networking/v1alpha1"
 	autoscalingv1alpha1 "knative.dev/serving/pkg/apis/autoscaling/v1alpha1"
+	"knative.dev/serving/pkg/apis/serving"
 	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
 	extravalidation "knative.dev/serving/pkg/webhook"
 
@@ -93,7 +95,7 @@ func newDefaultingAdmissionController(ctx context.Context, cmw configmap.Watcher
 		types,
 
 		// A function that infuses the context passed to Validate/SetDefaults with custom metadata.
-		store.ToContext,
+		withConfig(ctx, store),
 
 		// Whether to disallow unknown fields. We set this to 'false' since
 		// our CRDs have schemas
@@ -118,7 +120,7 @@ func newValidationAdmissionController(ctx context.Context, cmw configmap.Watcher
 		types,
 
 		// A function that infuses the context passed to Validate/SetDefaults with custom metadata.
-		store.ToContext,
+		withConfig(ctx, store),
 
 		// Whether to disallow unknown fields. We set this to 'false' since
 		// our CRDs have schemas
@@ -129,6 +131,23 @@ func newValidationAdmissionController(ctx context.Context, cmw configmap.Watcher
 	)
 }
 
+// withConfig returns a function decorating the contexts with the current state
+// of the config, and the lookup of the feature overrides of the namespaces.
+func withConfig(ctx context.Context, store *apisconfig.Store) func(context.Context) context.Context {
+	nsLister := namespaceinformer.Get(ctx).Lister()
+	annotations := func(namespace string) (map[string]string, error) {
+		ns, err := nsLister.Get(namespace)
+		if err != nil {
+			return nil, err
+		}
+		return ns.Annotations, nil
+	}
+	return func(ctx context.Context) context.Context {
+		ctx = serving.WithReferencesChecker(ctx, extravalidation.CheckReferences)
+		return apisconfig.WithNamespaceAnnotations(store.ToContext(ctx), annotations)
+	}
+}
+
 func newConfigValidationController(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
 	return configmaps.NewAdmissionController(ctx,
 
@@ -151,6 +170,7 @@ func newConfigValidationController(ctx context.Context, cmw configmap.Watcher) *
 			leaderelection.ConfigMapName(): leaderelection.NewConfigFromConfigMap,
 			domainconfig.DomainConfigName:  domainconfig.NewDomainFromConfigMap,
 			apisconfig.DefaultsConfigName:  apisconfig.NewDefaultsConfigFromConfigMap,
+			apisconfig.PolicyConfigName:    apisconfig.NewPolicyConfigFromConfigMap,
 		},
 	)
 }
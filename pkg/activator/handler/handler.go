@@ -20,9 +20,11 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"net/http/httptrace"
 	"net/http/httputil"
 	"strconv"
 	"strings"
+	"time"
 
 	"go.opencensus.io/plugin/ochttp"
 	"go.opencensus.io/trace"
@@ -32,12 +34,16 @@ import (
 	netheader "knative.dev/networking/pkg/http/header"
 	netproxy "knative.dev/networking/pkg/http/proxy"
 	"knative.dev/pkg/logging/logkey"
+	pkgmetrics "knative.dev/pkg/metrics"
 	pkghandler "knative.dev/pkg/network/handlers"
 	tracingconfig "knative.dev/pkg/tracing/config"
 	"knative.dev/pkg/tracing/propagation/tracecontextb3"
 	"knative.dev/serving/pkg/activator"
 	activatorconfig "knative.dev/serving/pkg/activator/config"
+	"knative.dev/serving/pkg/apis/serving"
+	v1 "knative.dev/serving/pkg/apis/serving/v1"
 	pkghttp "knative.dev/serving/pkg/http"
+	"knative.dev/serving/pkg/metrics"
 	"knative.dev/serving/pkg/networking"
 	"knative.dev/serving/pkg/queue"
 	"knative.dev/serving/pkg/reconciler/serverlessservice/resources/names"
@@ -48,6 +54,12 @@ type Throttler interface {
 	Try(ctx context.Context, revID types.NamespacedName, fn func(string) error) error
 }
 
+// coldStartDetector is implemented by Throttlers that know whether a revision
+// is scaled to zero.
+type coldStartDetector interface {
+	ScaledToZero(revID types.NamespacedName) bool
+}
+
 // activationHandler will wait for an active endpoint for a revision
 // to be available before proxying the request
 type activationHandler struct {
@@ -86,6 +98,11 @@ func (a *activationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	revID := RevIDFrom(r.Context())
+	coldStart := false
+	if d, ok := a.throttler.(coldStartDetector); ok {
+		coldStart = d.ScaledToZero(revID)
+	}
+	start := time.Now()
 	if err := a.throttler.Try(tryContext, revID, func(dest string) error {
 		trySpan.End()
 
@@ -93,6 +110,9 @@ func (a *activationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		if tracingEnabled {
 			proxyCtx, proxySpan = trace.StartSpan(r.Context(), "activator_proxy")
 		}
+		if coldStart {
+			proxyCtx = withColdStartMetrics(proxyCtx, RevisionFrom(r.Context()), start)
+		}
 		a.proxyRequest(revID, w, r.WithContext(proxyCtx), dest, tracingEnabled, a.usePassthroughLb)
 		proxySpan.End()
 
@@ -143,6 +163,24 @@ func (a *activationHandler) proxyRequest(revID types.NamespacedName, w http.Resp
 	proxy.ServeHTTP(w, r)
 }
 
+// withColdStartMetrics records the time a request for a revision scaled to
+// zero waited in the throttler, and returns a context that records the time
+// until the first byte of the response is received when the request is proxied.
+func withColdStartMetrics(ctx context.Context, rev *v1.Revision, start time.Time) context.Context {
+	reporterCtx := metrics.RevisionContext(rev.Namespace, rev.Labels[serving.ServiceLabelKey],
+		rev.Labels[serving.ConfigurationLabelKey], rev.Name)
+
+	proxyStart := time.Now()
+	pkgmetrics.Record(metrics.AugmentWithColdStartPhase(reporterCtx, metrics.ColdStartPhaseActivatorWait),
+		coldStartLatencyM.M(float64(proxyStart.Sub(start).Milliseconds())))
+	return httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		GotFirstResponseByte: func() {
+			pkgmetrics.Record(metrics.AugmentWithColdStartPhase(reporterCtx, metrics.ColdStartPhaseFirstByte),
+				coldStartLatencyM.M(float64(time.Since(proxyStart).Milliseconds())))
+		},
+	})
+}
+
 // useSecurePort replaces the default port with HTTPS port (8112).
 // TODO: endpointsToDests() should support HTTPS instead of this overwrite but it needs metadata request to be encrypted.
 // This code should be removed when https://github.com/knative/serving/issues/12821 was solved.
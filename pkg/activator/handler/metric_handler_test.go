@@ -121,7 +121,7 @@ func TestRequestMetricHandler(t *testing.T) {
 }
 
 func reset() {
-	metricstest.Unregister(requestConcurrencyM.Name(), requestCountM.Name(), responseTimeInMsecM.Name())
+	metricstest.Unregister(requestConcurrencyM.Name(), requestCountM.Name(), responseTimeInMsecM.Name(), coldStartLatencyM.Name())
 	register()
 }
 
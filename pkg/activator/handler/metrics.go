@@ -39,6 +39,11 @@ var (
 		"The response time in millisecond",
 		stats.UnitMilliseconds)
 
+	coldStartLatencyM = stats.Float64(
+		"cold_start_latencies",
+		"The duration of the phases of activations from zero in milliseconds",
+		stats.UnitMilliseconds)
+
 	// NOTE: 0 should not be used as boundary. See
 	// https://github.com/census-ecosystem/opencensus-go-exporter-stackdriver/issues/98
 	defaultLatencyDistribution = view.Distribution(5, 10, 20, 40, 60, 80, 100, 150, 200, 250, 300, 350, 400, 450, 500, 600, 700, 800, 900, 1000, 2000, 5000, 10000, 20000, 50000, 100000)
@@ -71,6 +76,12 @@ func register() {
 			Aggregation: defaultLatencyDistribution,
 			TagKeys:     []tag.Key{metrics.PodKey, metrics.ContainerKey, metrics.ResponseCodeKey, metrics.ResponseCodeClassKey},
 		},
+		&view.View{
+			Description: "The duration of the phases of activations from zero in milliseconds",
+			Measure:     coldStartLatencyM,
+			Aggregation: defaultLatencyDistribution,
+			TagKeys:     []tag.Key{metrics.ColdStartPhaseKey},
+		},
 	); err != nil {
 		panic(err)
 	}
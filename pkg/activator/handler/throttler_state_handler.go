@@ -0,0 +1,89 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handler
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/types"
+
+	activatornet "knative.dev/serving/pkg/activator/net"
+	pkghandler "knative.dev/serving/pkg/http/handler"
+)
+
+// ThrottlerStatePath is the path the ThrottlerStateHandler is served on.
+const ThrottlerStatePath = "/debug/throttler"
+
+// ThrottlerStater returns a point in time view of a throttler.
+type ThrottlerStater interface {
+	State(rev types.NamespacedName) activatornet.ThrottlerState
+}
+
+// ThrottlerStateHandler serves the state of the throttler. The state is
+// rendered as a table, or as JSON if the `format=json` query parameter is set.
+// The `revision=namespace/name` query parameter limits the output to a
+// single revision.
+type ThrottlerStateHandler struct {
+	Throttler ThrottlerStater
+	Logger    *zap.SugaredLogger
+}
+
+func (h *ThrottlerStateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	debug := &pkghandler.DebugHandler{
+		State: func(rev types.NamespacedName) (interface{}, error) {
+			return h.Throttler.State(rev), nil
+		},
+		WriteText: func(w io.Writer, state interface{}) error {
+			return writeThrottlerState(w, state.(activatornet.ThrottlerState))
+		},
+		Logger: h.Logger,
+	}
+	debug.ServeHTTP(w, r)
+}
+
+func writeThrottlerState(w io.Writer, state activatornet.ThrottlerState) error {
+	tw := pkghandler.NewDebugTabWriter(w)
+	fmt.Fprintf(tw, "activator:\t%s\n", state.ActivatorIP)
+	if state.Zone != "" {
+		fmt.Fprintf(tw, "zone:\t%s\n", state.Zone)
+	}
+	for _, rev := range state.Revisions {
+		fmt.Fprintf(tw, "\nrevision:\t%s\n", rev.Revision)
+		fmt.Fprintf(tw, "containerConcurrency:\t%d\n", rev.ContainerConcurrency)
+		fmt.Fprintf(tw, "routing:\t%s\n", rev.RoutingMode)
+		if rev.ClusterIPDest != "" {
+			fmt.Fprintf(tw, "clusterIP:\t%s\n", rev.ClusterIPDest)
+		}
+		fmt.Fprintf(tw, "activatorIndex:\t%d/%d\n", rev.ActivatorIndex, rev.NumActivators)
+		fmt.Fprintf(tw, "breaker:\tcapacity=%d inFlight=%d queued=%d\n", rev.Capacity, rev.InFlight, rev.Queued)
+		if len(rev.PodTrackers) == 0 {
+			continue
+		}
+		fmt.Fprintln(tw, "  DEST\tZONE\tASSIGNED\tIN-FLIGHT\tCAPACITY")
+		for _, pt := range rev.PodTrackers {
+			zone := pt.Zone
+			if zone == "" {
+				zone = "-"
+			}
+			fmt.Fprintf(tw, "  %s\t%s\t%t\t%d\t%d\n", pt.Dest, zone, pt.Assigned, pt.InFlight, pt.Capacity)
+		}
+	}
+	return tw.Flush()
+}
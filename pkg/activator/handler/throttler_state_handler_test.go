@@ -0,0 +1,131 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/types"
+
+	. "knative.dev/pkg/logging/testing"
+	activatornet "knative.dev/serving/pkg/activator/net"
+)
+
+type fakeThrottlerStater struct {
+	rev   types.NamespacedName
+	state activatornet.ThrottlerState
+}
+
+func (f *fakeThrottlerStater) State(rev types.NamespacedName) activatornet.ThrottlerState {
+	f.rev = rev
+	return f.state
+}
+
+func TestThrottlerStateHandler(t *testing.T) {
+	state := activatornet.ThrottlerState{
+		ActivatorIP: "10.0.0.1",
+		Zone:        "zone-a",
+		Revisions: []activatornet.RevisionThrottlerState{{
+			Revision:             "default/rev",
+			ContainerConcurrency: 1,
+			RoutingMode:          activatornet.RoutingModePod,
+			ActivatorIndex:       0,
+			NumActivators:        1,
+			Capacity:             1,
+			InFlight:             3,
+			Queued:               2,
+			PodTrackers: []activatornet.PodTrackerState{{
+				Dest:     "10.1.0.1:8012",
+				Zone:     "zone-a",
+				Assigned: true,
+				InFlight: 1,
+				Capacity: 1,
+			}},
+		}},
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		wantCode int
+		wantRev  types.NamespacedName
+		wantBody []string
+	}{{
+		name:     "text",
+		wantCode: http.StatusOK,
+		wantBody: []string{"activator:", "10.0.0.1", "default/rev", "capacity=1 inFlight=3 queued=2", "10.1.0.1:8012"},
+	}, {
+		name:     "revision filter",
+		query:    "?revision=default/rev",
+		wantCode: http.StatusOK,
+		wantRev:  types.NamespacedName{Namespace: "default", Name: "rev"},
+		wantBody: []string{"default/rev"},
+	}, {
+		name:     "invalid revision",
+		query:    "?revision=rev",
+		wantCode: http.StatusBadRequest,
+	}, {
+		name:     "unknown format",
+		query:    "?format=yaml",
+		wantCode: http.StatusBadRequest,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fake := &fakeThrottlerStater{state: state}
+			handler := &ThrottlerStateHandler{Throttler: fake, Logger: TestLogger(t)}
+
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, ThrottlerStatePath+test.query, nil))
+
+			if got, want := resp.Code, test.wantCode; got != want {
+				t.Fatalf("StatusCode = %d, want: %d", got, want)
+			}
+			if got, want := fake.rev, test.wantRev; got != want {
+				t.Errorf("Revision = %v, want: %v", got, want)
+			}
+			for _, want := range test.wantBody {
+				if !strings.Contains(resp.Body.String(), want) {
+					t.Errorf("Body = %q, want to contain %q", resp.Body.String(), want)
+				}
+			}
+		})
+	}
+
+	t.Run("json", func(t *testing.T) {
+		handler := &ThrottlerStateHandler{Throttler: &fakeThrottlerStater{state: state}, Logger: TestLogger(t)}
+
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, ThrottlerStatePath+"?format=json", nil))
+
+		if got, want := resp.Header().Get("Content-Type"), "application/json"; got != want {
+			t.Errorf("Content-Type = %q, want: %q", got, want)
+		}
+		var got activatornet.ThrottlerState
+		if err := json.Unmarshal(resp.Body.Bytes(), &got); err != nil {
+			t.Fatal("Failed to decode response:", err)
+		}
+		if !cmp.Equal(got, state) {
+			t.Error("State (-want, +got):", cmp.Diff(state, got))
+		}
+	})
+}
@@ -21,75 +21,101 @@ import (
 	"strconv"
 
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	"knative.dev/networking/pkg/apis/networking"
+	"knative.dev/serving/pkg/resources"
 )
 
-// healthyAddresses takes an endpoints object and a port name and return the set
-// of addresses that implement this port.
-func healthyAddresses(endpoints *corev1.Endpoints, portName string) sets.String {
-	var addresses int
-	for _, es := range endpoints.Subsets {
-		for _, port := range es.Ports {
-			if port.Name == portName {
-				addresses += len(es.Addresses)
-				break
-			}
+// healthyAddresses takes the EndpointSlices of a service and a port name and
+// returns the set of ready addresses in the slices that implement this port.
+func healthyAddresses(slices []*discoveryv1.EndpointSlice, portName string) sets.String {
+	ready := sets.NewString()
+	for _, slice := range slices {
+		if _, ok := endpointSlicePort(slice, portName); !ok {
+			continue
 		}
-	}
-
-	ready := make(sets.String, addresses)
-
-	for _, es := range endpoints.Subsets {
-		for _, port := range es.Ports {
-			if port.Name == portName {
-				for _, addr := range es.Addresses {
-					ready.Insert(addr.IP)
-				}
-				break
+		for _, ep := range slice.Endpoints {
+			// Per the API all the addresses are fungible, so the first one suffices.
+			if len(ep.Addresses) > 0 && resources.EndpointReady(ep) {
+				ready.Insert(ep.Addresses[0])
 			}
 		}
 	}
-
 	return ready
 }
 
-// endpointsToDests takes an endpoints object and a port name and returns two sets of
-// ready and non-ready l4 dests in the endpoints object which have that port.
-func endpointsToDests(endpoints *corev1.Endpoints, portName string) (ready, notReady sets.String) {
-	var readyAddresses, nonReadyAddresses int
-	for _, es := range endpoints.Subsets {
-		for _, port := range es.Ports {
-			if port.Name == portName {
-				readyAddresses += len(es.Addresses)
-				nonReadyAddresses += len(es.NotReadyAddresses)
-				break
+// endpointSlicesToDests takes the EndpointSlices of a service and a port name and
+// returns two sets of ready and non-ready l4 dests in them which have that port,
+// as well as the topology zones of those dests, where known.
+// Terminating endpoints are left out, unless none of the endpoints is ready. Then
+// the terminating endpoints which are still serving are treated as ready, so that
+// requests can drain to them while their replacements are coming up.
+func endpointSlicesToDests(slices []*discoveryv1.EndpointSlice, portName string) (ready, notReady sets.String, zones map[string]string) {
+	ready, notReady = sets.NewString(), sets.NewString()
+	terminating := sets.NewString()
+
+	for _, slice := range slices {
+		port, ok := endpointSlicePort(slice, portName)
+		if !ok {
+			continue
+		}
+		portStr := strconv.Itoa(int(port))
+		for _, ep := range slice.Endpoints {
+			if len(ep.Addresses) == 0 {
+				continue
+			}
+			// Per the API all the addresses are fungible, so the first one suffices.
+			// Prefer IP as we can avoid a DNS lookup this way.
+			dest := net.JoinHostPort(ep.Addresses[0], portStr)
+			switch {
+			case resources.EndpointReady(ep):
+				ready.Insert(dest)
+			case !resources.EndpointTerminating(ep):
+				notReady.Insert(dest)
+			case resources.EndpointServing(ep):
+				terminating.Insert(dest)
+			default:
+				continue
+			}
+			if zone := endpointZone(ep); zone != "" {
+				if zones == nil {
+					zones = make(map[string]string)
+				}
+				zones[dest] = zone
 			}
 		}
 	}
 
-	ready = make(sets.String, readyAddresses)
-	notReady = make(sets.String, nonReadyAddresses)
+	if len(ready) == 0 && len(terminating) > 0 {
+		ready = terminating
+	}
+	return ready, notReady, zones
+}
 
-	for _, es := range endpoints.Subsets {
-		for _, port := range es.Ports {
-			if port.Name == portName {
-				portStr := strconv.Itoa(int(port.Port))
-				for _, addr := range es.Addresses {
-					// Prefer IP as we can avoid a DNS lookup this way.
-					ready.Insert(net.JoinHostPort(addr.IP, portStr))
-				}
-				for _, addr := range es.NotReadyAddresses {
-					// Prefer IP as we can avoid a DNS lookup this way.
-					notReady.Insert(net.JoinHostPort(addr.IP, portStr))
-				}
-				break
-			}
+// endpointSlicePort returns the port number of the port with the given name
+// in the EndpointSlice. If the port is not found then ok is false.
+func endpointSlicePort(slice *discoveryv1.EndpointSlice, portName string) (int32, bool) {
+	for _, p := range slice.Ports {
+		if p.Name != nil && *p.Name == portName && p.Port != nil {
+			return *p.Port, true
 		}
 	}
+	return 0, false
+}
 
-	return ready, notReady
+// endpointZone returns the zone that should consume the endpoint. Topology
+// hints take precedence over the zone of the endpoint, so that we honor the
+// allocation of the EndpointSlice controller.
+func endpointZone(ep discoveryv1.Endpoint) string {
+	if ep.Hints != nil && len(ep.Hints.ForZones) > 0 {
+		return ep.Hints.ForZones[0].Name
+	}
+	if ep.Zone != nil {
+		return *ep.Zone
+	}
+	return ""
 }
 
 // getServicePort takes a service and a protocol and returns the port number of
@@ -22,126 +22,133 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/networking/pkg/apis/networking"
+	"knative.dev/pkg/ptr"
 )
 
-func TestEndpointsToDests(t *testing.T) {
+func TestEndpointSlicesToDests(t *testing.T) {
+	http1 := endpointSlicePorts(networking.ServicePortNameHTTP1, 1234)
 	for _, tc := range []struct {
 		name           string
-		endpoints      corev1.Endpoints
+		slices         []*discoveryv1.EndpointSlice
 		protocol       networking.ProtocolType
 		expectReady    sets.String
 		expectNotReady sets.String
+		expectZones    map[string]string
 	}{{
-		name:        "no endpoints",
-		endpoints:   corev1.Endpoints{},
+		name:        "no endpoint slices",
 		expectReady: sets.NewString(),
 	}, {
-		name: "single endpoint single address",
-		endpoints: corev1.Endpoints{
-			Subsets: []corev1.EndpointSubset{{
-				Addresses: []corev1.EndpointAddress{{
-					IP: "128.0.0.1",
-				}},
-				Ports: []corev1.EndpointPort{{
-					Name: networking.ServicePortNameHTTP1,
-					Port: 1234,
-				}},
-			}},
-		},
+		name: "single slice single endpoint",
+		slices: []*discoveryv1.EndpointSlice{{
+			Endpoints: []discoveryv1.Endpoint{readyEndpoint("128.0.0.1")},
+			Ports:     http1,
+		}},
 		expectReady: sets.NewString("128.0.0.1:1234"),
 	}, {
-		name: "single endpoint multiple address",
-		endpoints: corev1.Endpoints{
-			Subsets: []corev1.EndpointSubset{{
-				Addresses: []corev1.EndpointAddress{{
-					IP: "128.0.0.1",
-				}, {
-					IP: "128.0.0.2",
-				}},
-				Ports: []corev1.EndpointPort{{
-					Name: networking.ServicePortNameHTTP1,
-					Port: 1234,
-				}},
-			}},
-		},
+		name: "single slice multiple endpoints",
+		slices: []*discoveryv1.EndpointSlice{{
+			Endpoints: []discoveryv1.Endpoint{
+				readyEndpoint("128.0.0.1"),
+				// Nil conditions are to be interpreted as ready.
+				{Addresses: []string{"128.0.0.2"}},
+			},
+			Ports: http1,
+		}},
 		expectReady: sets.NewString("128.0.0.1:1234", "128.0.0.2:1234"),
 	}, {
-		name: "single endpoint multiple addresses, including no ready addresses",
-		endpoints: corev1.Endpoints{
-			Subsets: []corev1.EndpointSubset{{
-				Addresses: []corev1.EndpointAddress{{
-					IP: "128.0.0.1",
-				}, {
-					IP: "128.0.0.2",
-				}},
-				NotReadyAddresses: []corev1.EndpointAddress{{
-					IP: "128.0.0.3",
-				}},
-				Ports: []corev1.EndpointPort{{
-					Name: networking.ServicePortNameHTTP1,
-					Port: 1234,
-				}},
-			}},
-		},
+		name: "single slice multiple endpoints, including not ready endpoints",
+		slices: []*discoveryv1.EndpointSlice{{
+			Endpoints: []discoveryv1.Endpoint{
+				readyEndpoint("128.0.0.1"),
+				readyEndpoint("128.0.0.2"),
+				notReadyEndpoint("128.0.0.3"),
+			},
+			Ports: http1,
+		}},
 		expectReady:    sets.NewString("128.0.0.1:1234", "128.0.0.2:1234"),
 		expectNotReady: sets.NewString("128.0.0.3:1234"),
 	}, {
-		name: "multiple endpoint filter port",
-		endpoints: corev1.Endpoints{
-			Subsets: []corev1.EndpointSubset{{
-				Addresses: []corev1.EndpointAddress{{
-					IP: "128.0.0.1",
-				}},
-				Ports: []corev1.EndpointPort{{
-					Name: networking.ServicePortNameHTTP1,
-					Port: 1234,
-				}},
-			}, {
-				Addresses: []corev1.EndpointAddress{{
-					IP: "128.0.0.2",
-				}},
-				Ports: []corev1.EndpointPort{{
-					Name: "other-protocol",
-					Port: 1234,
-				}},
-			}},
-		},
+		name: "multiple slices filter port",
+		slices: []*discoveryv1.EndpointSlice{{
+			Endpoints: []discoveryv1.Endpoint{readyEndpoint("128.0.0.1")},
+			Ports:     http1,
+		}, {
+			Endpoints: []discoveryv1.Endpoint{readyEndpoint("128.0.0.2")},
+			Ports:     endpointSlicePorts("other-protocol", 1234),
+		}},
 		expectReady: sets.NewString("128.0.0.1:1234"),
 	}, {
-		name:     "multiple endpoint, different protocol",
+		name:     "multiple slices, different protocol",
 		protocol: networking.ProtocolH2C,
-		endpoints: corev1.Endpoints{
-			Subsets: []corev1.EndpointSubset{{
-				Addresses: []corev1.EndpointAddress{{
-					IP: "128.0.0.1",
-				}, {
-					IP: "128.0.0.2",
-				}},
-				Ports: []corev1.EndpointPort{{
-					Name: networking.ServicePortNameHTTP1,
-					Port: 1234,
-				}},
-			}, {
-				Addresses: []corev1.EndpointAddress{{
-					IP: "128.0.0.3",
-				}, {
-					IP: "128.0.0.4",
-				}},
-				Ports: []corev1.EndpointPort{{
-					Name: networking.ServicePortNameH2C,
-					Port: 5678,
-				}},
-			}},
-		},
+		slices: []*discoveryv1.EndpointSlice{{
+			Endpoints: []discoveryv1.Endpoint{readyEndpoint("128.0.0.1"), readyEndpoint("128.0.0.2")},
+			Ports:     http1,
+		}, {
+			Endpoints: []discoveryv1.Endpoint{readyEndpoint("128.0.0.3"), readyEndpoint("128.0.0.4")},
+			Ports:     endpointSlicePorts(networking.ServicePortNameH2C, 5678),
+		}},
 		expectReady: sets.NewString("128.0.0.3:5678", "128.0.0.4:5678"),
+	}, {
+		name: "endpoint in multiple slices",
+		slices: []*discoveryv1.EndpointSlice{{
+			Endpoints: []discoveryv1.Endpoint{readyEndpoint("128.0.0.1")},
+			Ports:     http1,
+		}, {
+			Endpoints: []discoveryv1.Endpoint{readyEndpoint("128.0.0.1"), readyEndpoint("128.0.0.2")},
+			Ports:     http1,
+		}},
+		expectReady: sets.NewString("128.0.0.1:1234", "128.0.0.2:1234"),
+	}, {
+		name: "terminating endpoints are ignored when there are ready ones",
+		slices: []*discoveryv1.EndpointSlice{{
+			Endpoints: []discoveryv1.Endpoint{
+				readyEndpoint("128.0.0.1"),
+				terminatingEndpoint("128.0.0.2", true /*serving*/),
+				notReadyEndpoint("128.0.0.3"),
+			},
+			Ports: http1,
+		}},
+		expectReady:    sets.NewString("128.0.0.1:1234"),
+		expectNotReady: sets.NewString("128.0.0.3:1234"),
+	}, {
+		name: "serving terminating endpoints drain when there are no ready ones",
+		slices: []*discoveryv1.EndpointSlice{{
+			Endpoints: []discoveryv1.Endpoint{
+				terminatingEndpoint("128.0.0.1", true /*serving*/),
+				terminatingEndpoint("128.0.0.2", false /*serving*/),
+				notReadyEndpoint("128.0.0.3"),
+			},
+			Ports: http1,
+		}},
+		expectReady:    sets.NewString("128.0.0.1:1234"),
+		expectNotReady: sets.NewString("128.0.0.3:1234"),
+	}, {
+		name: "zones and hints",
+		slices: []*discoveryv1.EndpointSlice{{
+			Endpoints: []discoveryv1.Endpoint{
+				withZone(readyEndpoint("128.0.0.1"), "us-east1-a"),
+				withHint(withZone(readyEndpoint("128.0.0.2"), "us-east1-a"), "us-east1-b"),
+				readyEndpoint("128.0.0.3"),
+				withZone(notReadyEndpoint("128.0.0.4"), "us-east1-c"),
+			},
+			Ports: http1,
+		}},
+		expectReady:    sets.NewString("128.0.0.1:1234", "128.0.0.2:1234", "128.0.0.3:1234"),
+		expectNotReady: sets.NewString("128.0.0.4:1234"),
+		expectZones: map[string]string{
+			"128.0.0.1:1234": "us-east1-a",
+			"128.0.0.2:1234": "us-east1-b",
+			"128.0.0.4:1234": "us-east1-c",
+		},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			if tc.protocol == "" {
 				tc.protocol = networking.ProtocolHTTP1
 			}
-			ready, notReady := endpointsToDests(&tc.endpoints, networking.ServicePortName(tc.protocol))
+			ready, notReady, zones := endpointSlicesToDests(tc.slices, networking.ServicePortName(tc.protocol))
 
 			if got, want := ready, tc.expectReady; !got.Equal(want) {
 				t.Error("Got unexpected ready dests (-want, +got):", cmp.Diff(want, got))
@@ -149,10 +156,77 @@ func TestEndpointsToDests(t *testing.T) {
 			if got, want := notReady, tc.expectNotReady; !got.Equal(want) {
 				t.Error("Got unexpected notReady dests (-want, +got):", cmp.Diff(want, got))
 			}
+			if got, want := zones, tc.expectZones; !cmp.Equal(got, want) {
+				t.Error("Got unexpected zones (-want, +got):", cmp.Diff(want, got))
+			}
 		})
 	}
 }
 
+func TestHealthyAddresses(t *testing.T) {
+	slices := []*discoveryv1.EndpointSlice{{
+		Endpoints: []discoveryv1.Endpoint{
+			readyEndpoint("130.0.0.1"),
+			notReadyEndpoint("130.0.0.2"),
+			terminatingEndpoint("130.0.0.3", true),
+		},
+		Ports: endpointSlicePorts(networking.ServicePortNameHTTP1, 8012),
+	}, {
+		Endpoints: []discoveryv1.Endpoint{readyEndpoint("130.0.0.1"), readyEndpoint("130.0.0.4")},
+		Ports:     endpointSlicePorts(networking.ServicePortNameHTTP1, 8012),
+	}, {
+		Endpoints: []discoveryv1.Endpoint{readyEndpoint("130.0.0.5")},
+		Ports:     endpointSlicePorts(networking.ServicePortNameH2C, 8013),
+	}}
+
+	got := healthyAddresses(slices, networking.ServicePortNameHTTP1)
+	if want := sets.NewString("130.0.0.1", "130.0.0.4"); !got.Equal(want) {
+		t.Error("healthyAddresses (-want, +got):", cmp.Diff(want, got))
+	}
+}
+
+func endpointSlicePorts(name string, port int32) []discoveryv1.EndpointPort {
+	return []discoveryv1.EndpointPort{{
+		Name: ptr.String(name),
+		Port: ptr.Int32(port),
+	}}
+}
+
+func readyEndpoint(ip string) discoveryv1.Endpoint {
+	return discoveryv1.Endpoint{
+		Addresses:  []string{ip},
+		Conditions: discoveryv1.EndpointConditions{Ready: ptr.Bool(true)},
+	}
+}
+
+func notReadyEndpoint(ip string) discoveryv1.Endpoint {
+	return discoveryv1.Endpoint{
+		Addresses:  []string{ip},
+		Conditions: discoveryv1.EndpointConditions{Ready: ptr.Bool(false)},
+	}
+}
+
+func terminatingEndpoint(ip string, serving bool) discoveryv1.Endpoint {
+	return discoveryv1.Endpoint{
+		Addresses: []string{ip},
+		Conditions: discoveryv1.EndpointConditions{
+			Ready:       ptr.Bool(false),
+			Serving:     ptr.Bool(serving),
+			Terminating: ptr.Bool(true),
+		},
+	}
+}
+
+func withZone(ep discoveryv1.Endpoint, zone string) discoveryv1.Endpoint {
+	ep.Zone = ptr.String(zone)
+	return ep
+}
+
+func withHint(ep discoveryv1.Endpoint, zone string) discoveryv1.Endpoint {
+	ep.Hints = &discoveryv1.EndpointHints{ForZones: []discoveryv1.ForZone{{Name: zone}}}
+	return ep
+}
+
 func TestGetServicePort(t *testing.T) {
 	for _, tc := range []struct {
 		name     string
@@ -200,50 +274,42 @@ func TestGetServicePort(t *testing.T) {
 func BenchmarkHealthyAddresses(b *testing.B) {
 	for _, n := range []int{1, 10, 100, 1000, 10000} {
 		b.Run(fmt.Sprint("addresses-", n), func(b *testing.B) {
-			ep := eps(10, n)
+			slices := endpointSlices(n)
 			for i := 0; i < b.N; i++ {
-				healthyAddresses(ep, networking.ServicePortNameHTTP1)
+				healthyAddresses(slices, networking.ServicePortNameHTTP1)
 			}
 		})
 	}
 }
 
-func BenchmarkEndpointsToDests(b *testing.B) {
+func BenchmarkEndpointSlicesToDests(b *testing.B) {
 	for _, n := range []int{1, 10, 100, 1000, 10000} {
 		b.Run(fmt.Sprint("addresses-", n), func(b *testing.B) {
-			ep := eps(10, n)
+			slices := endpointSlices(n)
 			for i := 0; i < b.N; i++ {
-				endpointsToDests(ep, networking.ServicePortNameHTTP1)
+				endpointSlicesToDests(slices, networking.ServicePortNameHTTP1)
 			}
 		})
 	}
 }
 
-func eps(activators, apps int) *corev1.Endpoints {
-	return &corev1.Endpoints{
-		Subsets: []corev1.EndpointSubset{{
-			Addresses: addresses("activator", activators),
-			Ports: []corev1.EndpointPort{{
-				Name: networking.ServicePortNameHTTP1,
-				Port: 1234,
-			}},
-		}, {
-			Addresses:         addresses("app", apps),
-			NotReadyAddresses: addresses("app-non-ready", apps),
-			Ports: []corev1.EndpointPort{{
-				Name: networking.ServicePortNameHTTP1,
-				Port: 1234,
-			}},
-		}},
-	}
-}
-
-func addresses(prefix string, n int) []corev1.EndpointAddress {
-	addrs := make([]corev1.EndpointAddress, 0, n)
-	for i := 0; i < n; i++ {
-		addrs = append(addrs, corev1.EndpointAddress{
-			IP: fmt.Sprintf("%s-%d", prefix, i),
-		})
+// endpointSlices returns apps ready and apps not ready endpoints, spread over
+// slices of at most 100 endpoints, like the EndpointSlice controller does.
+func endpointSlices(apps int) []*discoveryv1.EndpointSlice {
+	var (
+		slices []*discoveryv1.EndpointSlice
+		cur    *discoveryv1.EndpointSlice
+	)
+	for i := 0; i < 2*apps; i++ {
+		if i%100 == 0 {
+			cur = &discoveryv1.EndpointSlice{Ports: endpointSlicePorts(networking.ServicePortNameHTTP1, 1234)}
+			slices = append(slices, cur)
+		}
+		if i%2 == 0 {
+			cur.Endpoints = append(cur.Endpoints, readyEndpoint(fmt.Sprint("app-", i)))
+		} else {
+			cur.Endpoints = append(cur.Endpoints, notReadyEndpoint(fmt.Sprint("app-non-ready-", i)))
+		}
 	}
-	return addrs
+	return slices
 }
@@ -111,3 +111,48 @@ func newRoundRobinPolicy() lbPolicy {
 		return noop, nil
 	}
 }
+
+// newZoneAwarePolicy returns a policy that prefers the targets in the given zone
+// and delegates the actual pick to inner. Requests go to the targets in the other
+// zones first once the in-flight load of the local targets reaches spillThreshold
+// of their capacity, and fall back across zones whenever inner cannot acquire any
+// target in the preferred set.
+// Targets with unbounded capacity (CC=0) never reach the threshold, so with them
+// requests only spill over when there are no local targets at all.
+func newZoneAwarePolicy(zone string, spillThreshold float64, inner lbPolicy) lbPolicy {
+	return func(ctx context.Context, targets []*podTracker) (func(), *podTracker) {
+		var (
+			local, remote      []*podTracker
+			inFlight, capacity int
+			unbounded          bool
+		)
+		for _, t := range targets {
+			if t.zone != zone {
+				remote = append(remote, t)
+				continue
+			}
+			local = append(local, t)
+			if t.b == nil {
+				unbounded = true
+				continue
+			}
+			inFlight += t.InFlight()
+			capacity += t.Capacity()
+		}
+
+		preferred, fallback := local, remote
+		if !unbounded && float64(inFlight) >= spillThreshold*float64(capacity) {
+			preferred, fallback = remote, local
+		}
+		for _, ts := range [...][]*podTracker{preferred, fallback} {
+			if len(ts) == 0 {
+				continue
+			}
+			if cb, t := inner(ctx, ts); t != nil {
+				return cb, t
+			}
+		}
+		// We exhausted all the options...
+		return noop, nil
+	}
+}
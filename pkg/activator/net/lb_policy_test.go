@@ -234,6 +234,86 @@ func TestRoundRobin(t *testing.T) {
 	})
 }
 
+func TestZoneAware(t *testing.T) {
+	makeZonedTrackers := func(cc int, zones ...string) []*podTracker {
+		trackers := makeTrackers(len(zones), cc)
+		for i, zone := range zones {
+			trackers[i].zone = zone
+		}
+		return trackers
+	}
+
+	t.Run("prefers local zone", func(t *testing.T) {
+		podTrackers := makeZonedTrackers(1, "us-east1-b", "us-east1-a", "us-east1-c")
+		policy := newZoneAwarePolicy("us-east1-a", 1, firstAvailableLBPolicy)
+		cb, pt := policy(context.Background(), podTrackers)
+		t.Cleanup(cb)
+		if got, want := pt, podTrackers[1]; got != want {
+			t.Fatalf("Tracker = %v, want: %v", got, want)
+		}
+		// The local pod is full, so we must spill over.
+		cb, pt = policy(context.Background(), podTrackers)
+		t.Cleanup(cb)
+		if got, want := pt, podTrackers[0]; got != want {
+			t.Fatalf("Tracker = %v, want: %v", got, want)
+		}
+		cb, pt = policy(context.Background(), podTrackers)
+		t.Cleanup(cb)
+		if got, want := pt, podTrackers[2]; got != want {
+			t.Fatalf("Tracker = %v, want: %v", got, want)
+		}
+		if _, pt = policy(context.Background(), podTrackers); pt != nil {
+			t.Fatal("Wanted nil, got: ", pt)
+		}
+	})
+	t.Run("spills over at threshold", func(t *testing.T) {
+		podTrackers := makeZonedTrackers(2, "us-east1-a", "us-east1-b")
+		policy := newZoneAwarePolicy("us-east1-a", 0.5, firstAvailableLBPolicy)
+		cb, pt := policy(context.Background(), podTrackers)
+		t.Cleanup(cb)
+		if got, want := pt, podTrackers[0]; got != want {
+			t.Fatalf("Tracker = %v, want: %v", got, want)
+		}
+		// Half of the local capacity is in use, so prefer the remote pod.
+		cb, pt = policy(context.Background(), podTrackers)
+		t.Cleanup(cb)
+		if got, want := pt, podTrackers[1]; got != want {
+			t.Fatalf("Tracker = %v, want: %v", got, want)
+		}
+		cb, pt = policy(context.Background(), podTrackers)
+		t.Cleanup(cb)
+		if got, want := pt, podTrackers[1]; got != want {
+			t.Fatalf("Tracker = %v, want: %v", got, want)
+		}
+		// Remote is full now, so we fall back to the local pod.
+		cb, pt = policy(context.Background(), podTrackers)
+		t.Cleanup(cb)
+		if got, want := pt, podTrackers[0]; got != want {
+			t.Fatalf("Tracker = %v, want: %v", got, want)
+		}
+	})
+	t.Run("no local pods", func(t *testing.T) {
+		podTrackers := makeZonedTrackers(0, "us-east1-b", "")
+		policy := newZoneAwarePolicy("us-east1-a", 1, randomChoice2Policy)
+		cb, pt := policy(context.Background(), podTrackers)
+		t.Cleanup(cb)
+		if pt == nil {
+			t.Fatal("Tracker was nil")
+		}
+	})
+	t.Run("unbounded local pods", func(t *testing.T) {
+		podTrackers := makeZonedTrackers(0, "us-east1-b", "us-east1-a")
+		policy := newZoneAwarePolicy("us-east1-a", 0.1, randomChoice2Policy)
+		for i := 0; i < 5; i++ {
+			cb, pt := policy(context.Background(), podTrackers)
+			t.Cleanup(cb)
+			if got, want := pt, podTrackers[1]; got != want {
+				t.Fatalf("Tracker = %v, want: %v", got, want)
+			}
+		}
+	})
+}
+
 func BenchmarkPolicy(b *testing.B) {
 	for _, test := range []struct {
 		name   string
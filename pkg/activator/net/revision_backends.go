@@ -32,10 +32,11 @@ import (
 	"go.uber.org/zap/zapcore"
 	"golang.org/x/sync/errgroup"
 
-	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	corev1listers "k8s.io/client-go/listers/core/v1"
+	discoveryv1listers "k8s.io/client-go/listers/discovery/v1"
 	"k8s.io/client-go/tools/cache"
 
 	pkgnet "knative.dev/networking/pkg/apis/networking"
@@ -43,8 +44,8 @@ import (
 	nethttp "knative.dev/networking/pkg/http"
 	netheader "knative.dev/networking/pkg/http/header"
 	netprober "knative.dev/networking/pkg/prober"
-	endpointsinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/endpoints"
 	serviceinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/service"
+	endpointsliceinformer "knative.dev/pkg/client/injection/kube/informers/discovery/v1/endpointslice"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
 	"knative.dev/pkg/logging/logkey"
@@ -55,21 +56,26 @@ import (
 	"knative.dev/serving/pkg/networking"
 	"knative.dev/serving/pkg/queue"
 	"knative.dev/serving/pkg/reconciler/serverlessservice/resources/names"
+	"knative.dev/serving/pkg/resources"
 )
 
 // revisionDestsUpdate contains the state of healthy l4 dests for talking to a revision and is the
 // primary output from the RevisionBackendsManager system. If a healthy ClusterIP is found then
 // ClusterIPDest will be set to non empty string and Dests will be nil. Otherwise Dests will be set
 // to a slice of healthy l4 dests for reaching the revision.
+// Zones maps the Dests to their topology zone, where known.
 type revisionDestsUpdate struct {
 	Rev           types.NamespacedName
 	ClusterIPDest string
 	Dests         sets.String
+	Zones         map[string]string
 }
 
 type dests struct {
 	ready    sets.String
 	notReady sets.String
+	// zones maps the ready and not ready dests to their topology zone, where known.
+	zones map[string]string
 }
 
 func (d dests) becameNonReady(prev dests) sets.String {
@@ -105,6 +111,8 @@ type revisionWatcher struct {
 	healthyPods sets.String
 	// Stores whether the service ClusterIP has been seen as healthy.
 	clusterIPHealthy bool
+	// Stores the topology zones of the current dests.
+	zones map[string]string
 
 	transport     http.RoundTripper
 	destsCh       chan dests
@@ -301,7 +309,11 @@ func (rw *revisionWatcher) sendUpdate(clusterIP string, dests sets.String) {
 	case <-rw.stopCh:
 		return
 	default:
-		rw.updateCh <- revisionDestsUpdate{Rev: rw.rev, ClusterIPDest: clusterIP, Dests: dests}
+		update := revisionDestsUpdate{Rev: rw.rev, ClusterIPDest: clusterIP, Dests: dests}
+		if clusterIP == "" {
+			update.Zones = rw.zones
+		}
+		rw.updateCh <- update
 	}
 }
 
@@ -435,8 +447,9 @@ func (rw *revisionWatcher) run(probeFrequency time.Duration) {
 		case <-rw.stopCh:
 			return
 		case x := <-rw.destsCh:
-			rw.logger.Debugf("Updating Endpoints: ready backends: %d, not-ready backends: %d", len(x.ready), len(x.notReady))
+			rw.logger.Debugf("Updating EndpointSlices: ready backends: %d, not-ready backends: %d", len(x.ready), len(x.notReady))
 			prevDests, curDests = curDests, x
+			rw.zones = x.zones
 		case <-tickCh:
 		}
 
@@ -447,9 +460,10 @@ func (rw *revisionWatcher) run(probeFrequency time.Duration) {
 // revisionBackendsManager listens to revision endpoints and keeps track of healthy
 // l4 dests which can be used to reach a revision
 type revisionBackendsManager struct {
-	ctx            context.Context
-	revisionLister servinglisters.RevisionLister
-	serviceLister  corev1listers.ServiceLister
+	ctx                 context.Context
+	revisionLister      servinglisters.RevisionLister
+	serviceLister       corev1listers.ServiceLister
+	endpointSliceLister discoveryv1listers.EndpointSliceLister
 
 	revisionWatchers    map[types.NamespacedName]*revisionWatcher
 	revisionWatchersMux sync.RWMutex
@@ -471,20 +485,21 @@ func newRevisionBackendsManager(ctx context.Context, tr http.RoundTripper, usePa
 // newRevisionBackendsManagerWithProbeFrequency creates a fully spec'd RevisionBackendsManager.
 func newRevisionBackendsManagerWithProbeFrequency(ctx context.Context, tr http.RoundTripper,
 	usePassthroughLb bool, meshMode netcfg.MeshCompatibilityMode, probeFreq time.Duration) *revisionBackendsManager {
+	endpointSliceInformer := endpointsliceinformer.Get(ctx)
 	rbm := &revisionBackendsManager{
-		ctx:              ctx,
-		revisionLister:   revisioninformer.Get(ctx).Lister(),
-		serviceLister:    serviceinformer.Get(ctx).Lister(),
-		revisionWatchers: make(map[types.NamespacedName]*revisionWatcher),
-		updateCh:         make(chan revisionDestsUpdate),
-		transport:        tr,
-		usePassthroughLb: usePassthroughLb,
-		meshMode:         meshMode,
-		logger:           logging.FromContext(ctx),
-		probeFrequency:   probeFreq,
+		ctx:                 ctx,
+		revisionLister:      revisioninformer.Get(ctx).Lister(),
+		serviceLister:       serviceinformer.Get(ctx).Lister(),
+		endpointSliceLister: endpointSliceInformer.Lister(),
+		revisionWatchers:    make(map[types.NamespacedName]*revisionWatcher),
+		updateCh:            make(chan revisionDestsUpdate),
+		transport:           tr,
+		usePassthroughLb:    usePassthroughLb,
+		meshMode:            meshMode,
+		logger:              logging.FromContext(ctx),
+		probeFrequency:      probeFreq,
 	}
-	endpointsInformer := endpointsinformer.Get(ctx)
-	endpointsInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+	endpointSliceInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 		FilterFunc: reconciler.ChainFilterFuncs(
 			reconciler.LabelExistsFilterFunc(serving.RevisionUID),
 			// We are only interested in the private services, since that is
@@ -492,9 +507,9 @@ func newRevisionBackendsManagerWithProbeFrequency(ctx context.Context, tr http.R
 			reconciler.LabelFilterFunc(networking.ServiceTypeKey, string(networking.ServiceTypePrivate), false),
 		),
 		Handler: cache.ResourceEventHandlerFuncs{
-			AddFunc:    rbm.endpointsUpdated,
-			UpdateFunc: controller.PassNew(rbm.endpointsUpdated),
-			DeleteFunc: rbm.endpointsDeleted,
+			AddFunc:    rbm.endpointSlicesUpdated,
+			UpdateFunc: controller.PassNew(rbm.endpointSlicesUpdated),
+			DeleteFunc: rbm.endpointSliceDeleted,
 		},
 	})
 
@@ -547,31 +562,45 @@ func (rbm *revisionBackendsManager) getOrCreateRevisionWatcher(revID types.Names
 	return rwCh, nil
 }
 
-// endpointsUpdated is a handler function to be used by the Endpoints informer.
-// It updates the endpoints in the RevisionBackendsManager if the hosts changed
-func (rbm *revisionBackendsManager) endpointsUpdated(newObj interface{}) {
+// endpointSlicesUpdated is a handler function to be used by the EndpointSlice informer.
+// It updates the endpoints in the RevisionBackendsManager if the hosts changed.
+// Since the endpoints of a service can be spread over many EndpointSlices, all the
+// slices of the service are considered on every change.
+func (rbm *revisionBackendsManager) endpointSlicesUpdated(newObj interface{}) {
 	// Ignore the updates when we've terminated.
 	select {
 	case <-rbm.ctx.Done():
 		return
 	default:
 	}
-	endpoints := newObj.(*corev1.Endpoints)
-	revID := types.NamespacedName{Namespace: endpoints.Namespace, Name: endpoints.Labels[serving.RevisionLabelKey]}
+	slice := newObj.(*discoveryv1.EndpointSlice)
+	revID := types.NamespacedName{Namespace: slice.Namespace, Name: slice.Labels[serving.RevisionLabelKey]}
+
+	slices, err := rbm.serviceEndpointSlices(slice)
+	if err != nil {
+		rbm.logger.Errorw("Failed to list endpoint slices", zap.Error(err), zap.String(logkey.Key, revID.String()))
+		return
+	}
 
 	rw, err := rbm.getOrCreateRevisionWatcher(revID)
 	if err != nil {
 		rbm.logger.Errorw("Failed to get revision watcher", zap.Error(err), zap.String(logkey.Key, revID.String()))
 		return
 	}
-	ready, notReady := endpointsToDests(endpoints, pkgnet.ServicePortName(rw.protocol))
+	ready, notReady, zones := endpointSlicesToDests(slices, pkgnet.ServicePortName(rw.protocol))
 	select {
 	case <-rbm.ctx.Done():
 		return
-	case rw.destsCh <- dests{ready: ready, notReady: notReady}:
+	case rw.destsCh <- dests{ready: ready, notReady: notReady, zones: zones}:
 	}
 }
 
+// serviceEndpointSlices returns all the EndpointSlices of the service that
+// the given slice belongs to.
+func (rbm *revisionBackendsManager) serviceEndpointSlices(slice *discoveryv1.EndpointSlice) ([]*discoveryv1.EndpointSlice, error) {
+	return resources.EndpointSlicesForService(rbm.endpointSliceLister, slice.Namespace, slice.Labels[discoveryv1.LabelServiceName])
+}
+
 // deleteRevisionWatcher deletes the revision watcher for rev if it exists. It expects
 // a write lock is held on revisionWatchersMux when calling.
 func (rbm *revisionBackendsManager) deleteRevisionWatcher(rev types.NamespacedName) {
@@ -581,15 +610,21 @@ func (rbm *revisionBackendsManager) deleteRevisionWatcher(rev types.NamespacedNa
 	}
 }
 
-func (rbm *revisionBackendsManager) endpointsDeleted(obj interface{}) {
+func (rbm *revisionBackendsManager) endpointSliceDeleted(obj interface{}) {
 	// Ignore the updates when we've terminated.
 	select {
 	case <-rbm.ctx.Done():
 		return
 	default:
 	}
-	ep := obj.(*corev1.Endpoints)
-	revID := types.NamespacedName{Namespace: ep.Namespace, Name: ep.Labels[serving.RevisionLabelKey]}
+	slice := obj.(*discoveryv1.EndpointSlice)
+	revID := types.NamespacedName{Namespace: slice.Namespace, Name: slice.Labels[serving.RevisionLabelKey]}
+
+	// The service may still have other slices, in which case this is just an update.
+	if slices, err := rbm.serviceEndpointSlices(slice); err == nil && len(slices) > 0 {
+		rbm.endpointSlicesUpdated(slices[0])
+		return
+	}
 
 	rbm.logger.Debugw("Deleting endpoint", zap.String(logkey.Key, revID.String()))
 	rbm.revisionWatchersMux.Lock()
@@ -26,6 +26,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -33,8 +34,8 @@ import (
 	pkgnet "knative.dev/networking/pkg/apis/networking"
 	netcfg "knative.dev/networking/pkg/config"
 	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
-	fakeendpointsinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/endpoints/fake"
 	fakeserviceinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/service/fake"
+	fakeendpointsliceinformer "knative.dev/pkg/client/injection/kube/informers/discovery/v1/endpointslice/fake"
 	pkgnetwork "knative.dev/pkg/network"
 	"knative.dev/pkg/ptr"
 	rtesting "knative.dev/pkg/reconciler/testing"
@@ -610,45 +611,47 @@ func assertChClosed(t *testing.T, ch chan struct{}) {
 	}
 }
 
-func epSubset(port int32, portName string, ips, notReadyIps []string) *corev1.EndpointSubset {
-	ss := &corev1.EndpointSubset{
-		Ports: []corev1.EndpointPort{{
-			Name: portName,
-			Port: port,
-		}},
-	}
-	for _, ip := range ips {
-		ss.Addresses = append(ss.Addresses, corev1.EndpointAddress{IP: ip})
-	}
-	for _, notReady := range notReadyIps {
-		ss.NotReadyAddresses = append(ss.NotReadyAddresses, corev1.EndpointAddress{IP: notReady})
-	}
-	return ss
-}
-
-func ep(revL string, port int32, portName string, ips ...string) *corev1.Endpoints {
+func ep(revL string, port int32, portName string, ips ...string) *discoveryv1.EndpointSlice {
 	return epNotReady(revL, port, portName, ips, nil)
 }
 
-func epNotReady(revL string, port int32, portName string, readyIps, notReadyIps []string) *corev1.Endpoints {
-	return &corev1.Endpoints{
+func epNotReady(revL string, port int32, portName string, readyIps, notReadyIps []string) *discoveryv1.EndpointSlice {
+	slice := &discoveryv1.EndpointSlice{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: revL + "-ep",
 			Labels: map[string]string{
-				serving.RevisionUID:       time.Now().Format("150415.000"),
-				networking.ServiceTypeKey: string(networking.ServiceTypePrivate),
-				serving.RevisionLabelKey:  revL,
+				serving.RevisionUID:          time.Now().Format("150415.000"),
+				networking.ServiceTypeKey:    string(networking.ServiceTypePrivate),
+				serving.RevisionLabelKey:     revL,
+				discoveryv1.LabelServiceName: revL,
 			},
 		},
-		Subsets: []corev1.EndpointSubset{*epSubset(port, portName, readyIps, notReadyIps)},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Ports: []discoveryv1.EndpointPort{{
+			Name: ptr.String(portName),
+			Port: ptr.Int32(port),
+		}},
 	}
+	for _, ip := range readyIps {
+		slice.Endpoints = append(slice.Endpoints, discoveryv1.Endpoint{
+			Addresses:  []string{ip},
+			Conditions: discoveryv1.EndpointConditions{Ready: ptr.Bool(true)},
+		})
+	}
+	for _, ip := range notReadyIps {
+		slice.Endpoints = append(slice.Endpoints, discoveryv1.Endpoint{
+			Addresses:  []string{ip},
+			Conditions: discoveryv1.EndpointConditions{Ready: ptr.Bool(false)},
+		})
+	}
+	return slice
 }
 
 func TestRevisionBackendManagerAddEndpoint(t *testing.T) {
 	// Make sure we wait out all the jitter in the system.
 	for _, tc := range []struct {
 		name               string
-		endpointsArr       []*corev1.Endpoints
+		endpointsArr       []*discoveryv1.EndpointSlice
 		revisions          []*v1.Revision
 		services           []*corev1.Service
 		probeHostResponses map[string][]activatortest.FakeResponse
@@ -656,7 +659,7 @@ func TestRevisionBackendManagerAddEndpoint(t *testing.T) {
 		updateCnt          int
 	}{{
 		name:         "add slow healthy",
-		endpointsArr: []*corev1.Endpoints{ep(testRevision, 1234, "http", "128.0.0.1")},
+		endpointsArr: []*discoveryv1.EndpointSlice{ep(testRevision, 1234, "http", "128.0.0.1")},
 		revisions: []*v1.Revision{
 			revisionCC1(types.NamespacedName{Namespace: testNamespace, Name: testRevision}, pkgnet.ProtocolHTTP1),
 		},
@@ -684,7 +687,7 @@ func TestRevisionBackendManagerAddEndpoint(t *testing.T) {
 		updateCnt: 1,
 	}, {
 		name:         "add slow ready http2",
-		endpointsArr: []*corev1.Endpoints{ep(testRevision, 1234, "http2", "128.0.0.1")},
+		endpointsArr: []*discoveryv1.EndpointSlice{ep(testRevision, 1234, "http2", "128.0.0.1")},
 		revisions: []*v1.Revision{
 			revisionCC1(types.NamespacedName{Namespace: testNamespace, Name: testRevision}, pkgnet.ProtocolH2C),
 		},
@@ -712,7 +715,7 @@ func TestRevisionBackendManagerAddEndpoint(t *testing.T) {
 		updateCnt: 1,
 	}, {
 		name: "multiple revisions",
-		endpointsArr: []*corev1.Endpoints{
+		endpointsArr: []*discoveryv1.EndpointSlice{
 			ep("test-revision1", 1234, "http", "128.0.0.1"),
 			ep("test-revision2", 1235, "http", "128.1.0.2"),
 		},
@@ -741,7 +744,7 @@ func TestRevisionBackendManagerAddEndpoint(t *testing.T) {
 		updateCnt: 2,
 	}, {
 		name:         "no pods available, but non-mesh-related error",
-		endpointsArr: []*corev1.Endpoints{ep(testRevision, 1234, "http", "128.0.0.1")},
+		endpointsArr: []*discoveryv1.EndpointSlice{ep(testRevision, 1234, "http", "128.0.0.1")},
 		revisions: []*v1.Revision{
 			revisionCC1(types.NamespacedName{Namespace: testNamespace, Name: testRevision}, pkgnet.ProtocolHTTP1),
 		},
@@ -762,7 +765,7 @@ func TestRevisionBackendManagerAddEndpoint(t *testing.T) {
 		updateCnt:   0,
 	}, {
 		name:         "no pod addressability",
-		endpointsArr: []*corev1.Endpoints{ep(testRevision, 1234, "http", "128.0.0.1")},
+		endpointsArr: []*discoveryv1.EndpointSlice{ep(testRevision, 1234, "http", "128.0.0.1")},
 		revisions: []*v1.Revision{
 			revisionCC1(types.NamespacedName{Namespace: testNamespace, Name: testRevision}, pkgnet.ProtocolHTTP1),
 		},
@@ -788,7 +791,7 @@ func TestRevisionBackendManagerAddEndpoint(t *testing.T) {
 		updateCnt: 1,
 	}, {
 		name:         "unhealthy",
-		endpointsArr: []*corev1.Endpoints{ep(testRevision, 1234, "http", "128.0.0.1")},
+		endpointsArr: []*discoveryv1.EndpointSlice{ep(testRevision, 1234, "http", "128.0.0.1")},
 		revisions: []*v1.Revision{
 			revisionCC1(types.NamespacedName{Namespace: testNamespace, Name: testRevision}, pkgnet.ProtocolHTTP1),
 		},
@@ -808,7 +811,7 @@ func TestRevisionBackendManagerAddEndpoint(t *testing.T) {
 		expectDests: map[types.NamespacedName]revisionDestsUpdate{},
 	}, {
 		name:         "unready pod successfully probed",
-		endpointsArr: []*corev1.Endpoints{epNotReady(testRevision, 1234, "http", nil, []string{"128.0.0.1"})},
+		endpointsArr: []*discoveryv1.EndpointSlice{epNotReady(testRevision, 1234, "http", nil, []string{"128.0.0.1"})},
 		revisions: []*v1.Revision{
 			revisionCC1(types.NamespacedName{Namespace: testNamespace, Name: testRevision}, pkgnet.ProtocolHTTP1),
 		},
@@ -833,7 +836,7 @@ func TestRevisionBackendManagerAddEndpoint(t *testing.T) {
 		updateCnt: 1,
 	}, {
 		name:         "pod with exec probe only goes ready when kubernetes agrees",
-		endpointsArr: []*corev1.Endpoints{epNotReady(testRevision, 1234, "http", nil, []string{"128.0.0.1"})},
+		endpointsArr: []*discoveryv1.EndpointSlice{epNotReady(testRevision, 1234, "http", nil, []string{"128.0.0.1"})},
 		revisions: []*v1.Revision{
 			revision(types.NamespacedName{Namespace: testNamespace, Name: testRevision}, pkgnet.ProtocolHTTP1, 1, func(r *v1.Revision) {
 				r.Spec.Containers[0].ReadinessProbe = &corev1.Probe{
@@ -860,7 +863,7 @@ func TestRevisionBackendManagerAddEndpoint(t *testing.T) {
 		updateCnt:   0,
 	}, {
 		name:         "pod with exec probe goes ready when kubernetes agrees",
-		endpointsArr: []*corev1.Endpoints{epNotReady(testRevision, 1234, "http", []string{"128.0.0.1"}, nil)},
+		endpointsArr: []*discoveryv1.EndpointSlice{epNotReady(testRevision, 1234, "http", []string{"128.0.0.1"}, nil)},
 		revisions: []*v1.Revision{
 			revision(types.NamespacedName{Namespace: testNamespace, Name: testRevision}, pkgnet.ProtocolHTTP1, 1, func(r *v1.Revision) {
 				r.Spec.Containers[0].ReadinessProbe = &corev1.Probe{
@@ -899,7 +902,7 @@ func TestRevisionBackendManagerAddEndpoint(t *testing.T) {
 
 			ctx, cancel, _ := rtesting.SetupFakeContextWithCancel(t)
 
-			endpointsInformer := fakeendpointsinformer.Get(ctx)
+			endpointsInformer := fakeendpointsliceinformer.Get(ctx)
 			serviceInformer := fakeserviceinformer.Get(ctx)
 			revisions := fakerevisioninformer.Get(ctx)
 
@@ -927,7 +930,7 @@ func TestRevisionBackendManagerAddEndpoint(t *testing.T) {
 			}()
 
 			for _, ep := range tc.endpointsArr {
-				fakekubeclient.Get(ctx).CoreV1().Endpoints(testNamespace).Create(ctx, ep, metav1.CreateOptions{})
+				fakekubeclient.Get(ctx).DiscoveryV1().EndpointSlices(testNamespace).Create(ctx, ep, metav1.CreateOptions{})
 				endpointsInformer.Informer().GetIndexer().Add(ep)
 			}
 
@@ -1368,9 +1371,9 @@ func TestRevisionDeleted(t *testing.T) {
 	si := fakeserviceinformer.Get(ctx)
 	si.Informer().GetIndexer().Add(svc)
 
-	ei := fakeendpointsinformer.Get(ctx)
+	ei := fakeendpointsliceinformer.Get(ctx)
 	ep := ep(testRevision, 1234, "http", "128.0.0.1")
-	fakekubeclient.Get(ctx).CoreV1().Endpoints(testNamespace).Create(ctx, ep, metav1.CreateOptions{})
+	fakekubeclient.Get(ctx).DiscoveryV1().EndpointSlices(testNamespace).Create(ctx, ep, metav1.CreateOptions{})
 	waitInformers, err := rtesting.RunAndSyncInformers(ctx, ei.Informer())
 	if err != nil {
 		t.Fatal("Failed to start informers:", err)
@@ -1397,7 +1400,7 @@ func TestRevisionDeleted(t *testing.T) {
 		t.Error("Timedout waiting for initial response")
 	}
 	// Now delete the endpoints.
-	fakekubeclient.Get(ctx).CoreV1().Endpoints(testNamespace).Delete(ctx, ep.Name, metav1.DeleteOptions{})
+	fakekubeclient.Get(ctx).DiscoveryV1().EndpointSlices(testNamespace).Delete(ctx, ep.Name, metav1.DeleteOptions{})
 	select {
 	case r := <-rbm.updates():
 		t.Errorf("Unexpected update: %#v", r)
@@ -1406,13 +1409,64 @@ func TestRevisionDeleted(t *testing.T) {
 	}
 }
 
+func TestRevisionMultipleEndpointSlices(t *testing.T) {
+	ctx, cancel, _ := rtesting.SetupFakeContextWithCancel(t)
+
+	ei := fakeendpointsliceinformer.Get(ctx)
+	waitInformers, err := rtesting.RunAndSyncInformers(ctx, ei.Informer())
+	if err != nil {
+		t.Fatal("Failed to start informers:", err)
+	}
+
+	rev := revisionCC1(types.NamespacedName{Namespace: testNamespace, Name: testRevision}, pkgnet.ProtocolHTTP1)
+	fakeservingclient.Get(ctx).ServingV1().Revisions(testNamespace).Create(ctx, rev, metav1.CreateOptions{})
+	ri := fakerevisioninformer.Get(ctx)
+	ri.Informer().GetIndexer().Add(rev)
+
+	fakeRT := activatortest.FakeRoundTripper{}
+	rbm := newRevisionBackendsManagerWithProbeFrequency(ctx, pkgnetwork.RoundTripperFunc(fakeRT.RT), false /*usePassthroughLb*/, netcfg.MeshCompatibilityModeAuto, probeFreq)
+	defer func() {
+		cancel()
+		waitInformers()
+		waitForRevisionBackendManager(t, rbm)
+	}()
+
+	expectDests := func(want sets.String) {
+		t.Helper()
+		select {
+		case update := <-rbm.updates():
+			if got := update.Dests; !got.Equal(want) {
+				t.Errorf("Dests = %v, want: %v", got, want)
+			}
+		case <-time.After(updateTimeout):
+			t.Fatal("Timed out waiting for update event")
+		}
+	}
+
+	// Both slices belong to the same service.
+	slice1 := ep(testRevision, 1234, "http", "128.0.0.1")
+	slice1.Name = testRevision + "-ep-1"
+	slice2 := ep(testRevision, 1234, "http", "128.0.0.2")
+	slice2.Name = testRevision + "-ep-2"
+
+	slices := fakekubeclient.Get(ctx).DiscoveryV1().EndpointSlices(testNamespace)
+	slices.Create(ctx, slice1, metav1.CreateOptions{})
+	expectDests(sets.NewString("128.0.0.1:1234"))
+	slices.Create(ctx, slice2, metav1.CreateOptions{})
+	expectDests(sets.NewString("128.0.0.1:1234", "128.0.0.2:1234"))
+
+	// Deleting one of the slices leaves the other one in effect.
+	slices.Delete(ctx, slice1.Name, metav1.DeleteOptions{})
+	expectDests(sets.NewString("128.0.0.2:1234"))
+}
+
 func TestServiceDoesNotExist(t *testing.T) {
 	// Tests when the service is not available.
 	ctx, cancel, _ := rtesting.SetupFakeContextWithCancel(t)
 
-	ei := fakeendpointsinformer.Get(ctx)
+	ei := fakeendpointsliceinformer.Get(ctx)
 	eps := ep(testRevision, 1234, "http", "128.0.0.1")
-	fakekubeclient.Get(ctx).CoreV1().Endpoints(testNamespace).Create(ctx, eps, metav1.CreateOptions{})
+	fakekubeclient.Get(ctx).DiscoveryV1().EndpointSlices(testNamespace).Create(ctx, eps, metav1.CreateOptions{})
 	waitInformers, err := rtesting.RunAndSyncInformers(ctx, ei.Informer())
 	if err != nil {
 		t.Fatal("Failed to start informers:", err)
@@ -1461,9 +1515,9 @@ func TestServiceMoreThanOne(t *testing.T) {
 	// Tests when the service is not available.
 	ctx, cancel, _ := rtesting.SetupFakeContextWithCancel(t)
 
-	ei := fakeendpointsinformer.Get(ctx)
+	ei := fakeendpointsliceinformer.Get(ctx)
 	eps := ep(testRevision, 1234, "http", "128.0.0.1")
-	fakekubeclient.Get(ctx).CoreV1().Endpoints(testNamespace).Create(ctx, eps, metav1.CreateOptions{})
+	fakekubeclient.Get(ctx).DiscoveryV1().EndpointSlices(testNamespace).Create(ctx, eps, metav1.CreateOptions{})
 	waitInformers, err := rtesting.RunAndSyncInformers(ctx, ei.Informer())
 	if err != nil {
 		t.Fatal("Failed to start informers:", err)
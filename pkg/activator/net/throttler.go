@@ -27,14 +27,15 @@ import (
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 
-	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	discoveryv1listers "k8s.io/client-go/listers/discovery/v1"
 	"k8s.io/client-go/tools/cache"
 
 	pkgnet "knative.dev/networking/pkg/apis/networking"
 	netcfg "knative.dev/networking/pkg/config"
-	endpointsinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/endpoints"
+	endpointsliceinformer "knative.dev/pkg/client/injection/kube/informers/discovery/v1/endpointslice"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/kmeta"
 	"knative.dev/pkg/logging"
@@ -46,6 +47,7 @@ import (
 	servinglisters "knative.dev/serving/pkg/client/listers/serving/v1"
 	"knative.dev/serving/pkg/networking"
 	"knative.dev/serving/pkg/queue"
+	"knative.dev/serving/pkg/resources"
 )
 
 const (
@@ -62,9 +64,10 @@ const (
 	revisionMaxConcurrency = queue.MaxBreakerCapacity
 )
 
-func newPodTracker(dest string, b breaker) *podTracker {
+func newPodTracker(dest, zone string, b breaker) *podTracker {
 	tracker := &podTracker{
 		dest: dest,
+		zone: zone,
 		b:    b,
 	}
 	tracker.decreaseWeight = func() { tracker.weight.Add(-1) }
@@ -74,6 +77,8 @@ func newPodTracker(dest string, b breaker) *podTracker {
 
 type podTracker struct {
 	dest string
+	// zone is the topology zone of the pod, if known.
+	zone string
 	b    breaker
 
 	// weight is used for LB policy implementations.
@@ -101,6 +106,15 @@ func (p *podTracker) Capacity() int {
 	return p.b.Capacity()
 }
 
+// InFlight returns the number of requests currently in flight to the pod.
+// Without a breaker (CC=0) this is only tracked by the weight based policies.
+func (p *podTracker) InFlight() int {
+	if p.b == nil {
+		return int(p.getWeight())
+	}
+	return p.b.InFlight()
+}
+
 func (p *podTracker) UpdateConcurrency(c int) {
 	if p.b == nil {
 		return
@@ -117,6 +131,7 @@ func (p *podTracker) Reserve(ctx context.Context) (func(), bool) {
 
 type breaker interface {
 	Capacity() int
+	InFlight() int
 	Maybe(ctx context.Context, thunk func()) error
 	UpdateConcurrency(int)
 	Reserve(ctx context.Context) (func(), bool)
@@ -425,12 +440,15 @@ func (rt *revisionThrottler) handleUpdate(update revisionDestsUpdate) {
 		// Loop over dests, reuse existing tracker if we have one, otherwise create
 		// a new one.
 		for newDest := range update.Dests {
+			zone := update.Zones[newDest]
 			tracker, ok := trackersMap[newDest]
-			if !ok {
+			// The zone of a tracker is immutable, so if the address got reused
+			// in a different zone we start with a fresh tracker.
+			if !ok || tracker.zone != zone {
 				if rt.containerConcurrency == 0 {
-					tracker = newPodTracker(newDest, nil)
+					tracker = newPodTracker(newDest, zone, nil)
 				} else {
-					tracker = newPodTracker(newDest, queue.NewBreaker(queue.BreakerParams{
+					tracker = newPodTracker(newDest, zone, queue.NewBreaker(queue.BreakerParams{
 						QueueDepth:      breakerQueueDepth,
 						MaxConcurrency:  rt.containerConcurrency,
 						InitialCapacity: rt.containerConcurrency, // Presume full unused capacity.
@@ -444,7 +462,7 @@ func (rt *revisionThrottler) handleUpdate(update revisionDestsUpdate) {
 		return
 	}
 
-	rt.updateThrottlerState(len(update.Dests), nil /*trackers*/, newPodTracker(update.ClusterIPDest, nil))
+	rt.updateThrottlerState(len(update.Dests), nil /*trackers*/, newPodTracker(update.ClusterIPDest, "" /*zone*/, nil))
 }
 
 // Throttler load balances requests to revisions based on capacity. When `Run` is called it listens for
@@ -453,20 +471,45 @@ type Throttler struct {
 	revisionThrottlers      map[types.NamespacedName]*revisionThrottler
 	revisionThrottlersMutex sync.RWMutex
 	revisionLister          servinglisters.RevisionLister
+	endpointSliceLister     discoveryv1listers.EndpointSliceLister
 	ipAddress               string // The IP address of this activator.
 	logger                  *zap.SugaredLogger
-	epsUpdateCh             chan *corev1.Endpoints
+	epsUpdateCh             chan []*discoveryv1.EndpointSlice
+
+	// zone is the topology zone of this activator. If non-empty the
+	// revision throttlers prefer the pods in the same zone.
+	zone string
+	// zoneSpillThreshold is the fraction of the capacity of the pods in zone
+	// that can be in use before requests spill over to the other zones.
+	zoneSpillThreshold float64
+}
+
+// ThrottlerOption configures optional behavior of the Throttler.
+type ThrottlerOption func(*Throttler)
+
+// WithZoneAwareLB makes the Throttler prefer the pods in the given zone, until
+// spillThreshold of their capacity is in use. An empty zone is a no-op.
+func WithZoneAwareLB(zone string, spillThreshold float64) ThrottlerOption {
+	return func(t *Throttler) {
+		t.zone = zone
+		t.zoneSpillThreshold = spillThreshold
+	}
 }
 
 // NewThrottler creates a new Throttler
-func NewThrottler(ctx context.Context, ipAddr string) *Throttler {
+func NewThrottler(ctx context.Context, ipAddr string, opts ...ThrottlerOption) *Throttler {
 	revisionInformer := revisioninformer.Get(ctx)
+	endpointSliceInformer := endpointsliceinformer.Get(ctx)
 	t := &Throttler{
-		revisionThrottlers: make(map[types.NamespacedName]*revisionThrottler),
-		revisionLister:     revisionInformer.Lister(),
-		ipAddress:          ipAddr,
-		logger:             logging.FromContext(ctx),
-		epsUpdateCh:        make(chan *corev1.Endpoints),
+		revisionThrottlers:  make(map[types.NamespacedName]*revisionThrottler),
+		revisionLister:      revisionInformer.Lister(),
+		endpointSliceLister: endpointSliceInformer.Lister(),
+		ipAddress:           ipAddr,
+		logger:              logging.FromContext(ctx),
+		epsUpdateCh:         make(chan []*discoveryv1.EndpointSlice),
+	}
+	for _, opt := range opts {
+		opt(t)
 	}
 
 	// Watch revisions to create throttler with backlog immediately and delete
@@ -477,17 +520,13 @@ func NewThrottler(ctx context.Context, ipAddr string) *Throttler {
 		DeleteFunc: t.revisionDeleted,
 	})
 
-	// Watch activator endpoint to maintain activator count
-	endpointsInformer := endpointsinformer.Get(ctx)
-
-	// Handles public service updates.
-	endpointsInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+	// Watch activator endpoint to maintain activator count.
+	// Handles public service updates. The EndpointSlices of the public
+	// services are mirrored from their Endpoints and carry their labels.
+	endpointSliceInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 		FilterFunc: reconciler.LabelFilterFunc(networking.ServiceTypeKey,
 			string(networking.ServiceTypePublic), false),
-		Handler: cache.ResourceEventHandlerFuncs{
-			AddFunc:    t.publicEndpointsUpdated,
-			UpdateFunc: controller.PassNew(t.publicEndpointsUpdated),
-		},
+		Handler: controller.HandleAll(t.publicEndpointSlicesUpdated),
 	})
 	return t
 }
@@ -523,6 +562,15 @@ func (t *Throttler) Try(ctx context.Context, revID types.NamespacedName, functio
 	return rt.try(ctx, function)
 }
 
+// ScaledToZero returns true if the revision currently has no capacity, i.e.
+// requests for it wait for it to be scaled up from zero.
+func (t *Throttler) ScaledToZero(revID types.NamespacedName) bool {
+	t.revisionThrottlersMutex.RLock()
+	rt, ok := t.revisionThrottlers[revID]
+	t.revisionThrottlersMutex.RUnlock()
+	return ok && rt.breaker.Capacity() == 0
+}
+
 func (t *Throttler) getOrCreateRevisionThrottler(revID types.NamespacedName) (*revisionThrottler, error) {
 	// First, see if we can succeed with just an RLock. This is in the request path so optimizing
 	// for this case is important
@@ -549,6 +597,9 @@ func (t *Throttler) getOrCreateRevisionThrottler(revID types.NamespacedName) (*r
 			queue.BreakerParams{QueueDepth: breakerQueueDepth, MaxConcurrency: revisionMaxConcurrency},
 			t.logger,
 		)
+		if t.zone != "" {
+			revThrottler.lbPolicy = newZoneAwarePolicy(t.zone, t.zoneSpillThreshold, revThrottler.lbPolicy)
+		}
 		t.revisionThrottlers[revID] = revThrottler
 	}
 	return revThrottler, nil
@@ -598,16 +649,19 @@ func (t *Throttler) handleUpdate(update revisionDestsUpdate) {
 	}
 }
 
-func (t *Throttler) handlePubEpsUpdate(eps *corev1.Endpoints) {
-	t.logger.Infof("Public EPS updates: %#v", eps)
+// handlePubEpsUpdate handles an update of the EndpointSlices of a public
+// service. slices is never empty.
+func (t *Throttler) handlePubEpsUpdate(slices []*discoveryv1.EndpointSlice) {
+	slice := slices[0]
+	t.logger.Infof("Public EPS updates: %#v", slices)
 
-	revN := eps.Labels[serving.RevisionLabelKey]
+	revN := slice.Labels[serving.RevisionLabelKey]
 	if revN == "" {
 		// Perhaps, we're not the only ones using the same selector label.
-		t.logger.Infof("Ignoring update for PublicService %s/%s", eps.Namespace, eps.Name)
+		t.logger.Infof("Ignoring update for PublicService %s/%s", slice.Namespace, slice.Labels[discoveryv1.LabelServiceName])
 		return
 	}
-	rev := types.NamespacedName{Name: revN, Namespace: eps.Namespace}
+	rev := types.NamespacedName{Name: revN, Namespace: slice.Namespace}
 	if rt, err := t.getOrCreateRevisionThrottler(rev); err != nil {
 		if k8serrors.IsNotFound(err) {
 			t.logger.Debugw("Revision not found. It was probably removed", zap.String(logkey.Key, rev.String()))
@@ -615,13 +669,13 @@ func (t *Throttler) handlePubEpsUpdate(eps *corev1.Endpoints) {
 			t.logger.Errorw("Failed to get revision throttler", zap.Error(err), zap.String(logkey.Key, rev.String()))
 		}
 	} else {
-		rt.handlePubEpsUpdate(eps, t.ipAddress)
+		rt.handlePubEpsUpdate(slices, t.ipAddress)
 	}
 }
 
-func (rt *revisionThrottler) handlePubEpsUpdate(eps *corev1.Endpoints, selfIP string) {
+func (rt *revisionThrottler) handlePubEpsUpdate(slices []*discoveryv1.EndpointSlice, selfIP string) {
 	// NB: this is guaranteed to be executed on a single thread.
-	epSet := healthyAddresses(eps, rt.protocol)
+	epSet := healthyAddresses(slices, rt.protocol)
 	if !epSet.Has(selfIP) {
 		// No need to do anything, this activator is not in path.
 		return
@@ -665,10 +719,20 @@ func inferIndex(eps []string, ipAddress string) int {
 	return idx
 }
 
-func (t *Throttler) publicEndpointsUpdated(newObj interface{}) {
-	endpoints := newObj.(*corev1.Endpoints)
-	t.logger.Info("Updated public Endpoints: ", endpoints.Name)
-	t.epsUpdateCh <- endpoints
+// publicEndpointSlicesUpdated is a handler function to be used by the
+// EndpointSlice informer. The endpoints of a public service can be spread over
+// many EndpointSlices, so all of them are sent on every change of one of them.
+func (t *Throttler) publicEndpointSlicesUpdated(obj interface{}) {
+	slice := obj.(*discoveryv1.EndpointSlice)
+	t.logger.Info("Updated public EndpointSlice: ", slice.Name)
+	slices, err := resources.EndpointSlicesForService(t.endpointSliceLister, slice.Namespace, slice.Labels[discoveryv1.LabelServiceName])
+	if err != nil {
+		t.logger.Errorw("Failed to list the public EndpointSlices", zap.Error(err))
+		return
+	}
+	if len(slices) > 0 {
+		t.epsUpdateCh <- slices
+	}
 }
 
 // minOneOrValue function returns num if its greater than 1
@@ -704,6 +768,9 @@ type infiniteBreaker struct {
 	// immediately or wait for capacity to appear.
 	concurrency atomic.Int32
 
+	// inFlight is the number of requests currently in Maybe.
+	inFlight atomic.Int32
+
 	logger *zap.SugaredLogger
 }
 
@@ -747,8 +814,16 @@ func (ib *infiniteBreaker) UpdateConcurrency(cc int) {
 	}
 }
 
+// InFlight returns the number of requests currently in flight in this breaker.
+func (ib *infiniteBreaker) InFlight() int {
+	return int(ib.inFlight.Load())
+}
+
 // Maybe executes thunk when capacity is available
 func (ib *infiniteBreaker) Maybe(ctx context.Context, thunk func()) error {
+	ib.inFlight.Inc()
+	defer ib.inFlight.Dec()
+
 	has := ib.Capacity()
 	// We're scaled to serve.
 	if has > 0 {
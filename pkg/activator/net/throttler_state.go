@@ -0,0 +1,152 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package net
+
+import (
+	"sort"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// The routing modes reported in RevisionThrottlerState.
+const (
+	// RoutingModePod means requests are sent directly to the pods.
+	RoutingModePod = "pod"
+	// RoutingModeClusterIP means requests are sent to the private service's cluster IP.
+	RoutingModeClusterIP = "clusterIP"
+	// RoutingModeNone means there are no backends to send requests to.
+	RoutingModeNone = "none"
+)
+
+// ThrottlerState is a point in time view of the Throttler, used for debugging.
+type ThrottlerState struct {
+	// ActivatorIP is the IP address of this activator.
+	ActivatorIP string `json:"activatorIP"`
+	// Zone is the topology zone of this activator, if zone-aware load
+	// balancing is enabled.
+	Zone string `json:"zone,omitempty"`
+	// Revisions holds the state of the revision throttlers, sorted by revision.
+	Revisions []RevisionThrottlerState `json:"revisions"`
+}
+
+// RevisionThrottlerState is a point in time view of the throttler of a revision.
+type RevisionThrottlerState struct {
+	Revision             string `json:"revision"`
+	ContainerConcurrency int    `json:"containerConcurrency"`
+	// RoutingMode is one of RoutingModePod, RoutingModeClusterIP or RoutingModeNone.
+	RoutingMode   string `json:"routingMode"`
+	ClusterIPDest string `json:"clusterIPDest,omitempty"`
+
+	// ActivatorIndex is the index of this activator among NumActivators,
+	// used to pick the subset of the pods assigned to it. -1 means unknown.
+	ActivatorIndex int `json:"activatorIndex"`
+	NumActivators  int `json:"numActivators"`
+
+	// Capacity is the capacity of the revision breaker.
+	Capacity int `json:"capacity"`
+	// InFlight is the number of requests in the revision breaker, including
+	// the ones waiting for capacity.
+	InFlight int `json:"inFlight"`
+	// Queued is the number of requests waiting for capacity.
+	Queued int `json:"queued"`
+
+	// PodTrackers holds the state of all the pod trackers, sorted by dest.
+	PodTrackers []PodTrackerState `json:"podTrackers,omitempty"`
+}
+
+// PodTrackerState is a point in time view of a podTracker.
+type PodTrackerState struct {
+	Dest string `json:"dest"`
+	Zone string `json:"zone,omitempty"`
+	// Assigned is true if the pod is in the subset assigned to this activator.
+	Assigned bool `json:"assigned"`
+	InFlight int  `json:"inFlight"`
+	Capacity int  `json:"capacity"`
+}
+
+// State returns the current state of the Throttler. If rev is non-empty only
+// the throttler of that revision is included.
+func (t *Throttler) State(rev types.NamespacedName) ThrottlerState {
+	t.revisionThrottlersMutex.RLock()
+	rts := make([]*revisionThrottler, 0, len(t.revisionThrottlers))
+	for id, rt := range t.revisionThrottlers {
+		if rev.Name == "" || id == rev {
+			rts = append(rts, rt)
+		}
+	}
+	t.revisionThrottlersMutex.RUnlock()
+
+	ret := ThrottlerState{
+		ActivatorIP: t.ipAddress,
+		Zone:        t.zone,
+		Revisions:   make([]RevisionThrottlerState, 0, len(rts)),
+	}
+	for _, rt := range rts {
+		ret.Revisions = append(ret.Revisions, rt.state())
+	}
+	sort.Slice(ret.Revisions, func(i, j int) bool {
+		return ret.Revisions[i].Revision < ret.Revisions[j].Revision
+	})
+	return ret
+}
+
+func (rt *revisionThrottler) state() RevisionThrottlerState {
+	rt.mux.RLock()
+	trackers := append([]*podTracker(nil), rt.podTrackers...)
+	assigned := make(map[*podTracker]bool, len(rt.assignedTrackers))
+	for _, t := range rt.assignedTrackers {
+		assigned[t] = true
+	}
+	clusterIPTracker := rt.clusterIPTracker
+	rt.mux.RUnlock()
+
+	ret := RevisionThrottlerState{
+		Revision:             rt.revID.String(),
+		ContainerConcurrency: rt.containerConcurrency,
+		RoutingMode:          RoutingModeNone,
+		ActivatorIndex:       int(rt.activatorIndex.Load()),
+		NumActivators:        int(rt.numActivators.Load()),
+		Capacity:             rt.breaker.Capacity(),
+		InFlight:             rt.breaker.InFlight(),
+		PodTrackers:          make([]PodTrackerState, 0, len(trackers)),
+	}
+	if ret.InFlight > ret.Capacity {
+		ret.Queued = ret.InFlight - ret.Capacity
+	}
+
+	switch {
+	case clusterIPTracker != nil:
+		ret.RoutingMode = RoutingModeClusterIP
+		ret.ClusterIPDest = clusterIPTracker.dest
+	case len(trackers) > 0:
+		ret.RoutingMode = RoutingModePod
+	}
+
+	for _, t := range trackers {
+		ret.PodTrackers = append(ret.PodTrackers, PodTrackerState{
+			Dest:     t.dest,
+			Zone:     t.zone,
+			Assigned: assigned[t],
+			InFlight: t.InFlight(),
+			Capacity: t.Capacity(),
+		})
+	}
+	sort.Slice(ret.PodTrackers, func(i, j int) bool {
+		return ret.PodTrackers[i].Dest < ret.PodTrackers[j].Dest
+	})
+	return ret
+}
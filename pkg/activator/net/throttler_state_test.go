@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package net
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	. "knative.dev/pkg/logging/testing"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestThrottlerState(t *testing.T) {
+	logger := TestLogger(t)
+	ctx, cancel, _ := rtesting.SetupFakeContextWithCancel(t)
+	defer cancel()
+
+	podRev := types.NamespacedName{Namespace: testNamespace, Name: "pods"}
+	clusterIPRev := types.NamespacedName{Namespace: testNamespace, Name: "cluster-ip"}
+	emptyRev := types.NamespacedName{Namespace: testNamespace, Name: "empty"}
+
+	throttler := newTestThrottler(ctx)
+	throttler.revisionThrottlers[podRev] = newRevisionThrottler(podRev, 2 /*cc*/, "http", testBreakerParams, logger)
+	throttler.revisionThrottlers[clusterIPRev] = newRevisionThrottler(clusterIPRev, 0 /*cc*/, "http", testBreakerParams, logger)
+	throttler.revisionThrottlers[emptyRev] = newRevisionThrottler(emptyRev, 1 /*cc*/, "http", testBreakerParams, logger)
+
+	throttler.handleUpdate(revisionDestsUpdate{
+		Rev:   podRev,
+		Dests: sets.NewString("ip2", "ip1"),
+		Zones: map[string]string{"ip1": "zone-a"},
+	})
+	throttler.handleUpdate(revisionDestsUpdate{
+		Rev:           clusterIPRev,
+		ClusterIPDest: "129.0.0.1:1234",
+		Dests:         sets.NewString("ip3"),
+	})
+
+	podState := RevisionThrottlerState{
+		Revision:             podRev.String(),
+		ContainerConcurrency: 2,
+		RoutingMode:          RoutingModePod,
+		ActivatorIndex:       -1,
+		Capacity:             4,
+		PodTrackers: []PodTrackerState{{
+			Dest:     "ip1",
+			Zone:     "zone-a",
+			Assigned: true,
+			Capacity: 2,
+		}, {
+			Dest:     "ip2",
+			Assigned: true,
+			Capacity: 2,
+		}},
+	}
+	want := ThrottlerState{
+		ActivatorIP: "10.10.10.10",
+		Revisions: []RevisionThrottlerState{{
+			Revision:       clusterIPRev.String(),
+			RoutingMode:    RoutingModeClusterIP,
+			ClusterIPDest:  "129.0.0.1:1234",
+			ActivatorIndex: -1,
+			Capacity:       1,
+			PodTrackers:    []PodTrackerState{},
+		}, {
+			Revision:             emptyRev.String(),
+			ContainerConcurrency: 1,
+			RoutingMode:          RoutingModeNone,
+			ActivatorIndex:       -1,
+			PodTrackers:          []PodTrackerState{},
+		}, podState},
+	}
+	if got := throttler.State(types.NamespacedName{}); !cmp.Equal(got, want) {
+		t.Error("State() (-want, +got):", cmp.Diff(want, got))
+	}
+
+	want = ThrottlerState{
+		ActivatorIP: "10.10.10.10",
+		Revisions:   []RevisionThrottlerState{podState},
+	}
+	if got := throttler.State(podRev); !cmp.Equal(got, want) {
+		t.Error("State(rev) (-want, +got):", cmp.Diff(want, got))
+	}
+}
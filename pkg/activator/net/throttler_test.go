@@ -28,7 +28,7 @@ import (
 	"github.com/davecgh/go-spew/spew"
 	"github.com/google/go-cmp/cmp"
 	"golang.org/x/sync/errgroup"
-	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -36,7 +36,7 @@ import (
 
 	pkgnet "knative.dev/networking/pkg/apis/networking"
 	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
-	fakeendpointsinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/endpoints/fake"
+	fakeendpointsliceinformer "knative.dev/pkg/client/injection/kube/informers/discovery/v1/endpointslice/fake"
 	. "knative.dev/pkg/logging/testing"
 	rtesting "knative.dev/pkg/reconciler/testing"
 	"knative.dev/serving/pkg/apis/serving"
@@ -195,7 +195,7 @@ func TestThrottlerUpdateCapacity(t *testing.T) {
 func makeTrackers(num, cc int) []*podTracker {
 	x := make([]*podTracker, num)
 	for i := 0; i < num; i++ {
-		x[i] = newPodTracker(strconv.Itoa(i), nil)
+		x[i] = newPodTracker(strconv.Itoa(i), "" /*zone*/, nil)
 		if cc > 0 {
 			x[i].b = queue.NewBreaker(queue.BreakerParams{
 				QueueDepth:      1,
@@ -409,7 +409,7 @@ func TestThrottlerSuccesses(t *testing.T) {
 			servfake := fakeservingclient.Get(ctx)
 			fake := fakekubeclient.Get(ctx)
 			revisions := fakerevisioninformer.Get(ctx)
-			endpoints := fakeendpointsinformer.Get(ctx)
+			endpoints := fakeendpointsliceinformer.Get(ctx)
 
 			waitInformers, err := rtesting.RunAndSyncInformers(ctx, endpoints.Informer(),
 				revisions.Informer())
@@ -439,21 +439,9 @@ func TestThrottlerSuccesses(t *testing.T) {
 				waitInformers()
 			}()
 
-			publicEp := &corev1.Endpoints{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      testRevision,
-					Namespace: testNamespace,
-					Labels: map[string]string{
-						networking.ServiceTypeKey: string(networking.ServiceTypePublic),
-						serving.RevisionLabelKey:  testRevision,
-					},
-				},
-				Subsets: []corev1.EndpointSubset{
-					*epSubset(8012, "http", []string{"130.0.0.2"}, nil),
-				},
-			}
+			publicEp := publicEndpointSlice(8012, "http", "130.0.0.2")
 
-			fake.CoreV1().Endpoints(testNamespace).Create(ctx, publicEp, metav1.CreateOptions{})
+			fake.DiscoveryV1().EndpointSlices(testNamespace).Create(ctx, publicEp, metav1.CreateOptions{})
 			endpoints.Informer().GetIndexer().Add(publicEp)
 
 			revID := types.NamespacedName{Namespace: testNamespace, Name: testRevision}
@@ -582,6 +570,73 @@ func TestPodAssignmentFinite(t *testing.T) {
 	}
 }
 
+func TestPodTrackerZones(t *testing.T) {
+	logger := TestLogger(t)
+	revName := types.NamespacedName{Namespace: testNamespace, Name: testRevision}
+	rt := newRevisionThrottler(revName, 0 /*cc*/, pkgnet.ServicePortNameHTTP1, testBreakerParams, logger)
+
+	rt.handleUpdate(revisionDestsUpdate{
+		Rev:   revName,
+		Dests: sets.NewString("ip0", "ip1", "ip2"),
+		Zones: map[string]string{"ip0": "us-east1-a", "ip1": "us-east1-b"},
+	})
+	zones := func() map[string]string {
+		ret := make(map[string]string, len(rt.podTrackers))
+		for _, t := range rt.podTrackers {
+			ret[t.dest] = t.zone
+		}
+		return ret
+	}
+	if got, want := zones(), map[string]string{"ip0": "us-east1-a", "ip1": "us-east1-b", "ip2": ""}; !cmp.Equal(got, want) {
+		t.Error("Tracker zones mismatch (-want, +got):", cmp.Diff(want, got))
+	}
+	prev := rt.podTrackers[0]
+
+	// ip0 is reused in a different zone, so it must get a fresh tracker.
+	rt.handleUpdate(revisionDestsUpdate{
+		Rev:   revName,
+		Dests: sets.NewString("ip0", "ip1"),
+		Zones: map[string]string{"ip0": "us-east1-c", "ip1": "us-east1-b"},
+	})
+	if got, want := zones(), map[string]string{"ip0": "us-east1-c", "ip1": "us-east1-b"}; !cmp.Equal(got, want) {
+		t.Error("Tracker zones mismatch (-want, +got):", cmp.Diff(want, got))
+	}
+	for _, tracker := range rt.podTrackers {
+		if tracker == prev {
+			t.Error("Tracker for ip0 was reused across zones")
+		}
+	}
+}
+
+func TestThrottlerZoneAwareLB(t *testing.T) {
+	ctx, cancel, _ := rtesting.SetupFakeContextWithCancel(t)
+	defer cancel()
+
+	rev := revisionCC1(types.NamespacedName{Namespace: testNamespace, Name: testRevision}, pkgnet.ProtocolHTTP1)
+	fakeservingclient.Get(ctx).ServingV1().Revisions(rev.Namespace).Create(ctx, rev, metav1.CreateOptions{})
+	fakerevisioninformer.Get(ctx).Informer().GetIndexer().Add(rev)
+
+	throttler := NewThrottler(ctx, "130.0.0.2", WithZoneAwareLB("us-east1-a", 0.8))
+	rt, err := throttler.getOrCreateRevisionThrottler(types.NamespacedName{Namespace: rev.Namespace, Name: rev.Name})
+	if err != nil {
+		t.Fatal("RevisionThrottler can't be found:", err)
+	}
+	rt.handleUpdate(revisionDestsUpdate{
+		Rev:   types.NamespacedName{Namespace: rev.Namespace, Name: rev.Name},
+		Dests: sets.NewString("ip0", "ip1", "ip2"),
+		Zones: map[string]string{"ip0": "us-east1-b", "ip1": "us-east1-a", "ip2": "us-east1-c"},
+	})
+
+	cb, tracker := rt.acquireDest(ctx)
+	t.Cleanup(cb)
+	if tracker == nil {
+		t.Fatal("Tracker was nil")
+	}
+	if got, want := tracker.dest, "ip1"; got != want {
+		t.Errorf("Tracker = %s, want: %s", got, want)
+	}
+}
+
 func TestPodAssignmentInfinite(t *testing.T) {
 	logger := TestLogger(t)
 	revName := types.NamespacedName{Namespace: testNamespace, Name: testRevision}
@@ -632,7 +687,7 @@ func TestActivatorsIndexUpdate(t *testing.T) {
 	ctx, cancel, _ := rtesting.SetupFakeContextWithCancel(t)
 
 	fake := fakekubeclient.Get(ctx)
-	endpoints := fakeendpointsinformer.Get(ctx)
+	endpoints := fakeendpointsliceinformer.Get(ctx)
 	servfake := fakeservingclient.Get(ctx)
 	revisions := revisioninformer.Get(ctx)
 
@@ -668,20 +723,8 @@ func TestActivatorsIndexUpdate(t *testing.T) {
 	})
 
 	// Add activator endpoint with 2 activators.
-	publicEp := &corev1.Endpoints{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      testRevision,
-			Namespace: testNamespace,
-			Labels: map[string]string{
-				networking.ServiceTypeKey: string(networking.ServiceTypePublic),
-				serving.RevisionLabelKey:  testRevision,
-			},
-		},
-		Subsets: []corev1.EndpointSubset{
-			*epSubset(8013, "http2", []string{"130.0.0.1", "130.0.0.2"}, nil),
-		},
-	}
-	fake.CoreV1().Endpoints(testNamespace).Create(ctx, publicEp, metav1.CreateOptions{})
+	publicEp := publicEndpointSlice(8013, "http2", "130.0.0.1", "130.0.0.2")
+	fake.DiscoveryV1().EndpointSlices(testNamespace).Create(ctx, publicEp, metav1.CreateOptions{})
 	endpoints.Informer().GetIndexer().Add(publicEp)
 
 	rt, err := throttler.getOrCreateRevisionThrottler(revID)
@@ -708,11 +751,9 @@ func TestActivatorsIndexUpdate(t *testing.T) {
 		t.Fatalf("len(assignedTrackers) = %d, want %d", got, want)
 	}
 
-	publicEp.Subsets = []corev1.EndpointSubset{
-		*epSubset(8013, "http2", []string{"130.0.0.2"}, nil),
-	}
+	publicEp = publicEndpointSlice(8013, "http2", "130.0.0.2")
 
-	fake.CoreV1().Endpoints(testNamespace).Update(ctx, publicEp, metav1.UpdateOptions{})
+	fake.DiscoveryV1().EndpointSlices(testNamespace).Update(ctx, publicEp, metav1.UpdateOptions{})
 	endpoints.Informer().GetIndexer().Update(publicEp)
 
 	// Verify the index was computed.
@@ -728,7 +769,7 @@ func TestMultipleActivators(t *testing.T) {
 	ctx, cancel, _ := rtesting.SetupFakeContextWithCancel(t)
 
 	fake := fakekubeclient.Get(ctx)
-	endpoints := fakeendpointsinformer.Get(ctx)
+	endpoints := fakeendpointsliceinformer.Get(ctx)
 	servfake := fakeservingclient.Get(ctx)
 	revisions := revisioninformer.Get(ctx)
 
@@ -764,20 +805,8 @@ func TestMultipleActivators(t *testing.T) {
 	})
 
 	// Add activator endpoint with 2 activators.
-	publicEp := &corev1.Endpoints{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      testRevision,
-			Namespace: testNamespace,
-			Labels: map[string]string{
-				networking.ServiceTypeKey: string(networking.ServiceTypePublic),
-				serving.RevisionLabelKey:  testRevision,
-			},
-		},
-		Subsets: []corev1.EndpointSubset{
-			*epSubset(8012, "http", []string{"130.0.0.1", "130.0.0.2"},
-				nil)},
-	}
-	fake.CoreV1().Endpoints(testNamespace).Create(ctx, publicEp, metav1.CreateOptions{})
+	publicEp := publicEndpointSlice(8012, "http", "130.0.0.1", "130.0.0.2")
+	fake.DiscoveryV1().EndpointSlices(testNamespace).Create(ctx, publicEp, metav1.CreateOptions{})
 	endpoints.Informer().GetIndexer().Add(publicEp)
 
 	rt, err := throttler.getOrCreateRevisionThrottler(revID)
@@ -818,6 +847,28 @@ func TestMultipleActivators(t *testing.T) {
 	}
 }
 
+// publicEndpointSlice returns an EndpointSlice of the public service of the
+// test revision, as mirrored from its Endpoints, with the activators at ips.
+func publicEndpointSlice(port int32, portName string, ips ...string) *discoveryv1.EndpointSlice {
+	slice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testRevision + "-abcde",
+			Namespace: testNamespace,
+			Labels: map[string]string{
+				networking.ServiceTypeKey:    string(networking.ServiceTypePublic),
+				serving.RevisionLabelKey:     testRevision,
+				discoveryv1.LabelServiceName: testRevision,
+			},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Ports:       endpointSlicePorts(portName, port),
+	}
+	for _, ip := range ips {
+		slice.Endpoints = append(slice.Endpoints, readyEndpoint(ip))
+	}
+	return slice
+}
+
 func TestInfiniteBreakerCreation(t *testing.T) {
 	// This test verifies that we use infiniteBreaker when CC==0.
 	tttl := newRevisionThrottler(types.NamespacedName{Namespace: "a", Name: "b"}, 0, /*cc*/
@@ -1158,3 +1209,25 @@ func TestAssignSlice(t *testing.T) {
 		}
 	})
 }
+
+func TestThrottlerScaledToZero(t *testing.T) {
+	logger := TestLogger(t)
+	ctx, cancel, _ := rtesting.SetupFakeContextWithCancel(t)
+	defer cancel()
+
+	revID := types.NamespacedName{Namespace: testNamespace, Name: testRevision}
+	throttler := newTestThrottler(ctx)
+	if throttler.ScaledToZero(revID) {
+		t.Error("ScaledToZero() = true for an unknown revision")
+	}
+
+	throttler.revisionThrottlers[revID] = newRevisionThrottler(revID, 1 /*cc*/, pkgnet.ServicePortNameHTTP1, testBreakerParams, logger)
+	if !throttler.ScaledToZero(revID) {
+		t.Error("ScaledToZero() = false for a revision without backends")
+	}
+
+	throttler.handleUpdate(revisionDestsUpdate{Rev: revID, Dests: sets.NewString("ip1")})
+	if throttler.ScaledToZero(revID) {
+		t.Error("ScaledToZero() = true for a revision with backends")
+	}
+}
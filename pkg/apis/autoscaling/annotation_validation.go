@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
@@ -58,9 +59,14 @@ func ValidateAnnotations(ctx context.Context, config *autoscalerconfig.Config, a
 		Also(validateWindow(anns)).
 		Also(validateLastPodRetention(anns)).
 		Also(validateScaleDownDelay(anns)).
+		Also(validateScalingRules(anns)).
 		Also(validateMetric(anns)).
+		Also(validateMetricSource(config, anns)).
 		Also(validateAlgorithm(anns)).
-		Also(validateInitialScale(config, anns))
+		Also(validateInitialScale(config, anns)).
+		Also(validateApplyRecommendedResources(anns)).
+		Also(validateShareProcessNamespace(anns)).
+		Also(validateMinAvailable(config, anns))
 }
 
 func validateClass(m map[string]string) *apis.FieldError {
@@ -81,12 +87,19 @@ func validateAlgorithm(m map[string]string) *apis.FieldError {
 		switch v {
 		case MetricAggregationAlgorithmLinear,
 			MetricAggregationAlgorithmWeightedExponential,
-			MetricAggregationAlgorithmWeightedExponentialAlt:
-			return nil
+			MetricAggregationAlgorithmWeightedExponentialAlt,
+			MetricAggregationAlgorithmPercentile:
 		default:
 			return apis.ErrInvalidValue(v, k)
 		}
 	}
+	if k, v, ok := MetricPercentileAnnotation.Get(m); ok {
+		if p, err := strconv.ParseFloat(v, 64); err != nil {
+			return apis.ErrInvalidValue(v, k)
+		} else if p <= 0 || p > 100 {
+			return apis.ErrOutOfBoundsValue(p, 0, 100, k)
+		}
+	}
 	return nil
 }
 
@@ -148,6 +161,68 @@ func validateScaleDownDelay(m map[string]string) *apis.FieldError {
 	return errs
 }
 
+func validateScalingRules(m map[string]string) *apis.FieldError {
+	_, upErrs := ScaleUpRules(m)
+	_, downErrs := ScaleDownRules(m)
+	return upErrs.Also(downErrs)
+}
+
+func validateApplyRecommendedResources(m map[string]string) *apis.FieldError {
+	if k, v, ok := ApplyRecommendedResourcesAnnotation.Get(m); ok {
+		if _, err := strconv.ParseBool(v); err != nil {
+			return apis.ErrInvalidValue(v, k)
+		}
+	}
+	return nil
+}
+
+func validateShareProcessNamespace(m map[string]string) *apis.FieldError {
+	if k, v, ok := ShareProcessNamespaceAnnotation.Get(m); ok {
+		if _, err := strconv.ParseBool(v); err != nil {
+			return apis.ErrInvalidValue(v, k)
+		}
+	}
+	return nil
+}
+
+// validateMinAvailable verifies that the min-available annotation leaves at
+// least one of the min-scale Pods of the revision evictable, so that the
+// PodDisruptionBudget doesn't block draining their nodes.
+func validateMinAvailable(config *autoscalerconfig.Config, m map[string]string) *apis.FieldError {
+	k, v, ok := MinAvailableAnnotation.Get(m)
+	if !ok {
+		return nil
+	}
+	if p := strings.TrimSuffix(v, "%"); p != v {
+		if i, err := strconv.Atoi(p); err != nil {
+			return apis.ErrInvalidValue(v, k)
+		} else if i < 1 || i > 99 {
+			return apis.ErrOutOfBoundsValue(v, "1%", "99%", k)
+		}
+		return nil
+	}
+	i, err := strconv.ParseInt(v, 10, 32)
+	if err != nil {
+		return apis.ErrInvalidValue(v, k)
+	} else if i < 1 {
+		return apis.ErrOutOfBoundsValue(v, 1, math.MaxInt32, k)
+	}
+	min := config.MinScale
+	if _, s, ok := MinScaleAnnotation.Get(m); ok {
+		// An invalid min-scale is reported by validateMinMaxScale.
+		if ms, err := strconv.ParseInt(s, 10, 32); err == nil {
+			min = int32(ms)
+		}
+	}
+	if min >= 2 && i >= int64(min) {
+		return &apis.FieldError{
+			Message: fmt.Sprintf("min-available=%d must be less than min-scale=%d", i, min),
+			Paths:   []string{k},
+		}
+	}
+	return nil
+}
+
 func validateLastPodRetention(m map[string]string) *apis.FieldError {
 	if k, v, ok := ScaleToZeroPodRetentionPeriodAnnotation.Get(m); ok {
 		if d, err := time.ParseDuration(v); err != nil {
@@ -243,7 +318,7 @@ func validateMetric(m map[string]string) *apis.FieldError {
 		switch classValue {
 		case KPA:
 			switch metric {
-			case Concurrency, RPS:
+			case Concurrency, RPS, CPU, Memory, External:
 				return nil
 			}
 		case HPA:
@@ -262,6 +337,61 @@ func validateMetric(m map[string]string) *apis.FieldError {
 	return nil
 }
 
+func validateMetricSource(config *autoscalerconfig.Config, m map[string]string) (errs *apis.FieldError) {
+	if _, c, ok := ClassAnnotation.Get(m); ok && c != KPA {
+		return nil
+	}
+	if _, metric, _ := MetricAnnotation.Get(m); metric != External {
+		return nil
+	}
+
+	switch k, v, _ := MetricSourceTypeAnnotation.Get(m); v {
+	case MetricSourceHTTP, MetricSourcePrometheus:
+	case "":
+		errs = errs.Also(apis.ErrMissingField(MetricSourceTypeAnnotationKey))
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(v, k))
+	}
+	if k, v, ok := MetricSourceURLAnnotation.Get(m); !ok {
+		errs = errs.Also(apis.ErrMissingField(MetricSourceURLAnnotationKey))
+	} else if u, err := url.Parse(v); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs = errs.Also(apis.ErrInvalidValue(v, k))
+	} else if !MetricSourceAllowed(config.AllowedMetricSources, v) {
+		errs = errs.Also(apis.ErrInvalidValue(v, k+" not allowed by cluster"))
+	}
+	if _, v, _ := MetricSourceTypeAnnotation.Get(m); v == MetricSourcePrometheus {
+		if _, _, ok := MetricSourceQueryAnnotation.Get(m); !ok {
+			errs = errs.Also(apis.ErrMissingField(MetricSourceQueryAnnotationKey))
+		}
+	}
+	// Unlike the other metrics, an external one has no sensible default target.
+	if _, _, ok := TargetAnnotation.Get(m); !ok {
+		errs = errs.Also(apis.ErrMissingField(TargetAnnotationKey))
+	}
+	return errs
+}
+
+// MetricSourceAllowed returns whether an external metric may be read from the
+// given URL, i.e. whether it has the scheme and host of one of the given URL
+// prefixes, and a path starting with its path at a segment boundary.
+func MetricSourceAllowed(prefixes []string, rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.User != nil {
+		return false
+	}
+	for _, prefix := range prefixes {
+		p, err := url.Parse(prefix)
+		if err != nil || !strings.EqualFold(u.Scheme, p.Scheme) || !strings.EqualFold(u.Host, p.Host) {
+			continue
+		}
+		if p.Path == "" || strings.HasSuffix(p.Path, "/") && strings.HasPrefix(u.Path, p.Path) ||
+			u.Path == p.Path || strings.HasPrefix(u.Path, p.Path+"/") {
+			return true
+		}
+	}
+	return false
+}
+
 func validateInitialScale(config *autoscalerconfig.Config, m map[string]string) *apis.FieldError {
 	if k, v, ok := InitialScaleAnnotation.Get(m); ok {
 		initScaleInt, err := strconv.Atoi(v)
@@ -0,0 +1,163 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autoscaling
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/kmap"
+)
+
+// ScalingPolicyType is the kind of change of the pod count a ScalingPolicy
+// limits.
+type ScalingPolicyType string
+
+const (
+	// PodsScalingPolicy limits the change to a number of pods.
+	PodsScalingPolicy ScalingPolicyType = "Pods"
+	// PercentScalingPolicy limits the change to a percentage of the pods at
+	// the start of the period.
+	PercentScalingPolicy ScalingPolicyType = "Percent"
+)
+
+// ScalingPolicy limits the change of the pod count within a period.
+// +k8s:deepcopy-gen=true
+type ScalingPolicy struct {
+	Type   ScalingPolicyType
+	Value  int32
+	Period time.Duration
+}
+
+// SelectPolicy picks the policy that applies out of the policies of
+// ScalingRules.
+type SelectPolicy string
+
+const (
+	// MaxSelectPolicy applies the policy allowing the largest change.
+	MaxSelectPolicy SelectPolicy = "Max"
+	// MinSelectPolicy applies the policy allowing the smallest change.
+	MinSelectPolicy SelectPolicy = "Min"
+	// DisabledSelectPolicy disallows any change.
+	DisabledSelectPolicy SelectPolicy = "Disabled"
+)
+
+// ScalingRules configures the scaling in one direction, like the rules of
+// the behavior of a HorizontalPodAutoscaler.
+// +k8s:deepcopy-gen=true
+type ScalingRules struct {
+	// StabilizationWindow is the window over which the past recommendations
+	// are considered: the least of them when scaling up, the greatest when
+	// scaling down.
+	StabilizationWindow time.Duration
+	// SelectPolicy picks the policy that applies, Max if it is empty.
+	SelectPolicy SelectPolicy
+	Policies     []ScalingPolicy
+}
+
+// ScaleUpRules returns the rules for scaling up set by the annotations, or nil
+// if none of their annotations is set.
+func ScaleUpRules(m map[string]string) (*ScalingRules, *apis.FieldError) {
+	return scalingRules(m, ScaleUpPoliciesAnnotation, ScaleUpSelectPolicyAnnotation,
+		ScaleUpStabilizationWindowAnnotation)
+}
+
+// ScaleDownRules returns the rules for scaling down set by the annotations, or
+// nil if none of their annotations is set.
+func ScaleDownRules(m map[string]string) (*ScalingRules, *apis.FieldError) {
+	return scalingRules(m, ScaleDownPoliciesAnnotation, ScaleDownSelectPolicyAnnotation,
+		ScaleDownStabilizationWindowAnnotation)
+}
+
+func scalingRules(m map[string]string, policies, selectPolicy, window kmap.KeyPriority) (*ScalingRules, *apis.FieldError) {
+	var (
+		rules ScalingRules
+		set   bool
+		errs  *apis.FieldError
+	)
+	if k, v, ok := policies.Get(m); ok {
+		set = true
+		ps, err := ParseScalingPolicies(v)
+		if err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(v, k, err.Error()))
+		}
+		rules.Policies = ps
+	}
+	if k, v, ok := selectPolicy.Get(m); ok {
+		set = true
+		switch sp := SelectPolicy(v); sp {
+		case MaxSelectPolicy, MinSelectPolicy, DisabledSelectPolicy:
+			rules.SelectPolicy = sp
+		default:
+			errs = errs.Also(apis.ErrInvalidValue(v, k))
+		}
+	}
+	if k, v, ok := window.Get(m); ok {
+		set = true
+		if d, err := time.ParseDuration(v); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(v, k))
+		} else if d < 0 || d > WindowMax {
+			errs = errs.Also(apis.ErrOutOfBoundsValue(v, 0*time.Second, WindowMax, k))
+		} else if d.Round(time.Second) != d {
+			errs = errs.Also(apis.ErrGeneric("must be specified with at most second precision", k))
+		} else {
+			rules.StabilizationWindow = d
+		}
+	}
+	if !set || errs != nil {
+		return nil, errs
+	}
+	return &rules, nil
+}
+
+// ParseScalingPolicies parses a comma separated list of policies of the form
+// <type>=<value>/<period>, e.g. "Pods=4/1m,Percent=100/15s".
+func ParseScalingPolicies(s string) ([]ScalingPolicy, error) {
+	var ret []ScalingPolicy
+	for _, p := range strings.Split(s, ",") {
+		p = strings.TrimSpace(p)
+		t, rest, ok := strings.Cut(p, "=")
+		if !ok {
+			return nil, fmt.Errorf("policy %q is not of the form <type>=<value>/<period>", p)
+		}
+		v, period, ok := strings.Cut(rest, "/")
+		if !ok {
+			return nil, fmt.Errorf("policy %q is not of the form <type>=<value>/<period>", p)
+		}
+
+		policy := ScalingPolicy{Type: ScalingPolicyType(t)}
+		if policy.Type != PodsScalingPolicy && policy.Type != PercentScalingPolicy {
+			return nil, fmt.Errorf("policy type %q is neither %s nor %s", t, PodsScalingPolicy, PercentScalingPolicy)
+		}
+		value, err := strconv.ParseInt(v, 10, 32)
+		if err != nil || value <= 0 {
+			return nil, fmt.Errorf("policy value %q is not a positive integer", v)
+		}
+		policy.Value = int32(value)
+		if policy.Period, err = time.ParseDuration(period); err != nil {
+			return nil, fmt.Errorf("policy period %q is not a duration", period)
+		}
+		if policy.Period < time.Second || policy.Period > WindowMax || policy.Period.Round(time.Second) != policy.Period {
+			return nil, fmt.Errorf("policy period %v is not a whole number of seconds within [1s, %v]", policy.Period, WindowMax)
+		}
+		ret = append(ret, policy)
+	}
+	return ret, nil
+}
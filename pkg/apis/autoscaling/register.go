@@ -46,6 +46,16 @@ const (
 	//   autoscaling.knative.dev/min-scale: "1"
 	MinScaleAnnotationKey = GroupName + "/min-scale"
 
+	// MinAvailableAnnotationKey is the annotation to specify the minAvailable of
+	// the PodDisruptionBudget of a revision, as a number of Pods or a percentage
+	// of them, like "50%". A PodDisruptionBudget is only created for the
+	// revisions with an effective min-scale of at least 2, and defaults to
+	// keeping all but one of the min-scale Pods available through voluntary
+	// disruptions, like node drains. It must leave one of the min-scale Pods
+	// evictable, i.e. be less than min-scale or 100%, so as not to block the
+	// drains.
+	MinAvailableAnnotationKey = GroupName + "/min-available"
+
 	// MaxScaleAnnotationKey is the annotation to specify the maximum number of Pods
 	// the PodAutoscaler should provision. For example,
 	//   autoscaling.knative.dev/max-scale: "10"
@@ -56,9 +66,56 @@ const (
 	// allow-zero-initial-scale of config-autoscaler is true.
 	InitialScaleAnnotationKey = GroupName + "/initial-scale"
 
+	// PodBudgetAnnotationKey is the annotation on a namespace to specify the
+	// maximum total number of pods of the KPA-class revisions in it. When the
+	// revisions desire more pods, the budget is split among them by demand.
+	PodBudgetAnnotationKey = GroupName + "/pod-budget"
+
+	// ApplyRecommendedResourcesAnnotationKey is the annotation on a revision
+	// template to have the resource requests recommended for the latest ready
+	// revision of its Configuration applied to the revisions created from it.
+	// The recommendations are only computed when enable-resource-recommendations
+	// of config-autoscaler is true, for revisions scaled on the "cpu" or
+	// "memory" metric, whose pods report their resource usage.
+	ApplyRecommendedResourcesAnnotationKey = GroupName + "/apply-recommended-resources"
+
+	// ShareProcessNamespaceAnnotationKey is the annotation to have the pods of
+	// a revision scaled on the "cpu" or "memory" metric share their process
+	// namespace, for the queue-proxy to measure the usage of the containers
+	// from their processes where the cgroup of the pod is not visible to it.
+	// The processes and environment of the queue-proxy are then visible to
+	// the containers, and their entrypoints no longer run as PID 1.
+	ShareProcessNamespaceAnnotationKey = GroupName + "/share-process-namespace"
+
 	// ScaleDownDelayAnnotationKey is the annotation to specify a scale down delay.
 	ScaleDownDelayAnnotationKey = GroupName + "/scale-down-delay"
 
+	// ScaleUpPoliciesAnnotationKey and ScaleDownPoliciesAnnotationKey are the
+	// annotations to specify policies limiting the change of the pod count
+	// within a period, like the behavior of a HorizontalPodAutoscaler. For
+	// example, to scale up by 4 pods or by 100% every 15 seconds, whichever
+	// is more, and to never shed more than 2 pods per minute:
+	//   autoscaling.knative.dev/scale-up-policies: "Pods=4/15s,Percent=100/15s"
+	//   autoscaling.knative.dev/scale-down-policies: "Pods=2/1m"
+	// Only the kpa.autoscaling.knative.dev class autoscaler supports
+	// the scaling behavior annotations.
+	ScaleUpPoliciesAnnotationKey   = GroupName + "/scale-up-policies"
+	ScaleDownPoliciesAnnotationKey = GroupName + "/scale-down-policies"
+
+	// ScaleUpSelectPolicyAnnotationKey and ScaleDownSelectPolicyAnnotationKey
+	// are the annotations to specify which of the policies applies: the one
+	// allowing the largest change (Max, the default), the smallest one (Min),
+	// or none, disallowing any change (Disabled).
+	ScaleUpSelectPolicyAnnotationKey   = GroupName + "/scale-up-select-policy"
+	ScaleDownSelectPolicyAnnotationKey = GroupName + "/scale-down-select-policy"
+
+	// ScaleUpStabilizationWindowAnnotationKey and
+	// ScaleDownStabilizationWindowAnnotationKey are the annotations to specify
+	// the windows over which the past recommendations are considered: the least
+	// of them when scaling up, the greatest when scaling down.
+	ScaleUpStabilizationWindowAnnotationKey   = GroupName + "/scale-up-stabilization-window"
+	ScaleDownStabilizationWindowAnnotationKey = GroupName + "/scale-down-stabilization-window"
+
 	// MetricAnnotationKey is the annotation to specify what metric the PodAutoscaler
 	// should be scaled on. For example,
 	//   autoscaling.knative.dev/metric: cpu
@@ -71,6 +128,33 @@ const (
 	Memory = "memory"
 	// RPS is the requests per second reaching the Pod.
 	RPS = "rps"
+	// External is a metric published outside of the revision, such as the
+	// length of a queue in a broker, read from the endpoint given by the
+	// metric source annotations.
+	External = "external"
+
+	// MetricSourceTypeAnnotationKey is the annotation to specify the kind of
+	// endpoint the external metric is read from. For example,
+	//   autoscaling.knative.dev/metric: external
+	//   autoscaling.knative.dev/metric-source-type: prometheus
+	//   autoscaling.knative.dev/metric-source-url: http://prometheus.monitoring:9090
+	//   autoscaling.knative.dev/metric-source-query: sum(broker_queue_length{queue="orders"})
+	//   autoscaling.knative.dev/target: "50"   # 50 queued orders per pod
+	MetricSourceTypeAnnotationKey = GroupName + "/metric-source-type"
+	// MetricSourceHTTP is an endpoint serving a JSON document, the metric being
+	// the number at the dot-separated path given by the query, or the whole
+	// document if there is none.
+	MetricSourceHTTP = "http"
+	// MetricSourcePrometheus is a Prometheus server, the metric being the sum
+	// of the samples the PromQL query given by the query evaluates to.
+	MetricSourcePrometheus = "prometheus"
+	// MetricSourceURLAnnotationKey is the annotation to specify the URL of the
+	// endpoint the external metric is read from. It must be allowed by the
+	// allowed-metric-sources key of the config-autoscaler ConfigMap.
+	MetricSourceURLAnnotationKey = GroupName + "/metric-source-url"
+	// MetricSourceQueryAnnotationKey is the annotation to specify the query
+	// selecting the external metric at its endpoint.
+	MetricSourceQueryAnnotationKey = GroupName + "/metric-source-query"
 
 	// TargetAnnotationKey is the annotation to specify what metric value the
 	// PodAutoscaler should attempt to maintain. For example,
@@ -107,6 +191,9 @@ const (
 	//   KPA will compute the decay multiplier automatically based on the window size
 	//   and it is at least 0.2. This algorithm might not utilize all the values
 	//   in the window, due to their coefficients being infinitesimal.
+	// - percentile — the percentile of the per-second metric values in the
+	//   window given by the metric-percentile annotation, so that bursts
+	//   aren't hidden by the average.
 	MetricAggregationAlgorithmKey = GroupName + "/metric-aggregation-algorithm"
 
 	// MetricAggregationAlgorithmLinear is the linear aggregation algorithm with all weights
@@ -123,6 +210,19 @@ const (
 	// and return MetricAggregationAlgorithmWeightedExponential
 	MetricAggregationAlgorithmWeightedExponentialAlt = "weightedExponential"
 
+	// MetricAggregationAlgorithmPercentile is the aggregation algorithm that
+	// takes a percentile of the metric values, rather than their average.
+	MetricAggregationAlgorithmPercentile = "percentile"
+
+	// MetricPercentileAnnotationKey is the annotation to specify the percentile
+	// of the metric values, greater than 0 and at most 100, the percentile
+	// aggregation algorithm takes. It defaults to DefaultMetricPercentile.
+	MetricPercentileAnnotationKey = GroupName + "/metric-percentile"
+
+	// DefaultMetricPercentile is the percentile the percentile aggregation
+	// algorithm takes by default.
+	DefaultMetricPercentile = 90.0
+
 	// WindowAnnotationKey is the annotation to specify the time
 	// interval over which to calculate the average metric.  Larger
 	// values result in more smoothing. For example,
@@ -226,9 +326,15 @@ const (
 )
 
 var (
+	ApplyRecommendedResourcesAnnotation = kmap.KeyPriority{
+		ApplyRecommendedResourcesAnnotationKey,
+	}
 	ClassAnnotation = kmap.KeyPriority{
 		ClassAnnotationKey,
 	}
+	ShareProcessNamespaceAnnotation = kmap.KeyPriority{
+		ShareProcessNamespaceAnnotationKey,
+	}
 	InitialScaleAnnotation = kmap.KeyPriority{
 		InitialScaleAnnotationKey,
 		GroupName + "/initialScale",
@@ -241,13 +347,28 @@ var (
 	MetricAnnotation = kmap.KeyPriority{
 		MetricAnnotationKey,
 	}
+	MetricSourceTypeAnnotation = kmap.KeyPriority{
+		MetricSourceTypeAnnotationKey,
+	}
+	MetricSourceURLAnnotation = kmap.KeyPriority{
+		MetricSourceURLAnnotationKey,
+	}
+	MetricSourceQueryAnnotation = kmap.KeyPriority{
+		MetricSourceQueryAnnotationKey,
+	}
 	MetricAggregationAlgorithmAnnotation = kmap.KeyPriority{
 		MetricAggregationAlgorithmKey,
 		GroupName + "/metricAggregationAlgorithm",
 	}
+	MetricPercentileAnnotation = kmap.KeyPriority{
+		MetricPercentileAnnotationKey,
+	}
 	ActivationScale = kmap.KeyPriority{
 		ActivationScaleKey,
 	}
+	MinAvailableAnnotation = kmap.KeyPriority{
+		MinAvailableAnnotationKey,
+	}
 	MinScaleAnnotation = kmap.KeyPriority{
 		MinScaleAnnotationKey,
 		GroupName + "/minScale",
@@ -264,6 +385,24 @@ var (
 		ScaleDownDelayAnnotationKey,
 		GroupName + "/scaleDownDelay",
 	}
+	ScaleUpPoliciesAnnotation = kmap.KeyPriority{
+		ScaleUpPoliciesAnnotationKey,
+	}
+	ScaleDownPoliciesAnnotation = kmap.KeyPriority{
+		ScaleDownPoliciesAnnotationKey,
+	}
+	ScaleUpSelectPolicyAnnotation = kmap.KeyPriority{
+		ScaleUpSelectPolicyAnnotationKey,
+	}
+	ScaleDownSelectPolicyAnnotation = kmap.KeyPriority{
+		ScaleDownSelectPolicyAnnotationKey,
+	}
+	ScaleUpStabilizationWindowAnnotation = kmap.KeyPriority{
+		ScaleUpStabilizationWindowAnnotationKey,
+	}
+	ScaleDownStabilizationWindowAnnotation = kmap.KeyPriority{
+		ScaleDownStabilizationWindowAnnotationKey,
+	}
 	ScaleToZeroPodRetentionPeriodAnnotation = kmap.KeyPriority{
 		ScaleToZeroPodRetentionPeriodKey,
 		GroupName + "/scaleToZeroPodRetentionPeriod",
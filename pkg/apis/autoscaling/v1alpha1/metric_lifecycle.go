@@ -17,6 +17,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"strconv"
+
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"knative.dev/pkg/apis"
 	"knative.dev/serving/pkg/apis/autoscaling"
@@ -86,3 +88,14 @@ func (m *Metric) AggregationAlgorithm() string {
 	}
 	return ""
 }
+
+// AggregationPercentile returns the percentile of the metric values the
+// percentile aggregation algorithm takes.
+func (m *Metric) AggregationPercentile() float64 {
+	if _, s, ok := autoscaling.MetricPercentileAnnotation.Get(m.Annotations); ok {
+		if p, err := strconv.ParseFloat(s, 64); err == nil && p > 0 && p <= 100 {
+			return p
+		}
+	}
+	return autoscaling.DefaultMetricPercentile
+}
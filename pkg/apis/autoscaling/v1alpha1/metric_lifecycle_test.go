@@ -189,3 +189,32 @@ func TestMetricGetGroupVersionKind(t *testing.T) {
 		t.Errorf("got: %v, want: %v", got, want)
 	}
 }
+
+func TestMetricAggregationPercentile(t *testing.T) {
+	tests := []struct {
+		name string
+		anns map[string]string
+		want float64
+	}{{
+		name: "default",
+		want: autoscaling.DefaultMetricPercentile,
+	}, {
+		name: "set",
+		anns: map[string]string{autoscaling.MetricPercentileAnnotationKey: "99.5"},
+		want: 99.5,
+	}, {
+		name: "invalid",
+		anns: map[string]string{autoscaling.MetricPercentileAnnotationKey: "110"},
+		want: autoscaling.DefaultMetricPercentile,
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := &Metric{}
+			m.Annotations = tc.anns
+			if got := m.AggregationPercentile(); got != tc.want {
+				t.Errorf("AggregationPercentile() = %v, want: %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ package v1alpha1
 import (
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
@@ -65,11 +66,35 @@ type MetricSpec struct {
 	PanicWindow time.Duration `json:"panicWindow"`
 	// ScrapeTarget is the K8s service that publishes the metric endpoint.
 	ScrapeTarget string `json:"scrapeTarget"`
+	// Source is the endpoint the external metric the revision is scaled on
+	// is read from, if it is scaled on one.
+	// +optional
+	Source *ExternalMetricSource `json:"source,omitempty"`
+}
+
+// ExternalMetricSource describes an endpoint publishing a metric outside of
+// the revision, such as the length of a queue in a broker.
+type ExternalMetricSource struct {
+	// Type is the kind of the endpoint, either "http" or "prometheus".
+	Type string `json:"type"`
+	// URL is the address of the endpoint.
+	URL string `json:"url"`
+	// Query selects the metric at the endpoint: the dot-separated path of a
+	// number in the JSON document served by an http endpoint, or the PromQL
+	// query evaluated by a prometheus one.
+	// +optional
+	Query string `json:"query,omitempty"`
 }
 
 // MetricStatus reflects the status of metric collection for this specific entity.
 type MetricStatus struct {
 	duckv1.Status `json:",inline"`
+
+	// RecommendedResources are the resource requests recommended for the
+	// containers of the revision together, from their usage. The queue-proxy
+	// is not included.
+	// +optional
+	RecommendedResources corev1.ResourceList `json:"recommendedResources,omitempty"`
 }
 
 // MetricList is a list of Metric resources
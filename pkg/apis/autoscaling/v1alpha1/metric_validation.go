@@ -18,9 +18,11 @@ package v1alpha1
 
 import (
 	"context"
+	"net/url"
 
 	"k8s.io/apimachinery/pkg/api/equality"
 	"knative.dev/pkg/apis"
+	"knative.dev/serving/pkg/apis/autoscaling"
 	"knative.dev/serving/pkg/apis/serving"
 )
 
@@ -35,5 +37,28 @@ func (ms *MetricSpec) Validate(ctx context.Context) *apis.FieldError {
 	if equality.Semantic.DeepEqual(ms, &MetricSpec{}) {
 		return apis.ErrMissingField(apis.CurrentField)
 	}
+	if ms.Source != nil {
+		return ms.Source.Validate(ctx).ViaField("source")
+	}
 	return nil
 }
+
+// Validate validates the ExternalMetricSource.
+func (s *ExternalMetricSource) Validate(context.Context) (errs *apis.FieldError) {
+	switch s.Type {
+	case autoscaling.MetricSourceHTTP, autoscaling.MetricSourcePrometheus:
+	case "":
+		errs = errs.Also(apis.ErrMissingField("type"))
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(s.Type, "type"))
+	}
+	if s.URL == "" {
+		errs = errs.Also(apis.ErrMissingField("url"))
+	} else if u, err := url.Parse(s.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs = errs.Also(apis.ErrInvalidValue(s.URL, "url"))
+	}
+	if s.Type == autoscaling.MetricSourcePrometheus && s.Query == "" {
+		errs = errs.Also(apis.ErrMissingField("query"))
+	}
+	return errs
+}
@@ -25,6 +25,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"knative.dev/pkg/apis"
+	"knative.dev/serving/pkg/apis/autoscaling"
 )
 
 func TestMetricValidation(t *testing.T) {
@@ -98,6 +99,35 @@ func TestMetricValidation(t *testing.T) {
 			},
 		},
 		want: nil,
+	}, {
+		name: "valid external metric source",
+		m: &Metric{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: MetricSpec{
+				Source: &ExternalMetricSource{
+					Type: autoscaling.MetricSourceHTTP,
+					URL:  "http://queue.example.com/depth",
+				},
+			},
+		},
+		want: nil,
+	}, {
+		name: "invalid external metric source",
+		m: &Metric{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: MetricSpec{
+				Source: &ExternalMetricSource{
+					Type: autoscaling.MetricSourcePrometheus,
+					URL:  "prometheus",
+				},
+			},
+		},
+		want: apis.ErrInvalidValue("prometheus", "spec.source.url").
+			Also(apis.ErrMissingField("spec.source.query")),
 	}}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -31,6 +31,17 @@ import (
 	"knative.dev/serving/pkg/autoscaler/config/autoscalerconfig"
 )
 
+const (
+	// ReasonCrashLooping is the reason of the failed activation of a PA whose
+	// pods keep restarting before its target ever became ready.
+	ReasonCrashLooping = "CrashLooping"
+
+	// ReasonTerminalExitCode is the reason of the failed activation of a PA
+	// whose pods all exited with a terminal exit code before its target ever
+	// became ready.
+	ReasonTerminalExitCode = "TerminalExitCode"
+)
+
 var podCondSet = apis.NewLivingConditionSet(
 	PodAutoscalerConditionActive,
 	PodAutoscalerConditionScaleTargetInitialized,
@@ -65,6 +76,18 @@ func (pa *PodAutoscaler) Metric() string {
 	return defaultMetric(pa.Class())
 }
 
+// ExternalMetricSource returns the endpoint the external metric the PA is
+// scaled on is read from, or nil if it is not scaled on an external metric.
+func (pa *PodAutoscaler) ExternalMetricSource() *ExternalMetricSource {
+	if pa.Metric() != autoscaling.External {
+		return nil
+	}
+	_, t, _ := autoscaling.MetricSourceTypeAnnotation.Get(pa.Annotations)
+	_, u, _ := autoscaling.MetricSourceURLAnnotation.Get(pa.Annotations)
+	_, q, _ := autoscaling.MetricSourceQueryAnnotation.Get(pa.Annotations)
+	return &ExternalMetricSource{Type: t, URL: u, Query: q}
+}
+
 func (pa *PodAutoscaler) annotationInt32(k kmap.KeyPriority) (int32, bool) {
 	if _, s, ok := k.Get(pa.Annotations); ok {
 		i, err := strconv.ParseInt(s, 10, 32)
@@ -122,6 +145,20 @@ func (pa *PodAutoscaler) TargetUtilization() (float64, bool) {
 	return 0, false
 }
 
+// ScaleUpRules returns the rules for scaling up, or nil if they are not set or
+// invalid.
+func (pa *PodAutoscaler) ScaleUpRules() *autoscaling.ScalingRules {
+	rules, _ := autoscaling.ScaleUpRules(pa.Annotations)
+	return rules
+}
+
+// ScaleDownRules returns the rules for scaling down, or nil if they are not set
+// or invalid.
+func (pa *PodAutoscaler) ScaleDownRules() *autoscaling.ScalingRules {
+	rules, _ := autoscaling.ScaleDownRules(pa.Annotations)
+	return rules
+}
+
 // TargetBC returns the target burst capacity, if the corresponding annotation is set.
 func (pa *PodAutoscaler) TargetBC() (float64, bool) {
 	// The value is validated in the webhook.
@@ -250,6 +287,22 @@ func (pas *PodAutoscalerStatus) MarkInactive(reason, message string) {
 	podCondSet.Manage(pas).MarkFalse(PodAutoscalerConditionActive, reason, message)
 }
 
+// MarkActivationFailed marks the PA as inactive because its target can't
+// become ready, with ReasonCrashLooping or ReasonTerminalExitCode.
+func (pas *PodAutoscalerStatus) MarkActivationFailed(reason, message string) {
+	pas.MarkInactive(reason, message)
+}
+
+// ActivationFailure returns the "Active" condition of the PA if it was marked
+// with MarkActivationFailed, or nil.
+func (pas *PodAutoscalerStatus) ActivationFailure() *apis.Condition {
+	c := pas.GetCondition(PodAutoscalerConditionActive)
+	if c.IsFalse() && (c.Reason == ReasonCrashLooping || c.Reason == ReasonTerminalExitCode) {
+		return c
+	}
+	return nil
+}
+
 // MarkResourceNotOwned changes the "Active" condition to false to reflect that the
 // resource of the given kind and name has already been created, and we do not own it.
 func (pas *PodAutoscalerStatus) MarkResourceNotOwned(kind, name string) {
@@ -264,6 +317,23 @@ func (pas *PodAutoscalerStatus) MarkResourceFailedCreation(kind, name string) {
 		fmt.Sprintf("Failed to create %s %q.", kind, name))
 }
 
+// MarkThrottled marks the PA's PodAutoscalerConditionThrottled condition true,
+// as its scale is limited by the pod budget of its namespace.
+func (pas *PodAutoscalerStatus) MarkThrottled(message string) {
+	podCondSet.Manage(pas).MarkTrueWithReason(PodAutoscalerConditionThrottled, "PodBudgetExceeded", "%s", message)
+}
+
+// MarkNotThrottled clears the PA's PodAutoscalerConditionThrottled condition.
+func (pas *PodAutoscalerStatus) MarkNotThrottled() {
+	podCondSet.Manage(pas).ClearCondition(PodAutoscalerConditionThrottled)
+}
+
+// IsThrottled returns true if the PA's scale is limited by the pod budget of
+// its namespace.
+func (pas *PodAutoscalerStatus) IsThrottled() bool {
+	return pas.GetCondition(PodAutoscalerConditionThrottled).IsTrue()
+}
+
 // InactiveFor returns the time PA spent being inactive.
 func (pas *PodAutoscalerStatus) InactiveFor(now time.Time) time.Duration {
 	return pas.inStatusFor(corev1.ConditionFalse, now)
@@ -1279,3 +1279,58 @@ func TestIsScaleTargetInitialized(t *testing.T) {
 		t.Errorf("after marking initially active: got: %v, want: %v", got, want)
 	}
 }
+
+func TestActivationFailure(t *testing.T) {
+	p := PodAutoscaler{}
+	p.Status.InitializeConditions()
+	if got := p.Status.ActivationFailure(); got != nil {
+		t.Errorf("ActivationFailure() = %v, want: nil", got)
+	}
+
+	p.Status.MarkInactive("NoTraffic", "The target is not receiving traffic.")
+	if got := p.Status.ActivationFailure(); got != nil {
+		t.Errorf("ActivationFailure() after MarkInactive = %v, want: nil", got)
+	}
+
+	p.Status.MarkActivationFailed(ReasonTerminalExitCode, "exited with 127")
+	apistest.CheckConditionFailed(&p.Status, PodAutoscalerConditionActive, t)
+	got := p.Status.ActivationFailure()
+	if got == nil {
+		t.Fatal("ActivationFailure() = nil after MarkActivationFailed")
+	}
+	if got.Reason != ReasonTerminalExitCode || got.Message != "exited with 127" {
+		t.Errorf("ActivationFailure() = %s: %s, want: %s: %s", got.Reason, got.Message, ReasonTerminalExitCode, "exited with 127")
+	}
+
+	p.Status.MarkActive()
+	if got := p.Status.ActivationFailure(); got != nil {
+		t.Errorf("ActivationFailure() after MarkActive = %v, want: nil", got)
+	}
+}
+
+func TestThrottled(t *testing.T) {
+	p := PodAutoscaler{}
+	p.Status.InitializeConditions()
+	p.Status.MarkActive()
+	p.Status.MarkScaleTargetInitialized()
+	p.Status.MarkSKSReady()
+
+	p.Status.MarkThrottled("The pod budget is 100% used.")
+	if !p.Status.IsThrottled() {
+		t.Error("IsThrottled() = false after MarkThrottled")
+	}
+	if got, want := p.Status.GetCondition(PodAutoscalerConditionThrottled).Message, "The pod budget is 100% used."; got != want {
+		t.Errorf("Message = %q, want: %q", got, want)
+	}
+	// Throttling doesn't affect the readiness.
+	apistest.CheckConditionSucceeded(&p.Status, PodAutoscalerConditionReady, t)
+
+	p.Status.MarkNotThrottled()
+	if p.Status.IsThrottled() {
+		t.Error("IsThrottled() = true after MarkNotThrottled")
+	}
+	if cond := p.Status.GetCondition(PodAutoscalerConditionThrottled); cond != nil {
+		t.Errorf("Throttled condition = %v, want: nil", cond)
+	}
+	apistest.CheckConditionSucceeded(&p.Status, PodAutoscalerConditionReady, t)
+}
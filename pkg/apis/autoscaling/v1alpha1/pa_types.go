@@ -108,6 +108,9 @@ const (
 	PodAutoscalerConditionActive apis.ConditionType = "Active"
 	// PodAutoscalerConditionSKSReady is set when SKS is ready.
 	PodAutoscalerConditionSKSReady = "SKSReady"
+	// PodAutoscalerConditionThrottled is set when the PodAutoscaler's scale is
+	// limited by the pod budget of its namespace.
+	PodAutoscalerConditionThrottled apis.ConditionType = "Throttled"
 )
 
 // PodAutoscalerStatus communicates the observed state of the PodAutoscaler (from the controller).
@@ -127,6 +130,74 @@ type PodAutoscalerStatus struct {
 
 	// ActualScale shows the actual number of replicas for the revision.
 	ActualScale *int32 `json:"actualScale,omitempty"`
+
+	// RequestedScale shows the number of replicas the revision would scale to
+	// without the pod budget of its namespace. It is only set when the
+	// namespace has a pod budget.
+	// +optional
+	RequestedScale *int32 `json:"requestedScale,omitempty"`
+
+	// RecommendedResources are the resource requests recommended for the
+	// containers of the revision together, from their usage. The queue-proxy
+	// is not included.
+	// +optional
+	RecommendedResources corev1.ResourceList `json:"recommendedResources,omitempty"`
+
+	// ColdStart breaks down the latency of the most recent activation of the
+	// revision from zero.
+	// +optional
+	ColdStart *ColdStartStatus `json:"coldStart,omitempty"`
+
+	// LastScaleDecision is a condensed explanation of the latest scaling
+	// decision of the autoscaler for the revision. It only has the decision
+	// and the rules that shaped it, the observed values are in the
+	// explanations served by the autoscaler at /debug/scaling.
+	// +optional
+	LastScaleDecision string `json:"lastScaleDecision,omitempty"`
+}
+
+// ColdStartStatus breaks down the latency of an activation from zero by phase.
+// The phases are consecutive, so their sum is the time from the first request
+// to the first ready pod.
+type ColdStartStatus struct {
+	// ActivationTime is when the revision was scaled up from zero.
+	ActivationTime metav1.Time `json:"activationTime"`
+
+	// ReadyTime is when the first pod became ready. It is unset while the
+	// activation is in progress.
+	// +optional
+	ReadyTime *metav1.Time `json:"readyTime,omitempty"`
+
+	// PodName is the name of the first pod to become ready.
+	// +optional
+	PodName string `json:"podName,omitempty"`
+
+	// AutoscalerDecision is the time from the autoscaler observing a request
+	// for the revision until the revision was scaled up.
+	// +optional
+	AutoscalerDecision *metav1.Duration `json:"autoscalerDecision,omitempty"`
+
+	// PodScheduling is the time from the revision being scaled up until
+	// the pod was scheduled.
+	// +optional
+	PodScheduling *metav1.Duration `json:"podScheduling,omitempty"`
+
+	// PodInitialization is the time from the pod being scheduled until it was
+	// initialized. This includes running the init containers.
+	// +optional
+	PodInitialization *metav1.Duration `json:"podInitialization,omitempty"`
+
+	// ImagePull is the time from the pod being initialized until the user
+	// container was started. This is dominated by pulling the image when it
+	// is not cached on the node.
+	// +optional
+	ImagePull *metav1.Duration `json:"imagePull,omitempty"`
+
+	// Readiness is the time from the user container being started until the
+	// pod was ready, i.e. the application initialization and the readiness
+	// probe.
+	// +optional
+	Readiness *metav1.Duration `json:"readiness,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -22,16 +22,79 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ColdStartStatus) DeepCopyInto(out *ColdStartStatus) {
+	*out = *in
+	in.ActivationTime.DeepCopyInto(&out.ActivationTime)
+	if in.ReadyTime != nil {
+		in, out := &in.ReadyTime, &out.ReadyTime
+		*out = (*in).DeepCopy()
+	}
+	if in.AutoscalerDecision != nil {
+		in, out := &in.AutoscalerDecision, &out.AutoscalerDecision
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.PodScheduling != nil {
+		in, out := &in.PodScheduling, &out.PodScheduling
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.PodInitialization != nil {
+		in, out := &in.PodInitialization, &out.PodInitialization
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ImagePull != nil {
+		in, out := &in.ImagePull, &out.ImagePull
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Readiness != nil {
+		in, out := &in.Readiness, &out.Readiness
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ColdStartStatus.
+func (in *ColdStartStatus) DeepCopy() *ColdStartStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ColdStartStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalMetricSource) DeepCopyInto(out *ExternalMetricSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalMetricSource.
+func (in *ExternalMetricSource) DeepCopy() *ExternalMetricSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalMetricSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Metric) DeepCopyInto(out *Metric) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 	return
 }
@@ -90,6 +153,11 @@ func (in *MetricList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetricSpec) DeepCopyInto(out *MetricSpec) {
 	*out = *in
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(ExternalMetricSource)
+		**out = **in
+	}
 	return
 }
 
@@ -107,6 +175,13 @@ func (in *MetricSpec) DeepCopy() *MetricSpec {
 func (in *MetricStatus) DeepCopyInto(out *MetricStatus) {
 	*out = *in
 	in.Status.DeepCopyInto(&out.Status)
+	if in.RecommendedResources != nil {
+		in, out := &in.RecommendedResources, &out.RecommendedResources
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
 	return
 }
 
@@ -212,6 +287,23 @@ func (in *PodAutoscalerStatus) DeepCopyInto(out *PodAutoscalerStatus) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.RequestedScale != nil {
+		in, out := &in.RequestedScale, &out.RequestedScale
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RecommendedResources != nil {
+		in, out := &in.RecommendedResources, &out.RecommendedResources
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.ColdStart != nil {
+		in, out := &in.ColdStart, &out.ColdStart
+		*out = new(ColdStartStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -46,6 +46,11 @@ const (
 
 	// DryRunFeatureKey gates the podspec dryrun feature and runs with the value 'enabled'
 	DryRunFeatureKey = "features.knative.dev/podspec-dryrun"
+
+	// ReferencesCheckFeatureKey gates the check of the objects referenced by
+	// the podspec. Missing objects are warned about with the value 'enabled'
+	// and rejected with the value 'strict'.
+	ReferencesCheckFeatureKey = "features.knative.dev/podspec-references-check"
 )
 
 func defaultFeaturesConfig() *Features {
@@ -54,6 +59,7 @@ func defaultFeaturesConfig() *Features {
 		PodSpecAffinity:                  Disabled,
 		PodSpecTopologySpreadConstraints: Disabled,
 		PodSpecDryRun:                    Allowed,
+		PodSpecReferencesCheck:           Allowed,
 		PodSpecHostAliases:               Disabled,
 		PodSpecFieldRef:                  Disabled,
 		PodSpecNodeSelector:              Disabled,
@@ -80,30 +86,7 @@ func defaultFeaturesConfig() *Features {
 func NewFeaturesConfigFromMap(data map[string]string) (*Features, error) {
 	nc := defaultFeaturesConfig()
 
-	if err := cm.Parse(data,
-		asFlag("multi-container", &nc.MultiContainer),
-		asFlag("kubernetes.podspec-affinity", &nc.PodSpecAffinity),
-		asFlag("kubernetes.podspec-topologyspreadconstraints", &nc.PodSpecTopologySpreadConstraints),
-		asFlag("kubernetes.podspec-dryrun", &nc.PodSpecDryRun),
-		asFlag("kubernetes.podspec-hostaliases", &nc.PodSpecHostAliases),
-		asFlag("kubernetes.podspec-fieldref", &nc.PodSpecFieldRef),
-		asFlag("kubernetes.podspec-nodeselector", &nc.PodSpecNodeSelector),
-		asFlag("kubernetes.podspec-runtimeclassname", &nc.PodSpecRuntimeClassName),
-		asFlag("kubernetes.podspec-securitycontext", &nc.PodSpecSecurityContext),
-		asFlag("kubernetes.podspec-priorityclassname", &nc.PodSpecPriorityClassName),
-		asFlag("kubernetes.podspec-schedulername", &nc.PodSpecSchedulerName),
-		asFlag("kubernetes.containerspec-addcapabilities", &nc.ContainerSpecAddCapabilities),
-		asFlag("kubernetes.podspec-tolerations", &nc.PodSpecTolerations),
-		asFlag("kubernetes.podspec-volumes-emptydir", &nc.PodSpecVolumesEmptyDir),
-		asFlag("kubernetes.podspec-init-containers", &nc.PodSpecInitContainers),
-		asFlag("kubernetes.podspec-persistent-volume-claim", &nc.PodSpecPersistentVolumeClaim),
-		asFlag("kubernetes.podspec-persistent-volume-write", &nc.PodSpecPersistentVolumeWrite),
-		asFlag("kubernetes.podspec-dnspolicy", &nc.PodSpecDNSPolicy),
-		asFlag("kubernetes.podspec-dnsconfig", &nc.PodSpecDNSConfig),
-		asFlag("secure-pod-defaults", &nc.SecurePodDefaults),
-		asFlag("tag-header-based-routing", &nc.TagHeaderBasedRouting),
-		asFlag("queueproxy.mount-podinfo", &nc.QueueProxyMountPodInfo),
-		asFlag("autodetect-http2", &nc.AutoDetectHTTP2)); err != nil {
+	if err := cm.Parse(data, nc.flags()...); err != nil {
 		return nil, err
 	}
 	return nc, nil
@@ -120,6 +103,7 @@ type Features struct {
 	PodSpecAffinity                  Flag
 	PodSpecTopologySpreadConstraints Flag
 	PodSpecDryRun                    Flag
+	PodSpecReferencesCheck           Flag
 	PodSpecFieldRef                  Flag
 	PodSpecHostAliases               Flag
 	PodSpecNodeSelector              Flag
@@ -141,6 +125,53 @@ type Features struct {
 	AutoDetectHTTP2                  Flag
 }
 
+// flags returns the parsers of the flags of the config map keys into f.
+func (f *Features) flags() []cm.ParseFunc {
+	return append(asFlags(f.podSpecFlags()),
+		asFlag("kubernetes.podspec-dryrun", &f.PodSpecDryRun),
+		asFlag("secure-pod-defaults", &f.SecurePodDefaults),
+		asFlag("tag-header-based-routing", &f.TagHeaderBasedRouting),
+		asFlag("queueproxy.mount-podinfo", &f.QueueProxyMountPodInfo),
+		asFlag("autodetect-http2", &f.AutoDetectHTTP2),
+	)
+}
+
+// podSpecFlags returns the flags gating the fields of the PodSpecs by their
+// config map keys. Only the webhook evaluates them, when it defaults and
+// validates the resources, so they are the ones a namespace may override.
+func (f *Features) podSpecFlags() map[string]*Flag {
+	return map[string]*Flag{
+		"multi-container":                              &f.MultiContainer,
+		"kubernetes.podspec-affinity":                  &f.PodSpecAffinity,
+		"kubernetes.podspec-topologyspreadconstraints": &f.PodSpecTopologySpreadConstraints,
+		"kubernetes.podspec-references-check":          &f.PodSpecReferencesCheck,
+		"kubernetes.podspec-hostaliases":               &f.PodSpecHostAliases,
+		"kubernetes.podspec-fieldref":                  &f.PodSpecFieldRef,
+		"kubernetes.podspec-nodeselector":              &f.PodSpecNodeSelector,
+		"kubernetes.podspec-runtimeclassname":          &f.PodSpecRuntimeClassName,
+		"kubernetes.podspec-securitycontext":           &f.PodSpecSecurityContext,
+		"kubernetes.podspec-priorityclassname":         &f.PodSpecPriorityClassName,
+		"kubernetes.podspec-schedulername":             &f.PodSpecSchedulerName,
+		"kubernetes.containerspec-addcapabilities":     &f.ContainerSpecAddCapabilities,
+		"kubernetes.podspec-tolerations":               &f.PodSpecTolerations,
+		"kubernetes.podspec-volumes-emptydir":          &f.PodSpecVolumesEmptyDir,
+		"kubernetes.podspec-init-containers":           &f.PodSpecInitContainers,
+		"kubernetes.podspec-persistent-volume-claim":   &f.PodSpecPersistentVolumeClaim,
+		"kubernetes.podspec-persistent-volume-write":   &f.PodSpecPersistentVolumeWrite,
+		"kubernetes.podspec-dnspolicy":                 &f.PodSpecDNSPolicy,
+		"kubernetes.podspec-dnsconfig":                 &f.PodSpecDNSConfig,
+	}
+}
+
+// asFlags returns the parsers of the given flags by their keys.
+func asFlags(flags map[string]*Flag) []cm.ParseFunc {
+	parsers := make([]cm.ParseFunc, 0, len(flags))
+	for key, target := range flags {
+		parsers = append(parsers, asFlag(key, target))
+	}
+	return parsers
+}
+
 // asFlag parses the value at key as a Flag into the target, if it exists.
 func asFlag(key string, target *Flag) cm.ParseFunc {
 	return func(data map[string]string) error {
@@ -200,6 +200,15 @@ func TestFeaturesConfiguration(t *testing.T) {
 		data: map[string]string{
 			"kubernetes.podspec-dryrun": "Disabled",
 		},
+	}, {
+		name:    "kubernetes.podspec-references-check Enabled",
+		wantErr: false,
+		wantFeatures: defaultWith(&Features{
+			PodSpecReferencesCheck: Enabled,
+		}),
+		data: map[string]string{
+			"kubernetes.podspec-references-check": "Enabled",
+		},
 	}, {
 		name:    "kubernetes.podspec-hostaliases Disabled",
 		wantErr: false,
@@ -0,0 +1,116 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+
+	cm "knative.dev/pkg/configmap"
+	"knative.dev/pkg/logging"
+)
+
+// NamespaceFeatureAnnotationPrefix is the prefix of the annotations of a
+// Namespace overriding the config-features flags for the resources in it.
+// For example, to allow tolerations in a namespace only:
+//
+//	features.knative.dev/kubernetes.podspec-tolerations: Enabled
+//
+// Only the flags gating the fields of the PodSpecs, other than the dry run,
+// can be overridden, as the others are read by the controllers.
+//
+// Only cluster admins can annotate Namespaces by default.
+const NamespaceFeatureAnnotationPrefix = "features.knative.dev/"
+
+// NamespaceAnnotationsFunc returns the annotations of the namespace.
+type NamespaceAnnotationsFunc func(namespace string) (map[string]string, error)
+
+type nsAnnotationsKey struct{}
+
+// WithNamespaceAnnotations attaches the function looking up the annotations of
+// the namespaces to the context, so that ForNamespace can apply their feature
+// overrides.
+func WithNamespaceAnnotations(ctx context.Context, f NamespaceAnnotationsFunc) context.Context {
+	return context.WithValue(ctx, nsAnnotationsKey{}, f)
+}
+
+// ForNamespace returns the context with the Config for the resources in the
+// namespace attached: the Features are overridden by the annotations of the
+// namespace, if the context has a function looking them up.
+func ForNamespace(ctx context.Context, namespace string) context.Context {
+	lookup, ok := ctx.Value(nsAnnotationsKey{}).(NamespaceAnnotationsFunc)
+	cfg := FromContext(ctx)
+	if !ok || cfg == nil || cfg.Features == nil || namespace == "" {
+		return ctx
+	}
+
+	annotations, err := lookup(namespace)
+	if err != nil {
+		logging.FromContext(ctx).Warnw("Failed to look up the feature overrides of namespace "+namespace, zap.Error(err))
+		return ctx
+	}
+	features, ignored := cfg.Features.WithNamespaceOverrides(annotations)
+	if len(ignored) > 0 {
+		logging.FromContext(ctx).Warnf("Ignoring the annotations of namespace %s overriding features that can't be overridden per namespace: %v",
+			namespace, ignored)
+	}
+	if features == cfg.Features {
+		return ctx
+	}
+
+	nc := *cfg
+	nc.Features = features
+	return ToContext(ctx, &nc)
+}
+
+// WithNamespaceOverrides returns the features with the flags overridden by the
+// annotations of a namespace, or f itself if they override none, along with
+// the sorted keys of the annotations overriding flags that can't be
+// overridden per namespace, which are ignored. The invalid flags are ignored,
+// like in config-features.
+func (f *Features) WithNamespaceOverrides(annotations map[string]string) (*Features, []string) {
+	var (
+		data    = make(map[string]string, len(annotations))
+		ignored []string
+		known   = f.podSpecFlags()
+	)
+	for k, v := range annotations {
+		key := strings.TrimPrefix(k, NamespaceFeatureAnnotationPrefix)
+		if key == k {
+			continue
+		}
+		if _, ok := known[key]; !ok {
+			ignored = append(ignored, k)
+			continue
+		}
+		data[key] = v
+	}
+	sort.Strings(ignored)
+	if len(data) == 0 {
+		return f, ignored
+	}
+
+	nf := f.DeepCopy()
+	if err := cm.Parse(data, asFlags(nf.podSpecFlags())...); err != nil {
+		// The flag parsers never fail.
+		return f, ignored
+	}
+	return nf, ignored
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWithNamespaceOverrides(t *testing.T) {
+	cluster := defaultFeaturesConfig()
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        func(*Features)
+		wantIgnored []string
+	}{{
+		name: "no annotations",
+	}, {
+		name: "unrelated annotations",
+		annotations: map[string]string{
+			"kubernetes.podspec-tolerations":            "Enabled",
+			"features.knative.dev/podspec-dryrun":       "enabled",
+			"features.knative.dev/kubernetes.podspec-x": "Enabled",
+		},
+		wantIgnored: []string{
+			"features.knative.dev/kubernetes.podspec-x",
+			"features.knative.dev/podspec-dryrun",
+		},
+	}, {
+		name: "opt in and out",
+		annotations: map[string]string{
+			NamespaceFeatureAnnotationPrefix + "kubernetes.podspec-tolerations":      "enabled",
+			NamespaceFeatureAnnotationPrefix + "kubernetes.podspec-affinity":         "Allowed",
+			NamespaceFeatureAnnotationPrefix + "kubernetes.podspec-volumes-emptydir": "Disabled",
+		},
+		want: func(f *Features) {
+			f.PodSpecTolerations = Enabled
+			f.PodSpecAffinity = Allowed
+			f.PodSpecVolumesEmptyDir = Disabled
+		},
+	}, {
+		name: "flags read by the controllers",
+		annotations: map[string]string{
+			NamespaceFeatureAnnotationPrefix + "multi-container":           "Disabled",
+			NamespaceFeatureAnnotationPrefix + "queueproxy.mount-podinfo":  "Enabled",
+			NamespaceFeatureAnnotationPrefix + "tag-header-based-routing":  "Enabled",
+			NamespaceFeatureAnnotationPrefix + "autodetect-http2":          "Enabled",
+			NamespaceFeatureAnnotationPrefix + "secure-pod-defaults":       "Enabled",
+			NamespaceFeatureAnnotationPrefix + "kubernetes.podspec-dryrun": "Enabled",
+		},
+		want: func(f *Features) {
+			f.MultiContainer = Disabled
+		},
+		wantIgnored: []string{
+			NamespaceFeatureAnnotationPrefix + "autodetect-http2",
+			NamespaceFeatureAnnotationPrefix + "kubernetes.podspec-dryrun",
+			NamespaceFeatureAnnotationPrefix + "queueproxy.mount-podinfo",
+			NamespaceFeatureAnnotationPrefix + "secure-pod-defaults",
+			NamespaceFeatureAnnotationPrefix + "tag-header-based-routing",
+		},
+	}, {
+		name: "invalid flag",
+		annotations: map[string]string{
+			NamespaceFeatureAnnotationPrefix + "kubernetes.podspec-tolerations": "sure",
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := defaultFeaturesConfig()
+			if tt.want != nil {
+				tt.want(want)
+			}
+			got, ignored := cluster.WithNamespaceOverrides(tt.annotations)
+			if !cmp.Equal(got, want) {
+				t.Error("WithNamespaceOverrides (-want, +got):", cmp.Diff(want, got))
+			}
+			if !cmp.Equal(ignored, tt.wantIgnored) {
+				t.Error("Ignored overrides (-want, +got):", cmp.Diff(tt.wantIgnored, ignored))
+			}
+			if !cmp.Equal(cluster, defaultFeaturesConfig()) {
+				t.Error("WithNamespaceOverrides() modified the cluster features")
+			}
+		})
+	}
+}
+
+func TestForNamespace(t *testing.T) {
+	annotations := func(namespace string) (map[string]string, error) {
+		switch namespace {
+		case "gpu":
+			return map[string]string{
+				NamespaceFeatureAnnotationPrefix + "kubernetes.podspec-tolerations": "Enabled",
+			}, nil
+		case "plain":
+			return nil, nil
+		}
+		return nil, errors.New("namespace not found")
+	}
+	cfg := &Config{Features: defaultFeaturesConfig()}
+	ctx := WithNamespaceAnnotations(ToContext(context.Background(), cfg), annotations)
+
+	if got := FromContext(ForNamespace(ctx, "gpu")).Features.PodSpecTolerations; got != Enabled {
+		t.Errorf("PodSpecTolerations in gpu = %v, want: %v", got, Enabled)
+	}
+	if got := FromContext(ctx).Features.PodSpecTolerations; got != Disabled {
+		t.Errorf("PodSpecTolerations of the cluster = %v, want: %v", got, Disabled)
+	}
+	for _, ns := range []string{"plain", "missing", ""} {
+		if got := FromContext(ForNamespace(ctx, ns)); got != cfg {
+			t.Errorf("Config in %q = %v, want the cluster one", ns, got)
+		}
+	}
+
+	// Without a lookup the features of the cluster apply.
+	ctx = ToContext(context.Background(), cfg)
+	if got := FromContext(ForNamespace(ctx, "gpu")); got != cfg {
+		t.Errorf("Config without lookup = %v, want the cluster one", got)
+	}
+}
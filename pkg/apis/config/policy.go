@@ -0,0 +1,222 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	cm "knative.dev/pkg/configmap"
+)
+
+const (
+	// PolicyConfigName is the name of the config map for the constraints the
+	// revisions are validated against.
+	PolicyConfigName = "config-policy"
+
+	// The config-policy keys. Each of them can be overridden for a namespace
+	// with a key suffixed with "." and the namespace name.
+	allowedRegistriesKey       = "allowed-registries"
+	maxContainerConcurrencyKey = "max-container-concurrency"
+	maxTimeoutSecondsKey       = "max-timeout-seconds"
+	requiredResourcesKey       = "required-resources"
+	maxScaleKey                = "max-scale"
+	forbiddenEnvVarsKey        = "forbidden-env-vars"
+)
+
+var policyKeys = []string{
+	allowedRegistriesKey,
+	maxContainerConcurrencyKey,
+	maxTimeoutSecondsKey,
+	requiredResourcesKey,
+	maxScaleKey,
+	forbiddenEnvVarsKey,
+}
+
+// Policy holds the constraints the revisions are validated against.
+type Policy struct {
+	// Constraints are the constraints of the namespaces without overrides.
+	Constraints *PolicyConstraints
+
+	// NamespaceConstraints override Constraints for the namespaces they have
+	// keys for.
+	NamespaceConstraints map[string]*PolicyConstraints
+}
+
+// PolicyConstraints are the constraints of the revisions in a namespace.
+// The zero values don't constrain the revisions.
+type PolicyConstraints struct {
+	// AllowedRegistries are the registries, or repository prefixes such as
+	// gcr.io/my-project, the images of the containers must come from.
+	AllowedRegistries []string
+
+	// MaxContainerConcurrency is the maximum containerConcurrency. An
+	// unbounded containerConcurrency (0) isn't allowed when it is set.
+	MaxContainerConcurrency int64
+
+	// MaxTimeoutSeconds is the maximum timeoutSeconds.
+	MaxTimeoutSeconds int64
+
+	// RequiredResources are the resources the containers must request or be
+	// limited in, such as "requests.cpu" or "limits.memory".
+	RequiredResources []string
+
+	// MaxScale is the maximum max-scale. An unlimited max-scale (0) isn't
+	// allowed when it is set.
+	MaxScale int32
+
+	// ForbiddenEnvVars are the names of the env vars the containers can't set.
+	ForbiddenEnvVars sets.String
+}
+
+// For returns the constraints of the revisions in the namespace.
+func (p *Policy) For(namespace string) *PolicyConstraints {
+	if c, ok := p.NamespaceConstraints[namespace]; ok {
+		return c
+	}
+	return p.Constraints
+}
+
+// NewPolicyConfigFromMap creates a Policy from the supplied Map.
+func NewPolicyConfigFromMap(data map[string]string) (*Policy, error) {
+	constraints, err := newPolicyConstraintsFromMap(data)
+	if err != nil {
+		return nil, err
+	}
+	nc := &Policy{Constraints: constraints}
+
+	namespaces := sets.NewString()
+	for k := range data {
+		for _, key := range policyKeys {
+			if ns := strings.TrimPrefix(k, key+"."); ns != k && ns != "" {
+				namespaces.Insert(ns)
+			}
+		}
+	}
+	for _, ns := range namespaces.List() {
+		// The keys that aren't overridden are inherited from the cluster-wide ones.
+		nsData := make(map[string]string, len(policyKeys))
+		for _, key := range policyKeys {
+			if v, ok := data[key+"."+ns]; ok {
+				nsData[key] = v
+			} else if v, ok := data[key]; ok {
+				nsData[key] = v
+			}
+		}
+		constraints, err := newPolicyConstraintsFromMap(nsData)
+		if err != nil {
+			return nil, fmt.Errorf("namespace %q: %w", ns, err)
+		}
+		if nc.NamespaceConstraints == nil {
+			nc.NamespaceConstraints = make(map[string]*PolicyConstraints, namespaces.Len())
+		}
+		nc.NamespaceConstraints[ns] = constraints
+	}
+	return nc, nil
+}
+
+// NewPolicyConfigFromConfigMap creates a Policy from the supplied configMap.
+func NewPolicyConfigFromConfigMap(config *corev1.ConfigMap) (*Policy, error) {
+	return NewPolicyConfigFromMap(config.Data)
+}
+
+func newPolicyConstraintsFromMap(data map[string]string) (*PolicyConstraints, error) {
+	nc := &PolicyConstraints{}
+	var registries, resources sets.String
+	if err := cm.Parse(data,
+		cm.AsStringSet(allowedRegistriesKey, &registries),
+		cm.AsInt64(maxContainerConcurrencyKey, &nc.MaxContainerConcurrency),
+		cm.AsInt64(maxTimeoutSecondsKey, &nc.MaxTimeoutSeconds),
+		cm.AsStringSet(requiredResourcesKey, &resources),
+		cm.AsInt32(maxScaleKey, &nc.MaxScale),
+		cm.AsStringSet(forbiddenEnvVarsKey, &nc.ForbiddenEnvVars),
+	); err != nil {
+		return nil, err
+	}
+	// Empty values, such as "a,,b" or "", list nothing.
+	registries.Delete("")
+	resources.Delete("")
+	nc.ForbiddenEnvVars.Delete("")
+
+	if nc.MaxContainerConcurrency < 0 {
+		return nil, fmt.Errorf("%s = %d, must be at least 0", maxContainerConcurrencyKey, nc.MaxContainerConcurrency)
+	}
+	if nc.MaxTimeoutSeconds < 0 {
+		return nil, fmt.Errorf("%s = %d, must be at least 0", maxTimeoutSecondsKey, nc.MaxTimeoutSeconds)
+	}
+	if nc.MaxScale < 0 {
+		return nil, fmt.Errorf("%s = %d, must be at least 0", maxScaleKey, nc.MaxScale)
+	}
+	for _, r := range resources.List() {
+		kind, res, _ := strings.Cut(r, ".")
+		if kind != "requests" && kind != "limits" {
+			return nil, fmt.Errorf("%s: %q must start with requests. or limits.", requiredResourcesKey, r)
+		}
+		if msgs := validation.IsQualifiedName(res); len(msgs) > 0 {
+			return nil, fmt.Errorf("%s: %q is not a resource name: %s", requiredResourcesKey, r, strings.Join(msgs, ", "))
+		}
+	}
+	for _, r := range registries.List() {
+		// Normalize the registries, e.g. docker.io to index.docker.io.
+		var repo string
+		if strings.Contains(r, "/") {
+			rp, err := name.NewRepository(r, name.WeakValidation)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", allowedRegistriesKey, err)
+			}
+			repo = rp.Name()
+		} else {
+			rg, err := name.NewRegistry(r, name.WeakValidation)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", allowedRegistriesKey, err)
+			}
+			repo = rg.Name()
+		}
+		nc.AllowedRegistries = append(nc.AllowedRegistries, repo)
+	}
+	if resources.Len() > 0 {
+		nc.RequiredResources = resources.List()
+	}
+	if nc.ForbiddenEnvVars.Len() == 0 {
+		nc.ForbiddenEnvVars = nil
+	}
+	return nc, nil
+}
+
+// AllowsImage returns true if the image comes from one of the allowed
+// registries, or if any registry is allowed.
+func (c *PolicyConstraints) AllowsImage(image string) bool {
+	if len(c.AllowedRegistries) == 0 {
+		return true
+	}
+	ref, err := name.ParseReference(image, name.WeakValidation)
+	if err != nil {
+		return false
+	}
+	repo := ref.Context().Name()
+	for _, r := range c.AllowedRegistries {
+		if repo == r || strings.HasPrefix(repo, r+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	. "knative.dev/pkg/configmap/testing"
+)
+
+func TestPolicyConfigurationFromFile(t *testing.T) {
+	cm, example := ConfigMapsFromTestFile(t, PolicyConfigName)
+
+	if _, err := NewPolicyConfigFromConfigMap(cm); err != nil {
+		t.Error("NewPolicyConfigFromConfigMap(actual) =", err)
+	}
+
+	got, err := NewPolicyConfigFromConfigMap(example)
+	if err != nil {
+		t.Fatal("NewPolicyConfigFromConfigMap(example) =", err)
+	}
+
+	want := &Policy{Constraints: &PolicyConstraints{}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Error("Example does not represent default config: diff(-want,+got)\n", diff)
+	}
+}
+
+func TestPolicyConfiguration(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    map[string]string
+		want    *Policy
+		wantErr bool
+	}{{
+		name: "default",
+		data: map[string]string{},
+		want: &Policy{Constraints: &PolicyConstraints{}},
+	}, {
+		name: "cluster-wide constraints",
+		data: map[string]string{
+			"allowed-registries":        "gcr.io/my-project, docker.io",
+			"max-container-concurrency": "100",
+			"max-timeout-seconds":       "300",
+			"required-resources":        "requests.cpu,limits.memory",
+			"max-scale":                 "10",
+			"forbidden-env-vars":        "LD_PRELOAD,,GODEBUG",
+		},
+		want: &Policy{Constraints: &PolicyConstraints{
+			AllowedRegistries:       []string{"index.docker.io", "gcr.io/my-project"},
+			MaxContainerConcurrency: 100,
+			MaxTimeoutSeconds:       300,
+			RequiredResources:       []string{"limits.memory", "requests.cpu"},
+			MaxScale:                10,
+			ForbiddenEnvVars:        sets.NewString("GODEBUG", "LD_PRELOAD"),
+		}},
+	}, {
+		name: "namespace overrides",
+		data: map[string]string{
+			"max-scale":                   "10",
+			"forbidden-env-vars":          "LD_PRELOAD",
+			"max-scale.payments":          "50",
+			"allowed-registries.payments": "registry.example.com/payments",
+			"forbidden-env-vars.sandbox":  "",
+		},
+		want: &Policy{
+			Constraints: &PolicyConstraints{
+				MaxScale:         10,
+				ForbiddenEnvVars: sets.NewString("LD_PRELOAD"),
+			},
+			NamespaceConstraints: map[string]*PolicyConstraints{
+				"payments": {
+					AllowedRegistries: []string{"registry.example.com/payments"},
+					MaxScale:          50,
+					ForbiddenEnvVars:  sets.NewString("LD_PRELOAD"),
+				},
+				"sandbox": {
+					MaxScale: 10,
+				},
+			},
+		},
+	}, {
+		name:    "invalid max-scale",
+		data:    map[string]string{"max-scale": "lots"},
+		wantErr: true,
+	}, {
+		name:    "negative max-container-concurrency",
+		data:    map[string]string{"max-container-concurrency": "-1"},
+		wantErr: true,
+	}, {
+		name:    "negative max-timeout-seconds",
+		data:    map[string]string{"max-timeout-seconds": "-1"},
+		wantErr: true,
+	}, {
+		name:    "required resource without kind",
+		data:    map[string]string{"required-resources": "cpu"},
+		wantErr: true,
+	}, {
+		name:    "required resource with invalid name",
+		data:    map[string]string{"required-resources": "requests.not a resource"},
+		wantErr: true,
+	}, {
+		name:    "invalid registry",
+		data:    map[string]string{"allowed-registries": "not a registry"},
+		wantErr: true,
+	}, {
+		name:    "invalid namespace override",
+		data:    map[string]string{"max-scale.payments": "-1"},
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewPolicyConfigFromMap(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewPolicyConfigFromMap() error = %v, want: %v", err, tt.wantErr)
+			}
+			if !cmp.Equal(got, tt.want) {
+				t.Error("NewPolicyConfigFromMap (-want, +got):", cmp.Diff(tt.want, got))
+			}
+		})
+	}
+}
+
+func TestPolicyFor(t *testing.T) {
+	cluster := &PolicyConstraints{MaxScale: 10}
+	payments := &PolicyConstraints{MaxScale: 50}
+	policy := &Policy{
+		Constraints:          cluster,
+		NamespaceConstraints: map[string]*PolicyConstraints{"payments": payments},
+	}
+
+	if got := policy.For("payments"); got != payments {
+		t.Errorf("For(payments) = %v, want: %v", got, payments)
+	}
+	if got := policy.For("default"); got != cluster {
+		t.Errorf("For(default) = %v, want: %v", got, cluster)
+	}
+}
+
+func TestPolicyAllowsImage(t *testing.T) {
+	policy, err := NewPolicyConfigFromMap(map[string]string{
+		"allowed-registries": "docker.io,gcr.io/my-project",
+	})
+	if err != nil {
+		t.Fatal("NewPolicyConfigFromMap() =", err)
+	}
+
+	tests := []struct {
+		image string
+		want  bool
+	}{{
+		image: "nginx",
+		want:  true,
+	}, {
+		image: "docker.io/library/nginx:1.25",
+		want:  true,
+	}, {
+		image: "gcr.io/my-project/app@sha256:0a9b3d4e0a9b3d4e0a9b3d4e0a9b3d4e0a9b3d4e0a9b3d4e0a9b3d4e0a9b3d4e",
+		want:  true,
+	}, {
+		image: "gcr.io/my-project-2/app",
+	}, {
+		image: "gcr.io/other/app",
+	}, {
+		image: "ghcr.io/my-project/app",
+	}, {
+		image: "not an image",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			if got := policy.Constraints.AllowsImage(tt.image); got != tt.want {
+				t.Errorf("AllowsImage(%q) = %v, want: %v", tt.image, got, tt.want)
+			}
+		})
+	}
+
+	if !(&PolicyConstraints{}).AllowsImage("anything.example.com/app") {
+		t.Error("AllowsImage() = false without allowed registries, want: true")
+	}
+}
@@ -31,6 +31,7 @@ type Config struct {
 	Defaults   *Defaults
 	Features   *Features
 	Autoscaler *autoscalerconfig.Config
+	Policy     *Policy
 }
 
 // FromContext extracts a Config from the provided context.
@@ -61,6 +62,10 @@ func FromContextOrDefaults(ctx context.Context) *Config {
 	if cfg.Autoscaler == nil {
 		cfg.Autoscaler, _ = asconfig.NewConfigFromMap(map[string]string{})
 	}
+
+	if cfg.Policy == nil {
+		cfg.Policy, _ = NewPolicyConfigFromMap(map[string]string{})
+	}
 	return cfg
 }
 
@@ -86,6 +91,7 @@ func NewStore(logger configmap.Logger, onAfterStore ...func(name string, value i
 				DefaultsConfigName:  NewDefaultsConfigFromConfigMap,
 				FeaturesConfigName:  NewFeaturesConfigFromConfigMap,
 				asconfig.ConfigName: asconfig.NewConfigFromConfigMap,
+				PolicyConfigName:    NewPolicyConfigFromConfigMap,
 			},
 			onAfterStore...,
 		),
@@ -111,5 +117,8 @@ func (s *Store) Load() *Config {
 	if as, ok := s.UntypedLoad(asconfig.ConfigName).(*autoscalerconfig.Config); ok {
 		cfg.Autoscaler = as.DeepCopy()
 	}
+	if policy, ok := s.UntypedLoad(PolicyConfigName).(*Policy); ok {
+		cfg.Policy = policy.DeepCopy()
+	}
 	return cfg
 }
@@ -39,10 +39,12 @@ func TestStoreLoadWithContext(t *testing.T) {
 	defaultsConfig := ConfigMapFromTestFile(t, DefaultsConfigName)
 	featuresConfig := ConfigMapFromTestFile(t, FeaturesConfigName)
 	autoscalerConfig := ConfigMapFromTestFile(t, autoscalerconfig.ConfigName)
+	policyConfig := ConfigMapFromTestFile(t, PolicyConfigName)
 
 	store.OnConfigChanged(defaultsConfig)
 	store.OnConfigChanged(featuresConfig)
 	store.OnConfigChanged(autoscalerConfig)
+	store.OnConfigChanged(policyConfig)
 
 	config := FromContextOrDefaults(store.ToContext(context.Background()))
 
@@ -66,12 +68,20 @@ func TestStoreLoadWithContext(t *testing.T) {
 			t.Errorf("Unexpected autoscaler config (-want, +got):\n%v", diff)
 		}
 	})
+
+	t.Run("policy", func(t *testing.T) {
+		expected, _ := NewPolicyConfigFromConfigMap(policyConfig)
+		if diff := cmp.Diff(expected, config.Policy, ignoreStuff...); diff != "" {
+			t.Errorf("Unexpected policy config (-want, +got):\n%v", diff)
+		}
+	})
 }
 
 func TestStoreLoadWithContextOrDefaults(t *testing.T) {
 	defaultsConfig := ConfigMapFromTestFile(t, DefaultsConfigName)
 	featuresConfig := ConfigMapFromTestFile(t, FeaturesConfigName)
 	autoscalerConfig := ConfigMapFromTestFile(t, autoscalerconfig.ConfigName)
+	policyConfig := ConfigMapFromTestFile(t, PolicyConfigName)
 	config := FromContextOrDefaults(context.Background())
 
 	t.Run("defaults", func(t *testing.T) {
@@ -94,6 +104,13 @@ func TestStoreLoadWithContextOrDefaults(t *testing.T) {
 			t.Errorf("Unexpected autoscaler config (-want, +got):\n%v", diff)
 		}
 	})
+
+	t.Run("policy", func(t *testing.T) {
+		expected, _ := NewPolicyConfigFromConfigMap(policyConfig)
+		if diff := cmp.Diff(expected, config.Policy, ignoreStuff...); diff != "" {
+			t.Errorf("Unexpected policy config (-want, +got):\n%v", diff)
+		}
+	})
 }
 
 func TestStoreImmutableConfig(t *testing.T) {
@@ -102,12 +119,14 @@ func TestStoreImmutableConfig(t *testing.T) {
 	store.OnConfigChanged(ConfigMapFromTestFile(t, DefaultsConfigName))
 	store.OnConfigChanged(ConfigMapFromTestFile(t, FeaturesConfigName))
 	store.OnConfigChanged(ConfigMapFromTestFile(t, autoscalerconfig.ConfigName))
+	store.OnConfigChanged(ConfigMapFromTestFile(t, PolicyConfigName))
 
 	config := store.Load()
 
 	config.Defaults.RevisionTimeoutSeconds = 1234
 	config.Features.MultiContainer = Disabled
 	config.Autoscaler.TargetBurstCapacity = 99
+	config.Policy.Constraints.MaxScale = 5
 
 	newConfig := store.Load()
 
@@ -122,4 +141,8 @@ func TestStoreImmutableConfig(t *testing.T) {
 	if newConfig.Autoscaler.TargetBurstCapacity == 99 {
 		t.Error("Autoscaler config is not immutable")
 	}
+
+	if newConfig.Policy.Constraints.MaxScale == 5 {
+		t.Error("Policy config is not immutable")
+	}
 }
@@ -22,6 +22,7 @@ limitations under the License.
 package config
 
 import (
+	sets "k8s.io/apimachinery/pkg/util/sets"
 	autoscalerconfig "knative.dev/serving/pkg/autoscaler/config/autoscalerconfig"
 )
 
@@ -43,6 +44,11 @@ func (in *Config) DeepCopyInto(out *Config) {
 		*out = new(autoscalerconfig.Config)
 		**out = **in
 	}
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(Policy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -138,3 +144,72 @@ func (in *ObjectMetaTemplate) DeepCopyInto(out *ObjectMetaTemplate) {
 	*out = in.DeepCopy()
 	return
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Policy) DeepCopyInto(out *Policy) {
+	*out = *in
+	if in.Constraints != nil {
+		in, out := &in.Constraints, &out.Constraints
+		*out = new(PolicyConstraints)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NamespaceConstraints != nil {
+		in, out := &in.NamespaceConstraints, &out.NamespaceConstraints
+		*out = make(map[string]*PolicyConstraints, len(*in))
+		for key, val := range *in {
+			var outVal *PolicyConstraints
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = new(PolicyConstraints)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Policy.
+func (in *Policy) DeepCopy() *Policy {
+	if in == nil {
+		return nil
+	}
+	out := new(Policy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyConstraints) DeepCopyInto(out *PolicyConstraints) {
+	*out = *in
+	if in.AllowedRegistries != nil {
+		in, out := &in.AllowedRegistries, &out.AllowedRegistries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RequiredResources != nil {
+		in, out := &in.RequiredResources, &out.RequiredResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ForbiddenEnvVars != nil {
+		in, out := &in.ForbiddenEnvVars, &out.ForbiddenEnvVars
+		*out = make(sets.String, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyConstraints.
+func (in *PolicyConstraints) DeepCopy() *PolicyConstraints {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyConstraints)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -19,6 +19,7 @@ package serving
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -75,6 +76,18 @@ func ValidateRolloutDurationAnnotation(annos map[string]string) (errs *apis.Fiel
 	return errs
 }
 
+// ValidateRolloutOnConfigChangeAnnotation validates the rollout on config change
+// annotation. This annotation can be set on either service or configuration
+// objects.
+func ValidateRolloutOnConfigChangeAnnotation(annos map[string]string) *apis.FieldError {
+	if k, v, ok := RolloutOnConfigChangeAnnotation.Get(annos); ok {
+		if _, err := strconv.ParseBool(v); err != nil {
+			return apis.ErrInvalidValue(v, k)
+		}
+	}
+	return nil
+}
+
 // ValidateHasNoAutoscalingAnnotation validates that the respective entity does not have
 // annotations from the autoscaling group. It's to be used to validate Service and
 // Configuration.
@@ -448,6 +448,32 @@ func TestAnnotationUpdate(t *testing.T) {
 	}
 }
 
+func TestValidateRolloutOnConfigChangeAnnotation(t *testing.T) {
+	tests := []struct {
+		name  string
+		annos map[string]string
+		want  string
+	}{{
+		name: "unset",
+	}, {
+		name:  "valid",
+		annos: map[string]string{RolloutOnConfigChangeAnnotationKey: "true"},
+	}, {
+		name:  "invalid",
+		annos: map[string]string{RolloutOnConfigChangeAnnotationKey: "always"},
+		want:  "invalid value: always: serving.knative.dev/rollout-on-config-change",
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateRolloutOnConfigChangeAnnotation(tc.annos)
+			if got, want := err.Error(), tc.want; got != want {
+				t.Errorf("APIErr mismatch, diff(-want,+got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
 func TestValidateRolloutDurationAnnotation(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -0,0 +1,81 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serving
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/serving/pkg/apis/config"
+)
+
+// ReferencesCheckMode represents the possible values of the
+// config.ReferencesCheckFeatureKey annotation.
+type ReferencesCheckMode string
+
+const (
+	// ReferencesCheckEnabled warns about the missing referenced objects.
+	ReferencesCheckEnabled ReferencesCheckMode = "enabled"
+
+	// ReferencesCheckStrict rejects the podspecs referencing missing objects.
+	ReferencesCheckStrict ReferencesCheckMode = "strict"
+)
+
+// ReferencesChecker checks that the ConfigMaps, Secrets, keys and
+// ServiceAccounts the pod spec references exist in the namespace, and returns
+// the errors about the ones that don't.
+type ReferencesChecker func(ctx context.Context, namespace string, ps *corev1.PodSpec) *apis.FieldError
+
+type referencesCheckerKey struct{}
+
+// WithReferencesChecker attaches the checker of the objects referenced by the
+// pod specs to the context, so that ValidateReferences can run it.
+func WithReferencesChecker(ctx context.Context, checker ReferencesChecker) context.Context {
+	return context.WithValue(ctx, referencesCheckerKey{}, checker)
+}
+
+// ValidateReferences checks the objects referenced by the pod spec of the
+// object with the ReferencesChecker of the context, if the
+// kubernetes.podspec-references-check feature or the annotation of the object
+// enable it. The missing objects are warnings, unless the annotation is
+// "strict".
+func ValidateReferences(ctx context.Context, om metav1.ObjectMeta, ps *corev1.PodSpec) *apis.FieldError {
+	checker, ok := ctx.Value(referencesCheckerKey{}).(ReferencesChecker)
+	if !ok {
+		return nil
+	}
+
+	mode := ReferencesCheckMode(om.Annotations[config.ReferencesCheckFeatureKey])
+	switch config.FromContextOrDefaults(ctx).Features.PodSpecReferencesCheck {
+	case config.Enabled:
+		if mode != ReferencesCheckStrict {
+			mode = ReferencesCheckEnabled
+		}
+	case config.Disabled:
+		return nil
+	}
+
+	switch mode {
+	case ReferencesCheckStrict:
+		return checker(ctx, om.Namespace, ps)
+	case ReferencesCheckEnabled:
+		return checker(ctx, om.Namespace, ps).At(apis.WarningLevel)
+	}
+	return nil
+}
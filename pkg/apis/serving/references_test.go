@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serving
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/serving/pkg/apis/config"
+)
+
+func TestValidateReferences(t *testing.T) {
+	missing := apis.ErrGeneric(`Secret "creds" not found in namespace "foo"`, "imagePullSecrets[0].name")
+	checker := func(_ context.Context, namespace string, _ *corev1.PodSpec) *apis.FieldError {
+		if namespace != "foo" {
+			t.Errorf("namespace = %q, want: foo", namespace)
+		}
+		return missing
+	}
+
+	tests := []struct {
+		name       string
+		flag       config.Flag
+		annotation ReferencesCheckMode
+		noChecker  bool
+		want       *apis.FieldError
+	}{{
+		name: "allowed without annotation",
+		flag: config.Allowed,
+	}, {
+		name:       "allowed with annotation",
+		flag:       config.Allowed,
+		annotation: ReferencesCheckEnabled,
+		want:       missing.At(apis.WarningLevel),
+	}, {
+		name:       "allowed with strict annotation",
+		flag:       config.Allowed,
+		annotation: ReferencesCheckStrict,
+		want:       missing,
+	}, {
+		name: "enabled without annotation",
+		flag: config.Enabled,
+		want: missing.At(apis.WarningLevel),
+	}, {
+		name:       "enabled with strict annotation",
+		flag:       config.Enabled,
+		annotation: ReferencesCheckStrict,
+		want:       missing,
+	}, {
+		name:       "disabled with annotation",
+		flag:       config.Disabled,
+		annotation: ReferencesCheckStrict,
+	}, {
+		name:       "unknown annotation",
+		flag:       config.Allowed,
+		annotation: "sure",
+	}, {
+		name:      "no checker",
+		flag:      config.Enabled,
+		noChecker: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := config.ToContext(context.Background(), &config.Config{
+				Features: &config.Features{PodSpecReferencesCheck: tt.flag},
+			})
+			if !tt.noChecker {
+				ctx = WithReferencesChecker(ctx, checker)
+			}
+			om := metav1.ObjectMeta{Namespace: "foo", Name: "bar"}
+			if tt.annotation != "" {
+				om.Annotations = map[string]string{config.ReferencesCheckFeatureKey: string(tt.annotation)}
+			}
+
+			got := ValidateReferences(ctx, om, &corev1.PodSpec{})
+			if got.Error() != tt.want.Error() {
+				t.Errorf("ValidateReferences() = %v, want: %v", got, tt.want)
+			}
+			if got.Filter(apis.ErrorLevel).Error() != tt.want.Filter(apis.ErrorLevel).Error() {
+				t.Errorf("ValidateReferences() errors = %v, want: %v",
+					got.Filter(apis.ErrorLevel), tt.want.Filter(apis.ErrorLevel))
+			}
+		})
+	}
+}
@@ -60,6 +60,26 @@ const (
 	// The value can be specified with at most with a second precision.
 	RolloutDurationKey = GroupName + "/rollout-duration"
 
+	// RolloutOnConfigChangeAnnotationKey is an annotation attached to a
+	// Configuration, or to a Service to be propagated to its Configuration, to
+	// have a new Revision rolled out whenever a ConfigMap or Secret its
+	// template refers to through env or envFrom changes. Only the ConfigMaps
+	// and Secrets with the RolloutOnConfigChangeLabelKey label are watched.
+	RolloutOnConfigChangeAnnotationKey = GroupName + "/rollout-on-config-change"
+
+	// RolloutOnConfigChangeLabelKey is the label the ConfigMaps and Secrets
+	// must have for their changes to be rolled out to the Configurations with
+	// the RolloutOnConfigChangeAnnotationKey annotation. The controller only
+	// watches the ConfigMaps and Secrets with this label.
+	RolloutOnConfigChangeLabelKey = GroupName + "/rollout-on-config-change"
+
+	// ReferencedConfigHashAnnotationKey is the annotation attached to a Revision
+	// to record the hash of the versions of the ConfigMaps and Secrets it refers
+	// to, when its Configuration has the RolloutOnConfigChangeAnnotationKey
+	// annotation. When they change, the new hash is stamped into the template
+	// of the Configuration, so that a new Revision is created.
+	ReferencedConfigHashAnnotationKey = GroupName + "/referenced-config-hash"
+
 	// RoutingStateLabelKey is the label attached to a Revision indicating
 	// its state in relation to serving a Route.
 	RoutingStateLabelKey = GroupName + "/routingState"
@@ -158,6 +178,9 @@ var (
 		RolloutDurationKey,
 		GroupName + "/rolloutDuration",
 	}
+	RolloutOnConfigChangeAnnotation = kmap.KeyPriority{
+		RolloutOnConfigChangeAnnotationKey,
+	}
 	QueueSidecarResourcePercentageAnnotation = kmap.KeyPriority{
 		QueueSidecarResourcePercentageAnnotationKey,
 		"queue.sidecar." + GroupName + "/resourcePercentage",
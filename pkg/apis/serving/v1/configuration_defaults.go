@@ -20,6 +20,7 @@ import (
 	"context"
 
 	"knative.dev/pkg/apis"
+	"knative.dev/serving/pkg/apis/config"
 	"knative.dev/serving/pkg/apis/serving"
 )
 
@@ -42,6 +43,7 @@ func previousConfigSpec(ctx context.Context) *ConfigurationSpec {
 // SetDefaults implements apis.Defaultable
 func (c *Configuration) SetDefaults(ctx context.Context) {
 	ctx = apis.WithinParent(ctx, c.ObjectMeta)
+	ctx = config.ForNamespace(ctx, c.Namespace)
 
 	var prevSpec *ConfigurationSpec
 	if prev, ok := apis.GetBaseline(ctx).(*Configuration); ok && prev != nil {
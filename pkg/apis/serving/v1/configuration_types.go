@@ -17,6 +17,7 @@ limitations under the License.
 package v1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
@@ -91,6 +92,11 @@ type ConfigurationStatusFields struct {
 	// Configuration. It might not be ready yet, for that use LatestReadyRevisionName.
 	// +optional
 	LatestCreatedRevisionName string `json:"latestCreatedRevisionName,omitempty"`
+
+	// RecommendedResources holds the resource requests recommended for the
+	// container of the latest ready Revision from its observed usage.
+	// +optional
+	RecommendedResources corev1.ResourceList `json:"recommendedResources,omitempty"`
 }
 
 // ConfigurationStatus communicates the observed state of the Configuration (from the controller).
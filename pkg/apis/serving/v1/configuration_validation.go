@@ -19,8 +19,10 @@ package v1
 import (
 	"context"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
+	"knative.dev/serving/pkg/apis/config"
 	"knative.dev/serving/pkg/apis/serving"
 )
 
@@ -32,11 +34,23 @@ func (c *Configuration) Validate(ctx context.Context) (errs *apis.FieldError) {
 	// spec validation.
 	if !apis.IsInStatusUpdate(ctx) {
 		errs = errs.Also(serving.ValidateObjectMetadata(ctx, c.GetObjectMeta(), false))
+		errs = errs.Also(serving.ValidateRolloutOnConfigChangeAnnotation(c.GetAnnotations()).ViaField("annotations"))
 		errs = errs.Also(c.validateLabels().ViaField("labels"))
 		errs = errs.ViaField("metadata")
 
 		ctx = apis.WithinParent(ctx, c.ObjectMeta)
+		ctx = config.ForNamespace(ctx, c.Namespace)
 		errs = errs.Also(c.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
+
+		// The references of the Configurations owned by a Service are checked
+		// with the Service.
+		if c.Labels[serving.ServiceLabelKey] == "" {
+			var baseline *RevisionTemplateSpec
+			if apis.IsInUpdate(ctx) {
+				baseline = &apis.GetBaseline(ctx).(*Configuration).Spec.Template
+			}
+			errs = errs.Also(validateTemplateReferences(ctx, c.ObjectMeta, &c.Spec.Template, baseline))
+		}
 	}
 
 	if apis.IsInUpdate(ctx) {
@@ -59,6 +73,15 @@ func (cs *ConfigurationSpec) Validate(ctx context.Context) *apis.FieldError {
 	return cs.Template.Validate(ctx).ViaField("template")
 }
 
+// validateTemplateReferences checks the objects referenced by the template of
+// the object, unless an update leaves its spec unchanged.
+func validateTemplateReferences(ctx context.Context, om metav1.ObjectMeta, template, baseline *RevisionTemplateSpec) *apis.FieldError {
+	if baseline != nil && equality.Semantic.DeepEqual(template.Spec, baseline.Spec) {
+		return nil
+	}
+	return serving.ValidateReferences(ctx, om, &template.Spec.PodSpec).ViaField("spec.template.spec")
+}
+
 // validateLabels function validates configuration labels
 func (c *Configuration) validateLabels() (errs *apis.FieldError) {
 	if val, ok := c.Labels[serving.ServiceLabelKey]; ok {
@@ -888,3 +888,44 @@ func TestConfigurationAnnotationUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigurationReferencesValidation(t *testing.T) {
+	checker := func(context.Context, string, *corev1.PodSpec) *apis.FieldError {
+		return apis.ErrGeneric(`ServiceAccount "app" not found in namespace "foo"`, "serviceAccountName")
+	}
+	ctx := serving.WithReferencesChecker(context.Background(), checker)
+
+	c := &Configuration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "valid",
+			Namespace: "foo",
+			Annotations: map[string]string{
+				config.ReferencesCheckFeatureKey: string(serving.ReferencesCheckStrict),
+			},
+		},
+		Spec: ConfigurationSpec{
+			Template: RevisionTemplateSpec{
+				Spec: RevisionSpec{
+					PodSpec: corev1.PodSpec{
+						ServiceAccountName: "app",
+						Containers: []corev1.Container{{
+							Image: "busybox",
+						}},
+					},
+				},
+			},
+		},
+	}
+
+	want := apis.ErrGeneric(`ServiceAccount "app" not found in namespace "foo"`, "spec.template.spec.serviceAccountName")
+	if got := c.Validate(ctx).Filter(apis.ErrorLevel); got.Error() != want.Error() {
+		t.Errorf("Validate() = %v, want: %v", got, want)
+	}
+
+	// The Configurations of a Service are checked with it.
+	c.Labels = map[string]string{serving.ServiceLabelKey: "valid"}
+	c.OwnerReferences = []metav1.OwnerReference{{Kind: "Service", Name: "valid"}}
+	if got := c.Validate(ctx).Filter(apis.ErrorLevel); got != nil {
+		t.Error("Validate() of a Service's Configuration =", got)
+	}
+}
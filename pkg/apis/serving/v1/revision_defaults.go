@@ -36,6 +36,7 @@ func (r *Revision) SetDefaults(ctx context.Context) {
 	if apis.IsInUpdate(ctx) {
 		return
 	}
+	ctx = config.ForNamespace(ctx, r.Namespace)
 	r.Spec.SetDefaults(apis.WithinSpec(ctx))
 }
 
@@ -18,6 +18,7 @@ package v1
 
 import (
 	"fmt"
+	"strings"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -34,6 +35,11 @@ const (
 	// as false if the a container image for the revision is missing.
 	ReasonContainerMissing = "ContainerMissing"
 
+	// ReasonImageVerificationFailed defines the reason for marking container
+	// healthiness status as false if a container image for the revision doesn't
+	// satisfy the image verification policy.
+	ReasonImageVerificationFailed = "ImageVerificationFailed"
+
 	// ReasonResolvingDigests defines the reason for marking container healthiness status
 	// as unknown if the digests for the container images are being resolved.
 	ReasonResolvingDigests = "ResolvingDigests"
@@ -182,6 +188,8 @@ func (rs *RevisionStatus) PropagateAutoscalerStatus(ps *autoscalingv1alpha1.PodA
 		rs.DesiredReplicas = ps.DesiredScale
 	}
 
+	rs.RecommendedResources = ps.RecommendedResources
+
 	if cond == nil {
 		rs.MarkActiveUnknown("Deploying", "")
 		return
@@ -215,9 +223,15 @@ func (rs *RevisionStatus) PropagateAutoscalerStatus(ps *autoscalingv1alpha1.PodA
 		// ScaleTargetInitialized down the road, we would have marked resources
 		// unavailable here, and have no way of recovering later.
 		// If the ResourcesAvailable is already false, don't override the message.
+		// If the PA failed the activation early, e.g. since the pods crash-loop,
+		// surface its reason instead.
 		if !ps.IsScaleTargetInitialized() && !resUnavailable && ps.ServiceName != "" {
-			rs.MarkResourcesAvailableFalse(ReasonProgressDeadlineExceeded,
-				"Initial scale was never achieved")
+			if failure := ps.ActivationFailure(); failure != nil {
+				rs.MarkResourcesAvailableFalse(failure.Reason, failure.Message)
+			} else {
+				rs.MarkResourcesAvailableFalse(ReasonProgressDeadlineExceeded,
+					"Initial scale was never achieved")
+			}
 		}
 		rs.MarkActiveFalse(cond.Reason, cond.Message)
 	case corev1.ConditionTrue:
@@ -250,8 +264,28 @@ func RevisionContainerExitingMessage(message string) string {
 	return fmt.Sprint("Container failed with: ", message)
 }
 
+// RevisionContainerDiagnosticsMessage summarises the restarts and the last
+// probe failure of the container for the status messages, or returns the empty
+// string if there are none.
+func RevisionContainerDiagnosticsMessage(status ContainerStatus) string {
+	var parts []string
+	if status.RestartCount > 0 {
+		parts = append(parts, fmt.Sprint("restarts: ", status.RestartCount))
+	}
+	if status.ProbeFailure != "" {
+		parts = append(parts, "last probe failure: "+status.ProbeFailure)
+	}
+	return strings.Join(parts, "; ")
+}
+
 // RevisionContainerMissingMessage constructs the status message if a given image
 // cannot be pulled correctly.
 func RevisionContainerMissingMessage(image string, message string) string {
 	return fmt.Sprintf("Unable to fetch image %q: %s", image, message)
 }
+
+// RevisionImageVerificationFailedMessage constructs the status message if a
+// given image doesn't satisfy the image verification policy.
+func RevisionImageVerificationFailedMessage(image string, message string) string {
+	return fmt.Sprintf("Unable to verify image %q: %s", image, message)
+}
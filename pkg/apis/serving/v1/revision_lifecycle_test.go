@@ -24,6 +24,7 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"knative.dev/pkg/apis"
@@ -658,6 +659,27 @@ func TestPropagateAutoscalerStatusNoProgress(t *testing.T) {
 	}
 }
 
+func TestPropagateAutoscalerStatusActivationFailed(t *testing.T) {
+	r := &RevisionStatus{}
+	r.InitializeConditions()
+
+	// PodAutoscaler gave up on the activation of the crash-looping pods.
+	ps := &autoscalingv1alpha1.PodAutoscalerStatus{ServiceName: "testRevision"}
+	ps.InitializeConditions()
+	ps.MarkActivationFailed(autoscalingv1alpha1.ReasonCrashLooping, "Container restarted 3 times")
+	r.PropagateAutoscalerStatus(ps)
+
+	apistest.CheckConditionFailed(r, RevisionConditionActive, t)
+	apistest.CheckConditionFailed(r, RevisionConditionResourcesAvailable, t)
+	cond := r.GetCondition(RevisionConditionResourcesAvailable)
+	if got, want := cond.Reason, autoscalingv1alpha1.ReasonCrashLooping; got != want {
+		t.Errorf("Reason = %q, want: %q", got, want)
+	}
+	if got, want := cond.Message, "Container restarted 3 times"; got != want {
+		t.Errorf("Message = %q, want: %q", got, want)
+	}
+}
+
 func TestPropagateAutoscalerStatusRace(t *testing.T) {
 	r := &RevisionStatus{}
 	r.InitializeConditions()
@@ -688,10 +710,11 @@ func TestPropagateAutoscalerStatusRace(t *testing.T) {
 func TestPropagateAutoscalerStatusReplicas(t *testing.T) {
 	r := RevisionStatus{}
 	testCases := []struct {
-		name                string
-		ps                  autoscalingv1alpha1.PodAutoscalerStatus
-		wantActualReplicas  *int32
-		wantDesiredReplicas *int32
+		name                     string
+		ps                       autoscalingv1alpha1.PodAutoscalerStatus
+		wantActualReplicas       *int32
+		wantDesiredReplicas      *int32
+		wantRecommendedResources corev1.ResourceList
 	}{{
 		name: "active PodAutoScaler",
 		ps: autoscalingv1alpha1.PodAutoscalerStatus{
@@ -700,6 +723,20 @@ func TestPropagateAutoscalerStatusReplicas(t *testing.T) {
 		},
 		wantActualReplicas:  ptr.Int32(1),
 		wantDesiredReplicas: ptr.Int32(2),
+	}, {
+		name: "PodAutoScaler with recommended resources",
+		ps: autoscalingv1alpha1.PodAutoscalerStatus{
+			ActualScale:  ptr.Int32(1),
+			DesiredScale: ptr.Int32(1),
+			RecommendedResources: corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("100m"),
+			},
+		},
+		wantActualReplicas:  ptr.Int32(1),
+		wantDesiredReplicas: ptr.Int32(1),
+		wantRecommendedResources: corev1.ResourceList{
+			corev1.ResourceCPU: resource.MustParse("100m"),
+		},
 	}, {
 		name: "inactive PodAutoScaler",
 		ps: autoscalingv1alpha1.PodAutoscalerStatus{
@@ -720,6 +757,10 @@ func TestPropagateAutoscalerStatusReplicas(t *testing.T) {
 			if !cmp.Equal(tc.wantDesiredReplicas, r.DesiredReplicas) {
 				t.Errorf("r.DesiredReplicas replicas wasn't as expected, (-want, +got):\n%s", cmp.Diff(tc.wantDesiredReplicas, r.DesiredReplicas))
 			}
+
+			if !cmp.Equal(tc.wantRecommendedResources, r.RecommendedResources) {
+				t.Errorf("r.RecommendedResources wasn't as expected, (-want, +got):\n%s", cmp.Diff(tc.wantRecommendedResources, r.RecommendedResources))
+			}
 		})
 	}
 }
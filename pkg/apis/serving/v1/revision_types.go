@@ -164,12 +164,63 @@ type RevisionStatus struct {
 	// DesiredReplicas reflects the desired amount of pods running this revision.
 	// +optional
 	DesiredReplicas *int32 `json:"desiredReplicas,omitempty"`
+
+	// RecommendedResources holds the resource requests recommended for the
+	// container of this revision from its observed usage.
+	// +optional
+	RecommendedResources corev1.ResourceList `json:"recommendedResources,omitempty"`
 }
 
-// ContainerStatus holds the information of container name and image digest value
+// ContainerStatus holds the information of container name and image digest value,
+// along with the diagnostics of the container when the revision's pods fail to
+// become available.
 type ContainerStatus struct {
 	Name        string `json:"name,omitempty"`
 	ImageDigest string `json:"imageDigest,omitempty"`
+
+	// RestartCount is the number of times the container has been restarted
+	// in the pod the diagnostics were gathered from.
+	// +optional
+	RestartCount int32 `json:"restartCount,omitempty"`
+
+	// Waiting holds the reason the container is not running yet,
+	// e.g. ImagePullBackOff or CrashLoopBackOff.
+	// +optional
+	Waiting *ContainerWaiting `json:"waiting,omitempty"`
+
+	// LastTermination holds the details of the last termination of the
+	// container, e.g. OOMKilled.
+	// +optional
+	LastTermination *ContainerTermination `json:"lastTermination,omitempty"`
+
+	// ProbeFailure is the message of the last failed probe of the container.
+	// +optional
+	ProbeFailure string `json:"probeFailure,omitempty"`
+}
+
+// ContainerWaiting describes why a container is waiting to run.
+type ContainerWaiting struct {
+	// Reason is the (brief) reason the container is not yet running.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message regarding why the container is not yet running.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ContainerTermination describes how a container terminated.
+type ContainerTermination struct {
+	// ExitCode is the exit status of the container.
+	ExitCode int32 `json:"exitCode"`
+
+	// Reason is the (brief) reason of the termination, e.g. OOMKilled or Error.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message regarding the termination of the container.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
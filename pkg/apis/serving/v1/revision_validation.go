@@ -23,6 +23,7 @@ import (
 	"strings"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/validation"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/kmp"
@@ -33,6 +34,7 @@ import (
 
 // Validate ensures Revision is properly configured.
 func (r *Revision) Validate(ctx context.Context) *apis.FieldError {
+	ctx = config.ForNamespace(ctx, r.Namespace)
 	errs := serving.ValidateObjectMetadata(ctx, r.GetObjectMeta(), true).Also(
 		r.ValidateLabels().ViaField("labels")).ViaField("metadata")
 	errs = errs.Also(r.Status.Validate(apis.WithinStatus(ctx)).ViaField("status"))
@@ -53,7 +55,10 @@ func (r *Revision) Validate(ctx context.Context) *apis.FieldError {
 			}
 		}
 	} else {
+		// The parent metadata carries the namespace the policy is looked up for.
+		ctx := apis.WithinParent(ctx, r.ObjectMeta)
 		errs = errs.Also(r.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
+		errs = errs.Also(validateMaxScalePolicy(ctx, r.Annotations).ViaField("metadata.annotations"))
 	}
 
 	return errs
@@ -64,12 +69,14 @@ func (rts *RevisionTemplateSpec) Validate(ctx context.Context) *apis.FieldError
 	errs := rts.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec")
 	errs = errs.Also(autoscaling.ValidateAnnotations(ctx, config.FromContextOrDefaults(ctx).Autoscaler,
 		rts.GetAnnotations()).ViaField("metadata.annotations"))
+	errs = errs.Also(validateUtilizationMetric(ctx, rts))
 
 	// If the RevisionTemplateSpec has a name specified, then check that
 	// it follows the requirements on the name.
 	errs = errs.Also(validateRevisionName(ctx, rts.Name, rts.GenerateName))
 	errs = errs.Also(validateQueueSidecarAnnotation(rts.Annotations).ViaField("metadata.annotations"))
 	errs = errs.Also(validateProgressDeadlineAnnotation(rts.Annotations).ViaField("metadata.annotations"))
+	errs = errs.Also(validateMaxScalePolicy(ctx, rts.Annotations).ViaField("metadata.annotations"))
 	return errs
 }
 
@@ -115,9 +122,109 @@ func (rs *RevisionSpec) Validate(ctx context.Context) *apis.FieldError {
 		errs = errs.Also(serving.ValidateContainerConcurrency(ctx, rs.ContainerConcurrency).ViaField("containerConcurrency"))
 	}
 
+	return errs.Also(rs.validatePolicy(ctx))
+}
+
+// validatePolicy checks the spec against the config-policy constraints of the
+// namespace of the revision.
+func (rs *RevisionSpec) validatePolicy(ctx context.Context) *apis.FieldError {
+	ns := apis.ParentMeta(ctx).Namespace
+	policy := config.FromContextOrDefaults(ctx).Policy.For(ns)
+
+	var errs *apis.FieldError
+	for i := range rs.InitContainers {
+		errs = errs.Also(validateContainerPolicy(policy, ns, &rs.InitContainers[i]).ViaFieldIndex("initContainers", i))
+	}
+	for i := range rs.Containers {
+		errs = errs.Also(validateContainerPolicy(policy, ns, &rs.Containers[i]).ViaFieldIndex("containers", i))
+	}
+
+	if max := policy.MaxContainerConcurrency; max > 0 && rs.ContainerConcurrency != nil {
+		// An unbounded containerConcurrency (0) exceeds any maximum.
+		if cc := *rs.ContainerConcurrency; cc == 0 || cc > max {
+			errs = errs.Also(policyViolation(apis.ErrOutOfBoundsValue(cc, 1, max, "containerConcurrency"), ns))
+		}
+	}
+	if max := policy.MaxTimeoutSeconds; max > 0 && rs.TimeoutSeconds != nil && *rs.TimeoutSeconds > max {
+		errs = errs.Also(policyViolation(apis.ErrOutOfBoundsValue(*rs.TimeoutSeconds, 0, max, "timeoutSeconds"), ns))
+	}
 	return errs
 }
 
+// validateContainerPolicy checks the container against the config-policy
+// constraints of the namespace.
+func validateContainerPolicy(policy *config.PolicyConstraints, ns string, container *corev1.Container) *apis.FieldError {
+	var errs *apis.FieldError
+	// A missing image is reported by the pod spec validation.
+	if container.Image != "" && !policy.AllowsImage(container.Image) {
+		errs = errs.Also(policyViolation(&apis.FieldError{
+			Message: fmt.Sprintf("image %q is not from an allowed registry", container.Image),
+			Paths:   []string{"image"},
+			Details: "allowed registries: " + strings.Join(policy.AllowedRegistries, ", "),
+		}, ns))
+	}
+
+	for _, r := range policy.RequiredResources {
+		list := container.Resources.Requests
+		kind, name, _ := strings.Cut(r, ".")
+		if kind == "limits" {
+			list = container.Resources.Limits
+		}
+		if _, ok := list[corev1.ResourceName(name)]; !ok {
+			errs = errs.Also(policyViolation(apis.ErrMissingField("resources."+r), ns))
+		}
+	}
+
+	for i, env := range container.Env {
+		if policy.ForbiddenEnvVars.Has(env.Name) {
+			errs = errs.Also(policyViolation(&apis.FieldError{
+				Message: fmt.Sprintf("env var %q is forbidden", env.Name),
+				Paths:   []string{"name"},
+			}, ns).ViaFieldIndex("env", i))
+		}
+	}
+	return errs
+}
+
+// validateMaxScalePolicy checks the max-scale annotation against the
+// config-policy constraints of the namespace of the revision. A revision
+// without the annotation gets the default max-scale of the autoscaler.
+func validateMaxScalePolicy(ctx context.Context, annotations map[string]string) *apis.FieldError {
+	ns := apis.ParentMeta(ctx).Namespace
+	cfg := config.FromContextOrDefaults(ctx)
+	max := cfg.Policy.For(ns).MaxScale
+	if max == 0 {
+		return nil
+	}
+
+	k, v, ok := autoscaling.MaxScaleAnnotation.Get(annotations)
+	if !ok {
+		if d := cfg.Autoscaler.MaxScale; d > 0 && d <= max {
+			return nil
+		}
+		return policyViolation(&apis.FieldError{
+			Message: fmt.Sprint("max-scale must be set, at most ", max),
+			Paths:   []string{autoscaling.MaxScaleAnnotationKey},
+		}, ns)
+	}
+	scale, err := strconv.ParseInt(v, 10, 32)
+	if err != nil {
+		// The invalid values are reported by the annotation validation.
+		return nil
+	}
+	if scale == 0 || scale > int64(max) {
+		return policyViolation(apis.ErrOutOfBoundsValue(scale, 1, max, k), ns)
+	}
+	return nil
+}
+
+// policyViolation qualifies the error as a violation of the config-policy of
+// the namespace.
+func policyViolation(err *apis.FieldError, ns string) *apis.FieldError {
+	err.Message += fmt.Sprintf(" (%s of namespace %q)", config.PolicyConfigName, ns)
+	return err
+}
+
 // Validate implements apis.Validatable
 func (rs *RevisionStatus) Validate(_ context.Context) *apis.FieldError {
 	return nil
@@ -198,6 +305,37 @@ func validateQueueSidecarAnnotation(m map[string]string) *apis.FieldError {
 	return nil
 }
 
+// validateUtilizationMetric checks that the containers request the resource
+// the KPA scales the revision on, as its utilization is relative to the requests.
+func validateUtilizationMetric(ctx context.Context, rts *RevisionTemplateSpec) *apis.FieldError {
+	class := config.FromContextOrDefaults(ctx).Autoscaler.PodAutoscalerClass
+	if _, c, ok := autoscaling.ClassAnnotation.Get(rts.Annotations); ok {
+		class = c
+	}
+	if class != autoscaling.KPA {
+		return nil
+	}
+
+	var resource corev1.ResourceName
+	switch _, metric, _ := autoscaling.MetricAnnotation.Get(rts.Annotations); metric {
+	case autoscaling.CPU:
+		resource = corev1.ResourceCPU
+	case autoscaling.Memory:
+		resource = corev1.ResourceMemory
+	default:
+		return nil
+	}
+	for _, c := range rts.Spec.Containers {
+		if q, ok := c.Resources.Requests[resource]; ok && !q.IsZero() {
+			return nil
+		}
+	}
+	return &apis.FieldError{
+		Message: fmt.Sprintf("scaling on %s utilization requires %s requests", resource, resource),
+		Paths:   []string{"spec.containers"},
+	}
+}
+
 // ValidateProgressDeadlineAnnotation validates the revision progress deadline annotation.
 func validateProgressDeadlineAnnotation(annos map[string]string) *apis.FieldError {
 	if k, v, _ := serving.ProgressDeadlineAnnotation.Get(annos); v != "" {
@@ -90,6 +90,232 @@ func TestRevisionValidation(t *testing.T) {
 	}
 }
 
+func TestRevisionValidationWithNamespaceFeatures(t *testing.T) {
+	features, err := config.NewFeaturesConfigFromMap(map[string]string{})
+	if err != nil {
+		t.Fatal("NewFeaturesConfigFromMap() =", err)
+	}
+	ctx := config.ToContext(context.Background(), &config.Config{Features: features})
+	ctx = config.WithNamespaceAnnotations(ctx, func(namespace string) (map[string]string, error) {
+		if namespace == "gpu" {
+			return map[string]string{
+				config.NamespaceFeatureAnnotationPrefix + "kubernetes.podspec-tolerations": "Enabled",
+			}, nil
+		}
+		return nil, nil
+	})
+
+	rev := func(namespace string) *Revision {
+		return &Revision{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      "valid",
+			},
+			Spec: RevisionSpec{
+				PodSpec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Image: "busybox",
+					}},
+					Tolerations: []corev1.Toleration{{
+						Key:      "nvidia.com/gpu",
+						Operator: corev1.TolerationOpExists,
+					}},
+				},
+			},
+		}
+	}
+
+	if got := rev("gpu").Validate(ctx).Filter(apis.ErrorLevel); got != nil {
+		t.Error("Validate() in the opted-in namespace =", got)
+	}
+	want := apis.ErrDisallowedFields("spec.tolerations")
+	if got := rev("default").Validate(ctx).Filter(apis.ErrorLevel); got.Error() != want.Error() {
+		t.Errorf("Validate() in another namespace = %v, want: %v", got, want)
+	}
+}
+
+func TestRevisionValidationWithPolicy(t *testing.T) {
+	policy, err := config.NewPolicyConfigFromMap(map[string]string{
+		"forbidden-env-vars":                 "LD_PRELOAD",
+		"allowed-registries.payments":        "gcr.io/payments",
+		"max-container-concurrency.payments": "50",
+		"max-timeout-seconds.payments":       "60",
+		"required-resources.payments":        "requests.cpu,limits.memory",
+		"max-scale.payments":                 "10",
+	})
+	if err != nil {
+		t.Fatal("NewPolicyConfigFromMap() =", err)
+	}
+	ctx := config.ToContext(context.Background(), &config.Config{Policy: policy})
+
+	compliant := func(namespace string) *Revision {
+		return &Revision{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      "valid",
+				Annotations: map[string]string{
+					autoscaling.MaxScaleAnnotationKey: "10",
+				},
+			},
+			Spec: RevisionSpec{
+				PodSpec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Image: "gcr.io/payments/checkout",
+						Env: []corev1.EnvVar{{
+							Name:  "GODEBUG",
+							Value: "http2debug=1",
+						}},
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("100m"),
+							},
+							Limits: corev1.ResourceList{
+								corev1.ResourceMemory: resource.MustParse("128Mi"),
+							},
+						},
+					}},
+				},
+				ContainerConcurrency: ptr.Int64(50),
+				TimeoutSeconds:       ptr.Int64(60),
+			},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		r      *Revision
+		mutate func(*Revision)
+		want   *apis.FieldError
+	}{{
+		name: "compliant",
+		r:    compliant("payments"),
+	}, {
+		name: "image from another registry",
+		r:    compliant("payments"),
+		mutate: func(r *Revision) {
+			r.Spec.Containers[0].Image = "docker.io/payments/checkout"
+		},
+		want: policyViolation(&apis.FieldError{
+			Message: `image "docker.io/payments/checkout" is not from an allowed registry`,
+			Paths:   []string{"spec.containers[0].image"},
+			Details: "allowed registries: gcr.io/payments",
+		}, "payments"),
+	}, {
+		name: "unbounded containerConcurrency",
+		r:    compliant("payments"),
+		mutate: func(r *Revision) {
+			r.Spec.ContainerConcurrency = ptr.Int64(0)
+		},
+		want: policyViolation(apis.ErrOutOfBoundsValue(0, 1, 50, "spec.containerConcurrency"), "payments"),
+	}, {
+		name: "timeoutSeconds over the maximum",
+		r:    compliant("payments"),
+		mutate: func(r *Revision) {
+			r.Spec.TimeoutSeconds = ptr.Int64(61)
+		},
+		want: policyViolation(apis.ErrOutOfBoundsValue(61, 0, 60, "spec.timeoutSeconds"), "payments"),
+	}, {
+		name: "missing resources",
+		r:    compliant("payments"),
+		mutate: func(r *Revision) {
+			r.Spec.Containers[0].Resources = corev1.ResourceRequirements{}
+		},
+		want: policyViolation(apis.ErrMissingField("spec.containers[0].resources.limits.memory"), "payments").Also(
+			policyViolation(apis.ErrMissingField("spec.containers[0].resources.requests.cpu"), "payments")),
+	}, {
+		name: "max-scale over the maximum",
+		r:    compliant("payments"),
+		mutate: func(r *Revision) {
+			r.Annotations[autoscaling.MaxScaleAnnotationKey] = "11"
+		},
+		want: policyViolation(apis.ErrOutOfBoundsValue(11, 1, 10,
+			"metadata.annotations."+autoscaling.MaxScaleAnnotationKey), "payments"),
+	}, {
+		name: "max-scale unset",
+		r:    compliant("payments"),
+		mutate: func(r *Revision) {
+			delete(r.Annotations, autoscaling.MaxScaleAnnotationKey)
+		},
+		want: policyViolation(&apis.FieldError{
+			Message: "max-scale must be set, at most 10",
+			Paths:   []string{"metadata.annotations." + autoscaling.MaxScaleAnnotationKey},
+		}, "payments"),
+	}, {
+		name: "forbidden env var",
+		r:    compliant("payments"),
+		mutate: func(r *Revision) {
+			r.Spec.Containers[0].Env = append(r.Spec.Containers[0].Env, corev1.EnvVar{Name: "LD_PRELOAD"})
+		},
+		want: policyViolation(&apis.FieldError{
+			Message: `env var "LD_PRELOAD" is forbidden`,
+			Paths:   []string{"spec.containers[0].env[1].name"},
+		}, "payments"),
+	}, {
+		name: "another namespace",
+		r:    compliant("default"),
+		mutate: func(r *Revision) {
+			r.Spec.Containers[0].Image = "docker.io/payments/checkout"
+			r.Spec.Containers[0].Resources = corev1.ResourceRequirements{}
+			r.Spec.ContainerConcurrency = ptr.Int64(0)
+			r.Spec.TimeoutSeconds = ptr.Int64(300)
+			delete(r.Annotations, autoscaling.MaxScaleAnnotationKey)
+		},
+	}, {
+		name: "forbidden env var in another namespace",
+		r:    compliant("default"),
+		mutate: func(r *Revision) {
+			r.Spec.Containers[0].Env[0].Name = "LD_PRELOAD"
+		},
+		want: policyViolation(&apis.FieldError{
+			Message: `env var "LD_PRELOAD" is forbidden`,
+			Paths:   []string{"spec.containers[0].env[0].name"},
+		}, "default"),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.mutate != nil {
+				test.mutate(test.r)
+			}
+			got := test.r.Validate(ctx).Filter(apis.ErrorLevel)
+			if got, want := got.Error(), test.want.Error(); got != want {
+				t.Errorf("Validate (-want, +got): \n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestRevisionTemplateSpecValidationWithPolicy(t *testing.T) {
+	policy, err := config.NewPolicyConfigFromMap(map[string]string{
+		"max-scale.payments": "10",
+	})
+	if err != nil {
+		t.Fatal("NewPolicyConfigFromMap() =", err)
+	}
+	ctx := config.ToContext(context.Background(), &config.Config{Policy: policy})
+	ctx = apis.WithinParent(ctx, metav1.ObjectMeta{Namespace: "payments", Name: "checkout"})
+
+	rts := &RevisionTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				autoscaling.MaxScaleAnnotationKey: "0",
+			},
+		},
+		Spec: RevisionSpec{
+			PodSpec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Image: "busybox",
+				}},
+			},
+		},
+	}
+	want := policyViolation(apis.ErrOutOfBoundsValue(0, 1, 10,
+		"metadata.annotations."+autoscaling.MaxScaleAnnotationKey), "payments")
+	if got := rts.Validate(ctx).Filter(apis.ErrorLevel); got.Error() != want.Error() {
+		t.Errorf("Validate() = %v, want: %v", got, want)
+	}
+}
+
 func TestRevisionLabelAnnotationValidation(t *testing.T) {
 	validRevisionSpec := RevisionSpec{
 		PodSpec: corev1.PodSpec{
@@ -1062,6 +1288,70 @@ func TestRevisionTemplateSpecValidation(t *testing.T) {
 			Message: "progress-deadline=-1m3s must be positive",
 			Paths:   []string{serving.ProgressDeadlineAnnotationKey},
 		}).ViaField("metadata.annotations"),
+	}, {
+		name: "kpa cpu metric with requests",
+		rts: &RevisionTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					autoscaling.MetricAnnotationKey: autoscaling.CPU,
+				},
+			},
+			Spec: RevisionSpec{
+				PodSpec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Image: "helloworld",
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("100m"),
+							},
+						},
+					}},
+				},
+			},
+		},
+	}, {
+		name: "kpa memory metric without requests",
+		rts: &RevisionTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					autoscaling.ClassAnnotationKey:  autoscaling.KPA,
+					autoscaling.MetricAnnotationKey: autoscaling.Memory,
+				},
+			},
+			Spec: RevisionSpec{
+				PodSpec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Image: "helloworld",
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("100m"),
+							},
+						},
+					}},
+				},
+			},
+		},
+		want: &apis.FieldError{
+			Message: "scaling on memory utilization requires memory requests",
+			Paths:   []string{"spec.containers"},
+		},
+	}, {
+		name: "hpa cpu metric without requests",
+		rts: &RevisionTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					autoscaling.ClassAnnotationKey:  autoscaling.HPA,
+					autoscaling.MetricAnnotationKey: autoscaling.CPU,
+				},
+			},
+			Spec: RevisionSpec{
+				PodSpec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Image: "helloworld",
+					}},
+				},
+			},
+		},
 	}}
 
 	for _, test := range tests {
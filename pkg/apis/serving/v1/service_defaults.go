@@ -20,12 +20,14 @@ import (
 	"context"
 
 	"knative.dev/pkg/apis"
+	"knative.dev/serving/pkg/apis/config"
 	"knative.dev/serving/pkg/apis/serving"
 )
 
 // SetDefaults implements apis.Defaultable
 func (s *Service) SetDefaults(ctx context.Context) {
 	ctx = apis.WithinParent(ctx, s.ObjectMeta)
+	ctx = config.ForNamespace(ctx, s.Namespace)
 
 	var prevSpec *ServiceSpec
 	if prev, ok := apis.GetBaseline(ctx).(*Service); ok && prev != nil {
@@ -20,6 +20,7 @@ import (
 	"context"
 
 	"knative.dev/pkg/apis"
+	"knative.dev/serving/pkg/apis/config"
 	"knative.dev/serving/pkg/apis/serving"
 )
 
@@ -32,10 +33,18 @@ func (s *Service) Validate(ctx context.Context) (errs *apis.FieldError) {
 	if !apis.IsInStatusUpdate(ctx) {
 		errs = errs.Also(serving.ValidateObjectMetadata(ctx, s.GetObjectMeta(), false))
 		errs = errs.Also(serving.ValidateRolloutDurationAnnotation(s.GetAnnotations()).ViaField("annotations"))
+		errs = errs.Also(serving.ValidateRolloutOnConfigChangeAnnotation(s.GetAnnotations()).ViaField("annotations"))
 		errs = errs.ViaField("metadata")
 
 		ctx = apis.WithinParent(ctx, s.ObjectMeta)
+		ctx = config.ForNamespace(ctx, s.Namespace)
 		errs = errs.Also(s.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
+
+		var baseline *RevisionTemplateSpec
+		if apis.IsInUpdate(ctx) {
+			baseline = &apis.GetBaseline(ctx).(*Service).Spec.Template
+		}
+		errs = errs.Also(validateTemplateReferences(ctx, s.ObjectMeta, &s.Spec.Template, baseline))
 	}
 
 	if apis.IsInUpdate(ctx) {
@@ -18,6 +18,7 @@ package v1
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -790,3 +791,65 @@ func TestServiceAnnotationUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceReferencesValidation(t *testing.T) {
+	calls := 0
+	checker := func(context.Context, string, *corev1.PodSpec) *apis.FieldError {
+		calls++
+		return apis.ErrGeneric(`Secret "creds" not found in namespace "foo"`, "imagePullSecrets[0].name")
+	}
+	ctx := serving.WithReferencesChecker(context.Background(), checker)
+
+	service := func(image string) *Service {
+		return &Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "valid",
+				Namespace: "foo",
+				Annotations: map[string]string{
+					config.ReferencesCheckFeatureKey: string(serving.ReferencesCheckEnabled),
+				},
+			},
+			Spec: ServiceSpec{
+				ConfigurationSpec: ConfigurationSpec{
+					Template: RevisionTemplateSpec{
+						Spec: RevisionSpec{
+							PodSpec: corev1.PodSpec{
+								Containers: []corev1.Container{{
+									Image: image,
+								}},
+								ImagePullSecrets: []corev1.LocalObjectReference{{Name: "creds"}},
+							},
+						},
+					},
+				},
+				RouteSpec: RouteSpec{
+					Traffic: []TrafficTarget{{
+						LatestRevision: ptr.Bool(true),
+						Percent:        ptr.Int64(100),
+					}},
+				},
+			},
+		}
+	}
+
+	// The missing references are warnings without the strict mode.
+	want := `Secret "creds" not found in namespace "foo": spec.template.spec.imagePullSecrets[0].name`
+	got := service("busybox").Validate(ctx)
+	if errs := got.Filter(apis.ErrorLevel); errs != nil {
+		t.Error("Validate() on create =", errs)
+	}
+	if !strings.Contains(got.Filter(apis.WarningLevel).Error(), want) {
+		t.Errorf("Validate() warnings on create = %v, want: %v", got.Filter(apis.WarningLevel), want)
+	}
+
+	// The references of an unchanged template aren't checked again.
+	calls = 0
+	service("busybox").Validate(apis.WithinUpdate(ctx, service("busybox")))
+	if calls != 0 {
+		t.Errorf("Checker calls on unchanged update = %d, want: 0", calls)
+	}
+	service("helloworld").Validate(apis.WithinUpdate(ctx, service("busybox")))
+	if calls != 1 {
+		t.Errorf("Checker calls on update = %d, want: 1", calls)
+	}
+}
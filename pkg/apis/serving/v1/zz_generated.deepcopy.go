@@ -22,6 +22,7 @@ limitations under the License.
 package v1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	apis "knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
@@ -109,7 +110,7 @@ func (in *ConfigurationSpec) DeepCopy() *ConfigurationSpec {
 func (in *ConfigurationStatus) DeepCopyInto(out *ConfigurationStatus) {
 	*out = *in
 	in.Status.DeepCopyInto(&out.Status)
-	out.ConfigurationStatusFields = in.ConfigurationStatusFields
+	in.ConfigurationStatusFields.DeepCopyInto(&out.ConfigurationStatusFields)
 	return
 }
 
@@ -126,6 +127,13 @@ func (in *ConfigurationStatus) DeepCopy() *ConfigurationStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigurationStatusFields) DeepCopyInto(out *ConfigurationStatusFields) {
 	*out = *in
+	if in.RecommendedResources != nil {
+		in, out := &in.RecommendedResources, &out.RecommendedResources
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
 	return
 }
 
@@ -142,6 +150,16 @@ func (in *ConfigurationStatusFields) DeepCopy() *ConfigurationStatusFields {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContainerStatus) DeepCopyInto(out *ContainerStatus) {
 	*out = *in
+	if in.Waiting != nil {
+		in, out := &in.Waiting, &out.Waiting
+		*out = new(ContainerWaiting)
+		**out = **in
+	}
+	if in.LastTermination != nil {
+		in, out := &in.LastTermination, &out.LastTermination
+		*out = new(ContainerTermination)
+		**out = **in
+	}
 	return
 }
 
@@ -155,6 +173,38 @@ func (in *ContainerStatus) DeepCopy() *ContainerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerTermination) DeepCopyInto(out *ContainerTermination) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerTermination.
+func (in *ContainerTermination) DeepCopy() *ContainerTermination {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerTermination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerWaiting) DeepCopyInto(out *ContainerWaiting) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerWaiting.
+func (in *ContainerWaiting) DeepCopy() *ContainerWaiting {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerWaiting)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Revision) DeepCopyInto(out *Revision) {
 	*out = *in
@@ -260,12 +310,16 @@ func (in *RevisionStatus) DeepCopyInto(out *RevisionStatus) {
 	if in.ContainerStatuses != nil {
 		in, out := &in.ContainerStatuses, &out.ContainerStatuses
 		*out = make([]ContainerStatus, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.InitContainerStatuses != nil {
 		in, out := &in.InitContainerStatuses, &out.InitContainerStatuses
 		*out = make([]ContainerStatus, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.ActualReplicas != nil {
 		in, out := &in.ActualReplicas, &out.ActualReplicas
@@ -277,6 +331,13 @@ func (in *RevisionStatus) DeepCopyInto(out *RevisionStatus) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.RecommendedResources != nil {
+		in, out := &in.RecommendedResources, &out.RecommendedResources
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
 	return
 }
 
@@ -526,7 +587,7 @@ func (in *ServiceSpec) DeepCopy() *ServiceSpec {
 func (in *ServiceStatus) DeepCopyInto(out *ServiceStatus) {
 	*out = *in
 	in.Status.DeepCopyInto(&out.Status)
-	out.ConfigurationStatusFields = in.ConfigurationStatusFields
+	in.ConfigurationStatusFields.DeepCopyInto(&out.ConfigurationStatusFields)
 	in.RouteStatusFields.DeepCopyInto(&out.RouteStatusFields)
 	return
 }
@@ -18,6 +18,7 @@ package aggregation
 
 import (
 	"math"
+	"sort"
 	"sync"
 	"time"
 
@@ -68,6 +69,22 @@ type (
 		// and is bounded by minExponent below.
 		smoothingCoeff float64
 	}
+
+	// PercentileFloat64Buckets is the implementation of buckets, that
+	// returns a percentile of the bucket values within the window, rather
+	// than their average, so that bursts aren't hidden. Like for the other
+	// buckets, the values recorded within a bucket are summed up, so the
+	// percentile is one over time of the per-bucket sums, e.g. of the
+	// concurrency of the revision per second. It is computed exactly from
+	// the at most window/granularity bucket values, not estimated with a
+	// quantile sketch, and cannot be merged with that of other buckets.
+	PercentileFloat64Buckets struct {
+		*TimedFloat64Buckets
+
+		// quantile is the quantile of the bucket values to return,
+		// between 0 and 1.
+		quantile float64
+	}
 )
 
 // String implements the Stringer interface.
@@ -114,6 +131,16 @@ func NewWeightedFloat64Buckets(window, granularity time.Duration) *WeightedFloat
 	}
 }
 
+// NewPercentileFloat64Buckets generates a new PercentileFloat64Buckets with the
+// given granularity, which returns the given percentile, between 0 and 100, of
+// the bucket values.
+func NewPercentileFloat64Buckets(window, granularity time.Duration, percentile float64) *PercentileFloat64Buckets {
+	return &PercentileFloat64Buckets{
+		TimedFloat64Buckets: NewTimedFloat64Buckets(window, granularity),
+		quantile:            math.Max(0, math.Min(percentile, 100)) / 100,
+	}
+}
+
 // IsEmpty returns true if no data has been recorded for the `window` period.
 func (t *TimedFloat64Buckets) IsEmpty(now time.Time) bool {
 	now = now.Truncate(t.granularity)
@@ -182,6 +209,41 @@ func (t *WeightedFloat64Buckets) WindowAverage(now time.Time) float64 {
 	return ret
 }
 
+// WindowAverage returns the percentile of the bucket values over the window.
+// Despite its name, which it shares with the other buckets for them to be
+// used interchangeably, it returns a percentile, not an average. Like for the
+// average, the window is partial if the first write was less than
+// the window length ago. Gaps in the data, including the buckets since the
+// last write, are counted as 0, so the percentile drops once the data stops.
+// The percentile is the lower of the two bucket values nearest to it.
+func (t *PercentileFloat64Buckets) WindowAverage(now time.Time) float64 {
+	now = now.Truncate(t.granularity)
+	t.bucketsMutex.RLock()
+	defer t.bucketsMutex.RUnlock()
+	if now.Sub(t.lastWrite) >= t.window {
+		return 0
+	}
+
+	end := now
+	if t.lastWrite.After(end) {
+		end = t.lastWrite
+	}
+	start := end.Add(-time.Duration(len(t.buckets)-1) * t.granularity)
+	if t.firstWrite.After(start) {
+		start = t.firstWrite
+	}
+	values := make([]float64, 0, len(t.buckets))
+	for tm := start; !tm.After(end); tm = tm.Add(t.granularity) {
+		if tm.After(t.lastWrite) {
+			values = append(values, 0)
+			continue
+		}
+		values = append(values, t.buckets[t.timeToIndex(tm)%len(t.buckets)])
+	}
+	sort.Float64s(values)
+	return roundToNDigits(precision, values[int(t.quantile*float64(len(values)-1))])
+}
+
 // WindowAverage returns the average bucket value over the window.
 //
 // If the first write was less than the window length ago, an average is
@@ -296,6 +358,28 @@ func (t *TimedFloat64Buckets) Record(now time.Time, value float64) {
 	t.windowTotal += value
 }
 
+// Checkpoint returns the values of the buckets within the window up to the
+// last write, oldest first, and the time of the first of them. Recording the
+// values at their times into empty buckets restores the window.
+func (t *TimedFloat64Buckets) Checkpoint() (time.Time, []float64) {
+	t.bucketsMutex.RLock()
+	defer t.bucketsMutex.RUnlock()
+	if t.lastWrite.IsZero() {
+		return time.Time{}, nil
+	}
+
+	start := t.lastWrite.Add(-time.Duration(len(t.buckets)-1) * t.granularity)
+	if t.firstWrite.After(start) {
+		start = t.firstWrite
+	}
+	n := int(t.lastWrite.Sub(start)/t.granularity) + 1
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = t.buckets[t.timeToIndex(start.Add(time.Duration(i)*t.granularity))%len(t.buckets)]
+	}
+	return start, values
+}
+
 func min(a, b int) int {
 	if a < b {
 		return a
@@ -242,6 +242,72 @@ func TestTimedFloat64BucketsWeightedAverage(t *testing.T) {
 	}
 }
 
+func TestPercentileFloat64BucketsWindowAverage(t *testing.T) {
+	now := time.Now().Truncate(granularity)
+
+	buckets := NewPercentileFloat64Buckets(10*time.Second, granularity, 90)
+	if got, want := buckets.WindowAverage(now), 0.; got != want {
+		t.Errorf("WindowAverage = %v, want: %v", got, want)
+	}
+
+	// This verifies that we properly use firstWrite, rather than counting
+	// the unwritten buckets as zeros.
+	buckets.Record(now, 3)
+	if got, want := buckets.WindowAverage(now), 3.; got != want {
+		t.Errorf("WindowAverage = %v, want: %v", got, want)
+	}
+
+	// Fill the window with [4, 2, 3, 4, 5, 100, 7, 8, 9, 10], the first
+	// bucket adding to the 3 recorded before.
+	for i := 0; i < 10; i++ {
+		v := float64(i + 1)
+		if i == 5 {
+			v = 100
+		}
+		buckets.Record(now.Add(time.Duration(i)*time.Second), v)
+	}
+	// The average would be 15.2.
+	if got, want := buckets.WindowAverage(now.Add(9*time.Second)), 10.; got != want {
+		t.Errorf("WindowAverage = %v, want: %v", got, want)
+	}
+
+	// Check with a short hole: the buckets after the last write count as
+	// zeros, and the window holds [4, 5, 6, 7, 8, 9, 10, 0, 0, 0].
+	p0 := NewPercentileFloat64Buckets(10*time.Second, granularity, 0)
+	p50 := NewPercentileFloat64Buckets(10*time.Second, granularity, 50)
+	p100 := NewPercentileFloat64Buckets(10*time.Second, granularity, 100)
+	for i := 0; i < 10; i++ {
+		p0.Record(now.Add(time.Duration(i)*time.Second), float64(i+1))
+		p50.Record(now.Add(time.Duration(i)*time.Second), float64(i+1))
+		p100.Record(now.Add(time.Duration(i)*time.Second), float64(i+1))
+	}
+	if got, want := p0.WindowAverage(now.Add(12*time.Second)), 0.; got != want {
+		t.Errorf("WindowAverage = %v, want: %v", got, want)
+	}
+	if got, want := p50.WindowAverage(now.Add(12*time.Second)), 5.; got != want {
+		t.Errorf("WindowAverage = %v, want: %v", got, want)
+	}
+	if got, want := p100.WindowAverage(now.Add(12*time.Second)), 10.; got != want {
+		t.Errorf("WindowAverage = %v, want: %v", got, want)
+	}
+
+	// The percentile keeps dropping as the data stays away.
+	if got, want := p50.WindowAverage(now.Add(15*time.Second)), 0.; got != want {
+		t.Errorf("WindowAverage = %v, want: %v", got, want)
+	}
+
+	// Check write with holes, which count as zeros.
+	p0.Record(now.Add(12*time.Second), 5)
+	if got, want := p0.WindowAverage(now.Add(12*time.Second)), 0.; got != want {
+		t.Errorf("WindowAverage = %v, want: %v", got, want)
+	}
+
+	// Check with a long hole.
+	if got, want := p100.WindowAverage(now.Add(19*time.Second)), 0.; got != want {
+		t.Errorf("WindowAverage = %v, want: %v", got, want)
+	}
+}
+
 func TestTimedFloat64BucketsWindowAverage(t *testing.T) {
 	now := time.Now()
 	buckets := NewTimedFloat64Buckets(5*time.Second, granularity)
@@ -658,3 +724,49 @@ func (t *TimedFloat64Buckets) forEachBucket(now time.Time, acc func(time time.Ti
 		bucketTime = bucketTime.Add(-t.granularity)
 	}
 }
+
+func TestTimedFloat64BucketsCheckpoint(t *testing.T) {
+	now := time.Unix(1000, 0)
+	buckets := NewTimedFloat64Buckets(5*time.Second, granularity)
+	if start, values := buckets.Checkpoint(); !start.IsZero() || values != nil {
+		t.Errorf("Checkpoint() = %v, %v, want nothing for empty buckets", start, values)
+	}
+
+	buckets.Record(now, 1)
+	buckets.Record(now.Add(2*time.Second), 3)
+	start, values := buckets.Checkpoint()
+	if !start.Equal(now) {
+		t.Errorf("Checkpoint() start = %v, want: %v", start, now)
+	}
+	if want := []float64{1, 0, 3}; !cmp.Equal(values, want) {
+		t.Error("Checkpoint() values (-want, +got):", cmp.Diff(want, values))
+	}
+
+	// Only the buckets within the window are returned once it wrapped around.
+	for i := 3; i < 8; i++ {
+		buckets.Record(now.Add(time.Duration(i)*time.Second), float64(i+1))
+	}
+	start, values = buckets.Checkpoint()
+	if want := now.Add(3 * time.Second); !start.Equal(want) {
+		t.Errorf("Checkpoint() start = %v, want: %v", start, want)
+	}
+	if want := []float64{4, 5, 6, 7, 8}; !cmp.Equal(values, want) {
+		t.Error("Checkpoint() values (-want, +got):", cmp.Diff(want, values))
+	}
+
+	// Replaying the checkpoint restores the averages.
+	restored := NewWeightedFloat64Buckets(5*time.Second, granularity)
+	for i, v := range values {
+		restored.Record(start.Add(time.Duration(i)*time.Second), v)
+	}
+	want := NewWeightedFloat64Buckets(5*time.Second, granularity)
+	want.TimedFloat64Buckets = buckets
+	for _, tm := range []time.Time{now.Add(7 * time.Second), now.Add(9 * time.Second)} {
+		if got, want := restored.WindowAverage(tm), want.WindowAverage(tm); got != want {
+			t.Errorf("Restored WindowAverage(%v) = %v, want: %v", tm, got, want)
+		}
+		if got, want := restored.TimedFloat64Buckets.WindowAverage(tm), buckets.WindowAverage(tm); got != want {
+			t.Errorf("Restored TimedFloat64Buckets.WindowAverage(%v) = %v, want: %v", tm, got, want)
+		}
+	}
+}
@@ -40,6 +40,27 @@ func (t *TimeWindow) Record(now time.Time, value int32) {
 	t.window.Record(index, value)
 }
 
+// Sample is a value recorded at a time.
+type Sample struct {
+	Time  time.Time
+	Value int32
+}
+
+// Checkpoint returns the samples that make up the current and future maxima
+// of the window, oldest first. Recording them into an empty TimeWindow of the
+// same duration and granularity restores the window.
+func (t *TimeWindow) Checkpoint() []Sample {
+	entries := t.window.entries()
+	samples := make([]Sample, len(entries))
+	for i, e := range entries {
+		samples[i] = Sample{
+			Time:  time.Unix(int64(e.index)*int64(t.granularity.Seconds()), 0),
+			Value: e.value,
+		}
+	}
+	return samples
+}
+
 // Current returns the current maximum value observed in the previous
 // window duration.
 func (t *TimeWindow) Current() int32 {
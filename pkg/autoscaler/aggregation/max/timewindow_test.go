@@ -133,3 +133,39 @@ func BenchmarkLargeTimeWindowDescendingRecord(b *testing.B) {
 		})
 	}
 }
+
+func TestTimedWindowCheckpoint(t *testing.T) {
+	now := time.Unix(1000, 0)
+	m := NewTimeWindow(5*time.Second, time.Second)
+	for i, v := range []int32{9, 3, 7, 5, 2, 4} {
+		m.Record(now.Add(time.Duration(i)*time.Second), v)
+	}
+
+	samples := m.Checkpoint()
+	want := []Sample{
+		{Time: now.Add(2 * time.Second), Value: 7},
+		{Time: now.Add(3 * time.Second), Value: 5},
+		{Time: now.Add(5 * time.Second), Value: 4},
+	}
+	if len(samples) != len(want) {
+		t.Fatalf("Checkpoint() = %v, want: %v", samples, want)
+	}
+	for i := range want {
+		if !samples[i].Time.Equal(want[i].Time) || samples[i].Value != want[i].Value {
+			t.Errorf("Checkpoint()[%d] = %v, want: %v", i, samples[i], want[i])
+		}
+	}
+
+	restored := NewTimeWindow(5*time.Second, time.Second)
+	for _, s := range samples {
+		restored.Record(s.Time, s.Value)
+	}
+	for i := 6; i < 10; i++ {
+		tm := now.Add(time.Duration(i) * time.Second)
+		m.Record(tm, 1)
+		restored.Record(tm, 1)
+		if got, want := restored.Current(), m.Current(); got != want {
+			t.Errorf("Restored Current() at %d = %d, want: %d", i, got, want)
+		}
+	}
+}
@@ -101,6 +101,15 @@ func (m *window) Current() int32 {
 	return m.maxima[m.first].value
 }
 
+// entries returns the maxima in the window, oldest first.
+func (m *window) entries() []entry {
+	ret := make([]entry, m.length)
+	for i := range ret {
+		ret[i] = m.maxima[m.index(m.first+i)]
+	}
+	return ret
+}
+
 func (m *window) index(i int) int {
 	return i % len(m.maxima)
 }
@@ -0,0 +1,21 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package checkpoint periodically saves the metric windows and the autoscaler
+// state of the revisions owned by an autoscaler pod to a ConfigMap per bucket,
+// and restores them when the revisions' collections and autoscalers are
+// recreated after a restart or a change of the bucket leader.
+package checkpoint
@@ -0,0 +1,302 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkpoint
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/clock"
+
+	"knative.dev/pkg/hash"
+	"knative.dev/serving/pkg/autoscaler/metrics"
+	"knative.dev/serving/pkg/autoscaler/scaling"
+)
+
+const (
+	// maxDataSize caps the size of the data of a checkpoint ConfigMap below
+	// the 1MiB limit of Kubernetes objects.
+	maxDataSize = 900 * 1024
+
+	// requestTimeout bounds reading a checkpoint and saving the checkpoints
+	// when the Store stops.
+	requestTimeout = 5 * time.Second
+)
+
+// Revision is the checkpoint of a revision.
+type Revision struct {
+	Collection *metrics.CollectionCheckpoint `json:"collection,omitempty"`
+	Scaler     *scaling.ScalerCheckpoint     `json:"scaler,omitempty"`
+}
+
+// CollectionCheckpointer returns the checkpoints of metric collections.
+type CollectionCheckpointer interface {
+	Checkpoints(now time.Time) map[types.NamespacedName]metrics.CollectionCheckpoint
+}
+
+// ScalerCheckpointer returns the checkpoints of autoscalers.
+type ScalerCheckpointer interface {
+	Checkpoints(now time.Time) map[types.NamespacedName]scaling.ScalerCheckpoint
+}
+
+// ConfigMapName returns the name of the ConfigMap holding the checkpoints of
+// the bucket with the given ordinal and total bucket count.
+func ConfigMapName(ordinal, total int) string {
+	return fmt.Sprintf("autoscaler-checkpoint-%02d-of-%02d", ordinal, total)
+}
+
+func dataKey(key types.NamespacedName) string {
+	// Namespaces can't contain dots, hence the key is unambiguous.
+	return key.Namespace + "." + key.Name
+}
+
+// Store saves the checkpoints of the revisions in the buckets owned by this
+// pod every interval, and provides the saved checkpoints to restore from.
+type Store struct {
+	kc        kubernetes.Interface
+	namespace string
+	bs        *hash.BucketSet
+	isOwner   func(bkt string) bool
+	interval  time.Duration
+	logger    *zap.SugaredLogger
+	clock     clock.PassiveClock
+
+	collections CollectionCheckpointer
+	scalers     ScalerCheckpointer
+
+	// mux guards loaded.
+	mux sync.Mutex
+	// loaded caches the checkpoints of a bucket for an interval, so the
+	// revisions of a bucket being taken over are restored from a single read.
+	loaded map[string]*loadedBucket
+}
+
+type loadedBucket struct {
+	at        time.Time
+	revisions map[string]string
+}
+
+var (
+	_ metrics.CollectionCheckpointSource = (*Store)(nil)
+	_ scaling.ScalerCheckpointSource     = (*Store)(nil)
+)
+
+// New creates a Store keeping the checkpoint ConfigMaps in the given
+// namespace.
+func New(kc kubernetes.Interface, namespace string, bs *hash.BucketSet, isOwner func(bkt string) bool,
+	collections CollectionCheckpointer, scalers ScalerCheckpointer, interval time.Duration,
+	logger *zap.SugaredLogger) *Store {
+	return &Store{
+		kc:          kc,
+		namespace:   namespace,
+		bs:          bs,
+		isOwner:     isOwner,
+		interval:    interval,
+		logger:      logger.Named("checkpoint"),
+		clock:       clock.RealClock{},
+		collections: collections,
+		scalers:     scalers,
+		loaded:      make(map[string]*loadedBucket),
+	}
+}
+
+// Run saves the checkpoints every interval until ctx is done, and once more
+// before returning.
+func (s *Store) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			saveCtx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+			defer cancel()
+			s.Save(saveCtx)
+			return
+		case <-ticker.C:
+			s.Save(ctx)
+		}
+	}
+}
+
+// Save writes the checkpoints of the revisions in the buckets owned by this
+// pod. Failures are logged, since the next save will retry.
+func (s *Store) Save(ctx context.Context) {
+	now := s.clock.Now()
+	collections := s.collections.Checkpoints(now)
+	scalers := s.scalers.Checkpoints(now)
+
+	revisions := make(map[string]map[string]*Revision)
+	revision := func(key types.NamespacedName) *Revision {
+		bkt := s.bs.Owner(key.String())
+		if !s.isOwner(bkt) {
+			return nil
+		}
+		if revisions[bkt] == nil {
+			revisions[bkt] = make(map[string]*Revision)
+		}
+		r := revisions[bkt][dataKey(key)]
+		if r == nil {
+			r = &Revision{}
+			revisions[bkt][dataKey(key)] = r
+		}
+		return r
+	}
+	for key, cp := range collections {
+		if r := revision(key); r != nil {
+			cp := cp
+			r.Collection = &cp
+		}
+	}
+	for key, cp := range scalers {
+		if r := revision(key); r != nil {
+			cp := cp
+			r.Scaler = &cp
+		}
+	}
+
+	bkts := s.bs.BucketList()
+	for i, bkt := range bkts {
+		// Buckets without revisions are saved too, to drop the
+		// checkpoints of revisions that are gone.
+		if !s.isOwner(bkt) {
+			continue
+		}
+		name := ConfigMapName(i, len(bkts))
+		if err := s.save(ctx, name, revisions[bkt]); err != nil {
+			s.logger.Errorw("Failed to save checkpoint "+name, zap.Error(err))
+		}
+	}
+}
+
+func (s *Store) save(ctx context.Context, name string, revisions map[string]*Revision) error {
+	keys := make([]string, 0, len(revisions))
+	for k := range revisions {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	data := make(map[string]string, len(revisions))
+	size := 0
+	for i, k := range keys {
+		b, err := json.Marshal(revisions[k])
+		if err != nil {
+			return fmt.Errorf("failed to encode the checkpoint of %s: %w", k, err)
+		}
+		if size += len(k) + len(b); size > maxDataSize {
+			s.logger.Warnf("Checkpoint %s exceeds %d bytes, dropping %d of %d revisions",
+				name, maxDataSize, len(keys)-i, len(keys))
+			break
+		}
+		data[k] = string(b)
+	}
+
+	cm, err := s.kc.CoreV1().ConfigMaps(s.namespace).Get(ctx, name, metav1.GetOptions{})
+	if apierrs.IsNotFound(err) {
+		_, err = s.kc.CoreV1().ConfigMaps(s.namespace).Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: s.namespace,
+			},
+			Data: data,
+		}, metav1.CreateOptions{})
+		return err
+	} else if err != nil {
+		return err
+	}
+	want := cm.DeepCopy()
+	want.Data = data
+	_, err = s.kc.CoreV1().ConfigMaps(s.namespace).Update(ctx, want, metav1.UpdateOptions{})
+	return err
+}
+
+// CollectionCheckpoint implements metrics.CollectionCheckpointSource.
+func (s *Store) CollectionCheckpoint(key types.NamespacedName) (metrics.CollectionCheckpoint, bool) {
+	r, ok := s.revision(key)
+	if !ok || r.Collection == nil {
+		return metrics.CollectionCheckpoint{}, false
+	}
+	return *r.Collection, true
+}
+
+// ScalerCheckpoint implements scaling.ScalerCheckpointSource.
+func (s *Store) ScalerCheckpoint(key types.NamespacedName) (scaling.ScalerCheckpoint, bool) {
+	r, ok := s.revision(key)
+	if !ok || r.Scaler == nil {
+		return scaling.ScalerCheckpoint{}, false
+	}
+	return *r.Scaler, true
+}
+
+// revision returns the saved checkpoint of the given revision, reading the
+// ConfigMap of its bucket unless it was read within the last interval.
+func (s *Store) revision(key types.NamespacedName) (Revision, bool) {
+	bkt := s.bs.Owner(key.String())
+	logger := s.logger.With(zap.String("bucket", bkt))
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	now := s.clock.Now()
+	lb := s.loaded[bkt]
+	if lb == nil || now.Sub(lb.at) >= s.interval {
+		revisions, err := s.load(bkt)
+		if err != nil {
+			logger.Errorw("Failed to load checkpoint", zap.Error(err))
+			return Revision{}, false
+		}
+		lb = &loadedBucket{at: now, revisions: revisions}
+		s.loaded[bkt] = lb
+	}
+
+	data, ok := lb.revisions[dataKey(key)]
+	if !ok {
+		return Revision{}, false
+	}
+	var r Revision
+	if err := json.Unmarshal([]byte(data), &r); err != nil {
+		logger.Errorw("Failed to decode checkpoint of "+key.String(), zap.Error(err))
+		return Revision{}, false
+	}
+	return r, true
+}
+
+func (s *Store) load(bkt string) (map[string]string, error) {
+	bkts := s.bs.BucketList()
+	ordinal := sort.SearchStrings(bkts, bkt)
+	if ordinal == len(bkts) || bkts[ordinal] != bkt {
+		return nil, fmt.Errorf("unknown bucket %s", bkt)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	cm, err := s.kc.CoreV1().ConfigMaps(s.namespace).Get(ctx, ConfigMapName(ordinal, len(bkts)), metav1.GetOptions{})
+	if apierrs.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return cm.Data, nil
+}
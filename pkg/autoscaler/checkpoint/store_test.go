@@ -0,0 +1,168 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkpoint
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+	clocktest "k8s.io/utils/clock/testing"
+
+	"knative.dev/pkg/hash"
+	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/serving/pkg/autoscaler/bucket"
+	"knative.dev/serving/pkg/autoscaler/metrics"
+	"knative.dev/serving/pkg/autoscaler/scaling"
+)
+
+const testNamespace = "knative-serving"
+
+type fakeCollections map[types.NamespacedName]metrics.CollectionCheckpoint
+
+func (f fakeCollections) Checkpoints(time.Time) map[types.NamespacedName]metrics.CollectionCheckpoint {
+	return f
+}
+
+type fakeScalers map[types.NamespacedName]scaling.ScalerCheckpoint
+
+func (f fakeScalers) Checkpoints(time.Time) map[types.NamespacedName]scaling.ScalerCheckpoint {
+	return f
+}
+
+// revisionsInBuckets returns a revision owned by each of the buckets.
+func revisionsInBuckets(t *testing.T, bs *hash.BucketSet) []types.NamespacedName {
+	t.Helper()
+	bkts := bs.BucketList()
+	ret := make([]types.NamespacedName, len(bkts))
+	for i := 0; i < 1000; i++ {
+		key := types.NamespacedName{Namespace: "ns", Name: fmt.Sprint("rev-", i)}
+		for j, bkt := range bkts {
+			if ret[j].Name == "" && bs.Owner(key.String()) == bkt {
+				ret[j] = key
+			}
+		}
+	}
+	for i, key := range ret {
+		if key.Name == "" {
+			t.Fatal("Found no revision in bucket", bkts[i])
+		}
+	}
+	return ret
+}
+
+func TestStoreSaveAndRestore(t *testing.T) {
+	ctx := context.Background()
+	kc := fakek8s.NewSimpleClientset()
+	bs := bucket.AutoscalerBucketSet(2)
+	bkts := bs.BucketList()
+	revs := revisionsInBuckets(t, bs)
+	owned, other := revs[0], revs[1]
+
+	collection := metrics.CollectionCheckpoint{
+		Concurrency: metrics.BucketsCheckpoint{Start: 1000, Values: []float64{1, 2}},
+		RPS:         metrics.BucketsCheckpoint{Start: 1000, Values: []float64{3, 4}},
+	}
+	scaler := scaling.ScalerCheckpoint{Time: 1001, PanicTime: 990, MaxPanicPods: 4}
+
+	// The first pod owns the first bucket only.
+	s := New(kc, testNamespace, bs, func(bkt string) bool { return bkt == bkts[0] },
+		fakeCollections{owned: collection, other: collection},
+		fakeScalers{owned: scaler, other: scaler},
+		10*time.Second, logtesting.TestLogger(t))
+	s.Save(ctx)
+
+	cm, err := kc.CoreV1().ConfigMaps(testNamespace).Get(ctx, ConfigMapName(0, 2), metav1.GetOptions{})
+	if err != nil {
+		t.Fatal("Failed to get checkpoint:", err)
+	}
+	if got, want := len(cm.Data), 1; got != want {
+		t.Errorf("len(Data) = %d, want: %d", got, want)
+	}
+	if _, err := kc.CoreV1().ConfigMaps(testNamespace).Get(ctx, ConfigMapName(1, 2), metav1.GetOptions{}); err == nil {
+		t.Error("Checkpoint of the bucket not owned was saved")
+	}
+
+	// Another pod taking over restores from the checkpoint.
+	now := time.Unix(2000, 0)
+	clock := clocktest.NewFakePassiveClock(now)
+	restorer := New(kc, testNamespace, bs, func(string) bool { return false },
+		fakeCollections{}, fakeScalers{}, 10*time.Second, logtesting.TestLogger(t))
+	restorer.clock = clock
+
+	if got, ok := restorer.CollectionCheckpoint(owned); !ok || !cmp.Equal(got, collection) {
+		t.Errorf("CollectionCheckpoint() = %v, %v, want: %v", got, ok, collection)
+	}
+	if got, ok := restorer.ScalerCheckpoint(owned); !ok || !cmp.Equal(got, scaler) {
+		t.Errorf("ScalerCheckpoint() = %v, %v, want: %v", got, ok, scaler)
+	}
+	if _, ok := restorer.ScalerCheckpoint(other); ok {
+		t.Error("ScalerCheckpoint() found a checkpoint of a bucket not saved")
+	}
+
+	// The checkpoint is cached for an interval.
+	s.collections, s.scalers = fakeCollections{}, fakeScalers{}
+	s.Save(ctx)
+	if _, ok := restorer.ScalerCheckpoint(owned); !ok {
+		t.Error("ScalerCheckpoint() found no cached checkpoint")
+	}
+	clock.SetTime(now.Add(10 * time.Second))
+	if _, ok := restorer.ScalerCheckpoint(owned); ok {
+		t.Error("ScalerCheckpoint() found a checkpoint of a revision that is gone")
+	}
+}
+
+func TestStoreSizeCap(t *testing.T) {
+	ctx := context.Background()
+	kc := fakek8s.NewSimpleClientset()
+	bs := bucket.AutoscalerBucketSet(1)
+
+	values := make([]float64, 1000)
+	for i := range values {
+		values[i] = 1.0 / float64(i+3)
+	}
+	collections := fakeCollections{}
+	for i := 0; i < 100; i++ {
+		key := types.NamespacedName{Namespace: "ns", Name: fmt.Sprint("rev-", i)}
+		collections[key] = metrics.CollectionCheckpoint{
+			Concurrency: metrics.BucketsCheckpoint{Start: 1000, Values: values},
+			RPS:         metrics.BucketsCheckpoint{Start: 1000, Values: values},
+		}
+	}
+
+	s := New(kc, testNamespace, bs, func(string) bool { return true },
+		collections, fakeScalers{}, 10*time.Second, logtesting.TestLogger(t))
+	s.Save(ctx)
+
+	cm, err := kc.CoreV1().ConfigMaps(testNamespace).Get(ctx, ConfigMapName(0, 1), metav1.GetOptions{})
+	if err != nil {
+		t.Fatal("Failed to get checkpoint:", err)
+	}
+	size := 0
+	for k, v := range cm.Data {
+		size += len(k) + len(v)
+	}
+	if size > maxDataSize || len(cm.Data) == 0 || len(cm.Data) == len(collections) {
+		t.Errorf("Saved %d revisions in %d bytes, want some of %d revisions within %d bytes",
+			len(cm.Data), size, len(collections), maxDataSize)
+	}
+}
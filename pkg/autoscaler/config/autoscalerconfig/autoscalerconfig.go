@@ -23,6 +23,23 @@ type Config struct {
 	// Feature flags.
 	EnableScaleToZero bool
 
+	// EnableStatPush makes the queue-proxies push their stats to the
+	// autoscaler, which then only scrapes revisions whose ready pods do not
+	// all push them.
+	EnableStatPush bool
+
+	// EnableResourceRecommendations makes the autoscaler recommend the
+	// resource requests of the revisions from the resource usage their pods
+	// report, i.e. those of the revisions scaled on the "cpu" or "memory"
+	// metric. It leaves the pods of the revisions unchanged.
+	EnableResourceRecommendations bool
+
+	// AllowedMetricSources are the URL prefixes the external metrics the
+	// revisions are scaled on may be read from. The autoscaler sends its
+	// requests from inside the cluster, so external metrics are disabled
+	// unless the operator lists the endpoints they may be read from.
+	AllowedMetricSources []string
+
 	// Target concurrency knobs for different container concurrency configurations.
 	ContainerConcurrencyTargetFraction float64
 	ContainerConcurrencyTargetDefault  float64
@@ -24,6 +24,11 @@ package autoscalerconfig
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Config) DeepCopyInto(out *Config) {
 	*out = *in
+	if in.AllowedMetricSources != nil {
+		in, out := &in.AllowedMetricSources, &out.AllowedMetricSources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
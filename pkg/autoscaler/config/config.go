@@ -18,6 +18,8 @@ package config
 
 import (
 	"fmt"
+	"net/url"
+	"strings"
 	"time"
 
 	cm "knative.dev/pkg/configmap"
@@ -76,6 +78,9 @@ func NewConfigFromMap(data map[string]string) (*autoscalerconfig.Config, error)
 
 		cm.AsBool("enable-scale-to-zero", &lc.EnableScaleToZero),
 		cm.AsBool("allow-zero-initial-scale", &lc.AllowZeroInitialScale),
+		cm.AsBool("enable-stat-push", &lc.EnableStatPush),
+		cm.AsBool("enable-resource-recommendations", &lc.EnableResourceRecommendations),
+		asURLPrefixes("allowed-metric-sources", &lc.AllowedMetricSources),
 
 		cm.AsFloat64("max-scale-up-rate", &lc.MaxScaleUpRate),
 		cm.AsFloat64("max-scale-down-rate", &lc.MaxScaleDownRate),
@@ -111,6 +116,28 @@ func NewConfigFromMap(data map[string]string) (*autoscalerconfig.Config, error)
 	return validate(lc)
 }
 
+// asURLPrefixes parses a comma separated list of http or https URL prefixes.
+func asURLPrefixes(key string, target *[]string) cm.ParseFunc {
+	return func(data map[string]string) error {
+		raw, ok := data[key]
+		if !ok {
+			return nil
+		}
+		var prefixes []string
+		for _, p := range strings.Split(raw, ",") {
+			if p = strings.TrimSpace(p); p == "" {
+				continue
+			}
+			if u, err := url.Parse(p); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return fmt.Errorf("failed to parse %q: %q is not an http or https URL prefix", key, p)
+			}
+			prefixes = append(prefixes, p)
+		}
+		*target = prefixes
+		return nil
+	}
+}
+
 func validate(lc *autoscalerconfig.Config) (*autoscalerconfig.Config, error) {
 	if lc.ScaleToZeroGracePeriod <= 0 {
 		return nil, fmt.Errorf("scale-to-zero-grace-period must be positive, was: %v", lc.ScaleToZeroGracePeriod)
@@ -316,6 +316,42 @@ func TestNewConfig(t *testing.T) {
 			c.InitialScale = 0
 			return c
 		}(),
+	}, {
+		name: "with stat push",
+		input: map[string]string{
+			"enable-stat-push": "true",
+		},
+		want: func() *autoscalerconfig.Config {
+			c := defaultConfig()
+			c.EnableStatPush = true
+			return c
+		}(),
+	}, {
+		name: "with resource recommendations",
+		input: map[string]string{
+			"enable-resource-recommendations": "true",
+		},
+		want: func() *autoscalerconfig.Config {
+			c := defaultConfig()
+			c.EnableResourceRecommendations = true
+			return c
+		}(),
+	}, {
+		name: "with allowed metric sources",
+		input: map[string]string{
+			"allowed-metric-sources": "http://prometheus.monitoring:9090/, ,https://metrics.example.com/queues/",
+		},
+		want: func() *autoscalerconfig.Config {
+			c := defaultConfig()
+			c.AllowedMetricSources = []string{"http://prometheus.monitoring:9090/", "https://metrics.example.com/queues/"}
+			return c
+		}(),
+	}, {
+		name: "with invalid allowed metric sources",
+		input: map[string]string{
+			"allowed-metric-sources": "prometheus.monitoring:9090",
+		},
+		wantErr: true,
 	}, {
 		name: "with non-parseable allow-zero-initial-scale",
 		input: map[string]string{
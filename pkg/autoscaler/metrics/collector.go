@@ -17,11 +17,13 @@ limitations under the License.
 package metrics
 
 import (
+	"context"
 	"errors"
 	"sync"
 	"time"
 
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/clock"
 	"knative.dev/pkg/logging/logkey"
@@ -35,6 +37,11 @@ const (
 	// scrapeTickInterval is the interval of time between triggering StatsScraper.Scrape()
 	// to get metrics across all pods of a revision.
 	scrapeTickInterval = time.Second
+
+	// statPushTimeout is how long the stats pushed by the queue-proxy of a
+	// pod may stop arriving before the pod is no longer considered pushing
+	// them, and its revision is scraped again.
+	statPushTimeout = 3 * scrapeTickInterval
 )
 
 var (
@@ -43,11 +50,23 @@ var (
 
 	// ErrNotCollecting denotes that the collector is not collecting metrics for the given resource.
 	ErrNotCollecting = errors.New("no metrics are being collected for the requested resource")
+
+	// ErrMetricSourceNotAllowed denotes that the external metric is to be read
+	// from a URL the operator didn't allow.
+	ErrMetricSourceNotAllowed = errors.New("the metric source URL is not allowed by the cluster")
+
+	// ErrFailedReadSource denotes that the external metric could not be read.
+	// The cause is only logged, as it may disclose what the endpoint serves.
+	ErrFailedReadSource = errors.New("failed to read the external metric from its source")
 )
 
 // StatsScraperFactory creates a StatsScraper for a given Metric.
 type StatsScraperFactory func(*autoscalingv1alpha1.Metric, *zap.SugaredLogger) (StatsScraper, error)
 
+// MetricSourceFactory creates a MetricSource for a given Metric, or nil if it
+// is not scaled on an external metric.
+type MetricSourceFactory func(*autoscalingv1alpha1.Metric) (MetricSource, error)
+
 var emptyStat = Stat{}
 
 // StatMessage wraps a Stat with identifying information so it can be routed
@@ -69,6 +88,49 @@ type Collector interface {
 	// Watch registers a singleton function to call when a specific collector's status changes.
 	// The passed name is the namespace/name of the metric owned by the respective collector.
 	Watch(func(types.NamespacedName))
+	// RecommendedResources returns the resource requests recommended for the
+	// containers of the given metric's revision, or nil if there is no
+	// recommendation yet.
+	RecommendedResources(key types.NamespacedName) corev1.ResourceList
+}
+
+// StatRecorder records the stats the autoscaler receives or scrapes, e.g. to
+// replay them offline.
+type StatRecorder interface {
+	Record(StatMessage)
+}
+
+// CollectionCheckpoint is the compact state of the metric windows of a
+// collection, which is restored when the collection is recreated, e.g. after
+// a restart of the autoscaler or a change of the bucket leader.
+type CollectionCheckpoint struct {
+	// Concurrency and RPS are the per-second sums of the stats recorded
+	// within the stable window.
+	Concurrency BucketsCheckpoint `json:"concurrency"`
+	RPS         BucketsCheckpoint `json:"rps"`
+
+	// Utilization holds the sums of the resource utilization, for the
+	// collections of revisions scaled on it.
+	Utilization *BucketsCheckpoint `json:"utilization,omitempty"`
+
+	// External holds the values of the external metric, for the collections
+	// of revisions scaled on one.
+	External *BucketsCheckpoint `json:"external,omitempty"`
+}
+
+// BucketsCheckpoint holds consecutive bucket values.
+type BucketsCheckpoint struct {
+	// Start is the time of the first value, in seconds since the epoch.
+	Start  int64     `json:"start"`
+	Values []float64 `json:"values"`
+}
+
+// CollectionCheckpointSource provides the checkpoints to restore new
+// collections from.
+type CollectionCheckpointSource interface {
+	// CollectionCheckpoint returns the checkpoint of the collection with the
+	// given key, if there is one.
+	CollectionCheckpoint(key types.NamespacedName) (CollectionCheckpoint, bool)
 }
 
 // MetricClient surfaces the metrics that can be obtained via the collector.
@@ -80,6 +142,16 @@ type MetricClient interface {
 	// StableAndPanicRPS returns both the stable and the panic RPS
 	// for the given replica as of the given time.
 	StableAndPanicRPS(key types.NamespacedName, now time.Time) (float64, float64, error)
+
+	// StableAndPanicUtilization returns both the stable and the panic
+	// utilization of the resource the given replica is scaled on, as of the
+	// given time.
+	StableAndPanicUtilization(key types.NamespacedName, now time.Time) (float64, float64, error)
+
+	// StableAndPanicExternal returns both the stable and the panic value
+	// of the external metric the given replica is scaled on, as of the given
+	// time.
+	StableAndPanicExternal(key types.NamespacedName, now time.Time) (float64, float64, error)
 }
 
 // MetricCollector manages collection of metrics for many entities.
@@ -87,6 +159,7 @@ type MetricCollector struct {
 	logger *zap.SugaredLogger
 
 	statsScraperFactory StatsScraperFactory
+	metricSourceFactory MetricSourceFactory
 	clock               clock.WithTicker
 
 	collectionsMutex sync.RWMutex
@@ -94,6 +167,18 @@ type MetricCollector struct {
 
 	watcherMutex sync.RWMutex
 	watcher      func(types.NamespacedName)
+
+	// statRecorder, if set, receives the scraped stats. It is guarded by
+	// collectionsMutex.
+	statRecorder StatRecorder
+
+	// checkpoints, if set, provides the checkpoints new collections are
+	// restored from. It is guarded by collectionsMutex.
+	checkpoints CollectionCheckpointSource
+
+	// allowedMetricSources are the URL prefixes the external metrics may be
+	// read from. It is guarded by collectionsMutex.
+	allowedMetricSources []string
 }
 
 var _ Collector = (*MetricCollector)(nil)
@@ -101,11 +186,19 @@ var _ MetricClient = (*MetricCollector)(nil)
 
 // NewMetricCollector creates a new metric collector.
 func NewMetricCollector(statsScraperFactory StatsScraperFactory, logger *zap.SugaredLogger) *MetricCollector {
+	return NewMetricCollectorWithClock(statsScraperFactory, clock.RealClock{}, logger)
+}
+
+// NewMetricCollectorWithClock creates a new metric collector whose collections
+// run on the given clock, e.g. a fake one to replay stats faster than real
+// time.
+func NewMetricCollectorWithClock(statsScraperFactory StatsScraperFactory, clock clock.WithTicker, logger *zap.SugaredLogger) *MetricCollector {
 	return &MetricCollector{
 		logger:              logger,
 		collections:         make(map[types.NamespacedName]*collection),
 		statsScraperFactory: statsScraperFactory,
-		clock:               clock.RealClock{},
+		metricSourceFactory: NewMetricSource,
+		clock:               clock,
 	}
 }
 
@@ -121,20 +214,109 @@ func (c *MetricCollector) CreateOrUpdate(metric *autoscalingv1alpha1.Metric) err
 	if err != nil {
 		return err
 	}
+	source, err := c.metricSourceFactory(metric)
+	if err != nil {
+		return err
+	}
 	key := types.NamespacedName{Namespace: metric.Namespace, Name: metric.Name}
 
+	// Look up the checkpoint before taking the lock, as it might need to be
+	// fetched.
+	checkpoint, restore := c.checkpoint(key)
+
 	c.collectionsMutex.Lock()
 	defer c.collectionsMutex.Unlock()
 
+	var sourceErr error
+	if source != nil && !autoscaling.MetricSourceAllowed(c.allowedMetricSources, metric.Spec.Source.URL) {
+		// The rest of the metrics are still collected, but no request is
+		// sent to the source.
+		source, sourceErr = nil, ErrMetricSourceNotAllowed
+	}
+
 	collection, exists := c.collections[key]
 	if exists {
 		collection.updateScraper(scraper)
+		collection.updateSource(source)
 		collection.updateMetric(metric)
+		if sourceErr != nil {
+			return sourceErr
+		}
 		return collection.lastError()
 	}
 
-	c.collections[key] = newCollection(metric, scraper, c.clock, c.Inform, logger)
-	return nil
+	collection = newCollection(metric, scraper, source, c.clock, c.Inform, c.statRecorder, logger)
+	if restore {
+		logger.Info("Restoring the metric windows from the checkpoint")
+		collection.restore(checkpoint)
+	}
+	c.collections[key] = collection
+	return sourceErr
+}
+
+// checkpoint returns the checkpoint to restore the collection with the given
+// key from, if the collection doesn't exist yet and there is one.
+func (c *MetricCollector) checkpoint(key types.NamespacedName) (CollectionCheckpoint, bool) {
+	c.collectionsMutex.RLock()
+	_, exists := c.collections[key]
+	source := c.checkpoints
+	c.collectionsMutex.RUnlock()
+
+	if exists || source == nil {
+		return CollectionCheckpoint{}, false
+	}
+	return source.CollectionCheckpoint(key)
+}
+
+// SetCheckpointSource sets the source of the checkpoints the collections
+// created afterwards are restored from.
+func (c *MetricCollector) SetCheckpointSource(s CollectionCheckpointSource) {
+	c.collectionsMutex.Lock()
+	defer c.collectionsMutex.Unlock()
+	c.checkpoints = s
+}
+
+// Checkpoints returns the checkpoints of the collections that have data
+// within their stable window as of now.
+func (c *MetricCollector) Checkpoints(now time.Time) map[types.NamespacedName]CollectionCheckpoint {
+	c.collectionsMutex.RLock()
+	defer c.collectionsMutex.RUnlock()
+
+	ret := make(map[types.NamespacedName]CollectionCheckpoint, len(c.collections))
+	for key, collection := range c.collections {
+		if collection.isEmpty(now) {
+			continue
+		}
+		ret[key] = collection.checkpoint()
+	}
+	return ret
+}
+
+// SetAllowedMetricSources sets the URL prefixes the external metrics may be
+// read from, and informs the watcher of the collections reading one, so that
+// their sources are allowed or dropped accordingly.
+func (c *MetricCollector) SetAllowedMetricSources(prefixes []string) {
+	c.collectionsMutex.Lock()
+	c.allowedMetricSources = prefixes
+	var keys []types.NamespacedName
+	for key, collection := range c.collections {
+		if collection.currentMetric().Spec.Source != nil {
+			keys = append(keys, key)
+		}
+	}
+	c.collectionsMutex.Unlock()
+
+	for _, key := range keys {
+		c.Inform(key)
+	}
+}
+
+// SetStatRecorder sets the recorder receiving the stats scraped by the
+// collections created afterwards.
+func (c *MetricCollector) SetStatRecorder(r StatRecorder) {
+	c.collectionsMutex.Lock()
+	defer c.collectionsMutex.Unlock()
+	c.statRecorder = r
 }
 
 // Delete deletes a Metric and halts collection.
@@ -150,11 +332,17 @@ func (c *MetricCollector) Delete(namespace, name string) {
 }
 
 // Record records a stat that's been generated outside of the metric collector.
+// The revision is not scraped while the queue-proxies of all its ready pods
+// push their stats. Until then the pushed stats are not recorded, as the
+// scraped ones account for the pods that push them already.
 func (c *MetricCollector) Record(key types.NamespacedName, now time.Time, stat Stat) {
 	c.collectionsMutex.RLock()
 	defer c.collectionsMutex.RUnlock()
 
 	if collection, exists := c.collections[key]; exists {
+		if stat.PushedByQueueProxy() && collection.markPushed(stat.PodName, c.clock.Now()) {
+			return
+		}
 		collection.record(now, stat)
 	}
 }
@@ -218,6 +406,61 @@ func (c *MetricCollector) StableAndPanicRPS(key types.NamespacedName, now time.T
 		nil
 }
 
+// StableAndPanicUtilization returns both the stable and the panic utilization
+// of the resource the revision is scaled on, summed over its pods, each as a
+// percentage of the requests of the containers of the pod other than the
+// queue-proxy.
+// It may truncate metric buckets as a side-effect.
+func (c *MetricCollector) StableAndPanicUtilization(key types.NamespacedName, now time.Time) (float64, float64, error) {
+	c.collectionsMutex.RLock()
+	defer c.collectionsMutex.RUnlock()
+
+	collection, exists := c.collections[key]
+	if !exists {
+		return 0, 0, ErrNotCollecting
+	}
+
+	if collection.utilizationBuckets == nil ||
+		(collection.utilizationBuckets.IsEmpty(now) && collection.currentMetric().Spec.ScrapeTarget != "") {
+		return 0, 0, ErrNoData
+	}
+	return collection.utilizationBuckets.WindowAverage(now),
+		collection.utilizationPanicBuckets.WindowAverage(now),
+		nil
+}
+
+// StableAndPanicExternal returns both the stable and the panic value of the
+// external metric the revision is scaled on.
+// It may truncate metric buckets as a side-effect.
+func (c *MetricCollector) StableAndPanicExternal(key types.NamespacedName, now time.Time) (float64, float64, error) {
+	c.collectionsMutex.RLock()
+	defer c.collectionsMutex.RUnlock()
+
+	collection, exists := c.collections[key]
+	if !exists {
+		return 0, 0, ErrNotCollecting
+	}
+
+	if collection.externalBuckets == nil || collection.externalBuckets.IsEmpty(now) {
+		return 0, 0, ErrNoData
+	}
+	return collection.externalBuckets.WindowAverage(now),
+		collection.externalPanicBuckets.WindowAverage(now),
+		nil
+}
+
+// RecommendedResources implements Collector.
+func (c *MetricCollector) RecommendedResources(key types.NamespacedName) corev1.ResourceList {
+	c.collectionsMutex.RLock()
+	defer c.collectionsMutex.RUnlock()
+
+	collection, exists := c.collections[key]
+	if !exists {
+		return nil
+	}
+	return collection.usage.current()
+}
+
 type (
 	// windowAverager is the client side abstraction for various bucket types.
 	windowAverager interface {
@@ -225,6 +468,7 @@ type (
 		ResizeWindow(time.Duration)
 		WindowAverage(time.Time) float64
 		IsEmpty(time.Time) bool
+		Checkpoint() (time.Time, []float64)
 	}
 
 	// collection represents the collection of metrics for one specific entity.
@@ -240,11 +484,37 @@ type (
 		rpsBuckets              windowAverager
 		rpsPanicBuckets         windowAverager
 
+		// The utilization of the resource the revision is scaled on, if any.
+		utilizationMetric       string
+		utilizationBuckets      windowAverager
+		utilizationPanicBuckets windowAverager
+
+		// The external metric the revision is scaled on, if any.
+		externalBuckets      windowAverager
+		externalPanicBuckets windowAverager
+		// lastSourceRead is the bucket the external metric was last recorded
+		// in. It is only accessed by the scrape loop.
+		lastSourceRead time.Time
+
+		// usage is the resource usage of the pods, if they report it, from
+		// which their requests are recommended.
+		usage usageHistory
+		// inform notifies the watcher of the collector, e.g. of a new
+		// recommendation.
+		inform func()
+
 		// Fields relevant for metric scraping specifically.
 		scraper StatsScraper
+		source  MetricSource
 		lastErr error
 		grp     sync.WaitGroup
 		stopCh  chan struct{}
+
+		// pushes holds the time the queue-proxy of each pod last pushed a
+		// stat at, by pod name, and scraping whether the revision is
+		// scraped rather than all its pods pushing their stats.
+		pushes   map[string]time.Time
+		scraping bool
 	}
 )
 
@@ -260,20 +530,39 @@ func (c *collection) getScraper() StatsScraper {
 	return c.scraper
 }
 
+func (c *collection) updateSource(ms MetricSource) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.source = ms
+}
+
+func (c *collection) getSource() MetricSource {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+	return c.source
+}
+
 // newCollection creates a new collection, which uses the given scraper to
-// collect stats every scrapeTickInterval.
-func newCollection(metric *autoscalingv1alpha1.Metric, scraper StatsScraper, clock clock.WithTicker,
-	callback func(types.NamespacedName), logger *zap.SugaredLogger) *collection {
+// collect stats, and the given source to read the external metric, every
+// scrapeTickInterval.
+func newCollection(metric *autoscalingv1alpha1.Metric, scraper StatsScraper, source MetricSource, clock clock.WithTicker,
+	callback func(types.NamespacedName), recorder StatRecorder, logger *zap.SugaredLogger) *collection {
 	// Pick the constructor to use to build the buckets.
 	// NB: this relies on the fact that aggregation algorithm is set on annotation of revision
 	// and as such is immutable.
 	bucketCtor := func(w time.Duration, g time.Duration) windowAverager {
 		return aggregation.NewTimedFloat64Buckets(w, g)
 	}
-	if metric.AggregationAlgorithm() == autoscaling.MetricAggregationAlgorithmWeightedExponential {
+	switch metric.AggregationAlgorithm() {
+	case autoscaling.MetricAggregationAlgorithmWeightedExponential:
 		bucketCtor = func(w time.Duration, g time.Duration) windowAverager {
 			return aggregation.NewWeightedFloat64Buckets(w, g)
 		}
+	case autoscaling.MetricAggregationAlgorithmPercentile:
+		percentile := metric.AggregationPercentile()
+		bucketCtor = func(w time.Duration, g time.Duration) windowAverager {
+			return aggregation.NewPercentileFloat64Buckets(w, g, percentile)
+		}
 	}
 
 	c := &collection{
@@ -287,12 +576,25 @@ func newCollection(metric *autoscalingv1alpha1.Metric, scraper StatsScraper, clo
 		rpsPanicBuckets: bucketCtor(
 			metric.Spec.PanicWindow, config.BucketSize),
 		scraper: scraper,
+		source:  source,
 
-		stopCh: make(chan struct{}),
+		stopCh:   make(chan struct{}),
+		pushes:   make(map[string]time.Time),
+		scraping: scraper != nil,
+	}
+	if _, m, _ := autoscaling.MetricAnnotation.Get(metric.Annotations); m == autoscaling.CPU || m == autoscaling.Memory {
+		c.utilizationMetric = m
+		c.utilizationBuckets = bucketCtor(metric.Spec.StableWindow, config.BucketSize)
+		c.utilizationPanicBuckets = bucketCtor(metric.Spec.PanicWindow, config.BucketSize)
+	}
+	if metric.Spec.Source != nil {
+		c.externalBuckets = bucketCtor(metric.Spec.StableWindow, config.BucketSize)
+		c.externalPanicBuckets = bucketCtor(metric.Spec.PanicWindow, config.BucketSize)
 	}
 
 	key := types.NamespacedName{Namespace: metric.Namespace, Name: metric.Name}
 	logger = logger.Named("collector").With(zap.String(logkey.Key, key.String()))
+	c.inform = func() { callback(key) }
 
 	c.grp.Add(1)
 	go func() {
@@ -305,10 +607,23 @@ func newCollection(metric *autoscalingv1alpha1.Metric, scraper StatsScraper, clo
 			case <-c.stopCh:
 				return
 			case <-scrapeTicker.C():
+				// The external metric is read whether there are pods or not,
+				// so that the revision can be scaled from zero on it.
+				var sourceErr error
+				if source := c.getSource(); source != nil && c.externalBuckets != nil {
+					if err := c.readSource(source, clock.Now()); err != nil {
+						logger.Errorw("Failed to read the external metric", zap.Error(err))
+						sourceErr = ErrFailedReadSource
+					}
+				}
+
 				scraper := c.getScraper()
-				if scraper == nil {
-					// Don't scrape empty target service.
-					if c.updateLastError(nil) {
+				scraping := scraper != nil && !c.allPodsPush(scraper, clock.Now())
+				c.setScraping(scraping)
+				if !scraping {
+					// Don't scrape empty target service, nor one whose
+					// queue-proxies all push their stats.
+					if c.updateLastError(sourceErr) {
 						callback(key)
 					}
 					continue
@@ -317,12 +632,19 @@ func newCollection(metric *autoscalingv1alpha1.Metric, scraper StatsScraper, clo
 				stat, err := scraper.Scrape(c.currentMetric().Spec.StableWindow)
 				if err != nil {
 					logger.Errorw("Failed to scrape metrics", zap.Error(err))
+				} else {
+					err = sourceErr
 				}
 				if c.updateLastError(err) {
 					callback(key)
 				}
 				if stat != emptyStat {
-					c.record(clock.Now(), stat)
+					now := clock.Now()
+					c.record(now, stat)
+					if recorder != nil {
+						stat.Timestamp = now.Unix()
+						recorder.Record(StatMessage{Key: key, Stat: stat})
+					}
 				}
 			}
 		}
@@ -331,6 +653,28 @@ func newCollection(metric *autoscalingv1alpha1.Metric, scraper StatsScraper, clo
 	return c
 }
 
+// readSource reads the external metric from the given source and records its
+// value at the given time. Unlike the stats, which are summed over the pods,
+// the value is recorded only once per bucket.
+func (c *collection) readSource(source MetricSource, now time.Time) error {
+	bucket := now.Truncate(config.BucketSize)
+	if bucket.Equal(c.lastSourceRead) {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), httpClientTimeout)
+	defer cancel()
+
+	value, err := source.Read(ctx)
+	if err != nil {
+		return err
+	}
+	c.externalBuckets.Record(now, value)
+	c.externalPanicBuckets.Record(now, value)
+	c.lastSourceRead = bucket
+	return nil
+}
+
 // close stops collecting metrics, stops the scraper.
 func (c *collection) close() {
 	close(c.stopCh)
@@ -347,6 +691,14 @@ func (c *collection) updateMetric(metric *autoscalingv1alpha1.Metric) {
 	c.concurrencyPanicBuckets.ResizeWindow(metric.Spec.PanicWindow)
 	c.rpsBuckets.ResizeWindow(metric.Spec.StableWindow)
 	c.rpsPanicBuckets.ResizeWindow(metric.Spec.PanicWindow)
+	if c.utilizationBuckets != nil {
+		c.utilizationBuckets.ResizeWindow(metric.Spec.StableWindow)
+		c.utilizationPanicBuckets.ResizeWindow(metric.Spec.PanicWindow)
+	}
+	if c.externalBuckets != nil {
+		c.externalBuckets.ResizeWindow(metric.Spec.StableWindow)
+		c.externalPanicBuckets.ResizeWindow(metric.Spec.PanicWindow)
+	}
 }
 
 // currentMetric safely returns the current metric stored in the collection.
@@ -377,6 +729,44 @@ func (c *collection) lastError() error {
 	return c.lastErr
 }
 
+// markPushed records that the queue-proxy of the given pod pushed a stat at
+// the given time, and returns true if the revision is scraped nonetheless.
+func (c *collection) markPushed(pod string, now time.Time) bool {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.pushes[pod] = now
+	return c.scraping
+}
+
+// setScraping records whether the revision is scraped.
+func (c *collection) setScraping(scraping bool) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.scraping = scraping
+}
+
+// allPodsPush returns true if the queue-proxies of all the ready pods of the
+// revision pushed a stat within statPushTimeout of now. The pods that stopped
+// pushing are forgotten.
+func (c *collection) allPodsPush(scraper StatsScraper, now time.Time) bool {
+	c.mux.Lock()
+	for pod, t := range c.pushes {
+		if !t.After(now.Add(-statPushTimeout)) {
+			delete(c.pushes, pod)
+		}
+	}
+	pushing := len(c.pushes)
+	c.mux.Unlock()
+
+	if pushing == 0 {
+		return false
+	}
+	ready, err := scraper.ReadyPods()
+	return err == nil && pushing >= ready
+}
+
 // record adds a stat to the current collection.
 func (c *collection) record(now time.Time, stat Stat) {
 	// Proxied requests have been counted at the activator. Subtract
@@ -387,6 +777,77 @@ func (c *collection) record(now time.Time, stat Stat) {
 	rps := stat.RequestCount - stat.ProxiedRequestCount
 	c.rpsBuckets.Record(now, rps)
 	c.rpsPanicBuckets.Record(now, rps)
+	if c.utilizationBuckets != nil {
+		utilization := stat.CpuUtilization
+		if c.utilizationMetric == autoscaling.Memory {
+			utilization = stat.MemoryUtilization
+		}
+		c.utilizationBuckets.Record(now, utilization)
+		c.utilizationPanicBuckets.Record(now, utilization)
+	}
+	if stat.CpuUsage > 0 || stat.MemoryUsage > 0 {
+		if c.usage.record(now, stat.CpuUsage, stat.MemoryUsage) {
+			c.inform()
+		}
+	}
+}
+
+// isEmpty returns true if the collection has no data within its stable windows
+// as of now.
+func (c *collection) isEmpty(now time.Time) bool {
+	return c.concurrencyBuckets.IsEmpty(now) && c.rpsBuckets.IsEmpty(now) &&
+		(c.externalBuckets == nil || c.externalBuckets.IsEmpty(now))
+}
+
+// checkpoint returns the checkpoint of the stable windows. The panic windows
+// are a suffix of these and hence need not be checkpointed.
+func (c *collection) checkpoint() CollectionCheckpoint {
+	bucketsCheckpoint := func(w windowAverager) BucketsCheckpoint {
+		start, values := w.Checkpoint()
+		return BucketsCheckpoint{Start: start.Unix(), Values: values}
+	}
+	cp := CollectionCheckpoint{
+		Concurrency: bucketsCheckpoint(c.concurrencyBuckets),
+		RPS:         bucketsCheckpoint(c.rpsBuckets),
+	}
+	if c.utilizationBuckets != nil {
+		bc := bucketsCheckpoint(c.utilizationBuckets)
+		cp.Utilization = &bc
+	}
+	if c.externalBuckets != nil {
+		bc := bucketsCheckpoint(c.externalBuckets)
+		cp.External = &bc
+	}
+	return cp
+}
+
+// restore replays the checkpointed values into the windows of a new
+// collection. Values older than a window are dropped by the window itself.
+func (c *collection) restore(cp CollectionCheckpoint) {
+	replay := func(bc BucketsCheckpoint, windows ...windowAverager) {
+		start := time.Unix(bc.Start, 0)
+		for i, v := range bc.Values {
+			t := start.Add(time.Duration(i) * config.BucketSize)
+			for _, w := range windows {
+				w.Record(t, v)
+			}
+		}
+	}
+	replay(cp.Concurrency, c.concurrencyBuckets, c.concurrencyPanicBuckets)
+	replay(cp.RPS, c.rpsBuckets, c.rpsPanicBuckets)
+	if cp.Utilization != nil && c.utilizationBuckets != nil {
+		replay(*cp.Utilization, c.utilizationBuckets, c.utilizationPanicBuckets)
+	}
+	if cp.External != nil && c.externalBuckets != nil {
+		replay(*cp.External, c.externalBuckets, c.externalPanicBuckets)
+	}
+}
+
+// PushedByQueueProxy returns true if the stat was pushed by a queue-proxy
+// rather than sent by an activator. Only the queue-proxies report their
+// process uptime, which is positive from the first report on.
+func (s *Stat) PushedByQueueProxy() bool {
+	return s.ProcessUptime > 0
 }
 
 // add adds the stats from `src` to `dst`.
@@ -395,10 +856,17 @@ func (dst *Stat) add(src Stat) {
 	dst.AverageProxiedConcurrentRequests += src.AverageProxiedConcurrentRequests
 	dst.RequestCount += src.RequestCount
 	dst.ProxiedRequestCount += src.ProxiedRequestCount
+	dst.CpuUtilization += src.CpuUtilization
+	dst.MemoryUtilization += src.MemoryUtilization
+	dst.CpuUsage += src.CpuUsage
+	if src.MemoryUsage > dst.MemoryUsage {
+		dst.MemoryUsage = src.MemoryUsage
+	}
 }
 
 // average reduces the aggregate stat from `sample` pods to an averaged one over
-// `total` pods.
+// `total` pods. The resource usage is reduced to the one of a pod instead: the
+// average CPU usage and the peak memory usage.
 // The method performs no checks on the data, i.e. that sample is > 0.
 //
 // Assumption: A particular pod can stand for other pods, i.e. other pods
@@ -413,4 +881,7 @@ func (dst *Stat) average(sample, total float64) {
 	dst.AverageProxiedConcurrentRequests = dst.AverageProxiedConcurrentRequests / sample * total
 	dst.RequestCount = dst.RequestCount / sample * total
 	dst.ProxiedRequestCount = dst.ProxiedRequestCount / sample * total
+	dst.CpuUtilization = dst.CpuUtilization / sample * total
+	dst.MemoryUtilization = dst.MemoryUtilization / sample * total
+	dst.CpuUsage /= sample
 }
@@ -17,14 +17,19 @@ limitations under the License.
 package metrics
 
 import (
+	"context"
 	"errors"
 	"math"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"go.uber.org/atomic"
 	"go.uber.org/zap"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -599,14 +604,19 @@ func scraperFactory(scraper StatsScraper, err error) StatsScraperFactory {
 }
 
 type testScraper struct {
-	s   func() (Stat, error)
-	url string
+	s     func() (Stat, error)
+	url   string
+	ready int
 }
 
 func (s *testScraper) Scrape(time.Duration) (Stat, error) {
 	return s.s()
 }
 
+func (s *testScraper) ReadyPods() (int, error) {
+	return s.ready, nil
+}
+
 func TestMetricCollectorAggregate(t *testing.T) {
 	m := defaultMetric
 	m.Spec.StableWindow = 6 * time.Second
@@ -639,3 +649,442 @@ func TestMetricCollectorAggregate(t *testing.T) {
 		t.Errorf("Stable Concurrency = %f, want: %f", got, want)
 	}
 }
+
+func TestMetricCollectorPercentile(t *testing.T) {
+	now := time.Unix(1000, 0)
+	metricKey := types.NamespacedName{Namespace: defaultNamespace, Name: defaultName}
+
+	metric := defaultMetric.DeepCopy()
+	metric.Annotations = map[string]string{
+		autoscaling.MetricAggregationAlgorithmKey: autoscaling.MetricAggregationAlgorithmPercentile,
+		autoscaling.MetricPercentileAnnotationKey: "100",
+	}
+	coll := NewMetricCollector(scraperFactory(nil, nil), TestLogger(t))
+	coll.CreateOrUpdate(metric)
+	defer coll.Delete(defaultNamespace, defaultName)
+
+	// A burst in an otherwise steady concurrency.
+	for i := 0; i < 6; i++ {
+		concurrency := 1.
+		if i == 3 {
+			concurrency = 20
+		}
+		coll.Record(metricKey, now.Add(time.Duration(i)*time.Second), Stat{
+			PodName:                   "pod-1",
+			AverageConcurrentRequests: concurrency,
+		})
+	}
+	stable, panic, err := coll.StableAndPanicConcurrency(metricKey, now.Add(5*time.Second))
+	if err != nil {
+		t.Fatal("StableAndPanicConcurrency() =", err)
+	}
+	if math.Abs(stable-20) > 0.2 || math.Abs(panic-20) > 0.2 {
+		t.Errorf("StableAndPanicConcurrency() = %v, %v, want: 20, 20", stable, panic)
+	}
+}
+
+type chanStatRecorder chan StatMessage
+
+func (r chanStatRecorder) Record(sm StatMessage) {
+	r <- sm
+}
+
+func TestMetricCollectorRecordsScrapedStats(t *testing.T) {
+	mtp := &fake.ManualTickProvider{
+		Channel: make(chan time.Time),
+	}
+	now := time.Unix(1000, 0)
+	fc := fake.Clock{
+		FakeClock: clocktest.NewFakeClock(now),
+		TP:        mtp,
+	}
+	stat := Stat{
+		PodName:                   "testPod",
+		AverageConcurrentRequests: 10,
+		RequestCount:              20,
+	}
+	scraper := &testScraper{
+		s: func() (Stat, error) {
+			return stat, nil
+		},
+	}
+
+	recorder := make(chanStatRecorder, 1)
+	coll := NewMetricCollector(scraperFactory(scraper, nil), TestLogger(t))
+	coll.clock = fc
+	coll.SetStatRecorder(recorder)
+	coll.CreateOrUpdate(&defaultMetric)
+	mtp.Channel <- now
+
+	select {
+	case got := <-recorder:
+		want := StatMessage{
+			Key: types.NamespacedName{Namespace: defaultNamespace, Name: defaultName},
+			Stat: Stat{
+				PodName:                   "testPod",
+				AverageConcurrentRequests: 10,
+				RequestCount:              20,
+				Timestamp:                 now.Unix(),
+			},
+		}
+		if !cmp.Equal(got, want) {
+			t.Error("Recorded stat (-want, +got):", cmp.Diff(want, got))
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for the scraped stat to be recorded")
+	}
+}
+
+func TestMetricCollectorStatPushFallback(t *testing.T) {
+	mtp := &fake.ManualTickProvider{
+		Channel: make(chan time.Time),
+	}
+	now := time.Unix(1000, 0)
+	fc := fake.Clock{
+		FakeClock: clocktest.NewFakeClock(now),
+		TP:        mtp,
+	}
+	metricKey := types.NamespacedName{Namespace: defaultNamespace, Name: defaultName}
+
+	scrapes := make(chan struct{}, 10)
+	scraper := &testScraper{
+		s: func() (Stat, error) {
+			scrapes <- struct{}{}
+			return emptyStat, nil
+		},
+		ready: 2,
+	}
+	coll := NewMetricCollector(scraperFactory(scraper, nil), TestLogger(t))
+	coll.clock = fc
+	coll.CreateOrUpdate(&defaultMetric)
+	defer coll.Delete(defaultNamespace, defaultName)
+
+	// The scrape loop handles a tick only once it's done with the previous
+	// one, so the second tick ensures the first one has been handled.
+	scraped := func() int {
+		mtp.Channel <- fc.Now()
+		mtp.Channel <- fc.Now()
+		n := len(scrapes)
+		for i := 0; i < n; i++ {
+			<-scrapes
+		}
+		return n
+	}
+
+	if got := scraped(); got == 0 {
+		t.Error("Revision was not scraped before any stat was pushed")
+	}
+
+	// Stats sent by the activator don't stop the scraping.
+	coll.Record(metricKey, now, Stat{PodName: "activator", AverageConcurrentRequests: 1})
+	if got := scraped(); got == 0 {
+		t.Error("Revision was not scraped after an activator stat")
+	}
+
+	// The stats pushed while the revision is scraped are accounted for by
+	// the scraped ones already.
+	coll.Record(metricKey, now, Stat{PodName: "pod1", AverageConcurrentRequests: 5, ProcessUptime: 10})
+	if got := scraped(); got == 0 {
+		t.Error("Revision was not scraped while one of its two pods pushes")
+	}
+	if got, _, _ := coll.StableAndPanicConcurrency(metricKey, now); got != 1 {
+		t.Errorf("StableAndPanicConcurrency() = %v, want: 1 from the activator only", got)
+	}
+
+	coll.Record(metricKey, now, Stat{PodName: "pod1", AverageConcurrentRequests: 1, ProcessUptime: 10})
+	coll.Record(metricKey, now, Stat{PodName: "pod2", AverageConcurrentRequests: 1, ProcessUptime: 10})
+	if got := scraped(); got != 0 {
+		t.Errorf("Revision was scraped %d times while all its pods push", got)
+	}
+	coll.Record(metricKey, now, Stat{PodName: "pod1", AverageConcurrentRequests: 1, ProcessUptime: 10})
+	if got, _, _ := coll.StableAndPanicConcurrency(metricKey, now); got != 2 {
+		t.Errorf("StableAndPanicConcurrency() = %v, want: 2 with the pushed stat", got)
+	}
+
+	// Scraping resumes once the pushes stop arriving.
+	fc.SetTime(now.Add(statPushTimeout))
+	if got := scraped(); got == 0 {
+		t.Error("Revision was not scraped after the pushes stopped")
+	}
+}
+
+type fakeCheckpointSource map[types.NamespacedName]CollectionCheckpoint
+
+func (f fakeCheckpointSource) CollectionCheckpoint(key types.NamespacedName) (CollectionCheckpoint, bool) {
+	cp, ok := f[key]
+	return cp, ok
+}
+
+func TestMetricCollectorCheckpoints(t *testing.T) {
+	now := time.Unix(1000, 0)
+	metricKey := types.NamespacedName{Namespace: defaultNamespace, Name: defaultName}
+
+	coll := NewMetricCollector(scraperFactory(nil, nil), TestLogger(t))
+	coll.CreateOrUpdate(&defaultMetric)
+	defer coll.Delete(defaultNamespace, defaultName)
+	if got := coll.Checkpoints(now); len(got) != 0 {
+		t.Errorf("Checkpoints() = %v, want none without data", got)
+	}
+
+	for i := 0; i < 10; i++ {
+		coll.Record(metricKey, now.Add(time.Duration(i)*time.Second), Stat{
+			AverageConcurrentRequests: float64(i),
+			RequestCount:              float64(2 * i),
+		})
+	}
+	now = now.Add(9 * time.Second)
+	checkpoints := coll.Checkpoints(now)
+	want := CollectionCheckpoint{
+		Concurrency: BucketsCheckpoint{Start: 1000, Values: []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}},
+		RPS:         BucketsCheckpoint{Start: 1000, Values: []float64{0, 2, 4, 6, 8, 10, 12, 14, 16, 18}},
+	}
+	if got := checkpoints[metricKey]; !cmp.Equal(got, want) {
+		t.Error("Checkpoint (-want, +got):", cmp.Diff(want, got))
+	}
+
+	// A new collector restores the windows from the checkpoint.
+	restored := NewMetricCollector(scraperFactory(nil, nil), TestLogger(t))
+	restored.SetCheckpointSource(fakeCheckpointSource(checkpoints))
+	restored.CreateOrUpdate(&defaultMetric)
+	defer restored.Delete(defaultNamespace, defaultName)
+
+	wantStable, wantPanic, err := coll.StableAndPanicConcurrency(metricKey, now)
+	if err != nil {
+		t.Fatal("StableAndPanicConcurrency() =", err)
+	}
+	gotStable, gotPanic, err := restored.StableAndPanicConcurrency(metricKey, now)
+	if err != nil {
+		t.Fatal("Restored StableAndPanicConcurrency() =", err)
+	}
+	if gotStable != wantStable || gotPanic != wantPanic {
+		t.Errorf("Restored StableAndPanicConcurrency() = %v, %v, want: %v, %v", gotStable, gotPanic, wantStable, wantPanic)
+	}
+	wantStable, wantPanic, _ = coll.StableAndPanicRPS(metricKey, now)
+	gotStable, gotPanic, _ = restored.StableAndPanicRPS(metricKey, now)
+	if gotStable != wantStable || gotPanic != wantPanic {
+		t.Errorf("Restored StableAndPanicRPS() = %v, %v, want: %v, %v", gotStable, gotPanic, wantStable, wantPanic)
+	}
+}
+
+func TestMetricCollectorUtilization(t *testing.T) {
+	now := time.Unix(1000, 0)
+	metricKey := types.NamespacedName{Namespace: defaultNamespace, Name: defaultName}
+
+	coll := NewMetricCollector(scraperFactory(nil, nil), TestLogger(t))
+	coll.CreateOrUpdate(&defaultMetric)
+	if _, _, err := coll.StableAndPanicUtilization(metricKey, now); !errors.Is(err, ErrNoData) {
+		t.Errorf("StableAndPanicUtilization() = %v, want: %v", err, ErrNoData)
+	}
+	coll.Delete(defaultNamespace, defaultName)
+
+	metric := defaultMetric.DeepCopy()
+	metric.Annotations = map[string]string{autoscaling.MetricAnnotationKey: autoscaling.Memory}
+	coll.CreateOrUpdate(metric)
+	defer coll.Delete(defaultNamespace, defaultName)
+	coll.Record(metricKey, now, Stat{
+		PodName:           "pod-1",
+		CpuUtilization:    90,
+		MemoryUtilization: 40,
+	})
+	coll.Record(metricKey, now, Stat{
+		PodName:           "pod-2",
+		CpuUtilization:    10,
+		MemoryUtilization: 60,
+	})
+	stable, panic, err := coll.StableAndPanicUtilization(metricKey, now)
+	if err != nil {
+		t.Fatal("StableAndPanicUtilization() =", err)
+	}
+	if stable != 100 || panic != 100 {
+		t.Errorf("StableAndPanicUtilization() = %v, %v, want: 100, 100", stable, panic)
+	}
+
+	checkpoint := coll.Checkpoints(now)[metricKey]
+	want := &BucketsCheckpoint{Start: 1000, Values: []float64{100}}
+	if !cmp.Equal(checkpoint.Utilization, want) {
+		t.Error("Utilization checkpoint (-want, +got):", cmp.Diff(want, checkpoint.Utilization))
+	}
+}
+
+func TestMetricCollectorRecommendedResources(t *testing.T) {
+	start := time.Unix(6000, 0)
+	metricKey := types.NamespacedName{Namespace: defaultNamespace, Name: defaultName}
+
+	coll := NewMetricCollector(scraperFactory(nil, nil), TestLogger(t))
+	informed := atomic.NewInt32(0)
+	coll.Watch(func(types.NamespacedName) {
+		informed.Inc()
+	})
+	coll.CreateOrUpdate(&defaultMetric)
+	defer coll.Delete(defaultNamespace, defaultName)
+
+	if got := coll.RecommendedResources(metricKey); got != nil {
+		t.Errorf("RecommendedResources() = %v, want: nil", got)
+	}
+	for i := 0; i <= minUsageMinutes; i++ {
+		coll.Record(metricKey, start.Add(time.Duration(i)*time.Minute), Stat{
+			PodName:     "pod-1",
+			CpuUsage:    100,
+			MemoryUsage: 64 * mebibyte,
+		})
+	}
+
+	want := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("115m"),
+		corev1.ResourceMemory: resource.MustParse("74Mi"),
+	}
+	if got := coll.RecommendedResources(metricKey); !cmp.Equal(got, want) {
+		t.Error("RecommendedResources() (-want, +got):", cmp.Diff(want, got))
+	}
+	if got := informed.Load(); got != 1 {
+		t.Errorf("Watcher calls = %d, want: 1", got)
+	}
+	if got := coll.RecommendedResources(types.NamespacedName{Namespace: "unknown", Name: "metric"}); got != nil {
+		t.Errorf("RecommendedResources(unknown) = %v, want: nil", got)
+	}
+}
+
+type fakeMetricSource func() (float64, error)
+
+func (f fakeMetricSource) Read(context.Context) (float64, error) {
+	return f()
+}
+
+func TestMetricCollectorExternal(t *testing.T) {
+	mtp := &fake.ManualTickProvider{
+		Channel: make(chan time.Time),
+	}
+	now := time.Unix(1000, 0)
+	fc := fake.Clock{
+		FakeClock: clocktest.NewFakeClock(now),
+		TP:        mtp,
+	}
+	metricKey := types.NamespacedName{Namespace: defaultNamespace, Name: defaultName}
+
+	var (
+		value   = atomic.NewFloat64(12)
+		readErr = atomic.NewError(nil)
+	)
+	coll := NewMetricCollector(scraperFactory(nil, nil), TestLogger(t))
+	coll.clock = fc
+	coll.metricSourceFactory = func(m *autoscalingv1alpha1.Metric) (MetricSource, error) {
+		if m.Spec.Source == nil {
+			return nil, nil
+		}
+		return fakeMetricSource(func() (float64, error) {
+			return value.Load(), readErr.Load()
+		}), nil
+	}
+	coll.SetAllowedMetricSources([]string{"http://queue.example.com"})
+
+	// Without a scrape target, only the external metric is collected.
+	metric := defaultMetric.DeepCopy()
+	metric.Spec.ScrapeTarget = ""
+	metric.Spec.Source = &autoscalingv1alpha1.ExternalMetricSource{
+		Type: autoscaling.MetricSourceHTTP,
+		URL:  "http://queue.example.com/depth",
+	}
+	coll.CreateOrUpdate(metric)
+	defer coll.Delete(defaultNamespace, defaultName)
+
+	if _, _, err := coll.StableAndPanicExternal(metricKey, now); !errors.Is(err, ErrNoData) {
+		t.Errorf("StableAndPanicExternal() = %v, want: %v", err, ErrNoData)
+	}
+
+	// The scrape loop handles a tick only once it's done with the previous
+	// one, so the second tick ensures the first one has been handled. Both
+	// ticks fall in the same bucket, so the value is recorded once.
+	tick := func() {
+		fc.SetTime(fc.Now().Add(time.Second))
+		mtp.Channel <- fc.Now()
+		mtp.Channel <- fc.Now()
+	}
+	tick()
+	stable, panic, err := coll.StableAndPanicExternal(metricKey, fc.Now())
+	if err != nil {
+		t.Fatal("StableAndPanicExternal() =", err)
+	}
+	if stable != 12 || panic != 12 {
+		t.Errorf("StableAndPanicExternal() = %v, %v, want: 12, 12", stable, panic)
+	}
+	if cp := coll.Checkpoints(fc.Now())[metricKey]; cp.External == nil {
+		t.Error("Checkpoint has no external metric")
+	}
+
+	value.Store(0)
+	tick()
+	if stable, _, _ := coll.StableAndPanicExternal(metricKey, fc.Now()); stable != 6 {
+		t.Errorf("StableAndPanicExternal() = %v, want: 6", stable)
+	}
+
+	// Failures to read the metric are reported, without their cause.
+	readErr.Store(errors.New("connection refused"))
+	tick()
+	if err := coll.CreateOrUpdate(metric); !errors.Is(err, ErrFailedReadSource) || strings.Contains(err.Error(), "refused") {
+		t.Errorf("CreateOrUpdate() = %v, want: %v", err, ErrFailedReadSource)
+	}
+}
+
+func TestMetricCollectorExternalNotAllowed(t *testing.T) {
+	mtp := &fake.ManualTickProvider{
+		Channel: make(chan time.Time),
+	}
+	now := time.Unix(1000, 0)
+	fc := fake.Clock{
+		FakeClock: clocktest.NewFakeClock(now),
+		TP:        mtp,
+	}
+	metricKey := types.NamespacedName{Namespace: defaultNamespace, Name: defaultName}
+
+	reads := atomic.NewInt32(0)
+	coll := NewMetricCollector(scraperFactory(nil, nil), TestLogger(t))
+	coll.clock = fc
+	coll.metricSourceFactory = func(m *autoscalingv1alpha1.Metric) (MetricSource, error) {
+		return fakeMetricSource(func() (float64, error) {
+			reads.Inc()
+			return 12, nil
+		}), nil
+	}
+	informed := make(chan types.NamespacedName, 1)
+	coll.Watch(func(key types.NamespacedName) {
+		select {
+		case informed <- key:
+		default:
+		}
+	})
+
+	metric := defaultMetric.DeepCopy()
+	metric.Spec.ScrapeTarget = ""
+	metric.Spec.Source = &autoscalingv1alpha1.ExternalMetricSource{
+		Type: autoscaling.MetricSourceHTTP,
+		URL:  "http://169.254.169.254/latest/meta-data",
+	}
+	if err := coll.CreateOrUpdate(metric); !errors.Is(err, ErrMetricSourceNotAllowed) {
+		t.Errorf("CreateOrUpdate() = %v, want: %v", err, ErrMetricSourceNotAllowed)
+	}
+	defer coll.Delete(defaultNamespace, defaultName)
+
+	// The ticks are handled one at a time, so the second one ensures the
+	// first one has been.
+	fc.SetTime(fc.Now().Add(time.Second))
+	mtp.Channel <- fc.Now()
+	mtp.Channel <- fc.Now()
+	if got := reads.Load(); got != 0 {
+		t.Errorf("The source was read %d times, want: 0", got)
+	}
+
+	// Allowing the source makes the metric be reconciled again.
+	coll.SetAllowedMetricSources([]string{"http://169.254.169.254/latest/"})
+	if got := <-informed; got != metricKey {
+		t.Errorf("Informed of %v, want: %v", got, metricKey)
+	}
+	if err := coll.CreateOrUpdate(metric); err != nil {
+		t.Error("CreateOrUpdate() =", err)
+	}
+	fc.SetTime(fc.Now().Add(time.Second))
+	mtp.Channel <- fc.Now()
+	mtp.Channel <- fc.Now()
+	if got := reads.Load(); got != 1 {
+		t.Errorf("The source was read %d times, want: 1", got)
+	}
+}
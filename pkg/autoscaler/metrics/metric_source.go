@@ -0,0 +1,188 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"knative.dev/serving/pkg/apis/autoscaling"
+	autoscalingv1alpha1 "knative.dev/serving/pkg/apis/autoscaling/v1alpha1"
+)
+
+// prometheusQueryPath is the path of the instant query API of Prometheus.
+const prometheusQueryPath = "/api/v1/query"
+
+// NewMetricSource creates the MetricSource the external metric of the given
+// Metric is read from, or nil if it is not scaled on an external metric.
+func NewMetricSource(metric *autoscalingv1alpha1.Metric) (MetricSource, error) {
+	src := metric.Spec.Source
+	if src == nil {
+		return nil, nil
+	}
+	switch src.Type {
+	case autoscaling.MetricSourceHTTP:
+		var fields []string
+		if src.Query != "" {
+			fields = strings.Split(src.Query, ".")
+		}
+		return &httpJSONSource{client: client, url: src.URL, fields: fields}, nil
+	case autoscaling.MetricSourcePrometheus:
+		u, err := url.Parse(src.URL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid prometheus URL %q: %w", src.URL, err)
+		}
+		u.Path = path.Join(u.Path, prometheusQueryPath)
+		u.RawQuery = url.Values{"query": []string{src.Query}}.Encode()
+		return &prometheusSource{client: client, url: u.String()}, nil
+	default:
+		return nil, fmt.Errorf("unsupported metric source type %q", src.Type)
+	}
+}
+
+// httpJSONSource reads the number at a path in the JSON document served by
+// an HTTP endpoint.
+type httpJSONSource struct {
+	client *http.Client
+	url    string
+	fields []string
+}
+
+var _ MetricSource = (*httpJSONSource)(nil)
+
+// Read implements MetricSource.
+func (s *httpJSONSource) Read(ctx context.Context) (float64, error) {
+	var doc interface{}
+	if err := getJSON(ctx, s.client, s.url, &doc); err != nil {
+		return 0, err
+	}
+	for _, f := range s.fields {
+		obj, ok := doc.(map[string]interface{})
+		if !ok {
+			return 0, fmt.Errorf("no field %q in the document from %s", f, s.url)
+		}
+		if doc, ok = obj[f]; !ok {
+			return 0, fmt.Errorf("no field %q in the document from %s", f, s.url)
+		}
+	}
+	switch v := doc.(type) {
+	case float64:
+		return v, nil
+	case string:
+		return strconv.ParseFloat(v, 64)
+	default:
+		return 0, fmt.Errorf("the value at %q in the document from %s is not a number", strings.Join(s.fields, "."), s.url)
+	}
+}
+
+// prometheusSource evaluates a PromQL query on a Prometheus server.
+type prometheusSource struct {
+	client *http.Client
+	url    string
+}
+
+var _ MetricSource = (*prometheusSource)(nil)
+
+// prometheusResponse is the subset of the response of the Prometheus query
+// API the source needs.
+type prometheusResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+// prometheusSample is a sample of a vector, whose value is a pair of the
+// timestamp and the value as a string.
+type prometheusSample struct {
+	Value [2]interface{} `json:"value"`
+}
+
+// Read implements MetricSource. The result is the sum of the samples of a
+// vector, which is 0 if it is empty, or the value of a scalar.
+func (s *prometheusSource) Read(ctx context.Context) (float64, error) {
+	var resp prometheusResponse
+	if err := getJSON(ctx, s.client, s.url, &resp); err != nil {
+		return 0, err
+	}
+	if resp.Status != "success" {
+		return 0, fmt.Errorf("prometheus query failed: %s", resp.Error)
+	}
+
+	switch resp.Data.ResultType {
+	case "scalar":
+		var value [2]interface{}
+		if err := json.Unmarshal(resp.Data.Result, &value); err != nil {
+			return 0, fmt.Errorf("failed to decode the prometheus scalar: %w", err)
+		}
+		return prometheusValue(value)
+	case "vector":
+		var samples []prometheusSample
+		if err := json.Unmarshal(resp.Data.Result, &samples); err != nil {
+			return 0, fmt.Errorf("failed to decode the prometheus vector: %w", err)
+		}
+		var sum float64
+		for _, sample := range samples {
+			v, err := prometheusValue(sample.Value)
+			if err != nil {
+				return 0, err
+			}
+			sum += v
+		}
+		return sum, nil
+	default:
+		return 0, fmt.Errorf("unsupported prometheus result type %q", resp.Data.ResultType)
+	}
+}
+
+// prometheusValue parses the value of a timestamp and value pair.
+func prometheusValue(pair [2]interface{}) (float64, error) {
+	s, ok := pair[1].(string)
+	if !ok {
+		return 0, fmt.Errorf("invalid prometheus value %v", pair[1])
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+// getJSON decodes the JSON document served at the given URL into v.
+func getJSON(ctx context.Context, c *http.Client, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s returned status %d", url, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode the response from %s: %w", url, err)
+	}
+	return nil
+}
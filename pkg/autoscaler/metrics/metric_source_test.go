@@ -0,0 +1,155 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"knative.dev/serving/pkg/apis/autoscaling"
+	autoscalingv1alpha1 "knative.dev/serving/pkg/apis/autoscaling/v1alpha1"
+)
+
+func TestNewMetricSourceNone(t *testing.T) {
+	source, err := NewMetricSource(&defaultMetric)
+	if err != nil || source != nil {
+		t.Errorf("NewMetricSource() = %v, %v, want: nil, nil", source, err)
+	}
+
+	metric := defaultMetric.DeepCopy()
+	metric.Spec.Source = &autoscalingv1alpha1.ExternalMetricSource{Type: "kafka", URL: "http://kafka"}
+	if _, err := NewMetricSource(metric); err == nil {
+		t.Error("NewMetricSource() = nil, wanted an error for an unsupported type")
+	}
+}
+
+func TestMetricSourceRead(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  autoscalingv1alpha1.ExternalMetricSource
+		path    string
+		query   string
+		body    string
+		status  int
+		want    float64
+		wantErr bool
+	}{{
+		name:   "http, whole document",
+		source: autoscalingv1alpha1.ExternalMetricSource{Type: autoscaling.MetricSourceHTTP},
+		body:   `42`,
+		want:   42,
+	}, {
+		name:   "http, nested field",
+		source: autoscalingv1alpha1.ExternalMetricSource{Type: autoscaling.MetricSourceHTTP, Query: "queue.depth"},
+		body:   `{"queue": {"name": "orders", "depth": 17}}`,
+		want:   17,
+	}, {
+		name:   "http, numeric string",
+		source: autoscalingv1alpha1.ExternalMetricSource{Type: autoscaling.MetricSourceHTTP, Query: "pending"},
+		body:   `{"pending": "3.5"}`,
+		want:   3.5,
+	}, {
+		name:    "http, missing field",
+		source:  autoscalingv1alpha1.ExternalMetricSource{Type: autoscaling.MetricSourceHTTP, Query: "queue.size"},
+		body:    `{"queue": {"depth": 17}}`,
+		wantErr: true,
+	}, {
+		name:    "http, not a number",
+		source:  autoscalingv1alpha1.ExternalMetricSource{Type: autoscaling.MetricSourceHTTP, Query: "queue"},
+		body:    `{"queue": {"depth": 17}}`,
+		wantErr: true,
+	}, {
+		name:    "http, error status",
+		source:  autoscalingv1alpha1.ExternalMetricSource{Type: autoscaling.MetricSourceHTTP},
+		status:  http.StatusServiceUnavailable,
+		wantErr: true,
+	}, {
+		name:   "prometheus, vector",
+		source: autoscalingv1alpha1.ExternalMetricSource{Type: autoscaling.MetricSourcePrometheus, Query: "queue_length"},
+		path:   prometheusQueryPath,
+		query:  "queue_length",
+		body: `{"status": "success", "data": {"resultType": "vector", "result": [
+			{"metric": {"queue": "a"}, "value": [1683000000.1, "10"]},
+			{"metric": {"queue": "b"}, "value": [1683000000.1, "5"]}]}}`,
+		want: 15,
+	}, {
+		name:   "prometheus, empty vector",
+		source: autoscalingv1alpha1.ExternalMetricSource{Type: autoscaling.MetricSourcePrometheus, Query: "queue_length"},
+		path:   prometheusQueryPath,
+		query:  "queue_length",
+		body:   `{"status": "success", "data": {"resultType": "vector", "result": []}}`,
+		want:   0,
+	}, {
+		name:   "prometheus, scalar",
+		source: autoscalingv1alpha1.ExternalMetricSource{Type: autoscaling.MetricSourcePrometheus, Query: "scalar(sum(queue_length))"},
+		path:   prometheusQueryPath,
+		query:  "scalar(sum(queue_length))",
+		body:   `{"status": "success", "data": {"resultType": "scalar", "result": [1683000000.1, "7"]}}`,
+		want:   7,
+	}, {
+		name:    "prometheus, error",
+		source:  autoscalingv1alpha1.ExternalMetricSource{Type: autoscaling.MetricSourcePrometheus, Query: "queue_length{"},
+		path:    prometheusQueryPath,
+		query:   "queue_length{",
+		body:    `{"status": "error", "errorType": "bad_data", "error": "parse error"}`,
+		wantErr: true,
+	}, {
+		name:    "prometheus, matrix",
+		source:  autoscalingv1alpha1.ExternalMetricSource{Type: autoscaling.MetricSourcePrometheus, Query: "queue_length[1m]"},
+		path:    prometheusQueryPath,
+		query:   "queue_length[1m]",
+		body:    `{"status": "success", "data": {"resultType": "matrix", "result": []}}`,
+		wantErr: true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if test.path != "" && r.URL.Path != test.path {
+					t.Errorf("Path = %q, want: %q", r.URL.Path, test.path)
+				}
+				if got := r.URL.Query().Get("query"); got != test.query {
+					t.Errorf("Query = %q, want: %q", got, test.query)
+				}
+				if test.status != 0 {
+					w.WriteHeader(test.status)
+					return
+				}
+				w.Write([]byte(test.body))
+			}))
+			defer server.Close()
+
+			metric := defaultMetric.DeepCopy()
+			metric.Spec.Source = test.source.DeepCopy()
+			metric.Spec.Source.URL = server.URL
+			source, err := NewMetricSource(metric)
+			if err != nil {
+				t.Fatal("NewMetricSource() =", err)
+			}
+
+			got, err := source.Read(context.Background())
+			if (err != nil) != test.wantErr {
+				t.Fatalf("Read() = %v, wantErr: %v", err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("Read() = %v, want: %v", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	// usageHistoryLength is the period of usage the recommendations are
+	// computed from.
+	usageHistoryLength = 24 * time.Hour
+
+	// minUsageMinutes is the number of minutes of usage needed before
+	// recommending the requests.
+	minUsageMinutes = 30
+
+	// cpuRecommendationPercentile is the percentile of the per-minute CPU
+	// usage the recommended CPU requests cover. The recommended memory
+	// requests cover the peak usage, as running out of memory kills the pod.
+	cpuRecommendationPercentile = 0.9
+
+	// recommendationMargin is the headroom added on top of the usage.
+	recommendationMargin = 1.15
+
+	// recommendationTolerance is the relative change of a recommendation
+	// below which it isn't updated, so that the statuses it is published in
+	// don't churn.
+	recommendationTolerance = 0.1
+)
+
+// usageMinute is the usage of the pods of a revision within a minute.
+type usageMinute struct {
+	minute int64
+
+	// cpuSum and cpuCount make up the average CPU usage per pod in
+	// millicores, and memoryMax is the peak memory usage of a pod in bytes.
+	cpuSum    float64
+	cpuCount  float64
+	memoryMax float64
+}
+
+// usageHistory keeps the per-minute resource usage of the pods of a revision
+// and recommends their resource requests from it.
+type usageHistory struct {
+	mux sync.Mutex
+
+	// minutes holds the minutes with usage, oldest first.
+	minutes        []usageMinute
+	recommendation corev1.ResourceList
+}
+
+// record records the CPU usage in millicores and the memory usage in bytes of
+// a pod, and returns true if the recommendation changed. The recommendation
+// is updated whenever a minute is complete.
+func (h *usageHistory) record(now time.Time, cpu, memory float64) bool {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	minute := now.Unix() / 60
+	if n := len(h.minutes); n > 0 && h.minutes[n-1].minute >= minute {
+		// Late stats are counted in the current minute.
+		h.minutes[n-1].add(cpu, memory)
+		return false
+	}
+
+	changed := h.recommend()
+	oldest := minute - int64(usageHistoryLength/time.Minute)
+	i := 0
+	for i < len(h.minutes) && h.minutes[i].minute <= oldest {
+		i++
+	}
+	h.minutes = append(h.minutes[i:], usageMinute{minute: minute})
+	h.minutes[len(h.minutes)-1].add(cpu, memory)
+	return changed
+}
+
+func (m *usageMinute) add(cpu, memory float64) {
+	m.cpuSum += cpu
+	m.cpuCount++
+	if memory > m.memoryMax {
+		m.memoryMax = memory
+	}
+}
+
+// recommend computes the recommendation from the complete minutes and returns
+// true if it changed beyond the tolerance.
+func (h *usageHistory) recommend() bool {
+	if len(h.minutes) < minUsageMinutes {
+		return false
+	}
+
+	cpus := make([]float64, 0, len(h.minutes))
+	var memory float64
+	for _, m := range h.minutes {
+		cpus = append(cpus, m.cpuSum/m.cpuCount)
+		if m.memoryMax > memory {
+			memory = m.memoryMax
+		}
+	}
+	sort.Float64s(cpus)
+	cpu := cpus[int(math.Ceil(cpuRecommendationPercentile*float64(len(cpus))))-1]
+
+	// The requests are rounded up to whole millicores and mebibytes.
+	const mebibyte = 1 << 20
+	cpuMillis := int64(math.Max(math.Ceil(cpu*recommendationMargin), 1))
+	memoryBytes := int64(math.Max(math.Ceil(memory*recommendationMargin/mebibyte), 1)) * mebibyte
+	if h.recommendation != nil &&
+		withinTolerance(h.recommendation.Cpu().MilliValue(), cpuMillis) &&
+		withinTolerance(h.recommendation.Memory().Value(), memoryBytes) {
+		return false
+	}
+	h.recommendation = corev1.ResourceList{
+		corev1.ResourceCPU:    *resource.NewMilliQuantity(cpuMillis, resource.DecimalSI),
+		corev1.ResourceMemory: *resource.NewQuantity(memoryBytes, resource.BinarySI),
+	}
+	return true
+}
+
+// current returns the current recommendation, or nil if there is none yet.
+func (h *usageHistory) current() corev1.ResourceList {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+	return h.recommendation.DeepCopy()
+}
+
+func withinTolerance(old, new int64) bool {
+	return math.Abs(float64(new-old)) <= recommendationTolerance*float64(old)
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const mebibyte = 1 << 20
+
+func TestUsageHistory(t *testing.T) {
+	start := time.Unix(6000, 0)
+	var h usageHistory
+
+	// Record the usage of two pods per minute, for the minimum number of
+	// minutes.
+	for i := 0; i < minUsageMinutes; i++ {
+		now := start.Add(time.Duration(i) * time.Minute)
+		cpu := float64(10 * (i + 1))
+		if h.record(now, cpu-5, float64(i+1)*mebibyte) {
+			t.Fatalf("record() at minute %d = true, want: false", i)
+		}
+		if h.record(now.Add(time.Second), cpu+5, 0) {
+			t.Fatalf("record() at minute %d = true, want: false", i)
+		}
+		if got := h.current(); got != nil {
+			t.Fatalf("current() at minute %d = %v, want: nil", i, got)
+		}
+	}
+
+	// The first stat of the next minute completes the history. The 90th
+	// percentile of the per-minute CPU averages is 270m, and the peak memory is
+	// 30Mi, both with a 15% margin.
+	now := start.Add(minUsageMinutes * time.Minute)
+	if !h.record(now, 310, 0) {
+		t.Fatal("record() = false, want: true")
+	}
+	want := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("311m"),
+		corev1.ResourceMemory: resource.MustParse("35Mi"),
+	}
+	if got := h.current(); !cmp.Equal(got, want) {
+		t.Error("current() (-want, +got):", cmp.Diff(want, got))
+	}
+
+	// Small changes don't update the recommendation.
+	if h.record(now.Add(time.Minute), 310, 0) {
+		t.Error("record() = true, want: false")
+	}
+	if got := h.current(); !cmp.Equal(got, want) {
+		t.Error("current() (-want, +got):", cmp.Diff(want, got))
+	}
+
+	// Large ones do, once the minute they happen in is complete.
+	if h.record(now.Add(2*time.Minute), 10, 100*mebibyte) {
+		t.Error("record() = true, want: false")
+	}
+	if !h.record(now.Add(3*time.Minute), 10, 0) {
+		t.Error("record() = false, want: true")
+	}
+	if got := h.current()[corev1.ResourceMemory]; got.Value() != 115*mebibyte {
+		t.Errorf("Recommended memory = %d, want: %d", got.Value(), 115*mebibyte)
+	}
+
+	// Usage older than the history length is dropped.
+	h.record(now.Add(usageHistoryLength+10*time.Minute), 10, 0)
+	h.mux.Lock()
+	n := len(h.minutes)
+	h.mux.Unlock()
+	if n != 1 {
+		t.Errorf("len(minutes) = %d, want: 1", n)
+	}
+}
+
+func TestUsageHistoryCurrentIsACopy(t *testing.T) {
+	h := usageHistory{recommendation: corev1.ResourceList{
+		corev1.ResourceCPU: resource.MustParse("1"),
+	}}
+	got := h.current()
+	got[corev1.ResourceCPU] = resource.MustParse("2")
+	if cpu := h.current()[corev1.ResourceCPU]; cpu.MilliValue() != 1000 {
+		t.Errorf("Recommended CPU = %dm, want: 1000m", cpu.MilliValue())
+	}
+}
@@ -57,6 +57,19 @@ type Stat struct {
 	// Time/date that the stat was generated in seconds since
 	// 1970-01-01 00:00:00.000 UTC.
 	Timestamp int64 `protobuf:"varint,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// CPU in use, as a percentage of the CPU requests of the containers of the
+	// pod other than the queue-proxy, averaged over the reporting period.
+	CpuUtilization float64 `protobuf:"fixed64,8,opt,name=cpu_utilization,json=cpuUtilization,proto3" json:"cpu_utilization,omitempty"`
+	// Memory in use, as a percentage of the memory requests of the containers
+	// of the pod other than the queue-proxy.
+	MemoryUtilization float64 `protobuf:"fixed64,9,opt,name=memory_utilization,json=memoryUtilization,proto3" json:"memory_utilization,omitempty"`
+	// CPU in use by those containers in millicores, averaged over the
+	// reporting period. The stats scraped from several pods hold the average
+	// of the pods.
+	CpuUsage float64 `protobuf:"fixed64,10,opt,name=cpu_usage,json=cpuUsage,proto3" json:"cpu_usage,omitempty"`
+	// Memory in use by those containers in bytes. The stats scraped from
+	// several pods hold the peak of the pods.
+	MemoryUsage float64 `protobuf:"fixed64,11,opt,name=memory_usage,json=memoryUsage,proto3" json:"memory_usage,omitempty"`
 }
 
 func (m *Stat) Reset()         { *m = Stat{} }
@@ -141,6 +154,34 @@ func (m *Stat) GetTimestamp() int64 {
 	return 0
 }
 
+func (m *Stat) GetCpuUtilization() float64 {
+	if m != nil {
+		return m.CpuUtilization
+	}
+	return 0
+}
+
+func (m *Stat) GetMemoryUtilization() float64 {
+	if m != nil {
+		return m.MemoryUtilization
+	}
+	return 0
+}
+
+func (m *Stat) GetCpuUsage() float64 {
+	if m != nil {
+		return m.CpuUsage
+	}
+	return 0
+}
+
+func (m *Stat) GetMemoryUsage() float64 {
+	if m != nil {
+		return m.MemoryUsage
+	}
+	return 0
+}
+
 // WireStatMessage is a copy of the StatMessage Golang type, exploding the fields of
 // `types.NamespacedName` to make it compatible with protobufs.
 type WireStatMessage struct {
@@ -261,30 +302,34 @@ func init() {
 func init() { proto.RegisterFile("pkg/autoscaler/metrics/stat.proto", fileDescriptor_cf216df9f6fff44c) }
 
 var fileDescriptor_cf216df9f6fff44c = []byte{
-	// 359 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x92, 0x4f, 0x4b, 0xc3, 0x30,
-	0x18, 0xc6, 0x97, 0xad, 0xee, 0x4f, 0xe6, 0x54, 0x22, 0x42, 0x86, 0x52, 0xba, 0x0d, 0xa1, 0xa7,
-	0x0d, 0xa6, 0x67, 0x0f, 0xee, 0xe2, 0x65, 0x22, 0x15, 0xf1, 0x58, 0x62, 0xf6, 0x3a, 0x8a, 0xb6,
-	0x89, 0x49, 0x2a, 0x7e, 0x0c, 0x3f, 0x96, 0x27, 0xd9, 0xd1, 0xa3, 0x6c, 0x5f, 0x44, 0x1a, 0xb3,
-	0x4d, 0xc7, 0x4e, 0x0d, 0xcf, 0xfb, 0x7b, 0x9e, 0x17, 0x9e, 0xb7, 0xb8, 0x23, 0x9f, 0xa6, 0x03,
-	0x96, 0x1b, 0xa1, 0x39, 0x7b, 0x06, 0x35, 0x48, 0xc1, 0xa8, 0x84, 0xeb, 0x81, 0x36, 0xcc, 0xf4,
-	0xa5, 0x12, 0x46, 0x90, 0x9a, 0xd3, 0xba, 0x9f, 0x65, 0xec, 0xdd, 0x1a, 0x66, 0x48, 0x1b, 0xd7,
-	0xa5, 0x98, 0xc4, 0x19, 0x4b, 0x81, 0xa2, 0x00, 0x85, 0x8d, 0xa8, 0x26, 0xc5, 0xe4, 0x9a, 0xa5,
-	0x40, 0x2e, 0xf0, 0x31, 0x7b, 0x05, 0xc5, 0xa6, 0x10, 0x73, 0x91, 0xf1, 0x5c, 0x29, 0xc8, 0x4c,
-	0xac, 0xe0, 0x25, 0x07, 0x6d, 0x34, 0x2d, 0x07, 0x28, 0x44, 0x51, 0xdb, 0x21, 0xa3, 0x15, 0x11,
-	0x39, 0x80, 0x8c, 0x71, 0x6f, 0xe9, 0x97, 0x4a, 0xbc, 0x25, 0x30, 0xd9, 0x9a, 0x53, 0xb1, 0x39,
-	0x81, 0x43, 0x6f, 0x7e, 0xc9, 0x2d, 0x71, 0x3d, 0xdc, 0x72, 0x9e, 0x98, 0x8b, 0x3c, 0x33, 0xd4,
-	0xb3, 0xc6, 0x5d, 0x27, 0x8e, 0x0a, 0x8d, 0x0c, 0xf1, 0xd1, 0x72, 0xd7, 0x7f, 0x78, 0xc7, 0xc2,
-	0x87, 0x6e, 0x18, 0xfd, 0xf5, 0x9c, 0xe2, 0x3d, 0xa9, 0x04, 0x07, 0xad, 0xe3, 0x5c, 0x9a, 0x24,
-	0x05, 0x5a, 0xb5, 0x70, 0xcb, 0xa9, 0x77, 0x56, 0x24, 0x27, 0xb8, 0x51, 0x7c, 0xb5, 0x61, 0xa9,
-	0xa4, 0xb5, 0x00, 0x85, 0x95, 0x68, 0x2d, 0x74, 0x1f, 0xf1, 0xfe, 0x7d, 0xa2, 0xa0, 0xe8, 0x74,
-	0x0c, 0x5a, 0xb3, 0xa9, 0x35, 0x14, 0xb5, 0x6a, 0xc9, 0xf8, 0xb2, 0xdb, 0xb5, 0x40, 0x08, 0xf6,
-	0x6c, 0xe9, 0x65, 0x3b, 0xb0, 0x6f, 0xd2, 0xc1, 0x5e, 0x71, 0x2c, 0x5b, 0x49, 0x73, 0xd8, 0xea,
-	0xbb, 0x6b, 0xf5, 0x8b, 0xd4, 0xc8, 0x8e, 0xba, 0x57, 0xf8, 0x60, 0x63, 0x8f, 0x26, 0xe7, 0xb8,
-	0x9e, 0xba, 0x37, 0x45, 0x41, 0x25, 0x6c, 0x0e, 0xe9, 0xca, 0xba, 0x01, 0x47, 0x2b, 0xf2, 0x92,
-	0x7e, 0xcc, 0x7d, 0x34, 0x9b, 0xfb, 0xe8, 0x7b, 0xee, 0xa3, 0xf7, 0x85, 0x5f, 0x9a, 0x2d, 0xfc,
-	0xd2, 0xd7, 0xc2, 0x2f, 0x3d, 0x54, 0xed, 0xcf, 0x72, 0xf6, 0x13, 0x00, 0x00, 0xff, 0xff, 0x6d,
-	0xc3, 0xa5, 0x6a, 0x51, 0x02, 0x00, 0x00,
+	// 428 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x92, 0x41, 0x6f, 0x13, 0x31,
+	0x10, 0x85, 0x63, 0x12, 0x9a, 0xec, 0xa4, 0x69, 0xc1, 0x08, 0xc9, 0x55, 0xab, 0xd5, 0x26, 0x15,
+	0x22, 0x17, 0x12, 0x29, 0x70, 0xe6, 0x40, 0x2f, 0x5c, 0x8a, 0xd0, 0xa2, 0x8a, 0xe3, 0xca, 0x38,
+	0x43, 0xb4, 0xa2, 0xbb, 0x36, 0xb6, 0x17, 0x01, 0xbf, 0x82, 0x9f, 0xc5, 0xb1, 0x47, 0x8e, 0x28,
+	0x91, 0xf8, 0x1d, 0xc8, 0x53, 0x6f, 0xd2, 0x56, 0x3d, 0xd9, 0x7e, 0xf3, 0xbd, 0xe7, 0xc3, 0x1b,
+	0x18, 0x9b, 0x2f, 0xab, 0xb9, 0x6c, 0xbc, 0x76, 0x4a, 0x5e, 0xa2, 0x9d, 0x57, 0xe8, 0x6d, 0xa9,
+	0xdc, 0xdc, 0x79, 0xe9, 0x67, 0xc6, 0x6a, 0xaf, 0x79, 0x3f, 0x6a, 0x93, 0x7f, 0x5d, 0xe8, 0x7d,
+	0xf0, 0xd2, 0xf3, 0x23, 0x18, 0x18, 0xbd, 0x2c, 0x6a, 0x59, 0xa1, 0x60, 0x19, 0x9b, 0x26, 0x79,
+	0xdf, 0xe8, 0xe5, 0x3b, 0x59, 0x21, 0x7f, 0x0d, 0xc7, 0xf2, 0x1b, 0x5a, 0xb9, 0xc2, 0x42, 0xe9,
+	0x5a, 0x35, 0xd6, 0x62, 0xed, 0x0b, 0x8b, 0x5f, 0x1b, 0x74, 0xde, 0x89, 0x07, 0x19, 0x9b, 0xb2,
+	0xfc, 0x28, 0x22, 0x67, 0x5b, 0x22, 0x8f, 0x00, 0x3f, 0x87, 0xd3, 0xd6, 0x6f, 0xac, 0xfe, 0x5e,
+	0xe2, 0xf2, 0xde, 0x9c, 0x2e, 0xe5, 0x64, 0x11, 0x7d, 0x7f, 0x4d, 0xde, 0x13, 0x77, 0x0a, 0xa3,
+	0xe8, 0x29, 0x94, 0x6e, 0x6a, 0x2f, 0x7a, 0x64, 0xdc, 0x8f, 0xe2, 0x59, 0xd0, 0xf8, 0x02, 0x9e,
+	0xb6, 0x7f, 0xdd, 0x86, 0x1f, 0x12, 0xfc, 0x24, 0x0e, 0xf3, 0x9b, 0x9e, 0x67, 0x70, 0x60, 0xac,
+	0x56, 0xe8, 0x5c, 0xd1, 0x18, 0x5f, 0x56, 0x28, 0xf6, 0x08, 0x1e, 0x45, 0xf5, 0x82, 0x44, 0x7e,
+	0x02, 0x49, 0x38, 0x9d, 0x97, 0x95, 0x11, 0xfd, 0x8c, 0x4d, 0xbb, 0xf9, 0x4e, 0xe0, 0xcf, 0xe1,
+	0x50, 0x99, 0xa6, 0x68, 0x7c, 0x79, 0x59, 0xfe, 0x94, 0xbe, 0xd4, 0xb5, 0x18, 0x50, 0xca, 0x81,
+	0x32, 0xcd, 0xc5, 0x4e, 0xe5, 0x2f, 0x80, 0x57, 0x58, 0x69, 0xfb, 0xe3, 0x16, 0x9b, 0x10, 0xfb,
+	0xf8, 0x7a, 0x72, 0x13, 0x3f, 0x86, 0x84, 0x72, 0x9d, 0x5c, 0xa1, 0x00, 0xa2, 0x06, 0x21, 0x31,
+	0xbc, 0xf9, 0x18, 0xf6, 0xdb, 0x2c, 0x9a, 0x0f, 0x69, 0x3e, 0x8c, 0x29, 0x41, 0x9a, 0x7c, 0x86,
+	0xc3, 0x8f, 0xa5, 0xc5, 0xd0, 0xf5, 0x39, 0x3a, 0x72, 0x9d, 0x40, 0x12, 0xea, 0x76, 0x46, 0xaa,
+	0xb6, 0xf3, 0x9d, 0xc0, 0x39, 0xf4, 0xc2, 0x83, 0xea, 0x4d, 0x72, 0xba, 0xf3, 0x31, 0xf4, 0xc2,
+	0x12, 0x51, 0x55, 0xc3, 0xc5, 0x68, 0x16, 0xb7, 0x68, 0x16, 0x52, 0x73, 0x1a, 0x4d, 0xde, 0xc2,
+	0xa3, 0x3b, 0xff, 0x38, 0xfe, 0x0a, 0x06, 0x55, 0xbc, 0x0b, 0x96, 0x75, 0xa7, 0xc3, 0x85, 0xd8,
+	0x5a, 0xef, 0xc0, 0xf9, 0x96, 0x7c, 0x23, 0x7e, 0xaf, 0x53, 0x76, 0xb5, 0x4e, 0xd9, 0xdf, 0x75,
+	0xca, 0x7e, 0x6d, 0xd2, 0xce, 0xd5, 0x26, 0xed, 0xfc, 0xd9, 0xa4, 0x9d, 0x4f, 0x7b, 0xb4, 0xc4,
+	0x2f, 0xff, 0x0f, 0x00, 0xbe, 0x95, 0x4d, 0xf4, 0xe9, 0x02, 0x00, 0x00,
 }
 
 func (m *Stat) Marshal() (dAtA []byte, err error) {
@@ -307,6 +352,30 @@ func (m *Stat) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.MemoryUsage != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.MemoryUsage))))
+		i--
+		dAtA[i] = 0x59
+	}
+	if m.CpuUsage != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.CpuUsage))))
+		i--
+		dAtA[i] = 0x51
+	}
+	if m.MemoryUtilization != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.MemoryUtilization))))
+		i--
+		dAtA[i] = 0x49
+	}
+	if m.CpuUtilization != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.CpuUtilization))))
+		i--
+		dAtA[i] = 0x41
+	}
 	if m.Timestamp != 0 {
 		i = encodeVarintStat(dAtA, i, uint64(m.Timestamp))
 		i--
@@ -477,6 +546,18 @@ func (m *Stat) Size() (n int) {
 	if m.Timestamp != 0 {
 		n += 1 + sovStat(uint64(m.Timestamp))
 	}
+	if m.CpuUtilization != 0 {
+		n += 9
+	}
+	if m.MemoryUtilization != 0 {
+		n += 9
+	}
+	if m.CpuUsage != 0 {
+		n += 9
+	}
+	if m.MemoryUsage != 0 {
+		n += 9
+	}
 	return n
 }
 
@@ -657,6 +738,50 @@ func (m *Stat) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 8:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CpuUtilization", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.CpuUtilization = float64(math.Float64frombits(v))
+		case 9:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemoryUtilization", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.MemoryUtilization = float64(math.Float64frombits(v))
+		case 10:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CpuUsage", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.CpuUsage = float64(math.Float64frombits(v))
+		case 11:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemoryUsage", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.MemoryUsage = float64(math.Float64frombits(v))
 		default:
 			iNdEx = preIndex
 			skippy, err := skipStat(dAtA[iNdEx:])
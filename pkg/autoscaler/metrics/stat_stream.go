@@ -0,0 +1,86 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// maxWireStatMessagesSize bounds the size of a single message in a stream, to
+// not allocate arbitrary amounts of memory when reading a corrupt stream.
+const maxWireStatMessagesSize = 16 << 20
+
+// WriteWireStatMessages writes wsm to w, prefixed with its size as a uvarint.
+// A sequence of such writes forms a stream that can be read back by a
+// WireStatMessagesReader.
+func WriteWireStatMessages(w io.Writer, wsm *WireStatMessages) error {
+	b, err := wsm.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to marshal stat messages: %w", err)
+	}
+	var size [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(size[:], uint64(len(b)))
+	if _, err := w.Write(size[:n]); err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// WireStatMessagesReader reads a stream of length-delimited WireStatMessages,
+// as written by WriteWireStatMessages.
+type WireStatMessagesReader struct {
+	r   *bufio.Reader
+	buf []byte
+}
+
+// NewWireStatMessagesReader returns a reader for the stream in r.
+func NewWireStatMessagesReader(r io.Reader) *WireStatMessagesReader {
+	return &WireStatMessagesReader{r: bufio.NewReader(r)}
+}
+
+// Read returns the next WireStatMessages of the stream, or io.EOF if the stream
+// ended.
+func (r *WireStatMessagesReader) Read() (*WireStatMessages, error) {
+	size, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		// EOF here means the stream ended cleanly between two messages.
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("failed to read message size: %w", err)
+	}
+	if size > maxWireStatMessagesSize {
+		return nil, fmt.Errorf("message size %d exceeds the maximum of %d", size, maxWireStatMessagesSize)
+	}
+	if uint64(cap(r.buf)) < size {
+		r.buf = make([]byte, size)
+	}
+	r.buf = r.buf[:size]
+	if _, err := io.ReadFull(r.r, r.buf); err != nil {
+		return nil, fmt.Errorf("failed to read message: %w", err)
+	}
+	wsm := &WireStatMessages{}
+	if err := wsm.Unmarshal(r.buf); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal stat messages: %w", err)
+	}
+	return wsm, nil
+}
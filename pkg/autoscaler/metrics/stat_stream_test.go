@@ -0,0 +1,101 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestWireStatMessagesStream(t *testing.T) {
+	key := types.NamespacedName{Namespace: "test-namespace", Name: "test-name"}
+	want := []WireStatMessages{
+		ToWireStatMessages([]StatMessage{{
+			Key:  key,
+			Stat: Stat{PodName: "pod-1", AverageConcurrentRequests: 1.5, Timestamp: 100},
+		}}),
+		ToWireStatMessages([]StatMessage{{
+			Key:  key,
+			Stat: Stat{PodName: "pod-1", RequestCount: 10, Timestamp: 101},
+		}, {
+			Key:  key,
+			Stat: Stat{PodName: "pod-2", RequestCount: 20, Timestamp: 101},
+		}}),
+		ToWireStatMessages(nil),
+	}
+
+	var buf bytes.Buffer
+	for i := range want {
+		if err := WriteWireStatMessages(&buf, &want[i]); err != nil {
+			t.Fatal("WriteWireStatMessages() =", err)
+		}
+	}
+
+	r := NewWireStatMessagesReader(&buf)
+	for i := range want {
+		got, err := r.Read()
+		if err != nil {
+			t.Fatalf("Read() #%d = %v", i, err)
+		}
+		if len(want[i].Messages) == 0 {
+			// Unmarshalling does not distinguish nil and empty slices.
+			want[i].Messages = nil
+		}
+		if !cmp.Equal(got, &want[i]) {
+			t.Errorf("Read() #%d (-want, +got): %s", i, cmp.Diff(&want[i], got))
+		}
+	}
+	if _, err := r.Read(); !errors.Is(err, io.EOF) {
+		t.Errorf("Read() at end = %v, want: %v", err, io.EOF)
+	}
+}
+
+func TestWireStatMessagesReaderErrors(t *testing.T) {
+	var full bytes.Buffer
+	msg := ToWireStatMessages([]StatMessage{{Stat: Stat{PodName: "pod"}}})
+	if err := WriteWireStatMessages(&full, &msg); err != nil {
+		t.Fatal("WriteWireStatMessages() =", err)
+	}
+
+	tests := []struct {
+		name  string
+		input []byte
+	}{{
+		name:  "truncated message",
+		input: full.Bytes()[:full.Len()-1],
+	}, {
+		name:  "oversized message",
+		input: []byte{0xff, 0xff, 0xff, 0xff, 0x0f},
+	}, {
+		name:  "garbage",
+		input: []byte{0x02, 0xff, 0xff},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewWireStatMessagesReader(bytes.NewReader(test.input)).Read()
+			if err == nil || errors.Is(err, io.EOF) {
+				t.Errorf("Read() = %v, want a non EOF error", err)
+			}
+		})
+	}
+}
@@ -94,6 +94,16 @@ type StatsScraper interface {
 	// Scrape scrapes the Revision queue metric endpoint. The duration is used
 	// to cutoff young pods, whose stats might skew lower.
 	Scrape(time.Duration) (Stat, error)
+
+	// ReadyPods returns the number of ready pods of the Revision.
+	ReadyPods() (int, error)
+}
+
+// MetricSource defines the interface for reading a metric published outside
+// of the Revision, such as the length of a queue in a broker.
+type MetricSource interface {
+	// Read returns the current value of the metric.
+	Read(context.Context) (float64, error)
 }
 
 // scrapeClient defines the interface for collecting Revision metrics for a given
@@ -241,6 +251,11 @@ func (s *serviceScraper) Scrape(window time.Duration) (stat Stat, err error) {
 	}
 }
 
+// ReadyPods implements StatsScraper.
+func (s *serviceScraper) ReadyPods() (int, error) {
+	return s.podAccessor.ReadyCount()
+}
+
 func (s *serviceScraper) scrapePods(window time.Duration) (Stat, error) {
 	pods, youngPods, err := s.podAccessor.PodIPsSplitByAge(window, time.Now())
 	if err != nil {
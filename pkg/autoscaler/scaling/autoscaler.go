@@ -49,6 +49,10 @@ type autoscaler struct {
 	podCounter   podCounter
 	reporterCtx  context.Context
 
+	// stateMux guards the panic state and the delay window, which are
+	// updated by Scale and read by Checkpoint.
+	stateMux sync.Mutex
+
 	// State in panic mode.
 	panicTime    time.Time
 	maxPanicPods int32
@@ -57,9 +61,15 @@ type autoscaler struct {
 	// window has passed at the reduced concurrency.
 	delayWindow *max.TimeWindow
 
+	// behavior enforces the scale up and scale down rules, if any.
+	behavior behavior
+
 	// specMux guards the current DeciderSpec.
 	specMux     sync.RWMutex
 	deciderSpec *DeciderSpec
+
+	// history keeps the explanations of the recent decisions.
+	history explanationHistory
 }
 
 // New creates a new instance of default autoscaler implementation.
@@ -135,9 +145,12 @@ func (a *autoscaler) Update(deciderSpec *DeciderSpec) {
 // Scale calculates the desired scale based on current statistics given the current time.
 // desiredPodCount is the calculated pod count the autoscaler would like to set.
 // validScale signifies whether the desiredPodCount should be applied or not.
-// Scale is not thread safe in regards to panic state, but it's thread safe in
-// regards to acquiring the decider spec.
+// Scale is thread safe in regards to the panic state and to acquiring the
+// decider spec.
 func (a *autoscaler) Scale(logger *zap.SugaredLogger, now time.Time) ScaleResult {
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+
 	desugared := logger.Desugar()
 	debugEnabled := desugared.Core().Enabled(zapcore.DebugLevel)
 
@@ -146,6 +159,7 @@ func (a *autoscaler) Scale(logger *zap.SugaredLogger, now time.Time) ScaleResult
 	// If the error is NotFound, then presume 0.
 	if err != nil && !apierrors.IsNotFound(err) {
 		logger.Errorw("Failed to get ready pod count via K8S Lister", zap.Error(err))
+		a.history.record(ScaleExplanation{Time: now, Reason: "failed to get ready pod count: " + err.Error()})
 		return invalidSR
 	}
 	// Use 1 if there are zero current pods.
@@ -155,9 +169,25 @@ func (a *autoscaler) Scale(logger *zap.SugaredLogger, now time.Time) ScaleResult
 
 	metricName := spec.ScalingMetric
 	var observedStableValue, observedPanicValue float64
+	// When scaling on the utilization of a resource, the concurrency tells
+	// whether the revision receives requests at all.
+	var scalesOnUtilization bool
+	var stableConcurrency float64
+	// Only the concurrency and the RPS are measured in requests, like the
+	// burst capacity.
+	inRequests := true
 	switch spec.ScalingMetric {
 	case autoscaling.RPS:
 		observedStableValue, observedPanicValue, err = a.metricClient.StableAndPanicRPS(metricKey, now)
+	case autoscaling.CPU, autoscaling.Memory:
+		scalesOnUtilization, inRequests = true, false
+		observedStableValue, observedPanicValue, err = a.metricClient.StableAndPanicUtilization(metricKey, now)
+		if err == nil {
+			stableConcurrency, _, err = a.metricClient.StableAndPanicConcurrency(metricKey, now)
+		}
+	case autoscaling.External:
+		inRequests = false
+		observedStableValue, observedPanicValue, err = a.metricClient.StableAndPanicExternal(metricKey, now)
 	default:
 		metricName = autoscaling.Concurrency // concurrency is used by default
 		observedStableValue, observedPanicValue, err = a.metricClient.StableAndPanicConcurrency(metricKey, now)
@@ -166,8 +196,10 @@ func (a *autoscaler) Scale(logger *zap.SugaredLogger, now time.Time) ScaleResult
 	if err != nil {
 		if errors.Is(err, metrics.ErrNoData) {
 			logger.Debug("No data to scale on yet")
+			a.history.record(ScaleExplanation{Time: now, Reason: "no data to scale on yet"})
 		} else {
 			logger.Errorw("Failed to obtain metrics", zap.Error(err))
+			a.history.record(ScaleExplanation{Time: now, Reason: "failed to obtain metrics: " + err.Error()})
 		}
 		return invalidSR
 	}
@@ -186,6 +218,16 @@ func (a *autoscaler) Scale(logger *zap.SugaredLogger, now time.Time) ScaleResult
 
 	dspc := math.Ceil(observedStableValue / spec.TargetValue)
 	dppc := math.Ceil(observedPanicValue / spec.TargetValue)
+	if scalesOnUtilization {
+		// Idle pods still use some resources, so scale to zero once no requests
+		// arrived within the stable window. Conversely, keep at least one pod
+		// while requests arrive, as there is no utilization without pods.
+		if stableConcurrency == 0 {
+			dspc, dppc = 0, 0
+		} else {
+			dspc, dppc = math.Max(dspc, 1), math.Max(dppc, 1)
+		}
+	}
 	if debugEnabled {
 		desugared.Debug(
 			fmt.Sprintf("For metric %s observed values: stable = %0.3f; panic = %0.3f; target = %0.3f "+
@@ -197,6 +239,24 @@ func (a *autoscaler) Scale(logger *zap.SugaredLogger, now time.Time) ScaleResult
 	// We want to keep desired pod count in the  [maxScaleDown, maxScaleUp] range.
 	desiredStablePodCount := int32(math.Min(math.Max(dspc, maxScaleDown), maxScaleUp))
 	desiredPanicPodCount := int32(math.Min(math.Max(dppc, maxScaleDown), maxScaleUp))
+	// The counts are rate limited if the clamping changed them, which is
+	// checked before the ActivationScale adjustment, as that isn't a rate limit.
+	stableRateLimited := desiredStablePodCount != int32(dspc)
+	panicRateLimited := desiredPanicPodCount != int32(dppc)
+
+	exp := ScaleExplanation{
+		Time:                now,
+		Valid:               true,
+		Metric:              metricName,
+		ObservedStableValue: observedStableValue,
+		ObservedPanicValue:  observedPanicValue,
+		TargetValue:         spec.TargetValue,
+		ReadyPodCount:       originalReadyPodsCount,
+		StablePodCount:      int32(dspc),
+		PanicPodCount:       int32(dppc),
+		MaxScaleUp:          int32(maxScaleUp),
+		MaxScaleDown:        int32(maxScaleDown),
+	}
 
 	//	If ActivationScale > 1, then adjust the desired pod counts
 	if a.deciderSpec.ActivationScale > 1 {
@@ -227,7 +287,9 @@ func (a *autoscaler) Scale(logger *zap.SugaredLogger, now time.Time) ScaleResult
 	}
 
 	desiredPodCount := desiredStablePodCount
+	exp.RateLimited = stableRateLimited
 	if !a.panicTime.IsZero() {
+		exp.RateLimited = stableRateLimited || panicRateLimited
 		// In some edgecases stable window metric might be larger
 		// than panic one. And we should provision for stable as for panic,
 		// so pick the larger of the two.
@@ -243,6 +305,7 @@ func (a *autoscaler) Scale(logger *zap.SugaredLogger, now time.Time) ScaleResult
 			logger.Infof("Skipping pod count decrease from %d to %d.", a.maxPanicPods, desiredPodCount)
 		}
 		desiredPodCount = a.maxPanicPods
+		exp.PanicMode, exp.MaxPanicPods = true, a.maxPanicPods
 	} else {
 		logger.Debug("Operating in stable mode.")
 	}
@@ -254,9 +317,12 @@ func (a *autoscaler) Scale(logger *zap.SugaredLogger, now time.Time) ScaleResult
 	// interval (because the largest will be picked rather than the most recent
 	// in that case).
 	if a.delayWindow != nil {
+		exp.ScaleDownDelay = spec.ScaleDownDelay
 		a.delayWindow.Record(now, desiredPodCount)
 		delayedPodCount := a.delayWindow.Current()
 		if delayedPodCount != desiredPodCount {
+			undelayed := desiredPodCount
+			exp.UndelayedPodCount = &undelayed
 			if debugEnabled {
 				desugared.Debug(
 					fmt.Sprintf("Delaying scale to %d, staying at %d",
@@ -266,6 +332,18 @@ func (a *autoscaler) Scale(logger *zap.SugaredLogger, now time.Time) ScaleResult
 		}
 	}
 
+	if spec.ScaleUpRules != nil || spec.ScaleDownRules != nil {
+		limited := a.behavior.apply(now, spec.ScaleUpRules, spec.ScaleDownRules,
+			int32(originalReadyPodsCount), desiredPodCount)
+		if limited != desiredPodCount {
+			unlimited := desiredPodCount
+			exp.UnlimitedPodCount = &unlimited
+			desiredPodCount = limited
+		}
+	} else {
+		a.behavior = behavior{}
+	}
+
 	// Compute excess burst capacity
 	//
 	// the excess burst capacity is based on panic value, since we don't want to
@@ -273,11 +351,16 @@ func (a *autoscaler) Scale(logger *zap.SugaredLogger, now time.Time) ScaleResult
 	// Negative EBC means that the deployment does not have enough capacity to serve
 	// the desired burst off hand.
 	// EBC = TotCapacity - Cur#ReqInFlight - TargetBurstCapacity
+	//
+	// The burst capacity is in requests, which neither the utilization of a
+	// resource nor an external metric translate to, so when scaling on those
+	// the activator stays in the request path unless the target burst capacity
+	// is 0.
 	excessBCF := -1.
 	switch {
 	case spec.TargetBurstCapacity == 0:
 		excessBCF = 0
-	case spec.TargetBurstCapacity > 0:
+	case spec.TargetBurstCapacity > 0 && inRequests:
 		totCap := float64(originalReadyPodsCount) * spec.TotalValue
 		excessBCF = math.Floor(totCap - spec.TargetBurstCapacity - observedPanicValue)
 	}
@@ -289,6 +372,11 @@ func (a *autoscaler) Scale(logger *zap.SugaredLogger, now time.Time) ScaleResult
 	}
 
 	switch spec.ScalingMetric {
+	case autoscaling.CPU, autoscaling.Memory, autoscaling.External:
+		pkgmetrics.RecordBatch(a.reporterCtx,
+			excessBurstCapacityM.M(excessBCF),
+			desiredPodCountM.M(int64(desiredPodCount)),
+		)
 	case autoscaling.RPS:
 		pkgmetrics.RecordBatch(a.reporterCtx,
 			excessBurstCapacityM.M(excessBCF),
@@ -307,6 +395,10 @@ func (a *autoscaler) Scale(logger *zap.SugaredLogger, now time.Time) ScaleResult
 		)
 	}
 
+	exp.ActivationScale = spec.ActivationScale
+	exp.ExcessBurstCapacity, exp.DesiredPodCount = int32(excessBCF), desiredPodCount
+	a.history.record(exp)
+
 	return ScaleResult{
 		DesiredPodCount:     desiredPodCount,
 		ExcessBurstCapacity: int32(excessBCF),
@@ -314,6 +406,54 @@ func (a *autoscaler) Scale(logger *zap.SugaredLogger, now time.Time) ScaleResult
 	}
 }
 
+// Explanations implements Explainer.
+func (a *autoscaler) Explanations() []ScaleExplanation {
+	return a.history.list()
+}
+
+// Checkpoint implements Checkpointer.
+func (a *autoscaler) Checkpoint(now time.Time) ScalerCheckpoint {
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+
+	cp := ScalerCheckpoint{Time: now.Unix()}
+	if !a.panicTime.IsZero() {
+		cp.PanicTime, cp.MaxPanicPods = a.panicTime.Unix(), a.maxPanicPods
+	}
+	if a.delayWindow != nil {
+		for _, s := range a.delayWindow.Checkpoint() {
+			cp.ScaleDownDelay = append(cp.ScaleDownDelay, DelayedScale{Time: s.Time.Unix(), PodCount: s.Value})
+		}
+	}
+	return cp
+}
+
+// Restore implements Checkpointer. A checkpoint taken within the stable window
+// replaces the panic state the autoscaler starts in, since the restored metric
+// windows make up for the lost history. Older checkpoints are ignored.
+func (a *autoscaler) Restore(cp ScalerCheckpoint, now time.Time) {
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+
+	spec := a.currentSpec()
+	if time.Unix(cp.Time, 0).Add(spec.StableWindow).Before(now) {
+		return
+	}
+
+	if cp.PanicTime != 0 {
+		a.panicTime, a.maxPanicPods = time.Unix(cp.PanicTime, 0), cp.MaxPanicPods
+		pkgmetrics.Record(a.reporterCtx, panicM.M(1))
+	} else {
+		a.panicTime, a.maxPanicPods = time.Time{}, 0
+		pkgmetrics.Record(a.reporterCtx, panicM.M(0))
+	}
+	if a.delayWindow != nil {
+		for _, ds := range cp.ScaleDownDelay {
+			a.delayWindow.Record(time.Unix(ds.Time, 0), ds.PodCount)
+		}
+	}
+}
+
 func (a *autoscaler) currentSpec() *DeciderSpec {
 	a.specMux.RLock()
 	defer a.specMux.RUnlock()
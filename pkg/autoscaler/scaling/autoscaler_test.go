@@ -32,6 +32,7 @@ import (
 	servingmetrics "knative.dev/serving/pkg/metrics"
 
 	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/serving/pkg/apis/autoscaling"
 	"knative.dev/serving/pkg/autoscaler/metrics"
 	"knative.dev/serving/pkg/resources"
 
@@ -156,6 +157,55 @@ func TestAutoscalerScaleDownDelayZero(t *testing.T) {
 	})
 }
 
+func TestAutoscalerScaleDownRules(t *testing.T) {
+	pc := &fakePodCounter{}
+	metrics := &metricClient{}
+	spec := &DeciderSpec{
+		TargetValue:      10,
+		MaxScaleDownRate: 10,
+		MaxScaleUpRate:   10,
+		PanicThreshold:   100,
+		ScaleDownRules: &autoscaling.ScalingRules{
+			Policies: []autoscaling.ScalingPolicy{{
+				Type:   autoscaling.PodsScalingPolicy,
+				Value:  2,
+				Period: time.Minute,
+			}},
+		},
+	}
+	as := New(context.Background(), testNamespace, testRevision, metrics, pc, spec)
+	pc.readyCount = 10
+
+	now := time.Time{}
+	metrics.SetStableAndPanicConcurrency(100, 100)
+	expectScale(t, as, now, ScaleResult{
+		ScaleValid:      true,
+		DesiredPodCount: 10,
+	})
+
+	// At most 2 pods are removed per minute.
+	metrics.SetStableAndPanicConcurrency(10, 10)
+	expectScale(t, as, now.Add(2*time.Second), ScaleResult{
+		ScaleValid:      true,
+		DesiredPodCount: 8,
+	})
+	expectScale(t, as, now.Add(time.Minute), ScaleResult{
+		ScaleValid:      true,
+		DesiredPodCount: 8,
+	})
+	expectScale(t, as, now.Add(time.Minute+2*time.Second), ScaleResult{
+		ScaleValid:      true,
+		DesiredPodCount: 6,
+	})
+
+	// Scaling up isn't limited.
+	metrics.SetStableAndPanicConcurrency(90, 90)
+	expectScale(t, as, now.Add(time.Minute+4*time.Second), ScaleResult{
+		ScaleValid:      true,
+		DesiredPodCount: 9,
+	})
+}
+
 func TestAutoscalerNoDataNoAutoscale(t *testing.T) {
 	defer reset()
 	metrics := &metricClient{
@@ -243,6 +293,37 @@ func TestAutoscalerStableModeIncreaseWithRPS(t *testing.T) {
 	expectScale(t, a, time.Now(), ScaleResult{10, expectedEBC(10, 101, 99, 1), true})
 }
 
+func TestAutoscalerStableModeWithCPU(t *testing.T) {
+	metrics := &metricClient{StableConcurrency: 1, StableUtilization: 210, PanicUtilization: 210}
+	a, pc := newTestAutoscalerWithScalingMetric(70, 101, metrics, "cpu", false /*startInPanic*/)
+	pc.readyCount = 3
+	// The excess burst capacity is not computed for utilization metrics.
+	expectScale(t, a, time.Now(), ScaleResult{3, -1, true})
+
+	// Requests arrive, but no pod uses any CPU yet.
+	metrics.StableUtilization, metrics.PanicUtilization = 0, 0
+	expectScale(t, a, time.Now(), ScaleResult{1, -1, true})
+
+	// Idle pods still use some CPU, but no requests arrive.
+	metrics.StableConcurrency = 0
+	metrics.StableUtilization, metrics.PanicUtilization = 20, 20
+	expectScale(t, a, time.Now(), ScaleResult{0, -1, true})
+}
+
+func TestAutoscalerStableModeWithExternalMetric(t *testing.T) {
+	// No requests reach the revision, yet it scales from zero on the backlog.
+	metrics := &metricClient{StableExternal: 120, PanicExternal: 120}
+	a, pc := newTestAutoscalerWithScalingMetric(50, 101, metrics, "external", false /*startInPanic*/)
+	pc.readyCount = 0
+	now := time.Now()
+	expectScale(t, a, now, ScaleResult{3, -1, true})
+
+	// Once the backlog is cleared and the panic is over, it scales to zero.
+	pc.readyCount = 3
+	metrics.StableExternal, metrics.PanicExternal = 0, 0
+	expectScale(t, a, now.Add(stableWindow+tickInterval), ScaleResult{0, -1, true})
+}
+
 func TestAutoscalerUnpanicAfterSlowIncrease(t *testing.T) {
 	// Do initial jump from 10 to 25 pods.
 	metrics := &metricClient{StableConcurrency: 11, PanicConcurrency: 25}
@@ -329,6 +410,12 @@ func TestAutoscalerActivationScale(t *testing.T) {
 
 	metrics.StableConcurrency = 1.0
 	expectScale(t, a, time.Now(), ScaleResult{2, expectedEBC(10, 75, 0, 1), true})
+
+	// Raising the count to the activation scale isn't a rate limit.
+	exps := a.Explanations()
+	if got := exps[len(exps)-1]; got.RateLimited || got.StablePodCount != 1 || got.DesiredPodCount != 2 {
+		t.Errorf("Explanation = %+v, want 1 stable pod scaled to 2 without rate limiting", got)
+	}
 }
 
 // QPS is increasing exponentially. Each scaling event bring concurrency
@@ -698,6 +785,10 @@ type metricClient struct {
 	PanicConcurrency  float64
 	StableRPS         float64
 	PanicRPS          float64
+	StableUtilization float64
+	PanicUtilization  float64
+	StableExternal    float64
+	PanicExternal     float64
 	ErrF              func(key types.NamespacedName, now time.Time) error
 }
 
@@ -726,6 +817,26 @@ func (mc *metricClient) StableAndPanicRPS(key types.NamespacedName, now time.Tim
 	return mc.StableRPS, mc.PanicRPS, err
 }
 
+// StableAndPanicUtilization returns stable/panic utilization stored in the object
+// and the result of Errf as the error.
+func (mc *metricClient) StableAndPanicUtilization(key types.NamespacedName, now time.Time) (float64, float64, error) {
+	var err error
+	if mc.ErrF != nil {
+		err = mc.ErrF(key, now)
+	}
+	return mc.StableUtilization, mc.PanicUtilization, err
+}
+
+// StableAndPanicExternal returns stable/panic external metric values stored in
+// the object and the result of Errf as the error.
+func (mc *metricClient) StableAndPanicExternal(key types.NamespacedName, now time.Time) (float64, float64, error) {
+	var err error
+	if mc.ErrF != nil {
+		err = mc.ErrF(key, now)
+	}
+	return mc.StableExternal, mc.PanicExternal, err
+}
+
 func BenchmarkAutoscaler(b *testing.B) {
 	metrics := &metricClient{StableConcurrency: 50.0, PanicConcurrency: 10}
 	a := newTestAutoscalerNoPC(10, 101, metrics)
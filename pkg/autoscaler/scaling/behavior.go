@@ -0,0 +1,190 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"math"
+	"time"
+
+	"knative.dev/serving/pkg/apis/autoscaling"
+)
+
+// timedPodCount is a pod count, or a change of it, at a point in time.
+type timedPodCount struct {
+	time  time.Time
+	count int32
+}
+
+// behavior enforces the scaling rules of a DeciderSpec, following the
+// algorithm of the HorizontalPodAutoscaler.
+//
+// The rules apply to the pod counts the autoscaler decided on, as the pods
+// being started or terminated are not counted as ready. The state is not
+// checkpointed, since a new autoscaler starts out in panic mode, in which it
+// doesn't scale down.
+type behavior struct {
+	// podCount is the last decided pod count, if known.
+	podCount      int32
+	podCountKnown bool
+
+	// recommendations holds the recent desired pod counts, which the
+	// stabilization windows apply to, oldest first.
+	recommendations []timedPodCount
+	// changes holds the recent changes of the decided pod count, which the
+	// policies limit, oldest first.
+	changes []timedPodCount
+}
+
+// apply returns the pod count to scale to, given the desired pod count and
+// the ready one, and records the decision.
+func (b *behavior) apply(now time.Time, up, down *autoscaling.ScalingRules, ready, desired int32) int32 {
+	current := ready
+	if b.podCountKnown {
+		current = b.podCount
+	}
+	b.recommendations = append(b.recommendations, timedPodCount{time: now, count: desired})
+	b.trim(now, up, down)
+
+	// Bring the recommendation within the least recommendation over the scale
+	// up window and the greatest over the scale down window.
+	upRecommendation, downRecommendation := desired, desired
+	for _, r := range b.recommendations {
+		if up != nil && !r.time.Before(now.Add(-up.StabilizationWindow)) && r.count < upRecommendation {
+			upRecommendation = r.count
+		}
+		if down != nil && !r.time.Before(now.Add(-down.StabilizationWindow)) && r.count > downRecommendation {
+			downRecommendation = r.count
+		}
+	}
+	decided := current
+	if decided < upRecommendation {
+		decided = upRecommendation
+	}
+	if decided > downRecommendation {
+		decided = downRecommendation
+	}
+
+	switch {
+	case decided > current && up != nil:
+		// Activation is never prevented, so that a revision scaled to zero
+		// can always serve requests.
+		if limit := b.scaleUpLimit(now, up, current); decided > limit {
+			decided = int32(math.Max(float64(limit), 1))
+		}
+	case decided < current && down != nil:
+		if limit := b.scaleDownLimit(now, down, current); decided < limit {
+			decided = limit
+		}
+	}
+
+	if decided != current {
+		b.changes = append(b.changes, timedPodCount{time: now, count: decided - current})
+	}
+	b.podCount, b.podCountKnown = decided, true
+	return decided
+}
+
+// changeSince returns the sum of the changes of the pod count after the given
+// time.
+func (b *behavior) changeSince(t time.Time) int32 {
+	var sum int32
+	for _, c := range b.changes {
+		if c.time.After(t) {
+			sum += c.count
+		}
+	}
+	return sum
+}
+
+// scaleUpLimit returns the greatest pod count the rules allow scaling up to.
+func (b *behavior) scaleUpLimit(now time.Time, rules *autoscaling.ScalingRules, current int32) int32 {
+	if rules.SelectPolicy == autoscaling.DisabledSelectPolicy {
+		return current
+	}
+	if len(rules.Policies) == 0 {
+		return math.MaxInt32
+	}
+
+	var limit int32
+	for i, p := range rules.Policies {
+		start := current - b.changeSince(now.Add(-p.Period))
+		proposed := start + p.Value
+		if p.Type == autoscaling.PercentScalingPolicy {
+			proposed = int32(math.Ceil(float64(start) * (1 + float64(p.Value)/100)))
+		}
+		// Max picks the policy allowing the largest change, Min the smallest.
+		if pickMin := rules.SelectPolicy == autoscaling.MinSelectPolicy; i == 0 ||
+			(pickMin && proposed < limit) || (!pickMin && proposed > limit) {
+			limit = proposed
+		}
+	}
+	return limit
+}
+
+// scaleDownLimit returns the least pod count the rules allow scaling down to.
+func (b *behavior) scaleDownLimit(now time.Time, rules *autoscaling.ScalingRules, current int32) int32 {
+	if rules.SelectPolicy == autoscaling.DisabledSelectPolicy {
+		return current
+	}
+	if len(rules.Policies) == 0 {
+		return 0
+	}
+
+	var limit int32
+	for i, p := range rules.Policies {
+		start := current - b.changeSince(now.Add(-p.Period))
+		proposed := start - p.Value
+		if p.Type == autoscaling.PercentScalingPolicy {
+			proposed = int32(float64(start) * (1 - float64(p.Value)/100))
+		}
+		if pickMin := rules.SelectPolicy == autoscaling.MinSelectPolicy; i == 0 ||
+			(pickMin && proposed > limit) || (!pickMin && proposed < limit) {
+			limit = proposed
+		}
+	}
+	return limit
+}
+
+// trim drops the recommendations and changes older than any window or period
+// of the rules.
+func (b *behavior) trim(now time.Time, up, down *autoscaling.ScalingRules) {
+	var window, period time.Duration
+	for _, rules := range []*autoscaling.ScalingRules{up, down} {
+		if rules == nil {
+			continue
+		}
+		if rules.StabilizationWindow > window {
+			window = rules.StabilizationWindow
+		}
+		for _, p := range rules.Policies {
+			if p.Period > period {
+				period = p.Period
+			}
+		}
+	}
+	b.recommendations = trimBefore(b.recommendations, now.Add(-window))
+	b.changes = trimBefore(b.changes, now.Add(-period))
+}
+
+// trimBefore drops the leading pod counts before the given time.
+func trimBefore(s []timedPodCount, t time.Time) []timedPodCount {
+	i := 0
+	for i < len(s) && s[i].time.Before(t) {
+		i++
+	}
+	return s[i:]
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"testing"
+	"time"
+
+	"knative.dev/serving/pkg/apis/autoscaling"
+)
+
+func TestBehavior(t *testing.T) {
+	type step struct {
+		after   time.Duration
+		desired int32
+		want    int32
+	}
+	tests := []struct {
+		name     string
+		up, down *autoscaling.ScalingRules
+		ready    int32
+		steps    []step
+	}{{
+		name:  "no policies",
+		up:    &autoscaling.ScalingRules{},
+		down:  &autoscaling.ScalingRules{},
+		ready: 5,
+		steps: []step{{0, 20, 20}, {time.Second, 1, 1}},
+	}, {
+		name: "at most 2 pods per minute down",
+		down: &autoscaling.ScalingRules{Policies: []autoscaling.ScalingPolicy{
+			{Type: autoscaling.PodsScalingPolicy, Value: 2, Period: time.Minute},
+		}},
+		ready: 10,
+		steps: []step{
+			{0, 1, 8},
+			{10 * time.Second, 1, 8},
+			{49 * time.Second, 1, 8},
+			// The first change is a minute old.
+			{time.Second, 1, 6},
+			// Scaling up isn't limited.
+			{time.Second, 20, 20},
+		},
+	}, {
+		name: "max of pods and percent up",
+		up: &autoscaling.ScalingRules{Policies: []autoscaling.ScalingPolicy{
+			{Type: autoscaling.PodsScalingPolicy, Value: 4, Period: 15 * time.Second},
+			{Type: autoscaling.PercentScalingPolicy, Value: 100, Period: 15 * time.Second},
+		}},
+		ready: 2,
+		steps: []step{
+			{0, 50, 6},
+			{15 * time.Second, 50, 12},
+			{15 * time.Second, 50, 24},
+		},
+	}, {
+		name: "min of pods and percent up",
+		up: &autoscaling.ScalingRules{
+			SelectPolicy: autoscaling.MinSelectPolicy,
+			Policies: []autoscaling.ScalingPolicy{
+				{Type: autoscaling.PodsScalingPolicy, Value: 4, Period: 15 * time.Second},
+				{Type: autoscaling.PercentScalingPolicy, Value: 100, Period: 15 * time.Second},
+			},
+		},
+		ready: 2,
+		steps: []step{
+			{0, 50, 4},
+			{15 * time.Second, 50, 8},
+			{15 * time.Second, 50, 12},
+		},
+	}, {
+		name: "percent down truncates",
+		down: &autoscaling.ScalingRules{Policies: []autoscaling.ScalingPolicy{
+			{Type: autoscaling.PercentScalingPolicy, Value: 10, Period: time.Minute},
+		}},
+		ready: 15,
+		steps: []step{
+			{0, 0, 13},
+			{time.Minute, 0, 11},
+		},
+	}, {
+		name:  "disabled scale down",
+		down:  &autoscaling.ScalingRules{SelectPolicy: autoscaling.DisabledSelectPolicy},
+		ready: 3,
+		steps: []step{{0, 0, 3}, {time.Hour, 0, 3}, {time.Second, 5, 5}},
+	}, {
+		name:  "activation is never prevented",
+		up:    &autoscaling.ScalingRules{SelectPolicy: autoscaling.DisabledSelectPolicy},
+		ready: 0,
+		steps: []step{{0, 3, 1}, {time.Second, 3, 1}},
+	}, {
+		name:  "scale up stabilization",
+		up:    &autoscaling.ScalingRules{StabilizationWindow: 30 * time.Second},
+		ready: 1,
+		steps: []step{
+			{0, 1, 1},
+			{10 * time.Second, 5, 1},
+			{10 * time.Second, 8, 1},
+			{11 * time.Second, 8, 5},
+			{10 * time.Second, 8, 8},
+		},
+	}, {
+		name:  "scale down stabilization",
+		down:  &autoscaling.ScalingRules{StabilizationWindow: 30 * time.Second},
+		ready: 10,
+		steps: []step{
+			{0, 10, 10},
+			{10 * time.Second, 4, 10},
+			{10 * time.Second, 2, 10},
+			{11 * time.Second, 2, 4},
+			{10 * time.Second, 3, 3},
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var b behavior
+			now := time.Unix(1000, 0)
+			for i, s := range test.steps {
+				now = now.Add(s.after)
+				if got := b.apply(now, test.up, test.down, test.ready, s.desired); got != s.want {
+					t.Errorf("Step %d: apply(%d) = %d, want: %d", i, s.desired, got, s.want)
+				}
+			}
+		})
+	}
+}
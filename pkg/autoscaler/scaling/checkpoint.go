@@ -0,0 +1,64 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ScalerCheckpoint is the state of an autoscaler which is restored when the
+// autoscaler of a revision is recreated, e.g. after a restart of the
+// autoscaler or a change of the bucket leader. Times are in seconds since the
+// epoch.
+type ScalerCheckpoint struct {
+	// Time is when the checkpoint was taken.
+	Time int64 `json:"time"`
+	// PanicTime is when the autoscaler was last over the panic threshold, or
+	// zero if it wasn't in panic mode.
+	PanicTime    int64 `json:"panicTime,omitempty"`
+	MaxPanicPods int32 `json:"maxPanicPods,omitempty"`
+	// ScaleDownDelay holds the pod counts that determine the delayed scale,
+	// oldest first.
+	ScaleDownDelay []DelayedScale `json:"scaleDownDelay,omitempty"`
+}
+
+// DelayedScale is a pod count within the scale down delay window.
+type DelayedScale struct {
+	Time     int64 `json:"time"`
+	PodCount int32 `json:"podCount"`
+}
+
+// Checkpointer is implemented by UniScalers whose state can be checkpointed
+// and restored.
+type Checkpointer interface {
+	// Checkpoint returns the current state.
+	Checkpoint(now time.Time) ScalerCheckpoint
+	// Restore restores the state of a new UniScaler from the checkpoint.
+	Restore(cp ScalerCheckpoint, now time.Time)
+}
+
+// ScalerCheckpointSource provides the checkpoints to restore new UniScalers
+// from.
+type ScalerCheckpointSource interface {
+	// ScalerCheckpoint returns the checkpoint of the UniScaler with the given
+	// key, if there is one.
+	ScalerCheckpoint(key types.NamespacedName) (ScalerCheckpoint, bool)
+}
+
+var _ Checkpointer = (*autoscaler)(nil)
@@ -0,0 +1,135 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/types"
+
+	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/serving/pkg/autoscaler/aggregation/max"
+)
+
+func TestAutoscalerCheckpoint(t *testing.T) {
+	now := time.Unix(1000, 0)
+	metrics := &metricClient{StableConcurrency: 10, PanicConcurrency: 10}
+	a, pc := newTestAutoscaler(10, 100, metrics)
+	a.delayWindow = max.NewTimeWindow(10*time.Second, tickInterval)
+
+	if got, want := a.Checkpoint(now), (ScalerCheckpoint{Time: now.Unix()}); !cmp.Equal(got, want) {
+		t.Error("Checkpoint() (-want, +got):", cmp.Diff(want, got))
+	}
+
+	// Panic.
+	expectScale(t, a, now, ScaleResult{1, expectedEBC(10, 100, 10, 1), true})
+	metrics.SetStableAndPanicConcurrency(30, 30)
+	expectScale(t, a, now.Add(2*time.Second), ScaleResult{3, expectedEBC(10, 100, 30, 1), true})
+	pc.readyCount = 3
+
+	want := ScalerCheckpoint{
+		Time:         now.Add(4 * time.Second).Unix(),
+		PanicTime:    now.Add(2 * time.Second).Unix(),
+		MaxPanicPods: 3,
+		ScaleDownDelay: []DelayedScale{
+			{Time: now.Add(2 * time.Second).Unix(), PodCount: 3},
+		},
+	}
+	if got := a.Checkpoint(now.Add(4 * time.Second)); !cmp.Equal(got, want) {
+		t.Error("Checkpoint() (-want, +got):", cmp.Diff(want, got))
+	}
+
+	// A fresh checkpoint restores the panic state and the delay window.
+	metrics.SetStableAndPanicConcurrency(10, 10)
+	restored, _ := newTestAutoscaler(10, 100, metrics)
+	restored.delayWindow = max.NewTimeWindow(10*time.Second, tickInterval)
+	restored.Restore(want, now.Add(6*time.Second))
+	if got, want := restored.Checkpoint(now.Add(4*time.Second)), want; !cmp.Equal(got, want) {
+		t.Error("Restored Checkpoint() (-want, +got):", cmp.Diff(want, got))
+	}
+	expectScale(t, restored, now.Add(6*time.Second), ScaleResult{3, expectedEBC(10, 100, 10, 1), true})
+
+	// A checkpoint without panic ends the panic the autoscaler starts in.
+	started, _ := newTestAutoscalerWithScalingMetric(10, 100, metrics, "concurrency", true /*startInPanic*/)
+	started.Restore(ScalerCheckpoint{Time: now.Unix()}, now.Add(time.Second))
+	if !started.panicTime.IsZero() || started.maxPanicPods != 0 {
+		t.Errorf("Panic state = %v, %d, want none", started.panicTime, started.maxPanicPods)
+	}
+
+	// A checkpoint older than the stable window is ignored.
+	stale, _ := newTestAutoscaler(10, 100, metrics)
+	stale.Restore(want, now.Add(4*time.Second+stableWindow+time.Second))
+	if !stale.panicTime.IsZero() {
+		t.Errorf("PanicTime = %v, want none", stale.panicTime)
+	}
+}
+
+type fakeCheckpointSource map[types.NamespacedName]ScalerCheckpoint
+
+func (f fakeCheckpointSource) ScalerCheckpoint(key types.NamespacedName) (ScalerCheckpoint, bool) {
+	cp, ok := f[key]
+	return cp, ok
+}
+
+type checkpointingUniScaler struct {
+	fakeUniScaler
+	restored []ScalerCheckpoint
+}
+
+func (u *checkpointingUniScaler) Checkpoint(now time.Time) ScalerCheckpoint {
+	return ScalerCheckpoint{Time: now.Unix(), MaxPanicPods: u.replicas}
+}
+
+func (u *checkpointingUniScaler) Restore(cp ScalerCheckpoint, _ time.Time) {
+	u.restored = append(u.restored, cp)
+}
+
+func TestMultiScalerCheckpoints(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	uniScaler := &checkpointingUniScaler{}
+	uniScaler.setScaleResult(2, 0, true)
+	ms := NewMultiScaler(ctx.Done(), func(*Decider) (UniScaler, error) {
+		return uniScaler, nil
+	}, logtesting.TestLogger(t))
+
+	decider := newDecider()
+	key := types.NamespacedName{Namespace: decider.Namespace, Name: decider.Name}
+	cp := ScalerCheckpoint{Time: 1000, PanicTime: 990, MaxPanicPods: 5}
+	ms.SetCheckpointSource(fakeCheckpointSource{key: cp})
+
+	if _, err := ms.Create(ctx, decider); err != nil {
+		t.Fatal("Create() =", err)
+	}
+	// Creating an existing decider doesn't restore it again.
+	if _, err := ms.Create(ctx, decider); err != nil {
+		t.Fatal("Create() =", err)
+	}
+	if want := []ScalerCheckpoint{cp}; !cmp.Equal(uniScaler.restored, want) {
+		t.Error("Restored (-want, +got):", cmp.Diff(want, uniScaler.restored))
+	}
+
+	now := time.Unix(2000, 0)
+	want := map[types.NamespacedName]ScalerCheckpoint{key: {Time: now.Unix(), MaxPanicPods: 2}}
+	if got := ms.Checkpoints(now); !cmp.Equal(got, want) {
+		t.Error("Checkpoints() (-want, +got):", cmp.Diff(want, got))
+	}
+}
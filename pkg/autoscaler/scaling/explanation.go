@@ -0,0 +1,159 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// explanationHistorySize is the number of explanations kept per revision.
+const explanationHistorySize = 30
+
+// ScaleExplanation records how a single Scale evaluation arrived at its result.
+type ScaleExplanation struct {
+	Time time.Time `json:"time"`
+
+	// Valid is false if the evaluation did not produce a decision.
+	// Reason then says why.
+	Valid  bool   `json:"valid"`
+	Reason string `json:"reason,omitempty"`
+
+	Metric              string  `json:"metric,omitempty"`
+	ObservedStableValue float64 `json:"observedStableValue"`
+	ObservedPanicValue  float64 `json:"observedPanicValue"`
+	TargetValue         float64 `json:"targetValue"`
+	ReadyPodCount       int     `json:"readyPodCount"`
+
+	// StablePodCount and PanicPodCount are the pod counts needed for the
+	// observed values, before any limits are applied.
+	StablePodCount int32 `json:"stablePodCount"`
+	PanicPodCount  int32 `json:"panicPodCount"`
+
+	// MaxScaleUp and MaxScaleDown are the bounds imposed by the scale up and
+	// scale down rates. RateLimited is true if they changed the decision.
+	MaxScaleUp   int32 `json:"maxScaleUp"`
+	MaxScaleDown int32 `json:"maxScaleDown"`
+	RateLimited  bool  `json:"rateLimited,omitempty"`
+
+	// ActivationScale is the minimum scale applied when scaling from zero.
+	ActivationScale int32 `json:"activationScale,omitempty"`
+
+	PanicMode    bool  `json:"panicMode"`
+	MaxPanicPods int32 `json:"maxPanicPods,omitempty"`
+
+	// ScaleDownDelay is the window scale down decisions are deferred for.
+	// UndelayedPodCount is the decision before the window was applied, if
+	// the window changed it.
+	ScaleDownDelay    time.Duration `json:"scaleDownDelay,omitempty"`
+	UndelayedPodCount *int32        `json:"undelayedPodCount,omitempty"`
+
+	// UnlimitedPodCount is the decision before the scale up and scale down
+	// rules were applied, if they changed it.
+	UnlimitedPodCount *int32 `json:"unlimitedPodCount,omitempty"`
+
+	ExcessBurstCapacity int32 `json:"excessBurstCapacity"`
+	DesiredPodCount     int32 `json:"desiredPodCount"`
+}
+
+// Summary returns a single line condensed form of the explanation.
+func (e *ScaleExplanation) Summary() string {
+	if !e.Valid {
+		return "no decision: " + e.Reason
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "desired=%d ready=%d %s stable=%.2f panic=%.2f target=%.2f",
+		e.DesiredPodCount, e.ReadyPodCount, e.Metric,
+		e.ObservedStableValue, e.ObservedPanicValue, e.TargetValue)
+	if e.PanicMode {
+		fmt.Fprintf(&b, ", panicking at %d pods", e.MaxPanicPods)
+	}
+	if e.RateLimited {
+		fmt.Fprintf(&b, ", rate limited to [%d, %d]", e.MaxScaleDown, e.MaxScaleUp)
+	}
+	if e.UndelayedPodCount != nil {
+		fmt.Fprintf(&b, ", scale down to %d delayed by %v", *e.UndelayedPodCount, e.ScaleDownDelay)
+	}
+	if e.UnlimitedPodCount != nil {
+		fmt.Fprintf(&b, ", scale to %d limited by the scaling rules", *e.UnlimitedPodCount)
+	}
+	return b.String()
+}
+
+// StatusSummary returns a single line condensed form of the explanation made
+// of the decision and of the rules that shaped it only, to be published in
+// the status of the PodAutoscaler. Unlike the summary, it doesn't change with
+// every evaluation, so that publishing it doesn't update the status on every
+// tick. The observed values are in the explanations served by the autoscaler.
+func (e *ScaleExplanation) StatusSummary() string {
+	if !e.Valid {
+		return "no decision: " + e.Reason
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "desired=%d on %s", e.DesiredPodCount, e.Metric)
+	if e.PanicMode {
+		fmt.Fprintf(&b, ", panicking at %d pods", e.MaxPanicPods)
+	}
+	if e.RateLimited {
+		b.WriteString(", rate limited")
+	}
+	if e.UndelayedPodCount != nil {
+		fmt.Fprintf(&b, ", scale down delayed by %v", e.ScaleDownDelay)
+	}
+	if e.UnlimitedPodCount != nil {
+		b.WriteString(", limited by the scaling rules")
+	}
+	return b.String()
+}
+
+// Explainer is implemented by UniScalers that keep a history of explanations
+// of their decisions.
+type Explainer interface {
+	// Explanations returns the recent explanations, oldest first.
+	Explanations() []ScaleExplanation
+}
+
+// explanationHistory is a fixed size ring of explanations, safe for
+// concurrent use.
+type explanationHistory struct {
+	mux   sync.RWMutex
+	ring  [explanationHistorySize]ScaleExplanation
+	next  int
+	count int
+}
+
+func (h *explanationHistory) record(e ScaleExplanation) {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+	h.ring[h.next] = e
+	h.next = (h.next + 1) % len(h.ring)
+	if h.count < len(h.ring) {
+		h.count++
+	}
+}
+
+func (h *explanationHistory) list() []ScaleExplanation {
+	h.mux.RLock()
+	defer h.mux.RUnlock()
+	ret := make([]ScaleExplanation, 0, h.count)
+	for i := h.count; i > 0; i-- {
+		ret = append(ret, h.ring[(h.next-i+len(h.ring))%len(h.ring)])
+	}
+	return ret
+}
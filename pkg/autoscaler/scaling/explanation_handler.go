@@ -0,0 +1,80 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	pkghandler "knative.dev/serving/pkg/http/handler"
+)
+
+// ExplanationsPath is the path the ExplanationHandler is served on.
+const ExplanationsPath = "/debug/scaling"
+
+// ExplanationLister returns the explanations of the recent scaling decisions
+// for a revision.
+type ExplanationLister interface {
+	Explanations(namespace, name string) ([]ScaleExplanation, error)
+}
+
+// ExplanationHandler serves the recent scaling decisions of the revision
+// given by the `revision=namespace/name` query parameter. The decisions are
+// rendered as a table, or as JSON if the `format=json` query parameter is set.
+// Only the revisions owned by this autoscaler replica are known.
+type ExplanationHandler struct {
+	Lister ExplanationLister
+	Logger *zap.SugaredLogger
+}
+
+func (h *ExplanationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	debug := &pkghandler.DebugHandler{
+		RequireRevision: true,
+		State: func(rev types.NamespacedName) (interface{}, error) {
+			exps, err := h.Lister.Explanations(rev.Namespace, rev.Name)
+			if apierrors.IsNotFound(err) {
+				return nil, fmt.Errorf("revision %s is not scaled by this autoscaler: %w", rev, err)
+			} else if err != nil {
+				return nil, err
+			}
+			if exps == nil {
+				exps = []ScaleExplanation{}
+			}
+			return exps, nil
+		},
+		WriteText: func(w io.Writer, state interface{}) error {
+			return writeExplanations(w, state.([]ScaleExplanation))
+		},
+		Logger: h.Logger,
+	}
+	debug.ServeHTTP(w, r)
+}
+
+func writeExplanations(w io.Writer, exps []ScaleExplanation) error {
+	tw := pkghandler.NewDebugTabWriter(w)
+	fmt.Fprintln(tw, "TIME\tDECISION")
+	for i := range exps {
+		fmt.Fprintf(tw, "%s\t%s\n", exps[i].Time.Format(time.RFC3339), exps[i].Summary())
+	}
+	return tw.Flush()
+}
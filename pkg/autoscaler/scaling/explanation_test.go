@@ -0,0 +1,326 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	logtesting "knative.dev/pkg/logging/testing"
+	autoscalingv1alpha1 "knative.dev/serving/pkg/apis/autoscaling/v1alpha1"
+)
+
+func TestExplanationHistory(t *testing.T) {
+	var h explanationHistory
+	if got := h.list(); len(got) != 0 {
+		t.Errorf("list() = %v, want empty", got)
+	}
+
+	for i := 0; i < explanationHistorySize+5; i++ {
+		h.record(ScaleExplanation{DesiredPodCount: int32(i)})
+	}
+	got := h.list()
+	if len(got) != explanationHistorySize {
+		t.Fatalf("len(list()) = %d, want: %d", len(got), explanationHistorySize)
+	}
+	for i, e := range got {
+		if want := int32(i + 5); e.DesiredPodCount != want {
+			t.Errorf("list()[%d].DesiredPodCount = %d, want: %d", i, e.DesiredPodCount, want)
+		}
+	}
+}
+
+func TestScaleExplanationSummary(t *testing.T) {
+	undelayed := int32(1)
+	tests := []struct {
+		name string
+		exp  ScaleExplanation
+		want string
+	}{{
+		name: "invalid",
+		exp:  ScaleExplanation{Reason: "no data to scale on yet"},
+		want: "no decision: no data to scale on yet",
+	}, {
+		name: "stable",
+		exp: ScaleExplanation{
+			Valid:               true,
+			Metric:              "concurrency",
+			ObservedStableValue: 20,
+			ObservedPanicValue:  25,
+			TargetValue:         10,
+			ReadyPodCount:       2,
+			DesiredPodCount:     2,
+		},
+		want: "desired=2 ready=2 concurrency stable=20.00 panic=25.00 target=10.00",
+	}, {
+		name: "everything",
+		exp: ScaleExplanation{
+			Valid:             true,
+			Metric:            "rps",
+			TargetValue:       10,
+			ReadyPodCount:     4,
+			MaxScaleUp:        40,
+			MaxScaleDown:      2,
+			RateLimited:       true,
+			PanicMode:         true,
+			MaxPanicPods:      4,
+			ScaleDownDelay:    time.Minute,
+			UndelayedPodCount: &undelayed,
+			DesiredPodCount:   4,
+		},
+		want: "desired=4 ready=4 rps stable=0.00 panic=0.00 target=10.00, panicking at 4 pods, " +
+			"rate limited to [2, 40], scale down to 1 delayed by 1m0s",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.exp.Summary(); got != test.want {
+				t.Errorf("Summary() = %q, want: %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestScaleExplanationStatusSummary(t *testing.T) {
+	undelayed, unlimited := int32(1), int32(8)
+	tests := []struct {
+		name string
+		exp  ScaleExplanation
+		want string
+	}{{
+		name: "invalid",
+		exp:  ScaleExplanation{Reason: "no data to scale on yet"},
+		want: "no decision: no data to scale on yet",
+	}, {
+		name: "stable",
+		exp: ScaleExplanation{
+			Valid:               true,
+			Metric:              "concurrency",
+			ObservedStableValue: 20,
+			ObservedPanicValue:  25,
+			TargetValue:         10,
+			ReadyPodCount:       2,
+			DesiredPodCount:     2,
+		},
+		want: "desired=2 on concurrency",
+	}, {
+		name: "everything",
+		exp: ScaleExplanation{
+			Valid:             true,
+			Metric:            "rps",
+			TargetValue:       10,
+			ReadyPodCount:     4,
+			MaxScaleUp:        40,
+			MaxScaleDown:      2,
+			RateLimited:       true,
+			PanicMode:         true,
+			MaxPanicPods:      4,
+			ScaleDownDelay:    time.Minute,
+			UndelayedPodCount: &undelayed,
+			UnlimitedPodCount: &unlimited,
+			DesiredPodCount:   4,
+		},
+		want: "desired=4 on rps, panicking at 4 pods, rate limited, scale down delayed by 1m0s, " +
+			"limited by the scaling rules",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.exp.StatusSummary(); got != test.want {
+				t.Errorf("StatusSummary() = %q, want: %q", got, test.want)
+			}
+		})
+	}
+
+	// The observed values don't change the status summary.
+	a := tests[1].exp
+	b := a
+	b.ObservedStableValue, b.ObservedPanicValue = 21, 19
+	if a.StatusSummary() != b.StatusSummary() {
+		t.Errorf("StatusSummary() = %q, then %q with other observed values", a.StatusSummary(), b.StatusSummary())
+	}
+}
+
+func TestAutoscalerExplanations(t *testing.T) {
+	metrics := &metricClient{StableConcurrency: 1000, PanicConcurrency: 1001}
+	a, pc := newTestAutoscaler(10, 61, metrics)
+	now := time.Now()
+
+	// Need 100 pods but only scale x10, and panic.
+	expectScale(t, a, now, ScaleResult{10, expectedEBC(10, 61, 1001, 1), true})
+
+	pc.readyCount = 10
+	metrics.ErrF = func(types.NamespacedName, time.Time) error {
+		return errors.New("no metrics")
+	}
+	expectScale(t, a, now.Add(time.Second), ScaleResult{0, 0, false})
+
+	want := []ScaleExplanation{{
+		Time:                now,
+		Valid:               true,
+		Metric:              "concurrency",
+		ObservedStableValue: 1000,
+		ObservedPanicValue:  1001,
+		TargetValue:         10,
+		ReadyPodCount:       1,
+		StablePodCount:      100,
+		PanicPodCount:       101,
+		MaxScaleUp:          10,
+		MaxScaleDown:        0,
+		RateLimited:         true,
+		PanicMode:           true,
+		MaxPanicPods:        10,
+		DesiredPodCount:     10,
+	}, {
+		Time:   now.Add(time.Second),
+		Reason: "failed to obtain metrics: no metrics",
+	}}
+	got := a.Explanations()
+	if !cmp.Equal(got, want, cmpopts.IgnoreFields(ScaleExplanation{}, "ExcessBurstCapacity")) {
+		t.Error("Explanations (-want, +got):", cmp.Diff(want, got))
+	}
+}
+
+func TestMultiScalerExplanations(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ms, _ := createMultiScaler(ctx, logtesting.TestLogger(t))
+
+	decider := newDecider()
+	if _, err := ms.Explanations(decider.Namespace, decider.Name); !apierrors.IsNotFound(err) {
+		t.Errorf("Explanations() = %v, want NotFound", err)
+	}
+
+	if _, err := ms.Create(ctx, decider); err != nil {
+		t.Fatal("Create() =", err)
+	}
+	// The fake UniScaler does not keep explanations.
+	if got, err := ms.Explanations(decider.Namespace, decider.Name); err != nil || got != nil {
+		t.Errorf("Explanations() = %v, %v, want: nil, nil", got, err)
+	}
+}
+
+type fakeExplanationLister struct {
+	exps []ScaleExplanation
+	err  error
+}
+
+func (f *fakeExplanationLister) Explanations(namespace, name string) ([]ScaleExplanation, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.exps, nil
+}
+
+func TestExplanationHandler(t *testing.T) {
+	exps := []ScaleExplanation{{
+		Time:   time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC),
+		Reason: "no data to scale on yet",
+	}, {
+		Time:            time.Date(2023, 5, 1, 12, 0, 2, 0, time.UTC),
+		Valid:           true,
+		Metric:          "concurrency",
+		TargetValue:     10,
+		ReadyPodCount:   1,
+		DesiredPodCount: 1,
+	}}
+
+	tests := []struct {
+		name     string
+		query    string
+		err      error
+		wantCode int
+		wantBody []string
+	}{{
+		name:     "text",
+		query:    "?revision=ns/rev",
+		wantCode: http.StatusOK,
+		wantBody: []string{
+			"2023-05-01T12:00:00Z  no decision: no data to scale on yet",
+			"2023-05-01T12:00:02Z  desired=1 ready=1 concurrency",
+		},
+	}, {
+		name:     "missing revision",
+		wantCode: http.StatusBadRequest,
+	}, {
+		name:     "invalid revision",
+		query:    "?revision=rev",
+		wantCode: http.StatusBadRequest,
+	}, {
+		name:     "unknown format",
+		query:    "?revision=ns/rev&format=yaml",
+		wantCode: http.StatusBadRequest,
+	}, {
+		name:     "unknown revision",
+		query:    "?revision=ns/rev",
+		err:      apierrors.NewNotFound(autoscalingv1alpha1.Resource("Deciders"), "ns/rev"),
+		wantCode: http.StatusNotFound,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handler := &ExplanationHandler{
+				Lister: &fakeExplanationLister{exps: exps, err: test.err},
+				Logger: logtesting.TestLogger(t),
+			}
+
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, ExplanationsPath+test.query, nil))
+
+			if got, want := resp.Code, test.wantCode; got != want {
+				t.Fatalf("StatusCode = %d, want: %d", got, want)
+			}
+			for _, want := range test.wantBody {
+				if !strings.Contains(resp.Body.String(), want) {
+					t.Errorf("Body = %q, want to contain %q", resp.Body.String(), want)
+				}
+			}
+		})
+	}
+
+	t.Run("json", func(t *testing.T) {
+		handler := &ExplanationHandler{
+			Lister: &fakeExplanationLister{exps: exps},
+			Logger: logtesting.TestLogger(t),
+		}
+
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, ExplanationsPath+"?revision=ns/rev&format=json", nil))
+
+		if got, want := resp.Header().Get("Content-Type"), "application/json"; got != want {
+			t.Errorf("Content-Type = %q, want: %q", got, want)
+		}
+		var got []ScaleExplanation
+		if err := json.Unmarshal(resp.Body.Bytes(), &got); err != nil {
+			t.Fatal("Failed to decode response:", err)
+		}
+		if !cmp.Equal(got, exps) {
+			t.Error("Explanations (-want, +got):", cmp.Diff(exps, got))
+		}
+	})
+}
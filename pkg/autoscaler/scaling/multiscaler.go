@@ -27,6 +27,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"knative.dev/pkg/logging/logkey"
+	"knative.dev/serving/pkg/apis/autoscaling"
 	autoscalingv1alpha1 "knative.dev/serving/pkg/apis/autoscaling/v1alpha1"
 	"knative.dev/serving/pkg/autoscaler/metrics"
 )
@@ -45,6 +46,7 @@ type Decider struct {
 }
 
 // DeciderSpec is the parameters by which the Revision should be scaled.
+// +k8s:deepcopy-gen=true
 type DeciderSpec struct {
 	MaxScaleUpRate   float64
 	MaxScaleDownRate float64
@@ -82,9 +84,15 @@ type DeciderSpec struct {
 	// min-scale value while also preserving the ability to scale to zero.
 	// ActivationScale must be >= 2.
 	ActivationScale int32
+	// ScaleUpRules and ScaleDownRules, if set, stabilize and limit the changes
+	// of the pod count in either direction, like the behavior of a
+	// HorizontalPodAutoscaler.
+	ScaleUpRules   *autoscaling.ScalingRules
+	ScaleDownRules *autoscaling.ScalingRules
 }
 
 // DeciderStatus is the current scale recommendation.
+// +k8s:deepcopy-gen=true
 type DeciderStatus struct {
 	// DesiredScale is the target number of instances that autoscaler
 	// this revision needs.
@@ -96,6 +104,15 @@ type DeciderStatus struct {
 	// If this number is negative: Activator will be threaded in
 	// the request path by the PodAutoscaler controller.
 	ExcessBurstCapacity int32
+
+	// ActivationRequested is when a request for the revision was first
+	// observed while it was scaled to zero. It is reset when the revision
+	// scales back to zero.
+	ActivationRequested metav1.Time
+
+	// LastDecision is the condensed explanation of the latest evaluation
+	// of the autoscaler, if it keeps explanations.
+	LastDecision string
 }
 
 // ScaleResult holds the scale result of the UniScaler evaluation cycle.
@@ -144,6 +161,23 @@ func (sr *scalerRunner) latestScale() int32 {
 	return sr.decider.Status.DesiredScale
 }
 
+// markActivationRequested records now as the time the activation from zero
+// was requested, unless it was already recorded.
+func (sr *scalerRunner) markActivationRequested(now time.Time) {
+	sr.mux.Lock()
+	defer sr.mux.Unlock()
+	if sr.decider.Status.ActivationRequested.IsZero() {
+		sr.decider.Status.ActivationRequested = metav1.NewTime(now)
+	}
+}
+
+// setLastDecision records the summary of the latest scaling decision.
+func (sr *scalerRunner) setLastDecision(summary string) {
+	sr.mux.Lock()
+	defer sr.mux.Unlock()
+	sr.decider.Status.LastDecision = summary
+}
+
 func sameSign(a, b int32) bool {
 	return (a&math.MinInt32)^(b&math.MinInt32) == 0
 }
@@ -163,6 +197,9 @@ func (sr *scalerRunner) updateLatestScale(sRes ScaleResult) bool {
 		sr.decider.Status.DesiredScale = sRes.DesiredPodCount
 		ret = true
 	}
+	if sRes.DesiredPodCount == 0 {
+		sr.decider.Status.ActivationRequested = metav1.Time{}
+	}
 
 	// If sign has changed -- then we have to update KPA.
 	ret = ret || !sameSign(sr.decider.Status.ExcessBurstCapacity, sRes.ExcessBurstCapacity)
@@ -187,6 +224,10 @@ type MultiScaler struct {
 	watcher      func(types.NamespacedName)
 
 	tickProvider func(time.Duration) *time.Ticker
+
+	// checkpoints, if set, provides the checkpoints new UniScalers are
+	// restored from. It is guarded by scalersMutex.
+	checkpoints ScalerCheckpointSource
 }
 
 // NewMultiScaler constructs a MultiScaler.
@@ -216,9 +257,30 @@ func (m *MultiScaler) Get(_ context.Context, namespace, name string) (*Decider,
 	return scaler.safeDecider(), nil
 }
 
+// Explanations returns the explanations of the recent scaling decisions for
+// the given revision, oldest first.
+func (m *MultiScaler) Explanations(namespace, name string) ([]ScaleExplanation, error) {
+	key := types.NamespacedName{Namespace: namespace, Name: name}
+	m.scalersMutex.RLock()
+	defer m.scalersMutex.RUnlock()
+	scaler, exists := m.scalers[key]
+	if !exists {
+		return nil, errors.NewNotFound(autoscalingv1alpha1.Resource("Deciders"), key.String())
+	}
+	e, ok := scaler.scaler.(Explainer)
+	if !ok {
+		return nil, nil
+	}
+	return e.Explanations(), nil
+}
+
 // Create instantiates the desired Decider.
 func (m *MultiScaler) Create(_ context.Context, decider *Decider) (*Decider, error) {
 	key := types.NamespacedName{Namespace: decider.Namespace, Name: decider.Name}
+	// Look up the checkpoint before taking the lock, as it might need to be
+	// fetched.
+	checkpoint, restore := m.checkpoint(key)
+
 	m.scalersMutex.Lock()
 	defer m.scalersMutex.Unlock()
 	scaler, exists := m.scalers[key]
@@ -228,11 +290,52 @@ func (m *MultiScaler) Create(_ context.Context, decider *Decider) (*Decider, err
 		if err != nil {
 			return nil, err
 		}
+		if c, ok := scaler.scaler.(Checkpointer); ok && restore {
+			scaler.logger.Info("Restoring the autoscaler state from the checkpoint")
+			c.Restore(checkpoint, time.Now())
+		}
+		m.runScalerTicker(scaler, key)
 		m.scalers[key] = scaler
 	}
 	return scaler.safeDecider(), nil
 }
 
+// checkpoint returns the checkpoint to restore the UniScaler with the given
+// key from, if the UniScaler doesn't exist yet and there is one.
+func (m *MultiScaler) checkpoint(key types.NamespacedName) (ScalerCheckpoint, bool) {
+	m.scalersMutex.RLock()
+	_, exists := m.scalers[key]
+	source := m.checkpoints
+	m.scalersMutex.RUnlock()
+
+	if exists || source == nil {
+		return ScalerCheckpoint{}, false
+	}
+	return source.ScalerCheckpoint(key)
+}
+
+// SetCheckpointSource sets the source of the checkpoints the UniScalers
+// created afterwards are restored from.
+func (m *MultiScaler) SetCheckpointSource(s ScalerCheckpointSource) {
+	m.scalersMutex.Lock()
+	defer m.scalersMutex.Unlock()
+	m.checkpoints = s
+}
+
+// Checkpoints returns the checkpoints of the UniScalers that support them.
+func (m *MultiScaler) Checkpoints(now time.Time) map[types.NamespacedName]ScalerCheckpoint {
+	m.scalersMutex.RLock()
+	defer m.scalersMutex.RUnlock()
+
+	ret := make(map[types.NamespacedName]ScalerCheckpoint, len(m.scalers))
+	for key, runner := range m.scalers {
+		if c, ok := runner.scaler.(Checkpointer); ok {
+			ret[key] = c.Checkpoint(now)
+		}
+	}
+	return ret
+}
+
 // Update applies the desired DeciderSpec to a currently running Decider.
 func (m *MultiScaler) Update(_ context.Context, decider *Decider) (*Decider, error) {
 	key := types.NamespacedName{Namespace: decider.Namespace, Name: decider.Name}
@@ -327,13 +430,18 @@ func (m *MultiScaler) createScaler(decider *Decider, key types.NamespacedName) (
 		d.Status.ExcessBurstCapacity = int32(float64(d.Spec.InitialScale)*d.Spec.TotalValue - tbc)
 	}
 
-	m.runScalerTicker(runner, key)
 	return runner, nil
 }
 
 func (m *MultiScaler) tickScaler(scaler UniScaler, runner *scalerRunner, metricKey types.NamespacedName) {
 	sr := scaler.Scale(runner.logger, time.Now())
 
+	if e, ok := scaler.(Explainer); ok {
+		if exps := e.Explanations(); len(exps) > 0 {
+			runner.setLastDecision(exps[len(exps)-1].StatusSummary())
+		}
+	}
+
 	if !sr.ScaleValid {
 		return
 	}
@@ -354,6 +462,7 @@ func (m *MultiScaler) Poke(key types.NamespacedName, stat metrics.Stat) {
 	}
 
 	if scaler.latestScale() == 0 && stat.AverageConcurrentRequests != 0 {
+		scaler.markActivationRequested(time.Now())
 		scaler.pokeCh <- struct{}{}
 	}
 }
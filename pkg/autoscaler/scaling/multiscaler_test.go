@@ -325,6 +325,19 @@ func TestMultiScalerScaleFromZero(t *testing.T) {
 	if err := verifyTick(errCh); err != nil {
 		t.Fatal(err)
 	}
+
+	// The activation request is remembered until the revision is scaled to zero again.
+	m, err := ms.Get(ctx, decider.Namespace, decider.Name)
+	if err != nil {
+		t.Fatal("Get() =", err)
+	}
+	if m.Status.ActivationRequested.IsZero() {
+		t.Error("ActivationRequested is not set after Poke")
+	}
+	ms.scalers[metricKey].updateLatestScale(ScaleResult{0, 10, true})
+	if m, _ := ms.Get(ctx, decider.Namespace, decider.Name); !m.Status.ActivationRequested.IsZero() {
+		t.Errorf("ActivationRequested = %v, want zero after scaling to zero", m.Status.ActivationRequested)
+	}
 	ms.Delete(ctx, decider.Namespace, decider.Name)
 }
 
@@ -21,12 +21,16 @@ limitations under the License.
 
 package scaling
 
+import (
+	autoscaling "knative.dev/serving/pkg/apis/autoscaling"
+)
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Decider) DeepCopyInto(out *Decider) {
 	*out = *in
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -39,3 +43,46 @@ func (in *Decider) DeepCopy() *Decider {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeciderSpec) DeepCopyInto(out *DeciderSpec) {
+	*out = *in
+	if in.ScaleUpRules != nil {
+		in, out := &in.ScaleUpRules, &out.ScaleUpRules
+		*out = new(autoscaling.ScalingRules)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ScaleDownRules != nil {
+		in, out := &in.ScaleDownRules, &out.ScaleDownRules
+		*out = new(autoscaling.ScalingRules)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeciderSpec.
+func (in *DeciderSpec) DeepCopy() *DeciderSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DeciderSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeciderStatus) DeepCopyInto(out *DeciderStatus) {
+	*out = *in
+	in.ActivationRequested.DeepCopyInto(&out.ActivationRequested)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeciderStatus.
+func (in *DeciderStatus) DeepCopy() *DeciderStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DeciderStatus)
+	in.DeepCopyInto(out)
+	return out
+}
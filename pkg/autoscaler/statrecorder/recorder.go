@@ -0,0 +1,225 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statrecorder
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/clock"
+
+	"knative.dev/serving/pkg/autoscaler/metrics"
+)
+
+// Config configures a Recorder.
+type Config struct {
+	// Path is the file the stats are written to. When it reaches MaxFileSize
+	// it is rotated to Path.1, the previous Path.1 to Path.2 and so on.
+	Path string
+
+	// MaxFileSize is the size in bytes at which the file is rotated.
+	MaxFileSize int64
+
+	// MaxFiles is the number of files kept, including the current one. The
+	// size of the recording on disk is hence capped at MaxFileSize * MaxFiles.
+	MaxFiles int
+
+	// Namespaces and Revisions limit the recording to the revisions in the
+	// given namespaces and to the given revisions. All revisions are recorded
+	// if both are empty.
+	Namespaces []string
+	Revisions  []types.NamespacedName
+}
+
+// Recorder writes stats to a rotating file as length-delimited
+// WireStatMessages, one stat per message. The files of a recording can be
+// concatenated, oldest first, to replay the whole recording.
+type Recorder struct {
+	cfg        Config
+	namespaces sets.String
+	revisions  map[types.NamespacedName]struct{}
+	clock      clock.PassiveClock
+	logger     *zap.SugaredLogger
+
+	// mux guards the fields below.
+	mux sync.Mutex
+	// file is nil if it failed to be reopened, in which case it is reopened
+	// on the next write.
+	file    *os.File
+	size    int64
+	buf     bytes.Buffer
+	lastErr error
+	closed  bool
+}
+
+var _ metrics.StatRecorder = (*Recorder)(nil)
+
+// New creates a Recorder, appending to the file at cfg.Path if it exists.
+func New(cfg Config, logger *zap.SugaredLogger) (*Recorder, error) {
+	if cfg.Path == "" {
+		return nil, errors.New("path must be set")
+	}
+	if cfg.MaxFileSize <= 0 {
+		return nil, fmt.Errorf("max file size must be positive, was %d", cfg.MaxFileSize)
+	}
+	if cfg.MaxFiles < 1 {
+		return nil, fmt.Errorf("max files must be at least 1, was %d", cfg.MaxFiles)
+	}
+
+	r := &Recorder{
+		cfg:        cfg,
+		namespaces: sets.NewString(cfg.Namespaces...),
+		revisions:  make(map[types.NamespacedName]struct{}, len(cfg.Revisions)),
+		clock:      clock.RealClock{},
+		logger:     logger.Named("stat-recorder").With("path", cfg.Path),
+	}
+	for _, rev := range cfg.Revisions {
+		r.revisions[rev] = struct{}{}
+	}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Record writes sm to the recording, if its revision passes the filters. A
+// stat without a timestamp is recorded with the current time.
+func (r *Recorder) Record(sm metrics.StatMessage) {
+	if !r.matches(sm.Key) {
+		return
+	}
+	if sm.Stat.Timestamp == 0 {
+		sm.Stat.Timestamp = r.clock.Now().Unix()
+	}
+
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	if r.closed {
+		return
+	}
+
+	r.buf.Reset()
+	wsms := metrics.WireStatMessages{Messages: []*metrics.WireStatMessage{sm.ToWireStatMessage()}}
+	if err := metrics.WriteWireStatMessages(&r.buf, &wsms); err != nil {
+		r.reportError(err)
+		return
+	}
+
+	var err error
+	if r.file != nil && r.size > 0 && r.size+int64(r.buf.Len()) > r.cfg.MaxFileSize {
+		err = r.rotate()
+	}
+	if r.file == nil {
+		if err := r.open(); err != nil {
+			// The stat is dropped, the file is reopened on the next write.
+			r.reportError(err)
+			return
+		}
+	}
+	n, werr := r.file.Write(r.buf.Bytes())
+	r.size += int64(n)
+	if werr != nil {
+		err = werr
+	}
+	r.reportError(err)
+}
+
+// Close closes the recording. Stats recorded afterwards are dropped.
+func (r *Recorder) Close() error {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+func (r *Recorder) matches(key types.NamespacedName) bool {
+	if r.namespaces.Len() == 0 && len(r.revisions) == 0 {
+		return true
+	}
+	_, ok := r.revisions[key]
+	return ok || r.namespaces.Has(key.Namespace)
+}
+
+// reportError logs err, unless it is the same as the previous error, to not
+// log on every stat while e.g. the disk is full.
+func (r *Recorder) reportError(err error) {
+	if err != nil && (r.lastErr == nil || r.lastErr.Error() != err.Error()) {
+		r.logger.Errorw("Failed to record stat", zap.Error(err))
+	}
+	r.lastErr = err
+}
+
+func (r *Recorder) open() error {
+	f, err := os.OpenFile(r.cfg.Path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open recording: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("failed to stat recording: %w", err)
+	}
+	r.file, r.size = f, info.Size()
+	return nil
+}
+
+// rotate shifts the current and rotated files by one, dropping the oldest,
+// and opens a new current file. The current file is reopened even if shifting
+// fails, so the recording continues, if need be by growing the current file.
+// If reopening fails, the file is left nil.
+func (r *Recorder) rotate() error {
+	r.file.Close()
+	r.file = nil
+	shiftErr := r.shift()
+	if err := r.open(); err != nil {
+		return err
+	}
+	return shiftErr
+}
+
+func (r *Recorder) shift() error {
+	rotated := func(i int) string {
+		if i == 0 {
+			return r.cfg.Path
+		}
+		return r.cfg.Path + "." + strconv.Itoa(i)
+	}
+	if err := os.Remove(rotated(r.cfg.MaxFiles - 1)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove oldest recording: %w", err)
+	}
+	for i := r.cfg.MaxFiles - 2; i >= 0; i-- {
+		if err := os.Rename(rotated(i), rotated(i+1)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to rotate recording: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,261 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statrecorder
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/types"
+	clocktest "k8s.io/utils/clock/testing"
+
+	. "knative.dev/pkg/logging/testing"
+	"knative.dev/serving/pkg/autoscaler/metrics"
+)
+
+var (
+	rev1 = types.NamespacedName{Namespace: "ns-1", Name: "rev-1"}
+	rev2 = types.NamespacedName{Namespace: "ns-1", Name: "rev-2"}
+	rev3 = types.NamespacedName{Namespace: "ns-2", Name: "rev-3"}
+)
+
+func readRecording(t *testing.T, path string) []metrics.StatMessage {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal("Failed to open recording:", err)
+	}
+	defer f.Close()
+
+	var ret []metrics.StatMessage
+	r := metrics.NewWireStatMessagesReader(f)
+	for {
+		wsms, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return ret
+		} else if err != nil {
+			t.Fatal("Failed to read recording:", err)
+		}
+		for _, wsm := range wsms.Messages {
+			ret = append(ret, wsm.ToStatMessage())
+		}
+	}
+}
+
+func TestRecorderFilters(t *testing.T) {
+	now := time.Unix(1000, 0)
+	sms := []metrics.StatMessage{
+		{Key: rev1, Stat: metrics.Stat{PodName: "a", RequestCount: 1, Timestamp: 900}},
+		{Key: rev2, Stat: metrics.Stat{PodName: "b", RequestCount: 2}},
+		{Key: rev3, Stat: metrics.Stat{PodName: "c", RequestCount: 3}},
+	}
+
+	tests := []struct {
+		name       string
+		namespaces []string
+		revisions  []types.NamespacedName
+		want       []string
+	}{{
+		name: "everything",
+		want: []string{"a", "b", "c"},
+	}, {
+		name:       "namespace",
+		namespaces: []string{"ns-1"},
+		want:       []string{"a", "b"},
+	}, {
+		name:      "revision",
+		revisions: []types.NamespacedName{rev2},
+		want:      []string{"b"},
+	}, {
+		name:       "namespace and revision",
+		namespaces: []string{"ns-2"},
+		revisions:  []types.NamespacedName{rev1},
+		want:       []string{"a", "c"},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "stats.rec")
+			r, err := New(Config{
+				Path:        path,
+				MaxFileSize: 1 << 20,
+				MaxFiles:    1,
+				Namespaces:  test.namespaces,
+				Revisions:   test.revisions,
+			}, TestLogger(t))
+			if err != nil {
+				t.Fatal("New() =", err)
+			}
+			r.clock = clocktest.NewFakePassiveClock(now)
+			for _, sm := range sms {
+				r.Record(sm)
+			}
+			if err := r.Close(); err != nil {
+				t.Fatal("Close() =", err)
+			}
+
+			var got []string
+			for _, sm := range readRecording(t, path) {
+				got = append(got, sm.Stat.PodName)
+				want := sm.Stat.Timestamp
+				if sm.Key == rev1 {
+					if want != 900 {
+						t.Errorf("Timestamp of %s = %d, want the recorded one", sm.Stat.PodName, want)
+					}
+				} else if want != now.Unix() {
+					t.Errorf("Timestamp of %s = %d, want: %d", sm.Stat.PodName, want, now.Unix())
+				}
+			}
+			if !cmp.Equal(got, test.want) {
+				t.Error("Recorded pods (-want, +got):", cmp.Diff(test.want, got))
+			}
+		})
+	}
+}
+
+func TestRecorderRotation(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "stats.rec")
+
+	// Measure the size of a single record. All the records below have the
+	// same size, as the request count is never zero.
+	r, err := New(Config{Path: path, MaxFileSize: 1 << 20, MaxFiles: 1}, TestLogger(t))
+	if err != nil {
+		t.Fatal("New() =", err)
+	}
+	stat := func(i int) metrics.StatMessage {
+		return metrics.StatMessage{Key: rev1, Stat: metrics.Stat{RequestCount: float64(i), Timestamp: 1000}}
+	}
+	r.Record(stat(1))
+	r.Close()
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal("Stat() =", err)
+	}
+	recordSize := info.Size()
+	os.Remove(path)
+
+	// Three records fit in each file, and three files are kept.
+	r, err = New(Config{Path: path, MaxFileSize: 3 * recordSize, MaxFiles: 3}, TestLogger(t))
+	if err != nil {
+		t.Fatal("New() =", err)
+	}
+	for i := 1; i <= 11; i++ {
+		r.Record(stat(i))
+	}
+	r.Close()
+
+	for file, want := range map[string][]float64{
+		path:        {10, 11},
+		path + ".1": {7, 8, 9},
+		path + ".2": {4, 5, 6},
+	} {
+		var got []float64
+		for _, sm := range readRecording(t, file) {
+			got = append(got, sm.Stat.RequestCount)
+		}
+		if !cmp.Equal(got, want) {
+			t.Errorf("Stats in %s (-want, +got): %s", file, cmp.Diff(want, got))
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Stat(%s.3) = %v, want it to not exist", path, err)
+	}
+
+	// A new recorder appends to the current file, and rotates it once full.
+	r, err = New(Config{Path: path, MaxFileSize: 3 * recordSize, MaxFiles: 3}, TestLogger(t))
+	if err != nil {
+		t.Fatal("New() =", err)
+	}
+	r.Record(stat(12))
+	r.Record(stat(13))
+	r.Close()
+	// Records after Close are dropped.
+	r.Record(stat(14))
+
+	var got []float64
+	for _, sm := range readRecording(t, path) {
+		got = append(got, sm.Stat.RequestCount)
+	}
+	if want := []float64{13}; !cmp.Equal(got, want) {
+		t.Error("Stats after restart (-want, +got):", cmp.Diff(want, got))
+	}
+}
+
+func TestRecorderReopen(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "recording")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "stats.rec")
+	stat := func(i int) metrics.StatMessage {
+		return metrics.StatMessage{Key: rev1, Stat: metrics.Stat{RequestCount: float64(i), Timestamp: 1000}}
+	}
+
+	// Every record makes the file rotate.
+	r, err := New(Config{Path: path, MaxFileSize: 1, MaxFiles: 2}, TestLogger(t))
+	if err != nil {
+		t.Fatal("New() =", err)
+	}
+	defer r.Close()
+	r.Record(stat(1))
+
+	// The rotation fails to reopen the file without its directory, so the
+	// stat is dropped.
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatal(err)
+	}
+	r.Record(stat(2))
+	if r.file != nil {
+		t.Error("The recorder kept the closed file")
+	}
+
+	// The file is reopened once it can be.
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	r.Record(stat(3))
+
+	var got []float64
+	for _, sm := range readRecording(t, path) {
+		got = append(got, sm.Stat.RequestCount)
+	}
+	if want := []float64{3}; !cmp.Equal(got, want) {
+		t.Error("Stats after reopening (-want, +got):", cmp.Diff(want, got))
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	dir := t.TempDir()
+	for name, cfg := range map[string]Config{
+		"no path":          {MaxFileSize: 1, MaxFiles: 1},
+		"no max file size": {Path: filepath.Join(dir, "a"), MaxFiles: 1},
+		"no max files":     {Path: filepath.Join(dir, "a"), MaxFileSize: 1},
+		"missing dir":      {Path: filepath.Join(dir, "missing", "a"), MaxFileSize: 1, MaxFiles: 1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := New(cfg, TestLogger(t)); err == nil {
+				t.Error("New() succeeded, want an error")
+			}
+		})
+	}
+}
@@ -27,7 +27,12 @@ import (
 
 	"github.com/gorilla/websocket"
 	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 	netheader "knative.dev/networking/pkg/http/header"
+	"knative.dev/pkg/system"
+	"knative.dev/serving/pkg/apis/serving"
 	"knative.dev/serving/pkg/autoscaler/bucket"
 	"knative.dev/serving/pkg/autoscaler/metrics"
 )
@@ -48,11 +53,17 @@ type Server struct {
 	statsCh     chan<- metrics.StatMessage
 	openClients sync.WaitGroup
 	isBktOwner  func(bktName string) bool
+	mayPush     SenderFilter
 	logger      *zap.SugaredLogger
 }
 
+// SenderFilter decides whether the sender with the given IP may send the
+// given stat.
+type SenderFilter func(ip string, sm metrics.StatMessage) bool
+
 // New creates a Server which will receive autoscaler statistics and forward them to statsCh until Shutdown is called.
-func New(statsServerAddr string, statsCh chan<- metrics.StatMessage, logger *zap.SugaredLogger, isBktOwner func(bktName string) bool) *Server {
+// The stats mayPush rejects are dropped, if it is set.
+func New(statsServerAddr string, statsCh chan<- metrics.StatMessage, logger *zap.SugaredLogger, isBktOwner func(bktName string) bool, mayPush SenderFilter) *Server {
 	svr := Server{
 		addr:        statsServerAddr,
 		servingCh:   make(chan struct{}),
@@ -60,6 +71,7 @@ func New(statsServerAddr string, statsCh chan<- metrics.StatMessage, logger *zap
 		statsCh:     statsCh,
 		openClients: sync.WaitGroup{},
 		isBktOwner:  isBktOwner,
+		mayPush:     mayPush,
 		logger:      logger.Named("stats-websocket-server").With("address", statsServerAddr),
 	}
 
@@ -107,6 +119,39 @@ func (s *Server) serve(l net.Listener) error {
 	return nil
 }
 
+// PodSenderFilter returns a SenderFilter accepting the stats pushed by a
+// queue-proxy only from the pods of the revision they are for, or from an
+// autoscaler forwarding them to the owner of the bucket of the revision. The
+// stats sent by the activators are accepted from anywhere. The pods are
+// looked up by their IP, so the pushes are rejected where the autoscaler
+// doesn't see the IPs of its clients, e.g. behind a mesh sidecar, and the
+// revisions are scraped instead.
+func PodSenderFilter(revisionPods, autoscalerPods corev1listers.PodLister) SenderFilter {
+	return func(ip string, sm metrics.StatMessage) bool {
+		if !sm.Stat.PushedByQueueProxy() {
+			return true
+		}
+		return hasPodIP(revisionPods.Pods(sm.Key.Namespace),
+			labels.SelectorFromSet(labels.Set{serving.RevisionLabelKey: sm.Key.Name}), ip) ||
+			hasPodIP(autoscalerPods.Pods(system.Namespace()), labels.Everything(), ip)
+	}
+}
+
+// hasPodIP returns true if one of the pods matching the selector has the
+// given IP.
+func hasPodIP(lister corev1listers.PodNamespaceLister, selector labels.Selector, ip string) bool {
+	pods, err := lister.List(selector)
+	if err != nil {
+		return false
+	}
+	for _, p := range pods {
+		if p.Status.PodIP == ip {
+			return true
+		}
+	}
+	return false
+}
+
 func handleHealthz(w http.ResponseWriter, r *http.Request) bool {
 	if netheader.IsKubeletProbe(r) {
 		// As an initial approach, once stats server is up -- return true.
@@ -161,6 +206,12 @@ func (s *Server) Handler(w http.ResponseWriter, r *http.Request) {
 
 	s.logger.Debug("Connection upgraded to WebSocket. Entering receive loop.")
 
+	// The revisions the sender was found to be allowed to send the stats of,
+	// and whether it was found not to be, for the warning to be logged once.
+	sender, _, _ := net.SplitHostPort(r.RemoteAddr)
+	allowed := make(map[types.NamespacedName]bool)
+	var warned bool
+
 	for {
 		messageType, msg, err := conn.ReadMessage()
 		if err != nil {
@@ -191,6 +242,19 @@ func (s *Server) Handler(w http.ResponseWriter, r *http.Request) {
 
 				sm := wsm.ToStatMessage()
 				s.logger.Debugf("Received stat message: %+v", sm)
+				if s.mayPush != nil && !allowed[sm.Key] {
+					if !s.mayPush(sender, sm) {
+						if !warned {
+							s.logger.Warnw("Dropping the stats of a revision the sender is not a pod of",
+								zap.String("sender", sender), zap.String("revision", sm.Key.String()))
+							warned = true
+						}
+						continue
+					}
+					// Only the senders looked up are remembered, the stats of
+					// the activators are accepted without.
+					allowed[sm.Key] = sm.Stat.PushedByQueueProxy()
+				}
 				s.statsCh <- sm
 			}
 		default:
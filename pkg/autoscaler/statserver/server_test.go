@@ -31,9 +31,17 @@ import (
 	"golang.org/x/sync/errgroup"
 	nethttp "knative.dev/networking/pkg/http"
 	netheader "knative.dev/networking/pkg/http/header"
+	"knative.dev/pkg/system"
+	"knative.dev/serving/pkg/apis/serving"
 	"knative.dev/serving/pkg/autoscaler/metrics"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	_ "knative.dev/pkg/system/testing"
 )
 
 var (
@@ -199,6 +207,100 @@ func TestServerNotOwnerForBucketHost(t *testing.T) {
 	}
 }
 
+func TestServerSenderFilter(t *testing.T) {
+	statsCh := make(chan metrics.StatMessage)
+	server := newTestServer(statsCh)
+	server.mayPush = func(ip string, sm metrics.StatMessage) bool {
+		return ip == "127.0.0.1" && sm.Key.Name == msg1.Key.Name
+	}
+	defer server.Shutdown(0)
+
+	go server.listenAndServe()
+
+	// The stats of msg2 are dropped, so msg1 is received first.
+	statSink := dialOK(t, server.listenAddr())
+	if err := sendProto(statSink, []metrics.StatMessage{msg2, msg1}); err != nil {
+		t.Fatal("Expected send to succeed, got:", err)
+	}
+	if got := <-statsCh; !cmp.Equal(got, msg1) {
+		t.Error("StatMessage mismatch: diff (-got, +want)", cmp.Diff(got, msg1))
+	}
+	closeSink(t, statSink)
+}
+
+func TestPodSenderFilter(t *testing.T) {
+	revisionPods := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	autoscalerPods := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	pod := func(namespace, name, revision, ip string) *corev1.Pod {
+		p := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Status:     corev1.PodStatus{PodIP: ip},
+		}
+		if revision != "" {
+			p.Labels = map[string]string{serving.RevisionLabelKey: revision}
+		}
+		return p
+	}
+	revisionPods.Add(pod("test-namespace", "test-revision-pod", "test-revision", "10.0.0.1"))
+	revisionPods.Add(pod("test-namespace", "test-revision2-pod", "test-revision2", "10.0.0.2"))
+	autoscalerPods.Add(pod(system.Namespace(), "autoscaler", "", "10.0.0.9"))
+	autoscalerPods.Add(pod("test-namespace", "fake-autoscaler", "", "10.0.0.3"))
+
+	mayPush := PodSenderFilter(corev1listers.NewPodLister(revisionPods), corev1listers.NewPodLister(autoscalerPods))
+	pushed := func(namespace, name string) metrics.StatMessage {
+		return metrics.StatMessage{
+			Key:  types.NamespacedName{Namespace: namespace, Name: name},
+			Stat: metrics.Stat{PodName: "pod", AverageConcurrentRequests: 1, ProcessUptime: 10},
+		}
+	}
+
+	tests := []struct {
+		name string
+		ip   string
+		sm   metrics.StatMessage
+		want bool
+	}{{
+		name: "activator stat",
+		ip:   "10.0.0.42",
+		sm:   msg1,
+		want: true,
+	}, {
+		name: "pod of the revision",
+		ip:   "10.0.0.1",
+		sm:   pushed("test-namespace", "test-revision"),
+		want: true,
+	}, {
+		name: "pod of another revision",
+		ip:   "10.0.0.2",
+		sm:   pushed("test-namespace", "test-revision"),
+	}, {
+		name: "pod of a revision in another namespace",
+		ip:   "10.0.0.1",
+		sm:   pushed("other-namespace", "test-revision"),
+	}, {
+		name: "unknown pod",
+		ip:   "10.0.0.42",
+		sm:   pushed("test-namespace", "test-revision"),
+	}, {
+		name: "forwarded by an autoscaler",
+		ip:   "10.0.0.9",
+		sm:   pushed("test-namespace", "test-revision"),
+		want: true,
+	}, {
+		name: "autoscaler pod outside of the system namespace",
+		ip:   "10.0.0.3",
+		sm:   pushed("test-namespace", "test-revision"),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := mayPush(test.ip, test.sm); got != test.want {
+				t.Errorf("mayPush() = %v, want: %v", got, test.want)
+			}
+		})
+	}
+}
+
 func BenchmarkStatServer(b *testing.B) {
 	statsCh := make(chan metrics.StatMessage, 100)
 	server := newTestServer(statsCh)
@@ -307,7 +409,7 @@ func newTestServer(statsCh chan<- metrics.StatMessage) *testServer {
 
 func newTestServerWithOwnerFunc(statsCh chan<- metrics.StatMessage, f func(bkt string) bool) *testServer {
 	return &testServer{
-		Server:       New(testAddress, statsCh, zap.NewNop().Sugar(), f),
+		Server:       New(testAddress, statsCh, zap.NewNop().Sugar(), f, nil),
 		listenAddrCh: make(chan string, 1),
 	}
 }
@@ -17,8 +17,12 @@ limitations under the License.
 package deployment
 
 import (
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -45,16 +49,51 @@ const (
 	// ProgressDeadlineKey is the key to configure deployment progress deadline.
 	ProgressDeadlineKey = "progress-deadline"
 
+	// crashLoopRestartThresholdKey is the key to configure the number of
+	// restarts within crashLoopWindowKey after which a revision that never
+	// became ready is failed.
+	crashLoopRestartThresholdKey = "crash-loop-restart-threshold"
+
+	// crashLoopWindowKey is the key to configure the window the restarts of
+	// crashLoopRestartThresholdKey are counted in.
+	crashLoopWindowKey = "crash-loop-window"
+
+	// crashLoopWindowDefault is the default value of crashLoopWindowKey.
+	crashLoopWindowDefault = 5 * time.Minute
+
+	// terminalExitCodesKey is the config map key for the exit codes that fail
+	// a revision that never became ready when all of its pods exit with them.
+	terminalExitCodesKey = "terminal-exit-codes"
+
 	// digestResolutionTimeoutKey is the key to configure the digest resolution timeout.
 	digestResolutionTimeoutKey = "digest-resolution-timeout"
 
 	// digestResolutionTimeoutDefault is the default digest resolution timeout.
 	digestResolutionTimeoutDefault = 10 * time.Second
 
+	// digestResolutionCacheTTLKey is the key to configure how long the resolved
+	// digests are cached. The digests aren't cached by default.
+	digestResolutionCacheTTLKey = "digest-resolution-cache-ttl"
+
+	// digestResolutionCacheErrorTTLKey is the key to configure how long the
+	// authentication and authorization errors of the digest resolutions are
+	// cached. The errors aren't cached by default.
+	digestResolutionCacheErrorTTLKey = "digest-resolution-cache-error-ttl"
+
 	// registriesSkippingTagResolvingKey is the config map key for the set of registries
 	// (e.g. ko.local) where tags should not be resolved to digests.
 	registriesSkippingTagResolvingKey = "registries-skipping-tag-resolving"
 
+	// imageVerificationPolicyKey is the config map key for the evidence the
+	// resolved image digests are verified with. It can be overridden for a
+	// namespace with a key suffixed with "." and the namespace name.
+	imageVerificationPolicyKey = "image-verification-policy"
+
+	// imageVerificationPublicKeysKey is the config map key for the PEM-encoded
+	// public keys the image signatures or attestations are verified with. It can
+	// be overridden for a namespace like imageVerificationPolicyKey.
+	imageVerificationPublicKeysKey = "image-verification-public-keys"
+
 	// queueSidecar resource request keys.
 	queueSidecarCPURequestKey              = "queue-sidecar-cpu-request"
 	queueSidecarMemoryRequestKey           = "queue-sidecar-memory-request"
@@ -80,6 +119,7 @@ var (
 func defaultConfig() *Config {
 	cfg := &Config{
 		ProgressDeadline:               ProgressDeadlineDefault,
+		CrashLoopWindow:                crashLoopWindowDefault,
 		DigestResolutionTimeout:        digestResolutionTimeoutDefault,
 		RegistriesSkippingTagResolving: sets.NewString("kind.local", "ko.local", "dev.local"),
 		QueueSidecarCPURequest:         &QueueSidecarCPURequestDefault,
@@ -112,7 +152,12 @@ func NewConfigFromMap(configMap map[string]string) (*Config, error) {
 
 		cm.AsString(QueueSidecarImageKey, &nc.QueueSidecarImage),
 		cm.AsDuration(ProgressDeadlineKey, &nc.ProgressDeadline),
+		cm.AsInt32(crashLoopRestartThresholdKey, &nc.CrashLoopRestartThreshold),
+		cm.AsDuration(crashLoopWindowKey, &nc.CrashLoopWindow),
+		asExitCodes(terminalExitCodesKey, &nc.TerminalExitCodes),
 		cm.AsDuration(digestResolutionTimeoutKey, &nc.DigestResolutionTimeout),
+		cm.AsDuration(digestResolutionCacheTTLKey, &nc.DigestResolutionCacheTTL),
+		cm.AsDuration(digestResolutionCacheErrorTTLKey, &nc.DigestResolutionCacheErrorTTL),
 		cm.AsStringSet(registriesSkippingTagResolvingKey, &nc.RegistriesSkippingTagResolving),
 
 		cm.AsQuantity(queueSidecarCPURequestKey, &nc.QueueSidecarCPURequest),
@@ -140,13 +185,158 @@ func NewConfigFromMap(configMap map[string]string) (*Config, error) {
 		return nil, fmt.Errorf("progress-deadline must be rounded to a whole second, was: %v", nc.ProgressDeadline)
 	}
 
+	if nc.CrashLoopRestartThreshold < 0 {
+		return nil, fmt.Errorf("%s cannot be negative, was %d", crashLoopRestartThresholdKey, nc.CrashLoopRestartThreshold)
+	}
+
+	if nc.CrashLoopWindow <= 0 {
+		return nil, fmt.Errorf("%s cannot be a non-positive duration, was %v", crashLoopWindowKey, nc.CrashLoopWindow)
+	}
+
 	if nc.DigestResolutionTimeout <= 0 {
 		return nil, fmt.Errorf("digest-resolution-timeout cannot be a non-positive duration, was %v", nc.DigestResolutionTimeout)
 	}
 
+	if nc.DigestResolutionCacheTTL < 0 {
+		return nil, fmt.Errorf("digest-resolution-cache-ttl cannot be a negative duration, was %v", nc.DigestResolutionCacheTTL)
+	}
+
+	if nc.DigestResolutionCacheErrorTTL < 0 {
+		return nil, fmt.Errorf("digest-resolution-cache-error-ttl cannot be a negative duration, was %v", nc.DigestResolutionCacheErrorTTL)
+	}
+
+	if err := parseImageVerification(configMap, nc); err != nil {
+		return nil, err
+	}
+
+	patches, err := parseDeploymentPatches(configMap[deploymentPatchesKey])
+	if err != nil {
+		return nil, err
+	}
+	nc.DeploymentPatches = patches
+
 	return nc, nil
 }
 
+// asExitCodes parses the comma separated exit codes at the key, each between 1
+// and 255, into the target set. An empty value leaves the target unset.
+func asExitCodes(key string, target *sets.Int32) cm.ParseFunc {
+	return func(data map[string]string) error {
+		raw, ok := data[key]
+		if !ok || strings.TrimSpace(raw) == "" {
+			return nil
+		}
+		codes := sets.NewInt32()
+		for _, v := range strings.Split(raw, ",") {
+			code, err := strconv.ParseInt(strings.TrimSpace(v), 10, 32)
+			if err != nil {
+				return fmt.Errorf("failed to parse %q: %w", key, err)
+			}
+			if code < 1 || code > 255 {
+				return fmt.Errorf("%s must be between 1 and 255, was %d", key, code)
+			}
+			codes.Insert(int32(code))
+		}
+		*target = codes
+		return nil
+	}
+}
+
+// parseImageVerification parses the image verification policy and its
+// namespace overrides into the config.
+func parseImageVerification(configMap map[string]string, nc *Config) error {
+	policy, err := imageVerificationPolicy(configMap[imageVerificationPolicyKey], configMap[imageVerificationPublicKeysKey])
+	if err != nil {
+		return err
+	}
+	nc.ImageVerification = policy
+
+	namespaces := sets.NewString()
+	for k := range configMap {
+		for _, prefix := range []string{imageVerificationPolicyKey + ".", imageVerificationPublicKeysKey + "."} {
+			if ns := strings.TrimPrefix(k, prefix); ns != k && ns != "" {
+				namespaces.Insert(ns)
+			}
+		}
+	}
+	for _, ns := range namespaces.List() {
+		// The keys that aren't overridden are inherited from the cluster-wide policy.
+		mode, ok := configMap[imageVerificationPolicyKey+"."+ns]
+		if !ok {
+			mode = configMap[imageVerificationPolicyKey]
+		}
+		keys, ok := configMap[imageVerificationPublicKeysKey+"."+ns]
+		if !ok {
+			keys = configMap[imageVerificationPublicKeysKey]
+		}
+		policy, err := imageVerificationPolicy(mode, keys)
+		if err != nil {
+			return fmt.Errorf("namespace %q: %w", ns, err)
+		}
+		if nc.NamespaceImageVerification == nil {
+			nc.NamespaceImageVerification = make(map[string]*ImageVerificationPolicy, namespaces.Len())
+		}
+		nc.NamespaceImageVerification[ns] = policy
+	}
+	return nil
+}
+
+// imageVerificationPolicy returns the image verification policy with the given
+// mode and PEM-encoded public keys, or nil if the images aren't verified.
+func imageVerificationPolicy(mode, keys string) (*ImageVerificationPolicy, error) {
+	switch m := ImageVerificationMode(strings.TrimSpace(mode)); m {
+	case "", ImageVerificationNone:
+		return nil, nil
+	case ImageVerificationSignature, ImageVerificationAttestation:
+		publicKeys, err := ParsePublicKeys([]byte(keys))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", imageVerificationPublicKeysKey, err)
+		}
+		if len(publicKeys) == 0 {
+			return nil, fmt.Errorf("%s requires at least one public key in %s", imageVerificationPolicyKey, imageVerificationPublicKeysKey)
+		}
+		pems := make([]string, 0, len(publicKeys))
+		for _, block := range splitPEM([]byte(keys)) {
+			pems = append(pems, string(pem.EncodeToMemory(block)))
+		}
+		return &ImageVerificationPolicy{Mode: m, PublicKeys: pems}, nil
+	default:
+		return nil, fmt.Errorf("%s must be one of %q, %q or %q, was %q", imageVerificationPolicyKey,
+			ImageVerificationNone, ImageVerificationSignature, ImageVerificationAttestation, mode)
+	}
+}
+
+// ParsePublicKeys parses the PEM-encoded PKIX public keys.
+func ParsePublicKeys(data []byte) ([]interface{}, error) {
+	blocks := splitPEM(data)
+	if rest := strings.TrimSpace(string(data)); len(blocks) == 0 && rest != "" {
+		return nil, errors.New("no PEM-encoded public key found")
+	}
+	keys := make([]interface{}, 0, len(blocks))
+	for _, block := range blocks {
+		if block.Type != "PUBLIC KEY" {
+			return nil, fmt.Errorf("unexpected PEM block type %q, want: \"PUBLIC KEY\"", block.Type)
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func splitPEM(data []byte) []*pem.Block {
+	var blocks []*pem.Block
+	for {
+		var block *pem.Block
+		if block, data = pem.Decode(data); block == nil {
+			return blocks
+		}
+		blocks = append(blocks, block)
+	}
+}
+
 // NewConfigFromConfigMap creates a DeploymentConfig from the supplied configMap.
 func NewConfigFromConfigMap(config *corev1.ConfigMap) (*Config, error) {
 	return NewConfigFromMap(config.Data)
@@ -164,10 +354,33 @@ type Config struct {
 	// DigestResolutionTimeout is the maximum time allowed for image digest resolution.
 	DigestResolutionTimeout time.Duration
 
+	// DigestResolutionCacheTTL is how long the resolved image digests are
+	// reused by the revisions with the same image and pull credentials.
+	// Zero disables the caching.
+	DigestResolutionCacheTTL time.Duration
+
+	// DigestResolutionCacheErrorTTL is how long the authentication and
+	// authorization errors of the digest resolutions are reused. Zero disables
+	// the caching.
+	DigestResolutionCacheErrorTTL time.Duration
+
 	// ProgressDeadline is the time in seconds we wait for the deployment to
 	// be ready before considering it failed.
 	ProgressDeadline time.Duration
 
+	// CrashLoopRestartThreshold is the number of times a container of a
+	// revision that never became ready can restart within CrashLoopWindow
+	// before the revision is failed. Zero disables the check.
+	CrashLoopRestartThreshold int32
+
+	// CrashLoopWindow is the window the restarts of CrashLoopRestartThreshold
+	// are counted in.
+	CrashLoopWindow time.Duration
+
+	// TerminalExitCodes are the exit codes that fail a revision that never
+	// became ready once a container of each of its pods exited with one.
+	TerminalExitCodes sets.Int32
+
 	// QueueSidecarCPURequest is the CPU Request to set for the queue proxy sidecar container.
 	QueueSidecarCPURequest *resource.Quantity
 
@@ -194,4 +407,51 @@ type Config struct {
 
 	// QueueSidecarRootCA is a root certificate to be trusted by the queue proxy sidecar  qpoptions.
 	QueueSidecarRootCA string
+
+	// ImageVerification is the policy the resolved image digests are verified
+	// with, or nil if they aren't verified.
+	ImageVerification *ImageVerificationPolicy
+
+	// NamespaceImageVerification overrides ImageVerification for the
+	// namespaces it has keys for. A nil policy disables the verification.
+	NamespaceImageVerification map[string]*ImageVerificationPolicy
+
+	// DeploymentPatches are the patches applied, in order, to the Deployments
+	// of the revisions they select.
+	DeploymentPatches []DeploymentPatch
+}
+
+// ImageVerificationFor returns the policy the image digests of the revisions
+// in the namespace are verified with, or nil if they aren't verified.
+func (c *Config) ImageVerificationFor(namespace string) *ImageVerificationPolicy {
+	if policy, ok := c.NamespaceImageVerification[namespace]; ok {
+		return policy
+	}
+	return c.ImageVerification
+}
+
+// ImageVerificationMode is the kind of evidence an image digest is verified
+// with.
+type ImageVerificationMode string
+
+const (
+	// ImageVerificationNone doesn't verify the images.
+	ImageVerificationNone ImageVerificationMode = "none"
+
+	// ImageVerificationSignature requires a cosign signature of the image.
+	ImageVerificationSignature ImageVerificationMode = "signature"
+
+	// ImageVerificationAttestation requires a cosign in-toto attestation of
+	// the image, such as its provenance.
+	ImageVerificationAttestation ImageVerificationMode = "attestation"
+)
+
+// ImageVerificationPolicy is the policy image digests are verified with.
+type ImageVerificationPolicy struct {
+	// Mode is the kind of evidence the images need.
+	Mode ImageVerificationMode
+
+	// PublicKeys are the PEM-encoded public keys, one of which must have
+	// signed the evidence.
+	PublicKeys []string
 }
@@ -17,6 +17,12 @@ limitations under the License.
 package deployment
 
 import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
 	"testing"
 	"time"
 
@@ -89,6 +95,7 @@ func TestControllerConfiguration(t *testing.T) {
 			QueueSidecarCPURequest:         &QueueSidecarCPURequestDefault,
 			QueueSidecarTokenAudiences:     sets.NewString("foo", "bar", "boo-srv"),
 			ProgressDeadline:               ProgressDeadlineDefault,
+			CrashLoopWindow:                crashLoopWindowDefault,
 		},
 		data: map[string]string{
 			QueueSidecarImageKey:              defaultSidecarImage,
@@ -104,6 +111,7 @@ func TestControllerConfiguration(t *testing.T) {
 			QueueSidecarCPURequest:         &QueueSidecarCPURequestDefault,
 			QueueSidecarTokenAudiences:     sets.NewString(""),
 			ProgressDeadline:               444 * time.Second,
+			CrashLoopWindow:                crashLoopWindowDefault,
 		},
 		data: map[string]string{
 			QueueSidecarImageKey: defaultSidecarImage,
@@ -118,11 +126,91 @@ func TestControllerConfiguration(t *testing.T) {
 			QueueSidecarCPURequest:         &QueueSidecarCPURequestDefault,
 			QueueSidecarTokenAudiences:     sets.NewString(""),
 			ProgressDeadline:               ProgressDeadlineDefault,
+			CrashLoopWindow:                crashLoopWindowDefault,
 		},
 		data: map[string]string{
 			QueueSidecarImageKey:       defaultSidecarImage,
 			digestResolutionTimeoutKey: "60s",
 		},
+	}, {
+		name: "controller configuration with digest resolution cache",
+		wantConfig: &Config{
+			RegistriesSkippingTagResolving: sets.NewString("kind.local", "ko.local", "dev.local"),
+			DigestResolutionTimeout:        digestResolutionTimeoutDefault,
+			DigestResolutionCacheTTL:       5 * time.Minute,
+			DigestResolutionCacheErrorTTL:  30 * time.Second,
+			QueueSidecarImage:              defaultSidecarImage,
+			QueueSidecarCPURequest:         &QueueSidecarCPURequestDefault,
+			QueueSidecarTokenAudiences:     sets.NewString(""),
+			ProgressDeadline:               ProgressDeadlineDefault,
+			CrashLoopWindow:                crashLoopWindowDefault,
+		},
+		data: map[string]string{
+			QueueSidecarImageKey:             defaultSidecarImage,
+			digestResolutionCacheTTLKey:      "5m",
+			digestResolutionCacheErrorTTLKey: "30s",
+		},
+	}, {
+		name:    "controller configuration invalid digest resolution cache TTL",
+		wantErr: true,
+		data: map[string]string{
+			QueueSidecarImageKey:        defaultSidecarImage,
+			digestResolutionCacheTTLKey: "-1m",
+		},
+	}, {
+		name:    "controller configuration invalid digest resolution cache error TTL",
+		wantErr: true,
+		data: map[string]string{
+			QueueSidecarImageKey:             defaultSidecarImage,
+			digestResolutionCacheErrorTTLKey: "-1s",
+		},
+	}, {
+		name: "controller configuration with crash-loop detection",
+		wantConfig: &Config{
+			RegistriesSkippingTagResolving: sets.NewString("kind.local", "ko.local", "dev.local"),
+			DigestResolutionTimeout:        digestResolutionTimeoutDefault,
+			QueueSidecarImage:              defaultSidecarImage,
+			QueueSidecarCPURequest:         &QueueSidecarCPURequestDefault,
+			QueueSidecarTokenAudiences:     sets.NewString(""),
+			ProgressDeadline:               ProgressDeadlineDefault,
+			CrashLoopRestartThreshold:      3,
+			CrashLoopWindow:                2 * time.Minute,
+			TerminalExitCodes:              sets.NewInt32(126, 127),
+		},
+		data: map[string]string{
+			QueueSidecarImageKey:         defaultSidecarImage,
+			crashLoopRestartThresholdKey: "3",
+			crashLoopWindowKey:           "2m",
+			terminalExitCodesKey:         "127, 126",
+		},
+	}, {
+		name:    "controller configuration negative crash-loop restart threshold",
+		wantErr: true,
+		data: map[string]string{
+			QueueSidecarImageKey:         defaultSidecarImage,
+			crashLoopRestartThresholdKey: "-1",
+		},
+	}, {
+		name:    "controller configuration invalid crash-loop window",
+		wantErr: true,
+		data: map[string]string{
+			QueueSidecarImageKey: defaultSidecarImage,
+			crashLoopWindowKey:   "0s",
+		},
+	}, {
+		name:    "controller configuration invalid terminal exit code",
+		wantErr: true,
+		data: map[string]string{
+			QueueSidecarImageKey: defaultSidecarImage,
+			terminalExitCodesKey: "1,oops",
+		},
+	}, {
+		name:    "controller configuration out of range terminal exit code",
+		wantErr: true,
+		data: map[string]string{
+			QueueSidecarImageKey: defaultSidecarImage,
+			terminalExitCodesKey: "0",
+		},
 	}, {
 		name: "controller configuration with registries",
 		wantConfig: &Config{
@@ -132,6 +220,7 @@ func TestControllerConfiguration(t *testing.T) {
 			QueueSidecarCPURequest:         &QueueSidecarCPURequestDefault,
 			QueueSidecarTokenAudiences:     sets.NewString(""),
 			ProgressDeadline:               ProgressDeadlineDefault,
+			CrashLoopWindow:                crashLoopWindowDefault,
 		},
 		data: map[string]string{
 			QueueSidecarImageKey:              defaultSidecarImage,
@@ -144,6 +233,7 @@ func TestControllerConfiguration(t *testing.T) {
 			DigestResolutionTimeout:             digestResolutionTimeoutDefault,
 			QueueSidecarImage:                   defaultSidecarImage,
 			ProgressDeadline:                    ProgressDeadlineDefault,
+			CrashLoopWindow:                     crashLoopWindowDefault,
 			QueueSidecarCPURequest:              quantity("123m"),
 			QueueSidecarMemoryRequest:           quantity("456M"),
 			QueueSidecarEphemeralStorageRequest: quantity("789m"),
@@ -218,6 +308,7 @@ func TestControllerConfiguration(t *testing.T) {
 		wantConfig: &Config{
 			QueueSidecarImage:                   "1",
 			ProgressDeadline:                    2 * time.Second,
+			CrashLoopWindow:                     crashLoopWindowDefault,
 			DigestResolutionTimeout:             3 * time.Second,
 			RegistriesSkippingTagResolving:      sets.NewString("4"),
 			QueueSidecarCPURequest:              quantity("5m"),
@@ -259,6 +350,7 @@ func TestControllerConfiguration(t *testing.T) {
 		wantConfig: &Config{
 			QueueSidecarImage:                   "12",
 			ProgressDeadline:                    13 * time.Second,
+			CrashLoopWindow:                     crashLoopWindowDefault,
 			DigestResolutionTimeout:             14 * time.Second,
 			RegistriesSkippingTagResolving:      sets.NewString("15"),
 			QueueSidecarCPURequest:              quantity("16m"),
@@ -300,6 +392,161 @@ func TestControllerConfiguration(t *testing.T) {
 	}
 }
 
+func TestImageVerificationConfiguration(t *testing.T) {
+	ecKey := publicKeyPEM(t, func() interface{} {
+		k, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal("GenerateKey() =", err)
+		}
+		return &k.PublicKey
+	}())
+	edKey := publicKeyPEM(t, func() interface{} {
+		k, _, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal("GenerateKey() =", err)
+		}
+		return k
+	}())
+
+	tests := []struct {
+		name          string
+		data          map[string]string
+		wantErr       bool
+		wantDefault   *ImageVerificationPolicy
+		wantNamespace map[string]*ImageVerificationPolicy
+	}{{
+		name: "unset",
+		data: map[string]string{},
+	}, {
+		name: "none",
+		data: map[string]string{
+			imageVerificationPolicyKey: "none",
+		},
+	}, {
+		name: "signature",
+		data: map[string]string{
+			imageVerificationPolicyKey:     "signature",
+			imageVerificationPublicKeysKey: ecKey + edKey,
+		},
+		wantDefault: &ImageVerificationPolicy{
+			Mode:       ImageVerificationSignature,
+			PublicKeys: []string{ecKey, edKey},
+		},
+	}, {
+		name: "namespace overrides",
+		data: map[string]string{
+			imageVerificationPolicyKey:                  "signature",
+			imageVerificationPublicKeysKey:              ecKey,
+			imageVerificationPolicyKey + ".payments":    "attestation",
+			imageVerificationPublicKeysKey + ".builds":  edKey,
+			imageVerificationPolicyKey + ".sandbox":     "none",
+			imageVerificationPublicKeysKey + ".sandbox": "",
+		},
+		wantDefault: &ImageVerificationPolicy{
+			Mode:       ImageVerificationSignature,
+			PublicKeys: []string{ecKey},
+		},
+		wantNamespace: map[string]*ImageVerificationPolicy{
+			"payments": {
+				Mode:       ImageVerificationAttestation,
+				PublicKeys: []string{ecKey},
+			},
+			"builds": {
+				Mode:       ImageVerificationSignature,
+				PublicKeys: []string{edKey},
+			},
+			"sandbox": nil,
+		},
+	}, {
+		name: "namespace enabling the verification",
+		data: map[string]string{
+			imageVerificationPolicyKey + ".payments":     "signature",
+			imageVerificationPublicKeysKey + ".payments": ecKey,
+		},
+		wantNamespace: map[string]*ImageVerificationPolicy{
+			"payments": {
+				Mode:       ImageVerificationSignature,
+				PublicKeys: []string{ecKey},
+			},
+		},
+	}, {
+		name: "invalid policy",
+		data: map[string]string{
+			imageVerificationPolicyKey:     "sbom",
+			imageVerificationPublicKeysKey: ecKey,
+		},
+		wantErr: true,
+	}, {
+		name: "no public keys",
+		data: map[string]string{
+			imageVerificationPolicyKey: "signature",
+		},
+		wantErr: true,
+	}, {
+		name: "invalid public keys",
+		data: map[string]string{
+			imageVerificationPolicyKey:     "signature",
+			imageVerificationPublicKeysKey: "not a key",
+		},
+		wantErr: true,
+	}, {
+		name: "namespace without public keys",
+		data: map[string]string{
+			imageVerificationPolicyKey + ".payments": "attestation",
+		},
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.data[QueueSidecarImageKey] = defaultSidecarImage
+			got, err := NewConfigFromMap(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewConfigFromMap() error = %v, want: %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !cmp.Equal(got.ImageVerification, tt.wantDefault) {
+				t.Error("ImageVerification (-want, +got):", cmp.Diff(tt.wantDefault, got.ImageVerification))
+			}
+			if !cmp.Equal(got.NamespaceImageVerification, tt.wantNamespace) {
+				t.Error("NamespaceImageVerification (-want, +got):", cmp.Diff(tt.wantNamespace, got.NamespaceImageVerification))
+			}
+		})
+	}
+}
+
+func TestImageVerificationFor(t *testing.T) {
+	cluster := &ImageVerificationPolicy{Mode: ImageVerificationSignature}
+	payments := &ImageVerificationPolicy{Mode: ImageVerificationAttestation}
+	cfg := &Config{
+		ImageVerification: cluster,
+		NamespaceImageVerification: map[string]*ImageVerificationPolicy{
+			"payments": payments,
+			"sandbox":  nil,
+		},
+	}
+	for ns, want := range map[string]*ImageVerificationPolicy{
+		"default":  cluster,
+		"payments": payments,
+		"sandbox":  nil,
+	} {
+		if got := cfg.ImageVerificationFor(ns); got != want {
+			t.Errorf("ImageVerificationFor(%q) = %v, want: %v", ns, got, want)
+		}
+	}
+}
+
+func publicKeyPEM(t *testing.T, key interface{}) string {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		t.Fatal("MarshalPKIXPublicKey() =", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
 func quantity(val string) *resource.Quantity {
 	r := resource.MustParse(val)
 	return &r
@@ -0,0 +1,181 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployment
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"sigs.k8s.io/yaml"
+)
+
+// deploymentPatchesKey is the config map key for the patches applied to the
+// Deployments of the revisions, as a YAML list of DeploymentPatches.
+const deploymentPatchesKey = "deployment-patches"
+
+// DeploymentPatchType is the kind of patch a DeploymentPatch holds.
+type DeploymentPatchType string
+
+const (
+	// DeploymentPatchStrategic is a strategic merge patch, the default.
+	DeploymentPatchStrategic DeploymentPatchType = "strategic"
+
+	// DeploymentPatchJSON is a JSON patch (RFC 6902).
+	DeploymentPatchJSON DeploymentPatchType = "json"
+)
+
+// DeploymentPatch is a patch applied to the Deployments of the revisions it
+// selects, after they are built, to set the fields the revisions can't.
+type DeploymentPatch struct {
+	// Namespaces are the namespaces of the revisions the patch applies to.
+	// Empty selects every namespace.
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Selector selects the revisions the patch applies to by their labels.
+	// Nil selects every revision.
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// Type is the kind of the patch.
+	Type DeploymentPatchType `json:"type,omitempty"`
+
+	// Patch is the JSON-encoded patch.
+	Patch []byte `json:"-"`
+}
+
+// Matches returns true if the patch applies to the revisions in the namespace
+// with the labels.
+func (p *DeploymentPatch) Matches(namespace string, lbls map[string]string) bool {
+	if len(p.Namespaces) > 0 && !sets.NewString(p.Namespaces...).Has(namespace) {
+		return false
+	}
+	if p.Selector == nil {
+		return true
+	}
+	// The selector was validated when the config was loaded.
+	selector, err := metav1.LabelSelectorAsSelector(p.Selector)
+	return err == nil && selector.Matches(labels.Set(lbls))
+}
+
+// Apply returns the deployment with the patch applied. The deployment isn't
+// modified.
+func (p *DeploymentPatch) Apply(deployment *appsv1.Deployment) (*appsv1.Deployment, error) {
+	original, err := json.Marshal(deployment)
+	if err != nil {
+		return nil, err
+	}
+
+	var patched []byte
+	switch p.Type {
+	case DeploymentPatchJSON:
+		patch, err := jsonpatch.DecodePatch(p.Patch)
+		if err != nil {
+			return nil, err
+		}
+		if patched, err = patch.Apply(original); err != nil {
+			return nil, err
+		}
+	default:
+		if patched, err = strategicpatch.StrategicMergePatch(original, p.Patch, appsv1.Deployment{}); err != nil {
+			return nil, err
+		}
+	}
+
+	// Reject the fields a Deployment doesn't have, rather than dropping them.
+	decoder := json.NewDecoder(bytes.NewReader(patched))
+	decoder.DisallowUnknownFields()
+	out := &appsv1.Deployment{}
+	if err := decoder.Decode(out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PatchesFor returns the patches applying to the Deployments of the revisions
+// in the namespace with the labels, in the order they are applied.
+func (c *Config) PatchesFor(namespace string, lbls map[string]string) []DeploymentPatch {
+	var patches []DeploymentPatch
+	for i := range c.DeploymentPatches {
+		if c.DeploymentPatches[i].Matches(namespace, lbls) {
+			patches = append(patches, c.DeploymentPatches[i])
+		}
+	}
+	return patches
+}
+
+// parseDeploymentPatches parses and validates the patches of the config map
+// key. The strategic merge patches are checked to apply to a Deployment; the
+// JSON patches, which depend on the fields present, only to decode.
+func parseDeploymentPatches(data string) ([]DeploymentPatch, error) {
+	if data == "" {
+		return nil, nil
+	}
+
+	var raw []struct {
+		DeploymentPatch
+		Patch json.RawMessage `json:"patch"`
+	}
+	if err := yaml.UnmarshalStrict([]byte(data), &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", deploymentPatchesKey, err)
+	}
+
+	patches := make([]DeploymentPatch, 0, len(raw))
+	for i, r := range raw {
+		p := r.DeploymentPatch
+		p.Patch = r.Patch
+		if err := validateDeploymentPatch(&p); err != nil {
+			return nil, fmt.Errorf("invalid %s[%d]: %w", deploymentPatchesKey, i, err)
+		}
+		patches = append(patches, p)
+	}
+	return patches, nil
+}
+
+func validateDeploymentPatch(p *DeploymentPatch) error {
+	if len(p.Patch) == 0 || string(p.Patch) == "null" {
+		return errors.New("patch is required")
+	}
+	if p.Selector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(p.Selector); err != nil {
+			return fmt.Errorf("invalid selector: %w", err)
+		}
+	}
+
+	switch p.Type {
+	case "":
+		p.Type = DeploymentPatchStrategic
+		fallthrough
+	case DeploymentPatchStrategic:
+		if _, err := p.Apply(&appsv1.Deployment{}); err != nil {
+			return fmt.Errorf("invalid strategic merge patch: %w", err)
+		}
+	case DeploymentPatchJSON:
+		if _, err := jsonpatch.DecodePatch(p.Patch); err != nil {
+			return fmt.Errorf("invalid JSON patch: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown type %q, want %q or %q", p.Type, DeploymentPatchStrategic, DeploymentPatchJSON)
+	}
+	return nil
+}
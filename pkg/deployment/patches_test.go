@@ -0,0 +1,230 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployment
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDeploymentPatchesConfiguration(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+		want    []DeploymentPatch
+	}{{
+		name: "unset",
+	}, {
+		name: "strategic and JSON patches",
+		data: `
+- namespaces: [payments]
+  selector:
+    matchLabels:
+      serving.knative.dev/service: checkout
+  patch:
+    spec:
+      strategy:
+        rollingUpdate:
+          maxSurge: 50%
+- type: json
+  patch:
+  - op: add
+    path: /spec/template/metadata/labels/team
+    value: payments
+`,
+		want: []DeploymentPatch{{
+			Namespaces: []string{"payments"},
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"serving.knative.dev/service": "checkout"},
+			},
+			Type:  DeploymentPatchStrategic,
+			Patch: []byte(`{"spec":{"strategy":{"rollingUpdate":{"maxSurge":"50%"}}}}`),
+		}, {
+			Type:  DeploymentPatchJSON,
+			Patch: []byte(`[{"op":"add","path":"/spec/template/metadata/labels/team","value":"payments"}]`),
+		}},
+	}, {
+		name:    "not a list",
+		data:    "patch: {}",
+		wantErr: true,
+	}, {
+		name:    "unknown key",
+		data:    "- patch: {}\n  namespace: payments",
+		wantErr: true,
+	}, {
+		name:    "no patch",
+		data:    "- namespaces: [payments]",
+		wantErr: true,
+	}, {
+		name:    "unknown type",
+		data:    "- type: merge\n  patch: {}",
+		wantErr: true,
+	}, {
+		name:    "invalid selector",
+		data:    "- selector: {matchLabels: {'not a label': x}}\n  patch: {}",
+		wantErr: true,
+	}, {
+		name:    "strategic patch of an unknown field",
+		data:    "- patch: {spec: {replicaCount: 2}}",
+		wantErr: true,
+	}, {
+		name:    "strategic patch of the wrong type",
+		data:    "- patch: {spec: {paused: sure}}",
+		wantErr: true,
+	}, {
+		name:    "JSON patch that isn't a list",
+		data:    "- type: json\n  patch: {op: add}",
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewConfigFromMap(map[string]string{
+				QueueSidecarImageKey: defaultSidecarImage,
+				deploymentPatchesKey: tt.data,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewConfigFromMap() error = %v, want: %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !cmp.Equal(got.DeploymentPatches, tt.want) {
+				t.Error("DeploymentPatches (-want, +got):", cmp.Diff(tt.want, got.DeploymentPatches))
+			}
+		})
+	}
+}
+
+func TestPatchesFor(t *testing.T) {
+	all := DeploymentPatch{Patch: []byte("{}")}
+	payments := DeploymentPatch{Namespaces: []string{"payments", "billing"}, Patch: []byte("{}")}
+	critical := DeploymentPatch{
+		Selector: &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{{
+				Key:      "tier",
+				Operator: metav1.LabelSelectorOpIn,
+				Values:   []string{"critical"},
+			}},
+		},
+		Patch: []byte("{}"),
+	}
+	cfg := &Config{DeploymentPatches: []DeploymentPatch{all, payments, critical}}
+
+	tests := []struct {
+		name      string
+		namespace string
+		labels    map[string]string
+		want      []DeploymentPatch
+	}{{
+		name:      "no match but the cluster-wide patch",
+		namespace: "default",
+		want:      []DeploymentPatch{all},
+	}, {
+		name:      "namespace",
+		namespace: "billing",
+		labels:    map[string]string{"tier": "batch"},
+		want:      []DeploymentPatch{all, payments},
+	}, {
+		name:      "namespace and labels",
+		namespace: "payments",
+		labels:    map[string]string{"tier": "critical"},
+		want:      []DeploymentPatch{all, payments, critical},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cfg.PatchesFor(tt.namespace, tt.labels); !cmp.Equal(got, tt.want) {
+				t.Error("PatchesFor (-want, +got):", cmp.Diff(tt.want, got))
+			}
+		})
+	}
+}
+
+func TestDeploymentPatchApply(t *testing.T) {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "foo",
+			Labels: map[string]string{"app": "foo"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		patch   DeploymentPatch
+		want    *appsv1.Deployment
+		wantErr bool
+	}{{
+		name: "strategic",
+		patch: DeploymentPatch{
+			Type:  DeploymentPatchStrategic,
+			Patch: []byte(`{"metadata":{"labels":{"team":"payments"}},"spec":{"paused":true}}`),
+		},
+		want: &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "foo",
+				Labels: map[string]string{"app": "foo", "team": "payments"},
+			},
+			Spec: appsv1.DeploymentSpec{Paused: true},
+		},
+	}, {
+		name: "json",
+		patch: DeploymentPatch{
+			Type:  DeploymentPatchJSON,
+			Patch: []byte(`[{"op":"remove","path":"/metadata/labels/app"},{"op":"replace","path":"/metadata/name","value":"bar"}]`),
+		},
+		want: &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "bar",
+				Labels: map[string]string{},
+			},
+		},
+	}, {
+		name: "json of a missing field",
+		patch: DeploymentPatch{
+			Type:  DeploymentPatchJSON,
+			Patch: []byte(`[{"op":"replace","path":"/metadata/annotations/team","value":"payments"}]`),
+		},
+		wantErr: true,
+	}, {
+		name: "json adding an unknown field",
+		patch: DeploymentPatch{
+			Type:  DeploymentPatchJSON,
+			Patch: []byte(`[{"op":"add","path":"/spec/replicaCount","value":2}]`),
+		},
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.patch.Apply(deployment)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Apply() error = %v, want: %v", err, tt.wantErr)
+			}
+			if !cmp.Equal(got, tt.want) {
+				t.Error("Apply (-want, +got):", cmp.Diff(tt.want, got))
+			}
+			if deployment.Name != "foo" || len(deployment.Labels) != 1 {
+				t.Errorf("Apply() modified the deployment: %#v", deployment)
+			}
+		})
+	}
+}
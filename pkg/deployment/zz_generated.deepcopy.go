@@ -22,6 +22,7 @@ limitations under the License.
 package deployment
 
 import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	sets "k8s.io/apimachinery/pkg/util/sets"
 )
 
@@ -35,6 +36,13 @@ func (in *Config) DeepCopyInto(out *Config) {
 			(*out)[key] = val
 		}
 	}
+	if in.TerminalExitCodes != nil {
+		in, out := &in.TerminalExitCodes, &out.TerminalExitCodes
+		*out = make(sets.Int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.QueueSidecarCPURequest != nil {
 		in, out := &in.QueueSidecarCPURequest, &out.QueueSidecarCPURequest
 		x := (*in).DeepCopy()
@@ -72,6 +80,33 @@ func (in *Config) DeepCopyInto(out *Config) {
 			(*out)[key] = val
 		}
 	}
+	if in.ImageVerification != nil {
+		in, out := &in.ImageVerification, &out.ImageVerification
+		*out = new(ImageVerificationPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NamespaceImageVerification != nil {
+		in, out := &in.NamespaceImageVerification, &out.NamespaceImageVerification
+		*out = make(map[string]*ImageVerificationPolicy, len(*in))
+		for key, val := range *in {
+			var outVal *ImageVerificationPolicy
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = new(ImageVerificationPolicy)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.DeploymentPatches != nil {
+		in, out := &in.DeploymentPatches, &out.DeploymentPatches
+		*out = make([]DeploymentPatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -84,3 +119,55 @@ func (in *Config) DeepCopy() *Config {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentPatch) DeepCopyInto(out *DeploymentPatch) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Patch != nil {
+		in, out := &in.Patch, &out.Patch
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentPatch.
+func (in *DeploymentPatch) DeepCopy() *DeploymentPatch {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentPatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageVerificationPolicy) DeepCopyInto(out *ImageVerificationPolicy) {
+	*out = *in
+	if in.PublicKeys != nil {
+		in, out := &in.PublicKeys, &out.PublicKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageVerificationPolicy.
+func (in *ImageVerificationPolicy) DeepCopy() *ImageVerificationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageVerificationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
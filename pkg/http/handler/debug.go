@@ -0,0 +1,92 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/tabwriter"
+
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// DebugHandler serves a point in time view of the state of a component. The
+// `revision=namespace/name` query parameter selects the revision to return
+// the state of. The state is rendered as text, or as JSON if the
+// `format=json` query parameter is set.
+//
+// The debug endpoints are unauthenticated, so they must only be served on
+// localhost.
+type DebugHandler struct {
+	// RequireRevision rejects the requests not selecting a revision.
+	RequireRevision bool
+	// State returns the state of the given revision, which is empty if the
+	// request does not select one. A NotFound error results in a 404.
+	State func(rev types.NamespacedName) (interface{}, error)
+	// WriteText renders the state returned by State as text.
+	WriteText func(w io.Writer, state interface{}) error
+	Logger    *zap.SugaredLogger
+}
+
+func (h *DebugHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var rev types.NamespacedName
+	if s := r.URL.Query().Get("revision"); s != "" || h.RequireRevision {
+		ns, name, ok := strings.Cut(s, "/")
+		if !ok || ns == "" || name == "" {
+			http.Error(w, fmt.Sprintf("invalid revision %q, want namespace/name", s), http.StatusBadRequest)
+			return
+		}
+		rev = types.NamespacedName{Namespace: ns, Name: name}
+	}
+
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "text" && format != "json" {
+		http.Error(w, fmt.Sprintf("unknown format %q", format), http.StatusBadRequest)
+		return
+	}
+
+	state, err := h.State(rev)
+	if apierrors.IsNotFound(err) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	} else if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if format == "json" {
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(state)
+	} else {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		err = h.WriteText(w, state)
+	}
+	if err != nil {
+		h.Logger.Errorw("Failed to write debug state", zap.Error(err))
+	}
+}
+
+// NewDebugTabWriter returns a writer aligning the columns of the text
+// rendering of a debug state.
+func NewDebugTabWriter(w io.Writer) *tabwriter.Writer {
+	return tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+func TestDebugHandler(t *testing.T) {
+	tests := []struct {
+		name            string
+		query           string
+		requireRevision bool
+		err             error
+		wantCode        int
+		wantRev         types.NamespacedName
+		wantType        string
+		wantBody        string
+	}{{
+		name:     "text",
+		wantCode: http.StatusOK,
+		wantType: "text/plain; charset=utf-8",
+		wantBody: "state:  /\n",
+	}, {
+		name:     "json",
+		query:    "?revision=ns/rev&format=json",
+		wantCode: http.StatusOK,
+		wantRev:  types.NamespacedName{Namespace: "ns", Name: "rev"},
+		wantType: "application/json",
+		wantBody: "\"ns/rev\"\n",
+	}, {
+		name:            "required revision",
+		query:           "?revision=ns/rev",
+		requireRevision: true,
+		wantCode:        http.StatusOK,
+		wantRev:         types.NamespacedName{Namespace: "ns", Name: "rev"},
+		wantType:        "text/plain; charset=utf-8",
+		wantBody:        "state:  ns/rev\n",
+	}, {
+		name:            "missing revision",
+		requireRevision: true,
+		wantCode:        http.StatusBadRequest,
+	}, {
+		name:     "invalid revision",
+		query:    "?revision=rev",
+		wantCode: http.StatusBadRequest,
+	}, {
+		name:     "unknown format",
+		query:    "?format=yaml",
+		wantCode: http.StatusBadRequest,
+	}, {
+		name:     "not found",
+		query:    "?revision=ns/rev",
+		err:      apierrors.NewNotFound(schema.GroupResource{Resource: "revisions"}, "ns/rev"),
+		wantCode: http.StatusNotFound,
+		wantRev:  types.NamespacedName{Namespace: "ns", Name: "rev"},
+	}, {
+		name:     "error",
+		err:      errors.New("failed"),
+		wantCode: http.StatusInternalServerError,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var gotRev types.NamespacedName
+			handler := &DebugHandler{
+				RequireRevision: test.requireRevision,
+				State: func(rev types.NamespacedName) (interface{}, error) {
+					gotRev = rev
+					return rev.String(), test.err
+				},
+				WriteText: func(w io.Writer, state interface{}) error {
+					tw := NewDebugTabWriter(w)
+					fmt.Fprintf(tw, "state:\t%s\n", state)
+					return tw.Flush()
+				},
+				Logger: logtesting.TestLogger(t),
+			}
+
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/debug"+test.query, nil))
+
+			if got, want := resp.Code, test.wantCode; got != want {
+				t.Fatalf("StatusCode = %d, want: %d", got, want)
+			}
+			if got, want := gotRev, test.wantRev; got != want {
+				t.Errorf("Revision = %v, want: %v", got, want)
+			}
+			if test.wantCode != http.StatusOK {
+				return
+			}
+			if got, want := resp.Header().Get("Content-Type"), test.wantType; got != want {
+				t.Errorf("Content-Type = %q, want: %q", got, want)
+			}
+			if got, want := resp.Body.String(), test.wantBody; got != want {
+				t.Errorf("Body = %q, want: %q", got, want)
+			}
+		})
+	}
+}
@@ -61,6 +61,12 @@ const (
 	// LabelResponseTimeout is the label timeout.
 	LabelResponseTimeout = metricskey.LabelResponseTimeout
 
+	// LabelColdStartPhase is the label for the phase of a cold start.
+	LabelColdStartPhase = "cold_start_phase"
+
+	// LabelCacheResult is the label for the result of a cache lookup.
+	LabelCacheResult = "cache_result"
+
 	// ValueUnknown is the default value if the field is unknown, e.g. project will be unknown if Knative
 	// is not running on GKE.
 	ValueUnknown = metricskey.ValueUnknown
@@ -77,4 +83,31 @@ var (
 	ResponseCodeKey      = tag.MustNewKey(LabelResponseCode)
 	ResponseCodeClassKey = tag.MustNewKey(LabelResponseCodeClass)
 	RouteTagKey          = tag.MustNewKey(LabelRouteTag)
+	ColdStartPhaseKey    = tag.MustNewKey(LabelColdStartPhase)
+	CacheResultKey       = tag.MustNewKey(LabelCacheResult)
+)
+
+// The phases of a cold start, reported with the ColdStartPhaseKey tag.
+const (
+	// ColdStartPhaseAutoscalerDecision is the time from the autoscaler
+	// observing a request until it scaled the revision up from zero.
+	ColdStartPhaseAutoscalerDecision = "autoscaler_decision"
+	// ColdStartPhasePodScheduling is the time from the scale up until the pod
+	// was scheduled.
+	ColdStartPhasePodScheduling = "pod_scheduling"
+	// ColdStartPhasePodInitialization is the time from the pod being scheduled
+	// until it was initialized.
+	ColdStartPhasePodInitialization = "pod_initialization"
+	// ColdStartPhaseImagePull is the time from the pod being initialized until
+	// the user container was started.
+	ColdStartPhaseImagePull = "image_pull"
+	// ColdStartPhaseReadiness is the time from the user container being
+	// started until the pod was ready.
+	ColdStartPhaseReadiness = "readiness"
+	// ColdStartPhaseActivatorWait is the time a request waited in the
+	// activator for the revision to get capacity.
+	ColdStartPhaseActivatorWait = "activator_wait"
+	// ColdStartPhaseFirstByte is the time from the activator proxying a
+	// request until it received the first byte of the response.
+	ColdStartPhaseFirstByte = "first_byte"
 )
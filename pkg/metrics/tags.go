@@ -148,6 +148,12 @@ func AugmentWithResponseAndRouteTag(baseCtx context.Context, responseCode int, r
 	return ctx
 }
 
+// AugmentWithColdStartPhase augments the given context with the cold start phase tag.
+func AugmentWithColdStartPhase(baseCtx context.Context, phase string) context.Context {
+	ctx, _ := tag.New(baseCtx, tag.Upsert(ColdStartPhaseKey, phase))
+	return ctx
+}
+
 // responseCodeClass converts response code to a string of response code class.
 // e.g. The response code class is "5xx" for response code 503.
 func responseCodeClass(responseCode int) string {
@@ -33,6 +33,10 @@ const (
 	// health check and lifecycle hooks for queue-proxy.
 	QueueAdminPort = 8022
 
+	// ActivatorAdminPort specifies the port number for the activator's
+	// debug endpoints, which are only served on localhost.
+	ActivatorAdminPort = 8014
+
 	// AutoscalingQueueMetricsPort specifies the port number for metrics emitted
 	// by queue-proxy for autoscaler.
 	AutoscalingQueueMetricsPort = 9090
@@ -0,0 +1,262 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cgroup reads the resource usage of the containers of a pod from the
+// cgroup v1 or v2 files visible in one of them.
+package cgroup
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultRoot is where the cgroup hierarchy is mounted in a container.
+const DefaultRoot = "/sys/fs/cgroup"
+
+// Usage is the resource usage of a cgroup at a point in time.
+type Usage struct {
+	// CPU is the CPU time used since the cgroup was created.
+	CPU time.Duration
+
+	// Memory is the working set in bytes, i.e. the memory in use minus the
+	// inactive page cache, which the kernel can reclaim. This is how the
+	// kubelet accounts memory as well.
+	Memory int64
+}
+
+// Reader reads the usage of a cgroup.
+type Reader struct {
+	// root is where the hierarchy is mounted and path the cgroup within it.
+	root string
+	path string
+	v2   bool
+}
+
+// NewReader creates a Reader for the cgroup mounted at root. The cgroup
+// version is detected from the files found there.
+func NewReader(root string) *Reader {
+	return &Reader{root: root, path: "/", v2: isV2(root)}
+}
+
+func isV2(root string) bool {
+	_, err := os.Stat(filepath.Join(root, "cgroup.controllers"))
+	return err == nil
+}
+
+// file returns the path of the file of the cgroup with the given name. In
+// cgroup v1 every controller has a hierarchy of its own, in the given
+// directory of the root.
+func (r *Reader) file(controller, name string) string {
+	if r.v2 {
+		return filepath.Join(r.root, r.path, name)
+	}
+	return filepath.Join(r.root, controller, r.path, name)
+}
+
+// Read reads the current usage.
+func (r *Reader) Read() (Usage, error) {
+	if r.v2 {
+		return r.readV2()
+	}
+	return r.readV1()
+}
+
+func (r *Reader) readV2() (Usage, error) {
+	usec, err := readStat(r.file("", "cpu.stat"), "usage_usec")
+	if err != nil {
+		return Usage{}, err
+	}
+	current, err := readInt(r.file("", "memory.current"))
+	if err != nil {
+		return Usage{}, err
+	}
+	inactive, err := readStat(r.file("", "memory.stat"), "inactive_file")
+	if err != nil {
+		return Usage{}, err
+	}
+	return Usage{
+		CPU:    time.Duration(usec) * time.Microsecond,
+		Memory: workingSet(current, inactive),
+	}, nil
+}
+
+func (r *Reader) readV1() (Usage, error) {
+	// Depending on the container runtime the CPU accounting controller is
+	// mounted on its own or together with the CPU controller.
+	var (
+		nsec int64
+		err  error
+	)
+	for _, dir := range []string{"cpuacct", "cpu,cpuacct"} {
+		nsec, err = readInt(r.file(dir, "cpuacct.usage"))
+		if !errors.Is(err, os.ErrNotExist) {
+			break
+		}
+	}
+	if err != nil {
+		return Usage{}, err
+	}
+	usage, err := readInt(r.file("memory", "memory.usage_in_bytes"))
+	if err != nil {
+		return Usage{}, err
+	}
+	inactive, err := readStat(r.file("memory", "memory.stat"), "total_inactive_file")
+	if err != nil {
+		return Usage{}, err
+	}
+	return Usage{
+		CPU:    time.Duration(nsec),
+		Memory: workingSet(usage, inactive),
+	}, nil
+}
+
+func workingSet(usage, inactive int64) int64 {
+	if inactive > usage {
+		return 0
+	}
+	return usage - inactive
+}
+
+// PodReader reads the usage of the containers of a pod other than the one
+// it runs in, as the usage of the cgroup of the pod less that of the cgroup
+// of its own container. The usage of the pause container is negligible and
+// counted along.
+type PodReader struct {
+	pod *Reader
+	own *Reader
+}
+
+// NewPodReader creates a PodReader for the hierarchy mounted at root, in
+// which the cgroup of the calling process, read from selfCgroup, i.e.
+// /proc/self/cgroup, is that of its container and the parent of that the
+// cgroup of the pod. An error is returned if the cgroup of the pod is not
+// visible, as when the container runs in a cgroup namespace of its own or
+// only the cgroup of the container is mounted.
+func NewPodReader(root, selfCgroup string) (*PodReader, error) {
+	v2 := isV2(root)
+	path, err := ownPath(selfCgroup, v2)
+	if err != nil {
+		return nil, err
+	}
+	if path == "/" {
+		return nil, errors.New("the cgroup of the pod is not visible, the container runs in a cgroup namespace of its own")
+	}
+	own := &Reader{root: root, path: path, v2: v2}
+	if _, err := own.Read(); err != nil {
+		return nil, fmt.Errorf("the cgroup of the pod is not visible under %s: %w", root, err)
+	}
+	return &PodReader{
+		pod: &Reader{root: root, path: filepath.Dir(path), v2: v2},
+		own: own,
+	}, nil
+}
+
+// ownPath returns the path of the cgroup listed in selfCgroup. In cgroup v1
+// that of the memory controller is returned, the container runtimes create
+// the cgroups of a container under the same path in every hierarchy.
+func ownPath(selfCgroup string, v2 bool) (string, error) {
+	b, err := os.ReadFile(selfCgroup)
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(string(b), "\n") {
+		// Every line is "hierarchy-ID:controller-list:cgroup-path", with
+		// the single hierarchy of cgroup v2 listed as "0::path".
+		fields := strings.SplitN(line, ":", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		if v2 && fields[0] == "0" && fields[1] == "" {
+			return fields[2], nil
+		}
+		if !v2 {
+			for _, c := range strings.Split(fields[1], ",") {
+				if c == "memory" {
+					return fields[2], nil
+				}
+			}
+		}
+	}
+	return "", fmt.Errorf("the cgroup of the process not found in %s", selfCgroup)
+}
+
+// Read reads the current usage.
+func (r *PodReader) Read() (Usage, error) {
+	pod, err := r.pod.Read()
+	if err != nil {
+		return Usage{}, err
+	}
+	own, err := r.own.Read()
+	if err != nil {
+		return Usage{}, err
+	}
+	// The cgroups are not read at once, so the usage of the container may
+	// have grown past that of the pod in the meantime.
+	var usage Usage
+	if pod.CPU > own.CPU {
+		usage.CPU = pod.CPU - own.CPU
+	}
+	if pod.Memory > own.Memory {
+		usage.Memory = pod.Memory - own.Memory
+	}
+	return usage, nil
+}
+
+// readInt reads a file holding a single integer.
+func readInt(path string) (int64, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	v, err := strconv.ParseInt(string(bytes.TrimSpace(b)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return v, nil
+}
+
+// readStat reads the given key from a file of "key value" lines.
+func readStat(path, key string) (int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		k, v, ok := strings.Cut(s.Text(), " ")
+		if !ok || k != key {
+			continue
+		}
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse %s in %s: %w", key, path, err)
+		}
+		return n, nil
+	}
+	if err := s.Err(); err != nil {
+		return 0, err
+	}
+	return 0, fmt.Errorf("%s not found in %s", key, path)
+}
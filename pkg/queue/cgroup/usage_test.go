@@ -0,0 +1,135 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cgroup
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name    string
+		root    string
+		want    Usage
+		wantErr bool
+	}{{
+		name: "cgroup v2",
+		root: "v2",
+		want: Usage{
+			CPU:    1500 * time.Millisecond,
+			Memory: 80 << 20,
+		},
+	}, {
+		name: "cgroup v1",
+		root: "v1",
+		want: Usage{
+			CPU:    2500 * time.Millisecond,
+			Memory: 40 << 20,
+		},
+	}, {
+		name:    "unparseable",
+		root:    "broken",
+		wantErr: true,
+	}, {
+		name:    "missing",
+		root:    "missing",
+		wantErr: true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := NewReader(filepath.Join("testdata", test.root)).Read()
+			if (err != nil) != test.wantErr {
+				t.Fatalf("Read() = %v, wantErr: %v", err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("Read() = %+v, want: %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestPodReader(t *testing.T) {
+	tests := []struct {
+		name    string
+		root    string
+		self    string
+		want    Usage
+		wantErr bool
+	}{{
+		// The pod uses 4s of CPU and 180MiB, of which the queue-proxy 1s and
+		// 30MiB.
+		name: "cgroup v2",
+		root: "pod-v2",
+		self: "v2",
+		want: Usage{
+			CPU:    3 * time.Second,
+			Memory: 150 << 20,
+		},
+	}, {
+		// The pod uses 5s of CPU and 90MiB, of which the queue-proxy 1s and
+		// 20MiB.
+		name: "cgroup v1",
+		root: "pod-v1",
+		self: "v1",
+		want: Usage{
+			CPU:    4 * time.Second,
+			Memory: 70 << 20,
+		},
+	}, {
+		name:    "cgroup namespace",
+		root:    "pod-v2",
+		self:    "namespaced",
+		wantErr: true,
+	}, {
+		name:    "cgroup not mounted",
+		root:    "pod-v2",
+		self:    "elsewhere",
+		wantErr: true,
+	}, {
+		name:    "cgroup version mismatch",
+		root:    "pod-v1",
+		self:    "v2",
+		wantErr: true,
+	}, {
+		name:    "missing",
+		root:    "pod-v2",
+		self:    "missing",
+		wantErr: true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r, err := NewPodReader(filepath.Join("testdata", test.root), filepath.Join("testdata", "self", test.self))
+			if (err != nil) != test.wantErr {
+				t.Fatalf("NewPodReader() = %v, wantErr: %v", err, test.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			got, err := r.Read()
+			if err != nil {
+				t.Fatal("Read() =", err)
+			}
+			if got != test.want {
+				t.Errorf("Read() = %+v, want: %+v", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package procfs reads the resource usage of the other containers of a pod
+// from the processes they run. The containers of the pod must share their
+// process namespace for them to be visible, which revisions only do when
+// they opt in; see the cgroup package for how the usage is read otherwise.
+package procfs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// DefaultRoot is where the proc filesystem is mounted in a container.
+const DefaultRoot = "/proc"
+
+// clockTicks is the number of ticks per second the CPU times of the processes
+// are counted in, i.e. USER_HZ, which is 100 on every architecture Linux
+// supports in practice.
+const clockTicks = 100
+
+// Usage is the resource usage of the processes at a point in time.
+type Usage struct {
+	// CPU is the CPU time used by the processes and the children they waited
+	// for. It decreases when a process exits without being waited for.
+	CPU time.Duration
+
+	// Memory is the resident memory private to the processes in bytes. The
+	// pages they share, mostly those of the files they map, are left out
+	// for them not to be counted once per process.
+	Memory int64
+}
+
+// Reader reads the usage of the processes of the containers of the pod other
+// than the one it runs in.
+type Reader struct {
+	root     string
+	self     string
+	pageSize int64
+}
+
+// NewReader creates a Reader for the proc filesystem mounted at root.
+func NewReader(root string) *Reader {
+	return &Reader{
+		root:     root,
+		self:     strconv.Itoa(os.Getpid()),
+		pageSize: int64(os.Getpagesize()),
+	}
+}
+
+// Read reads the current usage. The processes in the same cgroup as the
+// calling process belong to its own container and are skipped, as is the
+// pause container, which is the init process of a pod sharing its process
+// namespace.
+func (r *Reader) Read() (Usage, error) {
+	own, err := os.ReadFile(filepath.Join(r.root, r.self, "cgroup"))
+	if err != nil {
+		return Usage{}, err
+	}
+	entries, err := os.ReadDir(r.root)
+	if err != nil {
+		return Usage{}, err
+	}
+
+	var (
+		usage Usage
+		found bool
+	)
+	for _, e := range entries {
+		pid := e.Name()
+		if _, err := strconv.Atoi(pid); err != nil || !e.IsDir() || pid == "1" {
+			continue
+		}
+		cgroup, err := os.ReadFile(filepath.Join(r.root, pid, "cgroup"))
+		if exited(err) {
+			continue
+		} else if err != nil {
+			return Usage{}, err
+		}
+		if bytes.Equal(cgroup, own) {
+			continue
+		}
+		cpu, err := readStat(filepath.Join(r.root, pid, "stat"))
+		if exited(err) {
+			continue
+		} else if err != nil {
+			return Usage{}, err
+		}
+		pages, err := readStatm(filepath.Join(r.root, pid, "statm"))
+		if exited(err) {
+			continue
+		} else if err != nil {
+			return Usage{}, err
+		}
+		usage.CPU += cpu
+		usage.Memory += pages * r.pageSize
+		found = true
+	}
+	if !found {
+		return Usage{}, errors.New("no process of another container found, the pod does not share its process namespace")
+	}
+	return usage, nil
+}
+
+// exited returns whether the error is from reading the files of a process
+// that exited since it was listed.
+func exited(err error) bool {
+	return errors.Is(err, os.ErrNotExist) || errors.Is(err, syscall.ESRCH)
+}
+
+// readStat reads the CPU time from the stat file of a process.
+func readStat(path string) (time.Duration, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	// The command name is in parentheses and may contain spaces and
+	// parentheses itself, so the fields are counted from the last one, i.e.
+	// from the state, which is the third field.
+	i := bytes.LastIndexByte(b, ')')
+	if i < 0 {
+		return 0, fmt.Errorf("failed to parse %s", path)
+	}
+	fields := strings.Fields(string(b[i+1:]))
+	if len(fields) < 15 {
+		return 0, fmt.Errorf("failed to parse %s: %d fields", path, len(fields))
+	}
+
+	// utime, stime, cutime and cstime are the fields 14 to 17.
+	var ticks int64
+	for _, f := range fields[11:15] {
+		n, err := strconv.ParseInt(f, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		ticks += n
+	}
+	return time.Duration(ticks) * time.Second / clockTicks, nil
+}
+
+// readStatm reads the resident pages not shared with other processes from
+// the statm file of a process.
+func readStatm(path string) (int64, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	// The fields are size, resident, shared, text, lib, data and dt.
+	fields := strings.Fields(string(b))
+	if len(fields) < 3 {
+		return 0, fmt.Errorf("failed to parse %s: %d fields", path, len(fields))
+	}
+	resident, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	shared, err := strconv.ParseInt(fields[2], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if shared > resident {
+		return 0, nil
+	}
+	return resident - shared, nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package procfs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name    string
+		root    string
+		want    Usage
+		wantErr bool
+	}{{
+		// The pod runs the pause container as pid 1, the queue-proxy as pid 7
+		// and the user container as pids 12 and 13. Only the latter count.
+		name: "user container",
+		root: "pod",
+		want: Usage{
+			CPU:    2600 * time.Millisecond,
+			Memory: 1792 * 4096,
+		},
+	}, {
+		name:    "process namespace not shared",
+		root:    "alone",
+		wantErr: true,
+	}, {
+		name:    "missing",
+		root:    "missing",
+		wantErr: true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := NewReader(filepath.Join("testdata", test.root))
+			r.self, r.pageSize = "7", 4096
+			got, err := r.Read()
+			if (err != nil) != test.wantErr {
+				t.Fatalf("Read() = %v, wantErr: %v", err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("Read() = %+v, want: %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestReadExitedProcess(t *testing.T) {
+	root := t.TempDir()
+	for pid, cgroup := range map[string]string{"7": "0::/\n", "12": "0::/../user-container\n", "13": "0::/../user-container\n"} {
+		if err := os.Mkdir(filepath.Join(root, pid), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(root, pid, "cgroup"), []byte(cgroup), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Process 13 exited before its stat was read.
+	stat := "12 (app) S 1 12 12 0 -1 4194560 0 0 0 0 100 0 0 0 20 0 1 0 100 1000 10\n"
+	if err := os.WriteFile(filepath.Join(root, "12", "stat"), []byte(stat), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "12", "statm"), []byte("1000 10 0 5 0 8 0\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewReader(root)
+	r.self, r.pageSize = "7", 4096
+	got, err := r.Read()
+	if err != nil {
+		t.Fatal("Read() =", err)
+	}
+	if want := (Usage{CPU: time.Second, Memory: 10 * 4096}); got != want {
+		t.Errorf("Read() = %+v, want: %+v", got, want)
+	}
+}
@@ -35,9 +35,10 @@ const (
 
 // ProtobufStatsReporter structure represents a protobuf stats reporter.
 type ProtobufStatsReporter struct {
-	startTime time.Time
-	stat      atomic.Value
-	podName   string
+	startTime   time.Time
+	stat        atomic.Value
+	podName     string
+	utilization UtilizationSource
 
 	// RequestCount and ProxiedRequestCount need to be divided by the reporting period
 	// they were collected over to get a "per-second" value.
@@ -61,23 +62,42 @@ func NewProtobufStatsReporter(pod string, reportingPeriod time.Duration) *Protob
 	return r
 }
 
+// SetUtilizationSource makes the reporter capture the resource usage
+// of the pod from s along with the request metrics. It must be called before
+// the first Report.
+func (r *ProtobufStatsReporter) SetUtilizationSource(s UtilizationSource) {
+	r.utilization = s
+}
+
 // Report captures request metrics.
 func (r *ProtobufStatsReporter) Report(stats netstats.RequestStatsReport) {
-	r.stat.Store(metrics.Stat{
+	now := time.Now()
+	stat := metrics.Stat{
 		PodName:       r.podName,
-		ProcessUptime: time.Since(r.startTime).Seconds(),
+		ProcessUptime: now.Sub(r.startTime).Seconds(),
 
 		// RequestCount and ProxiedRequestCount are a rate over time while concurrency is not.
 		RequestCount:                     stats.RequestCount / r.reportingPeriodSeconds,
 		ProxiedRequestCount:              stats.ProxiedRequestCount / r.reportingPeriodSeconds,
 		AverageConcurrentRequests:        stats.AverageConcurrency,
 		AverageProxiedConcurrentRequests: stats.AverageProxiedConcurrency,
-	})
+	}
+	if r.utilization != nil {
+		u := r.utilization.Utilization(now)
+		stat.CpuUtilization, stat.MemoryUtilization = u.CPUUtilization, u.MemoryUtilization
+		stat.CpuUsage, stat.MemoryUsage = u.CPU, u.Memory
+	}
+	r.stat.Store(stat)
+}
+
+// Stat returns the most recently captured stat.
+func (r *ProtobufStatsReporter) Stat() metrics.Stat {
+	return r.stat.Load().(metrics.Stat)
 }
 
 // ServeHTTP serves the stats in protobuf format over HTTP.
 func (r *ProtobufStatsReporter) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
-	data := r.stat.Load().(metrics.Stat)
+	data := r.Stat()
 	buffer, err := proto.Marshal(&data)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -120,6 +120,35 @@ func TestProtobufStatsReporterReport(t *testing.T) {
 	}
 }
 
+type fixedUtilization ResourceUsage
+
+func (f fixedUtilization) Utilization(time.Time) ResourceUsage {
+	return ResourceUsage(f)
+}
+
+func TestProtobufStatsReporterUtilization(t *testing.T) {
+	reporter := NewProtobufStatsReporter(pod, time.Second)
+	reporter.SetUtilizationSource(fixedUtilization{
+		CPUUtilization:    42,
+		MemoryUtilization: 24,
+		CPU:               210,
+		Memory:            48 << 20,
+	})
+	reporter.Report(netstats.RequestStatsReport{AverageConcurrency: 1})
+
+	want := metrics.Stat{
+		PodName:                   pod,
+		AverageConcurrentRequests: 1,
+		CpuUtilization:            42,
+		MemoryUtilization:         24,
+		CpuUsage:                  210,
+		MemoryUsage:               48 << 20,
+	}
+	if got := scrapeProtobufStat(t, reporter); !cmp.Equal(want, got, ignoreStatFields) {
+		t.Errorf("Scraped stat mismatch; diff(-want,+got):\n%s", cmp.Diff(want, got, ignoreStatFields))
+	}
+}
+
 func TestInitialProtobufStateValid(t *testing.T) {
 	r := NewProtobufStatsReporter(pod, 1*time.Second)
 	emptyStat := metrics.Stat{
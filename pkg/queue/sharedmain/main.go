@@ -43,10 +43,13 @@ import (
 	"knative.dev/pkg/tracing"
 	tracingconfig "knative.dev/pkg/tracing/config"
 	"knative.dev/pkg/tracing/propagation/tracecontextb3"
+	"knative.dev/pkg/websocket"
 	pkghttp "knative.dev/serving/pkg/http"
 	"knative.dev/serving/pkg/logging"
 	"knative.dev/serving/pkg/networking"
 	"knative.dev/serving/pkg/queue"
+	"knative.dev/serving/pkg/queue/cgroup"
+	"knative.dev/serving/pkg/queue/procfs"
 	"knative.dev/serving/pkg/queue/readiness"
 )
 
@@ -96,6 +99,15 @@ type config struct {
 	// Metrics configuration
 	ServingRequestMetricsBackend string `split_words:"true"` // optional
 	MetricsCollectorAddress      string `split_words:"true"` // optional
+	StatPushEndpoint             string `split_words:"true"` // optional
+
+	// Resource requests of the containers of the pod other than the
+	// queue-proxy, set when the revision is scaled on the utilization of the
+	// resource. The usage of those containers is read from the cgroup of the
+	// pod, or from their processes if the pod shares its process namespace.
+	ServingCPURequest            int64 `split_words:"true"` // optional, in millicores
+	ServingMemoryRequest         int64 `split_words:"true"` // optional, in bytes
+	ServingShareProcessNamespace bool  `split_words:"true"` // optional
 
 	// Tracing configuration
 	TracingConfigDebug          bool                      `split_words:"true"` // optional
@@ -207,15 +219,39 @@ func Main(opts ...Option) error {
 	metrics.MemStatsOrDie(d.Ctx)
 
 	protoStatReporter := queue.NewProtobufStatsReporter(env.ServingPod, reportingPeriod)
+	if env.ServingCPURequest > 0 || env.ServingMemoryRequest > 0 {
+		if env.ServingShareProcessNamespace {
+			protoStatReporter.SetUtilizationSource(queue.NewProcessUtilization(procfs.NewReader(procfs.DefaultRoot),
+				env.ServingCPURequest, env.ServingMemoryRequest, logger))
+		} else if reader, err := cgroup.NewPodReader(cgroup.DefaultRoot, "/proc/self/cgroup"); err != nil {
+			logger.Errorw("Failed to find the cgroup of the pod, not reporting the resource usage", zap.Error(err))
+		} else {
+			protoStatReporter.SetUtilizationSource(queue.NewCgroupUtilization(reader,
+				env.ServingCPURequest, env.ServingMemoryRequest, logger))
+		}
+	}
 
 	reportTicker := time.NewTicker(reportingPeriod)
 	defer reportTicker.Stop()
 
+	// Push the stats to the autoscaler in addition to serving them for
+	// scraping, if requested.
+	var statPusher *queue.StatPusher
+	if env.StatPushEndpoint != "" {
+		logger.Info("Pushing stats to the autoscaler at ", env.StatPushEndpoint)
+		statSink := websocket.NewDurableSendingConnection(env.StatPushEndpoint, logger)
+		defer statSink.Shutdown()
+		statPusher = queue.NewStatPusher(statSink, env.ServingNamespace, env.ServingRevision, logger)
+	}
+
 	stats := netstats.NewRequestStats(time.Now())
 	go func() {
 		for now := range reportTicker.C {
 			stat := stats.Report(now)
 			protoStatReporter.Report(stat)
+			if statPusher != nil {
+				statPusher.Push(now, protoStatReporter.Stat())
+			}
 		}
 	}()
 
@@ -0,0 +1,73 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/types"
+
+	"knative.dev/serving/pkg/autoscaler/metrics"
+)
+
+// RawSender sends raw byte array messages with a message type
+// (implemented by knative.dev/pkg/websocket.ManagedConnection).
+type RawSender interface {
+	SendRaw(msgType int, msg []byte) error
+}
+
+// StatPusher pushes the stats of a queue-proxy to the autoscaler, as an
+// alternative to the autoscaler scraping them.
+type StatPusher struct {
+	sink    RawSender
+	key     types.NamespacedName
+	failing bool
+	logger  *zap.SugaredLogger
+}
+
+// NewStatPusher creates a StatPusher sending the stats of the given revision
+// to sink.
+func NewStatPusher(sink RawSender, namespace, revision string, logger *zap.SugaredLogger) *StatPusher {
+	return &StatPusher{
+		sink:   sink,
+		key:    types.NamespacedName{Namespace: namespace, Name: revision},
+		logger: logger,
+	}
+}
+
+// Push sends stat, stamped with the given time, to the autoscaler. The
+// autoscaler scrapes the pod while its pushes fail to arrive, so only the
+// first failure and the recovery are logged. Push is not safe for concurrent
+// use.
+func (p *StatPusher) Push(now time.Time, stat metrics.Stat) {
+	stat.Timestamp = now.Unix()
+	wsms := metrics.ToWireStatMessages([]metrics.StatMessage{{Key: p.key, Stat: stat}})
+	b, err := wsms.Marshal()
+	if err == nil {
+		err = p.sink.SendRaw(websocket.BinaryMessage, b)
+	}
+
+	switch {
+	case err != nil && !p.failing:
+		p.logger.Warnw("Failed to push stats to the autoscaler", zap.Error(err))
+	case err == nil && p.failing:
+		p.logger.Info("Pushing stats to the autoscaler again")
+	}
+	p.failing = err != nil
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/websocket"
+	"k8s.io/apimachinery/pkg/types"
+
+	. "knative.dev/pkg/logging/testing"
+	"knative.dev/serving/pkg/autoscaler/metrics"
+)
+
+type fakeRawSender struct {
+	msgs [][]byte
+	err  error
+}
+
+func (s *fakeRawSender) SendRaw(msgType int, msg []byte) error {
+	if msgType != websocket.BinaryMessage {
+		return errors.New("not a binary message")
+	}
+	if s.err != nil {
+		return s.err
+	}
+	s.msgs = append(s.msgs, msg)
+	return nil
+}
+
+func TestStatPusher(t *testing.T) {
+	sink := &fakeRawSender{}
+	p := NewStatPusher(sink, "ns", "rev", TestLogger(t))
+
+	now := time.Unix(1000, 0)
+	stat := metrics.Stat{
+		PodName:                   pod,
+		AverageConcurrentRequests: 3,
+		RequestCount:              39,
+		ProcessUptime:             12,
+	}
+	p.Push(now, stat)
+
+	// Failures are not fatal, the next push goes through again.
+	sink.err = errors.New("not connected")
+	p.Push(now.Add(time.Second), stat)
+	sink.err = nil
+	p.Push(now.Add(2*time.Second), stat)
+
+	var got []metrics.StatMessage
+	for _, msg := range sink.msgs {
+		var wsms metrics.WireStatMessages
+		if err := wsms.Unmarshal(msg); err != nil {
+			t.Fatal("Unmarshal() =", err)
+		}
+		for _, wsm := range wsms.Messages {
+			got = append(got, wsm.ToStatMessage())
+		}
+	}
+
+	key := types.NamespacedName{Namespace: "ns", Name: "rev"}
+	stamped := func(ts int64) metrics.StatMessage {
+		s := stat
+		s.Timestamp = ts
+		return metrics.StatMessage{Key: key, Stat: s}
+	}
+	want := []metrics.StatMessage{stamped(1000), stamped(1002)}
+	if !cmp.Equal(got, want) {
+		t.Error("Pushed stats (-want, +got):", cmp.Diff(want, got))
+	}
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+
+	"knative.dev/serving/pkg/queue/cgroup"
+	"knative.dev/serving/pkg/queue/procfs"
+)
+
+// ResourceUsage is the resource usage of the containers of the pod other
+// than the queue-proxy.
+type ResourceUsage struct {
+	// CPUUtilization and MemoryUtilization are the usage as percentages of the
+	// requests of those containers.
+	CPUUtilization    float64
+	MemoryUtilization float64
+
+	// CPU is the usage in millicores and Memory in bytes.
+	CPU    float64
+	Memory float64
+}
+
+// UtilizationSource provides the resource utilization of the containers of
+// the pod other than the queue-proxy.
+type UtilizationSource interface {
+	// Utilization returns the CPU and memory usage of the containers.
+	Utilization(now time.Time) ResourceUsage
+}
+
+// ContainersUtilization computes the utilization of the containers of the pod
+// other than the queue-proxy from their usage.
+type ContainersUtilization struct {
+	read          func() (cgroup.Usage, error)
+	cpuRequest    float64 // in millicores
+	memoryRequest float64 // in bytes
+	logger        *zap.SugaredLogger
+
+	// The previous usage, to compute the CPU utilization over the period
+	// since, and whether reading it failed.
+	last     cgroup.Usage
+	lastTime time.Time
+	failing  bool
+}
+
+var _ UtilizationSource = (*ContainersUtilization)(nil)
+
+// NewCgroupUtilization creates a ContainersUtilization reading the usage from
+// the cgroup of the pod and relating it to the given requests of the
+// containers. A resource without requests is reported as not utilized,
+// though its usage is.
+func NewCgroupUtilization(reader *cgroup.PodReader, cpuRequestMillis, memoryRequestBytes int64, logger *zap.SugaredLogger) *ContainersUtilization {
+	return newContainersUtilization(reader.Read, cpuRequestMillis, memoryRequestBytes, logger)
+}
+
+// NewProcessUtilization is like NewCgroupUtilization, but reads the usage from
+// the processes of the containers, for pods sharing their process namespace.
+func NewProcessUtilization(reader *procfs.Reader, cpuRequestMillis, memoryRequestBytes int64, logger *zap.SugaredLogger) *ContainersUtilization {
+	return newContainersUtilization(func() (cgroup.Usage, error) {
+		usage, err := reader.Read()
+		return cgroup.Usage{CPU: usage.CPU, Memory: usage.Memory}, err
+	}, cpuRequestMillis, memoryRequestBytes, logger)
+}
+
+func newContainersUtilization(read func() (cgroup.Usage, error), cpuRequestMillis, memoryRequestBytes int64, logger *zap.SugaredLogger) *ContainersUtilization {
+	return &ContainersUtilization{
+		read:          read,
+		cpuRequest:    float64(cpuRequestMillis),
+		memoryRequest: float64(memoryRequestBytes),
+		logger:        logger,
+	}
+}
+
+// Utilization implements UtilizationSource. The CPU usage is averaged over
+// the period since the previous call, hence the first call reports none.
+// Utilization is not safe for concurrent use.
+func (u *ContainersUtilization) Utilization(now time.Time) ResourceUsage {
+	usage, err := u.read()
+	if err != nil {
+		if !u.failing {
+			u.logger.Errorw("Failed to read the resource usage of the containers", zap.Error(err))
+		}
+		u.failing = true
+		u.lastTime = time.Time{}
+		return ResourceUsage{}
+	}
+	u.failing = false
+
+	ret := ResourceUsage{Memory: float64(usage.Memory)}
+	// When read from the processes, the CPU time of those that exit without
+	// being waited for is lost, so the period they exit in may appear to
+	// have used none.
+	if !u.lastTime.IsZero() && now.After(u.lastTime) && usage.CPU > u.last.CPU {
+		ret.CPU = float64(usage.CPU-u.last.CPU) / float64(now.Sub(u.lastTime)) * 1000
+	}
+	if u.cpuRequest > 0 {
+		ret.CPUUtilization = ret.CPU / u.cpuRequest * 100
+	}
+	if u.memoryRequest > 0 {
+		ret.MemoryUtilization = ret.Memory / u.memoryRequest * 100
+	}
+	u.last, u.lastTime = usage, now
+	return ret
+}
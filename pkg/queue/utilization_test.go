@@ -0,0 +1,90 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	. "knative.dev/pkg/logging/testing"
+	"knative.dev/serving/pkg/queue/cgroup"
+)
+
+func TestContainersUtilization(t *testing.T) {
+	var (
+		usage cgroup.Usage
+		err   error
+	)
+	u := newContainersUtilization(func() (cgroup.Usage, error) { return usage, err }, 500, 200<<20, TestLogger(t))
+
+	now := time.Unix(1000, 0)
+	check := func(wantCPU, wantMemory float64) {
+		t.Helper()
+		got := u.Utilization(now)
+		if got.CPUUtilization != wantCPU || got.MemoryUtilization != wantMemory {
+			t.Errorf("Utilization() = %v, %v, want: %v, %v", got.CPUUtilization, got.MemoryUtilization, wantCPU, wantMemory)
+		}
+	}
+
+	// The first call has nothing to compute the CPU utilization over.
+	usage = cgroup.Usage{CPU: 10 * time.Second, Memory: 50 << 20}
+	check(0, 25)
+
+	// 250ms of CPU over a second is half of the 500m requested.
+	now = now.Add(time.Second)
+	usage = cgroup.Usage{CPU: 10*time.Second + 250*time.Millisecond, Memory: 300 << 20}
+	check(50, 150)
+	if got := u.Utilization(now); got.CPU != 0 || got.Memory != 300<<20 {
+		t.Errorf("Usage = %vm, %v bytes, want: 0m, %v bytes", got.CPU, got.Memory, 300<<20)
+	}
+
+	// The period after a failure is skipped.
+	now = now.Add(time.Second)
+	err = errors.New("not found")
+	check(0, 0)
+	now = now.Add(time.Second)
+	err = nil
+	usage = cgroup.Usage{CPU: 20 * time.Second, Memory: 100 << 20}
+	check(0, 50)
+	now = now.Add(2 * time.Second)
+	usage = cgroup.Usage{CPU: 21 * time.Second, Memory: 100 << 20}
+	check(100, 50)
+
+	// A process exiting without being waited for takes its CPU time along.
+	now = now.Add(time.Second)
+	usage = cgroup.Usage{CPU: 15 * time.Second, Memory: 100 << 20}
+	check(0, 50)
+	now = now.Add(time.Second)
+	usage = cgroup.Usage{CPU: 15*time.Second + 500*time.Millisecond, Memory: 100 << 20}
+	check(100, 50)
+}
+
+func TestContainersUtilizationNoRequests(t *testing.T) {
+	u := newContainersUtilization(func() (cgroup.Usage, error) {
+		return cgroup.Usage{CPU: time.Second, Memory: 50 << 20}, nil
+	}, 0, 0, TestLogger(t))
+	now := time.Unix(1000, 0)
+	u.Utilization(now)
+	got := u.Utilization(now.Add(time.Second))
+	if got.CPUUtilization != 0 || got.MemoryUtilization != 0 {
+		t.Errorf("Utilization() = %v, %v, want none without requests", got.CPUUtilization, got.MemoryUtilization)
+	}
+	if got.Memory == 0 {
+		t.Error("Memory usage = 0, want the usage of the containers")
+	}
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kpa
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"k8s.io/apimachinery/pkg/labels"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/ptr"
+	"knative.dev/serving/pkg/apis/autoscaling"
+	autoscalingv1alpha1 "knative.dev/serving/pkg/apis/autoscaling/v1alpha1"
+	palisters "knative.dev/serving/pkg/client/listers/autoscaling/v1alpha1"
+)
+
+// budgetResyncPeriod is the period after which a throttled PA is reconciled
+// again, to pick up the pods released by the other revisions of its namespace.
+const budgetResyncPeriod = 5 * time.Second
+
+// podBudget splits the pod budget of a namespace, set by its
+// PodBudgetAnnotationKey annotation, among the KPA-class revisions in it.
+type podBudget struct {
+	nsLister corev1listers.NamespaceLister
+	paLister palisters.PodAutoscalerLister
+}
+
+// apply returns the scale the PA may have within the pod budget of its
+// namespace, given the scale it wants, and records the throttling in its status.
+func (b *podBudget) apply(ctx context.Context, pa *autoscalingv1alpha1.PodAutoscaler, want int32) int32 {
+	logger := logging.FromContext(ctx)
+
+	budget, ok := b.get(ctx, pa.Namespace)
+	if !ok {
+		pa.Status.RequestedScale = nil
+		pa.Status.MarkNotThrottled()
+		return want
+	}
+	pa.Status.RequestedScale = ptr.Int32(want)
+
+	pas, err := b.paLister.PodAutoscalers(pa.Namespace).List(labels.Everything())
+	if err != nil {
+		logger.Warnw("Failed to list the PodAutoscalers sharing the pod budget", zap.Error(err))
+		return want
+	}
+	available := budget
+	demands := map[string]int32{pa.Name: want}
+	for _, other := range pas {
+		if other.Name == pa.Name || other.DeletionTimestamp != nil {
+			continue
+		}
+		// The pods of the other classes count against the budget, but aren't
+		// limited by it.
+		if other.Class() != autoscaling.KPA {
+			if actual := other.Status.GetActualScale(); actual > 0 {
+				available -= actual
+			}
+			continue
+		}
+		demand := other.Status.GetDesiredScale()
+		if other.Status.RequestedScale != nil {
+			demand = *other.Status.RequestedScale
+		}
+		if demand > 0 {
+			demands[other.Name] = demand
+		}
+	}
+	if available < 0 {
+		available = 0
+	}
+
+	got := fairShare(available, demands, pa.Name)
+	if got < want {
+		logger.Infof("Throttling the scale from %d to %d within the pod budget of %d", want, got, budget)
+		pa.Status.MarkThrottled(fmt.Sprintf(
+			"The pod budget of %d in namespace %s allows %d of the %d pods wanted.", budget, pa.Namespace, got, want))
+	} else {
+		pa.Status.MarkNotThrottled()
+	}
+	return got
+}
+
+// get returns the pod budget of the namespace, if it has one.
+func (b *podBudget) get(ctx context.Context, namespace string) (int32, bool) {
+	ns, err := b.nsLister.Get(namespace)
+	if err != nil {
+		return 0, false
+	}
+	v, ok := ns.Annotations[autoscaling.PodBudgetAnnotationKey]
+	if !ok {
+		return 0, false
+	}
+	budget, err := strconv.ParseInt(v, 10, 32)
+	if err != nil || budget <= 0 {
+		logging.FromContext(ctx).Warnf("Ignoring the pod budget %q of namespace %s, which is not a positive integer", v, namespace)
+		return 0, false
+	}
+	return int32(budget), true
+}
+
+// fairShare returns the share of the budget of the revision with the given
+// name, given the demands of all the revisions. When the demands exceed the
+// budget, every revision with a demand gets a pod first, in the order of their
+// names, and the rest of the budget is split in proportion to the demands
+// beyond that pod, rounding up the largest remainders.
+func fairShare(budget int32, demands map[string]int32, name string) int32 {
+	var (
+		total int64
+		names []string
+	)
+	for n, d := range demands {
+		if d > 0 {
+			total += int64(d)
+			names = append(names, n)
+		}
+	}
+	if total <= int64(budget) {
+		return demands[name]
+	}
+	sort.Strings(names)
+
+	share := make(map[string]int32, len(names))
+	left := budget
+	for _, n := range names {
+		if left == 0 {
+			return share[name]
+		}
+		share[n] = 1
+		left--
+	}
+
+	// As the demands exceed the budget, the extra demand exceeds what is left
+	// of it, and no share exceeds its demand.
+	extra := total - int64(len(names))
+	type remainder struct {
+		name string
+		r    int64
+	}
+	remainders := make([]remainder, 0, len(names))
+	given := int32(0)
+	for _, n := range names {
+		q := int64(left) * int64(demands[n]-1)
+		share[n] += int32(q / extra)
+		given += int32(q / extra)
+		remainders = append(remainders, remainder{name: n, r: q % extra})
+	}
+	sort.SliceStable(remainders, func(i, j int) bool {
+		return remainders[i].r > remainders[j].r
+	})
+	for i := int32(0); i < left-given; i++ {
+		share[remainders[i].name]++
+	}
+	return share[name]
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kpa
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/ptr"
+	"knative.dev/serving/pkg/apis/autoscaling"
+	autoscalingv1alpha1 "knative.dev/serving/pkg/apis/autoscaling/v1alpha1"
+
+	. "knative.dev/serving/pkg/reconciler/testing/v1"
+	. "knative.dev/serving/pkg/testing"
+)
+
+func TestFairShare(t *testing.T) {
+	tests := []struct {
+		name    string
+		budget  int32
+		demands map[string]int32
+		want    map[string]int32
+	}{{
+		name:    "within budget",
+		budget:  10,
+		demands: map[string]int32{"a": 4, "b": 6},
+		want:    map[string]int32{"a": 4, "b": 6},
+	}, {
+		name:    "proportional",
+		budget:  10,
+		demands: map[string]int32{"a": 11, "b": 21},
+		want:    map[string]int32{"a": 4, "b": 6},
+	}, {
+		name:    "largest remainder rounds up",
+		budget:  10,
+		demands: map[string]int32{"a": 5, "b": 5, "c": 5},
+		want:    map[string]int32{"a": 4, "b": 3, "c": 3},
+	}, {
+		name:    "small demand keeps a pod",
+		budget:  10,
+		demands: map[string]int32{"a": 1, "b": 100},
+		want:    map[string]int32{"a": 1, "b": 9},
+	}, {
+		name:    "budget below the revision count",
+		budget:  2,
+		demands: map[string]int32{"a": 5, "b": 5, "c": 5},
+		want:    map[string]int32{"a": 1, "b": 1, "c": 0},
+	}, {
+		name:    "no demand",
+		budget:  3,
+		demands: map[string]int32{"a": 0, "b": 10},
+		want:    map[string]int32{"a": 0, "b": 3},
+	}, {
+		name:    "no budget",
+		budget:  0,
+		demands: map[string]int32{"a": 2},
+		want:    map[string]int32{"a": 0},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var total int32
+			for name, want := range test.want {
+				got := fairShare(test.budget, test.demands, name)
+				if got != want {
+					t.Errorf("fairShare(%s) = %d, want: %d", name, got, want)
+				}
+				total += got
+			}
+			if total > test.budget {
+				t.Errorf("Total share = %d, want at most: %d", total, test.budget)
+			}
+		})
+	}
+}
+
+func TestPodBudgetApply(t *testing.T) {
+	namespace := func(budget string) *corev1.Namespace {
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}}
+		if budget != "" {
+			ns.Annotations = map[string]string{autoscaling.PodBudgetAnnotationKey: budget}
+		}
+		return ns
+	}
+	withRequestedScale := func(s int32) PodAutoscalerOption {
+		return func(pa *autoscalingv1alpha1.PodAutoscaler) {
+			pa.Status.RequestedScale = ptr.Int32(s)
+		}
+	}
+
+	tests := []struct {
+		name          string
+		objs          []runtime.Object
+		want          int32
+		wantRequested *int32
+		wantThrottled bool
+	}{{
+		name: "no budget",
+		objs: []runtime.Object{
+			namespace(""),
+			kpa(testNamespace, "other", withScales(10, 10)),
+		},
+		want: 8,
+	}, {
+		name: "invalid budget",
+		objs: []runtime.Object{
+			namespace("lots"),
+		},
+		want: 8,
+	}, {
+		name: "within budget",
+		objs: []runtime.Object{
+			namespace("10"),
+			kpa(testNamespace, "other", withScales(2, 2)),
+		},
+		want:          8,
+		wantRequested: ptr.Int32(8),
+	}, {
+		name: "split by demand",
+		objs: []runtime.Object{
+			namespace("10"),
+			kpa(testNamespace, "other", withScales(4, 4), withRequestedScale(8)),
+		},
+		want:          5,
+		wantRequested: ptr.Int32(8),
+		wantThrottled: true,
+	}, {
+		name: "other classes count against the budget",
+		objs: []runtime.Object{
+			namespace("10"),
+			kpa(testNamespace, "other", WithHPAClass, withScales(6, 6)),
+		},
+		want:          4,
+		wantRequested: ptr.Int32(8),
+		wantThrottled: true,
+	}, {
+		name: "other namespaces don't count",
+		objs: []runtime.Object{
+			namespace("10"),
+			kpa("elsewhere", "other", withScales(10, 10)),
+		},
+		want:          8,
+		wantRequested: ptr.Int32(8),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := logtesting.TestContextWithLogger(t)
+			listers := NewListers(test.objs)
+			b := &podBudget{
+				nsLister: listers.GetNamespaceLister(),
+				paLister: listers.GetPodAutoscalerLister(),
+			}
+
+			pa := kpa(testNamespace, testRevision)
+			pa.Status.MarkThrottled("previously")
+			if got := b.apply(ctx, pa, 8); got != test.want {
+				t.Errorf("apply() = %d, want: %d", got, test.want)
+			}
+			if got, want := pa.Status.RequestedScale, test.wantRequested; (got == nil) != (want == nil) || (got != nil && *got != *want) {
+				t.Errorf("RequestedScale = %v, want: %v", got, want)
+			}
+			if got := pa.Status.IsThrottled(); got != test.wantThrottled {
+				t.Errorf("IsThrottled() = %v, want: %v", got, test.wantThrottled)
+			}
+		})
+	}
+}
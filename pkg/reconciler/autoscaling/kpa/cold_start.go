@@ -0,0 +1,173 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kpa
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	pkgmetrics "knative.dev/pkg/metrics"
+	autoscalingv1alpha1 "knative.dev/serving/pkg/apis/autoscaling/v1alpha1"
+	"knative.dev/serving/pkg/apis/serving"
+	"knative.dev/serving/pkg/autoscaler/scaling"
+	"knative.dev/serving/pkg/metrics"
+	revisionresources "knative.dev/serving/pkg/reconciler/revision/resources"
+	resourceutil "knative.dev/serving/pkg/resources"
+)
+
+// reconcileColdStart tracks the activations of the PA's revision from zero in
+// pa.Status.ColdStart. The activation starts when the revision is scaled up
+// while the PA is inactive and there are no ready pods, and completes when the
+// first pod scheduled for it becomes ready. At that point the phases of the
+// cold start are computed from the pod's conditions and reported.
+func reconcileColdStart(pa *autoscalingv1alpha1.PodAutoscaler, decider *scaling.Decider,
+	want int32, podCounter resourceutil.PodAccessor, now time.Time) error {
+	var first *corev1.Pod
+	ready := 0
+	if err := podCounter.ProcessPods(func(p *corev1.Pod) {
+		ready++
+		if cs := pa.Status.ColdStart; cs == nil || cs.ReadyTime != nil || !coldStartPod(p, cs) {
+			return
+		}
+		if first == nil || podReadyTime(p).Before(podReadyTime(first)) {
+			first = p
+		}
+	}, podRunningAndReady); err != nil {
+		return err
+	}
+
+	if want > 0 && pa.Status.IsInactive() && ready == 0 {
+		cs := &autoscalingv1alpha1.ColdStartStatus{ActivationTime: metav1.NewTime(now)}
+		if t := decider.Status.ActivationRequested; !t.IsZero() && t.Time.Before(now) {
+			cs.AutoscalerDecision = &metav1.Duration{Duration: now.Sub(t.Time)}
+		}
+		pa.Status.ColdStart = cs
+		return nil
+	}
+
+	if first != nil {
+		computeColdStartPhases(pa.Status.ColdStart, first)
+	}
+	return nil
+}
+
+// coldStartCompleted returns true if the status of the PA transitioned from
+// old to new completes a cold start.
+func coldStartCompleted(old, new *autoscalingv1alpha1.PodAutoscaler) bool {
+	cs := new.Status.ColdStart
+	if cs == nil || cs.ReadyTime == nil {
+		return false
+	}
+	prev := old.Status.ColdStart
+	return prev == nil || prev.ReadyTime == nil || !prev.ActivationTime.Equal(&cs.ActivationTime)
+}
+
+func podRunningAndReady(p *corev1.Pod) bool {
+	return p.Status.Phase == corev1.PodRunning && p.DeletionTimestamp == nil &&
+		podCondition(p, corev1.PodReady) != nil
+}
+
+// coldStartPod returns true if p was created for the activation tracked by cs.
+// Timestamps of Kubernetes objects have a precision of a second, hence the
+// comparison is done with that precision.
+func coldStartPod(p *corev1.Pod, cs *autoscalingv1alpha1.ColdStartStatus) bool {
+	return !p.CreationTimestamp.Time.Before(cs.ActivationTime.Time.Truncate(time.Second))
+}
+
+// podCondition returns the condition of the given type, if it is true.
+func podCondition(p *corev1.Pod, t corev1.PodConditionType) *corev1.PodCondition {
+	for i := range p.Status.Conditions {
+		if c := &p.Status.Conditions[i]; c.Type == t && c.Status == corev1.ConditionTrue {
+			return c
+		}
+	}
+	return nil
+}
+
+func podReadyTime(p *corev1.Pod) time.Time {
+	return podCondition(p, corev1.PodReady).LastTransitionTime.Time
+}
+
+// computeColdStartPhases fills in the phases of cs from the conditions and
+// container statuses of the first pod ready after the activation.
+func computeColdStartPhases(cs *autoscalingv1alpha1.ColdStartStatus, p *corev1.Pod) {
+	readyTime := podReadyTime(p)
+
+	scheduled := readyTime
+	if c := podCondition(p, corev1.PodScheduled); c != nil {
+		scheduled = c.LastTransitionTime.Time
+	}
+	initialized := scheduled
+	if c := podCondition(p, corev1.PodInitialized); c != nil {
+		initialized = c.LastTransitionTime.Time
+	}
+	// The user containers are started once all their images are pulled.
+	var started time.Time
+	for _, s := range p.Status.ContainerStatuses {
+		if s.Name == revisionresources.QueueContainerName || s.State.Running == nil {
+			continue
+		}
+		if t := s.State.Running.StartedAt.Time; t.After(started) {
+			started = t
+		}
+	}
+	if started.IsZero() {
+		started = initialized
+	}
+
+	created := p.CreationTimestamp.Time
+	if created.Before(cs.ActivationTime.Time) {
+		created = cs.ActivationTime.Time
+	}
+
+	cs.PodName = p.Name
+	cs.ReadyTime = &metav1.Time{Time: readyTime}
+	cs.PodScheduling = phaseDuration(created, scheduled)
+	cs.PodInitialization = phaseDuration(scheduled, initialized)
+	cs.ImagePull = phaseDuration(initialized, started)
+	cs.Readiness = phaseDuration(started, readyTime)
+}
+
+// phaseDuration returns the duration between from and to, which is never
+// negative, since the timestamps come from different clocks.
+func phaseDuration(from, to time.Time) *metav1.Duration {
+	d := to.Sub(from)
+	if d < 0 {
+		d = 0
+	}
+	return &metav1.Duration{Duration: d}
+}
+
+func reportColdStartMetrics(pa *autoscalingv1alpha1.PodAutoscaler, cs *autoscalingv1alpha1.ColdStartStatus) {
+	ctx := metrics.RevisionContext(pa.Namespace, pa.Labels[serving.ServiceLabelKey],
+		pa.Labels[serving.ConfigurationLabelKey], pa.Name)
+
+	record := func(phase string, d *metav1.Duration) {
+		if d == nil {
+			return
+		}
+		pkgmetrics.Record(metrics.AugmentWithColdStartPhase(ctx, phase),
+			coldStartLatencyM.M(float64(d.Milliseconds())))
+	}
+	record(metrics.ColdStartPhaseAutoscalerDecision, cs.AutoscalerDecision)
+	record(metrics.ColdStartPhasePodScheduling, cs.PodScheduling)
+	record(metrics.ColdStartPhasePodInitialization, cs.PodInitialization)
+	record(metrics.ColdStartPhaseImagePull, cs.ImagePull)
+	record(metrics.ColdStartPhaseReadiness, cs.Readiness)
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kpa
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeinformers "k8s.io/client-go/informers"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+
+	autoscalingv1alpha1 "knative.dev/serving/pkg/apis/autoscaling/v1alpha1"
+	"knative.dev/serving/pkg/apis/serving"
+	"knative.dev/serving/pkg/autoscaler/scaling"
+	revisionresources "knative.dev/serving/pkg/reconciler/revision/resources"
+	resourceutil "knative.dev/serving/pkg/resources"
+
+	. "knative.dev/serving/pkg/testing"
+)
+
+func coldStartPodAt(name string, created time.Time, offsets ...time.Duration) *corev1.Pod {
+	at := func(i int) metav1.Time { return metav1.NewTime(created.Add(offsets[i])) }
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         testNamespace,
+			CreationTimestamp: metav1.NewTime(created),
+			Labels:            map[string]string{serving.RevisionLabelKey: testRevision},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{
+				Type:               corev1.PodScheduled,
+				Status:             corev1.ConditionTrue,
+				LastTransitionTime: at(0),
+			}, {
+				Type:               corev1.PodInitialized,
+				Status:             corev1.ConditionTrue,
+				LastTransitionTime: at(1),
+			}, {
+				Type:               corev1.PodReady,
+				Status:             corev1.ConditionTrue,
+				LastTransitionTime: at(3),
+			}},
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "user-container",
+				State: corev1.ContainerState{
+					Running: &corev1.ContainerStateRunning{StartedAt: at(2)},
+				},
+			}, {
+				Name: revisionresources.QueueContainerName,
+				State: corev1.ContainerState{
+					Running: &corev1.ContainerStateRunning{StartedAt: at(3)},
+				},
+			}},
+		},
+	}
+}
+
+func TestReconcileColdStart(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	activation := now.Add(-time.Minute)
+	duration := func(d time.Duration) *metav1.Duration { return &metav1.Duration{Duration: d} }
+	inProgress := &autoscalingv1alpha1.ColdStartStatus{ActivationTime: metav1.NewTime(activation)}
+
+	tests := []struct {
+		name      string
+		pa        *autoscalingv1alpha1.PodAutoscaler
+		want      int32
+		requested time.Time
+		pods      []*corev1.Pod
+		wantCS    *autoscalingv1alpha1.ColdStartStatus
+	}{{
+		name:      "activation from zero",
+		pa:        kpa(testNamespace, testRevision, WithNoTraffic("NoTraffic", "")),
+		want:      1,
+		requested: now.Add(-2 * time.Second),
+		wantCS: &autoscalingv1alpha1.ColdStartStatus{
+			ActivationTime:     metav1.NewTime(now),
+			AutoscalerDecision: duration(2 * time.Second),
+		},
+	}, {
+		name: "activation with a retained pod",
+		pa:   kpa(testNamespace, testRevision, WithNoTraffic("NoTraffic", "")),
+		want: 1,
+		pods: []*corev1.Pod{coldStartPodAt("retained", now.Add(-time.Hour), 0, 0, 0, 0)},
+	}, {
+		name: "no activation while active",
+		pa:   kpa(testNamespace, testRevision, WithTraffic),
+		want: 1,
+	}, {
+		name: "waiting for the first pod",
+		pa: kpa(testNamespace, testRevision, WithBufferedTraffic, func(pa *autoscalingv1alpha1.PodAutoscaler) {
+			pa.Status.ColdStart = inProgress.DeepCopy()
+		}),
+		want:   1,
+		wantCS: inProgress,
+	}, {
+		name: "first pod ready",
+		pa: kpa(testNamespace, testRevision, WithBufferedTraffic, func(pa *autoscalingv1alpha1.PodAutoscaler) {
+			pa.Status.ColdStart = inProgress.DeepCopy()
+		}),
+		want: 2,
+		pods: []*corev1.Pod{
+			coldStartPodAt("slow", activation.Add(time.Second), 2*time.Second, 3*time.Second, 40*time.Second, 50*time.Second),
+			coldStartPodAt("fast", activation.Add(time.Second), 2*time.Second, 3*time.Second, 10*time.Second, 15*time.Second),
+		},
+		wantCS: &autoscalingv1alpha1.ColdStartStatus{
+			ActivationTime:    metav1.NewTime(activation),
+			ReadyTime:         &metav1.Time{Time: activation.Add(16 * time.Second)},
+			PodName:           "fast",
+			PodScheduling:     duration(2 * time.Second),
+			PodInitialization: duration(time.Second),
+			ImagePull:         duration(7 * time.Second),
+			Readiness:         duration(5 * time.Second),
+		},
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			kubeClient := fakek8s.NewSimpleClientset()
+			podsClient := kubeinformers.NewSharedInformerFactory(kubeClient, 0).Core().V1().Pods()
+			for _, p := range tc.pods {
+				podsClient.Informer().GetIndexer().Add(p)
+			}
+			podCounter := resourceutil.NewPodAccessor(podsClient.Lister(), testNamespace, testRevision)
+
+			decider := &scaling.Decider{}
+			if !tc.requested.IsZero() {
+				decider.Status.ActivationRequested = metav1.NewTime(tc.requested)
+			}
+			if err := reconcileColdStart(tc.pa, decider, tc.want, podCounter, now); err != nil {
+				t.Fatal("reconcileColdStart() =", err)
+			}
+			if got := tc.pa.Status.ColdStart; !cmp.Equal(got, tc.wantCS) {
+				t.Error("ColdStart (-want, +got):", cmp.Diff(tc.wantCS, got))
+			}
+		})
+	}
+}
+
+func TestColdStartCompleted(t *testing.T) {
+	now := metav1.NewTime(time.Now().Truncate(time.Second))
+	earlier := metav1.NewTime(now.Add(-time.Hour))
+	withColdStart := func(cs *autoscalingv1alpha1.ColdStartStatus) *autoscalingv1alpha1.PodAutoscaler {
+		pa := kpa(testNamespace, testRevision)
+		pa.Status.ColdStart = cs
+		return pa
+	}
+	inProgress := &autoscalingv1alpha1.ColdStartStatus{ActivationTime: now}
+	ready := &autoscalingv1alpha1.ColdStartStatus{ActivationTime: now, ReadyTime: &now}
+
+	tests := []struct {
+		name     string
+		old, new *autoscalingv1alpha1.PodAutoscaler
+		want     bool
+	}{{
+		name: "no cold start",
+		old:  withColdStart(nil),
+		new:  withColdStart(nil),
+	}, {
+		name: "cold start in progress",
+		old:  withColdStart(nil),
+		new:  withColdStart(inProgress),
+	}, {
+		name: "cold start completed",
+		old:  withColdStart(inProgress),
+		new:  withColdStart(ready),
+		want: true,
+	}, {
+		name: "cold start completed in a single update",
+		old:  withColdStart(nil),
+		new:  withColdStart(ready),
+		want: true,
+	}, {
+		name: "cold start already completed",
+		old:  withColdStart(ready),
+		new:  withColdStart(ready),
+	}, {
+		name: "next cold start completed",
+		old: withColdStart(&autoscalingv1alpha1.ColdStartStatus{
+			ActivationTime: earlier,
+			ReadyTime:      &earlier,
+		}),
+		new:  withColdStart(ready),
+		want: true,
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := coldStartCompleted(tc.old, tc.new); got != tc.want {
+				t.Errorf("coldStartCompleted() = %v, want: %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -19,10 +19,16 @@ package kpa
 import (
 	"context"
 
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/clock"
 
 	networkingclient "knative.dev/networking/pkg/client/injection/client"
 	sksinformer "knative.dev/networking/pkg/client/injection/informers/networking/v1alpha1/serverlessservice"
+	nsinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/namespace"
 	filteredpodinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/pod/filtered"
 	servingclient "knative.dev/serving/pkg/client/injection/client"
 	"knative.dev/serving/pkg/client/injection/ducks/autoscaling/v1alpha1/podscalable"
@@ -56,6 +62,7 @@ func NewController(
 	sksInformer := sksinformer.Get(ctx)
 	podsInformer := filteredpodinformer.Get(ctx, serving.RevisionUID)
 	metricInformer := metricinformer.Get(ctx)
+	nsInformer := nsinformer.Get(ctx)
 	psInformerFactory := podscalable.Get(ctx)
 
 	onlyKPAClass := pkgreconciler.AnnotationFilterFunc(
@@ -70,6 +77,7 @@ func NewController(
 		},
 		podsLister: podsInformer.Lister(),
 		deciders:   deciders,
+		clock:      &clock.RealClock{},
 	}
 	impl := pareconciler.NewImpl(ctx, c, autoscaling.KPA, func(impl *controller.Impl) controller.Options {
 		logger.Info("Setting up ConfigMap receivers")
@@ -85,6 +93,10 @@ func NewController(
 		return controller.Options{ConfigStore: configStore}
 	})
 	c.scaler = newScaler(ctx, psInformerFactory, impl.EnqueueAfter)
+	c.scaler.budget = &podBudget{
+		nsLister: nsInformer.Lister(),
+		paLister: paInformer.Lister(),
+	}
 
 	logger.Info("Setting up KPA-Class event handlers")
 
@@ -93,6 +105,25 @@ func NewController(
 		Handler:    controller.HandleAll(impl.Enqueue),
 	})
 
+	// Record the cold start latencies once the status completing the cold
+	// start has been persisted, in the replica reconciling the PA only.
+	leader, _ := impl.Reconciler.(interface {
+		IsLeaderFor(types.NamespacedName) bool
+	})
+	paInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: onlyKPAClass,
+		Handler: cache.ResourceEventHandlerFuncs{
+			UpdateFunc: func(old, new interface{}) {
+				pa := new.(*autoscalingv1alpha1.PodAutoscaler)
+				key := types.NamespacedName{Namespace: pa.Namespace, Name: pa.Name}
+				if coldStartCompleted(old.(*autoscalingv1alpha1.PodAutoscaler), pa) &&
+					(leader == nil || leader.IsLeaderFor(key)) {
+					reportColdStartMetrics(pa, pa.Status.ColdStart)
+				}
+			},
+		},
+	})
+
 	onlyPAControlled := controller.FilterController(&autoscalingv1alpha1.PodAutoscaler{})
 	handleMatchingControllers := cache.FilteringResourceEventHandler{
 		FilterFunc: pkgreconciler.ChainFilterFuncs(onlyKPAClass, onlyPAControlled),
@@ -107,6 +138,29 @@ func NewController(
 		Handler:    controller.HandleAll(impl.EnqueueLabelOfNamespaceScopedResource("", serving.RevisionLabelKey)),
 	})
 
+	// Reconcile the PAs of a namespace when its pod budget changes.
+	enqueueNamespacePAs := func(obj interface{}) {
+		ns := obj.(*corev1.Namespace)
+		pas, err := paInformer.Lister().PodAutoscalers(ns.Name).List(labels.Everything())
+		if err != nil {
+			logger.Warnw("Failed to list the PodAutoscalers of namespace "+ns.Name, zap.Error(err))
+			return
+		}
+		for _, pa := range pas {
+			if onlyKPAClass(pa) {
+				impl.Enqueue(pa)
+			}
+		}
+	}
+	nsInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(old, new interface{}) {
+			if old.(*corev1.Namespace).Annotations[autoscaling.PodBudgetAnnotationKey] !=
+				new.(*corev1.Namespace).Annotations[autoscaling.PodBudgetAnnotationKey] {
+				enqueueNamespacePAs(new)
+			}
+		},
+	})
+
 	// Have the Deciders enqueue the PAs whose decisions have changed.
 	deciders.Watch(impl.EnqueueKey)
 
@@ -0,0 +1,107 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kpa
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	autoscalingv1alpha1 "knative.dev/serving/pkg/apis/autoscaling/v1alpha1"
+	"knative.dev/serving/pkg/deployment"
+)
+
+// activationFailure is the reason and message of the failure of the pods of a
+// target that never became ready.
+type activationFailure struct {
+	reason  string
+	message string
+}
+
+// detectActivationFailure returns the failure of the pods of a target that
+// never became ready, if a container restarted crash-loop-restart-threshold
+// times within the crash-loop-window of its pod, or a container of every pod
+// exited with one of the terminal-exit-codes. It returns nil otherwise.
+func detectActivationFailure(cfg *deployment.Config, pods []*corev1.Pod) *activationFailure {
+	var (
+		terminal     *activationFailure
+		terminalPods int
+		livePods     int
+	)
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		livePods++
+
+		statuses := make([]corev1.ContainerStatus, 0, len(pod.Status.InitContainerStatuses)+len(pod.Status.ContainerStatuses))
+		statuses = append(statuses, pod.Status.InitContainerStatuses...)
+		statuses = append(statuses, pod.Status.ContainerStatuses...)
+
+		exitedTerminally := false
+		for i := range statuses {
+			status := &statuses[i]
+			if crashLooping(cfg, pod, status) {
+				return &activationFailure{
+					reason: autoscalingv1alpha1.ReasonCrashLooping,
+					message: fmt.Sprintf("Container %q restarted %d times within %v",
+						status.Name, status.RestartCount, cfg.CrashLoopWindow),
+				}
+			}
+
+			if exitedTerminally {
+				continue
+			}
+			t := status.LastTerminationState.Terminated
+			if t == nil {
+				t = status.State.Terminated
+			}
+			if t != nil && cfg.TerminalExitCodes.Has(t.ExitCode) {
+				exitedTerminally = true
+				if terminal == nil {
+					terminal = &activationFailure{
+						reason: autoscalingv1alpha1.ReasonTerminalExitCode,
+						message: fmt.Sprintf("Container %q exited with terminal exit code %d in every pod",
+							status.Name, t.ExitCode),
+					}
+				}
+			}
+		}
+		if exitedTerminally {
+			terminalPods++
+		}
+	}
+
+	if livePods > 0 && terminalPods == livePods {
+		return terminal
+	}
+	return nil
+}
+
+// crashLooping returns whether the container restarted at least
+// crash-loop-restart-threshold times between the start of its pod and its
+// last termination, within the crash-loop-window.
+func crashLooping(cfg *deployment.Config, pod *corev1.Pod, status *corev1.ContainerStatus) bool {
+	if cfg.CrashLoopRestartThreshold == 0 || status.RestartCount < cfg.CrashLoopRestartThreshold {
+		return false
+	}
+	t := status.LastTerminationState.Terminated
+	if t == nil || t.FinishedAt.IsZero() || pod.Status.StartTime == nil {
+		return false
+	}
+	return t.FinishedAt.Sub(pod.Status.StartTime.Time) <= cfg.CrashLoopWindow
+}
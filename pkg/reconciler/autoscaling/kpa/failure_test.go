@@ -0,0 +1,127 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kpa
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	autoscalingv1alpha1 "knative.dev/serving/pkg/apis/autoscaling/v1alpha1"
+	"knative.dev/serving/pkg/deployment"
+)
+
+func TestDetectActivationFailure(t *testing.T) {
+	start := time.Now().Add(-time.Hour)
+	cfg := &deployment.Config{
+		CrashLoopRestartThreshold: 3,
+		CrashLoopWindow:           5 * time.Minute,
+		TerminalExitCodes:         sets.NewInt32(126, 127),
+	}
+
+	// pod returns a pod started at start whose container restarted the given
+	// times, and last exited with the exit code after the given time.
+	pod := func(restarts int32, exitCode int32, after time.Duration) *corev1.Pod {
+		p := &corev1.Pod{
+			Status: corev1.PodStatus{
+				StartTime: &metav1.Time{Time: start},
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:         "user-container",
+					RestartCount: restarts,
+				}, {
+					Name: "queue-proxy",
+				}},
+			},
+		}
+		if restarts > 0 {
+			p.Status.ContainerStatuses[0].LastTerminationState.Terminated = &corev1.ContainerStateTerminated{
+				ExitCode:   exitCode,
+				FinishedAt: metav1.Time{Time: start.Add(after)},
+			}
+		}
+		return p
+	}
+	deleted := pod(1, 127, time.Second)
+	deleted.DeletionTimestamp = &metav1.Time{Time: start}
+
+	tests := []struct {
+		name string
+		cfg  *deployment.Config
+		pods []*corev1.Pod
+		want *activationFailure
+	}{{
+		name: "no pods",
+		cfg:  cfg,
+	}, {
+		name: "healthy pods",
+		cfg:  cfg,
+		pods: []*corev1.Pod{pod(0, 0, 0), pod(0, 0, 0)},
+	}, {
+		name: "restarts within the window",
+		cfg:  cfg,
+		pods: []*corev1.Pod{pod(0, 0, 0), pod(3, 1, 2*time.Minute)},
+		want: &activationFailure{
+			reason:  autoscalingv1alpha1.ReasonCrashLooping,
+			message: `Container "user-container" restarted 3 times within 5m0s`,
+		},
+	}, {
+		name: "restarts outside of the window",
+		cfg:  cfg,
+		pods: []*corev1.Pod{pod(3, 1, 10*time.Minute)},
+	}, {
+		name: "too few restarts",
+		cfg:  cfg,
+		pods: []*corev1.Pod{pod(2, 1, time.Minute)},
+	}, {
+		name: "restarts without threshold",
+		cfg:  &deployment.Config{CrashLoopWindow: 5 * time.Minute},
+		pods: []*corev1.Pod{pod(10, 1, time.Minute)},
+	}, {
+		name: "terminal exit code in every pod",
+		cfg:  cfg,
+		pods: []*corev1.Pod{pod(1, 127, time.Second), pod(1, 126, time.Second), deleted},
+		want: &activationFailure{
+			reason:  autoscalingv1alpha1.ReasonTerminalExitCode,
+			message: `Container "user-container" exited with terminal exit code 127 in every pod`,
+		},
+	}, {
+		name: "terminal exit code in some pods",
+		cfg:  cfg,
+		pods: []*corev1.Pod{pod(1, 127, time.Second), pod(1, 1, time.Second)},
+	}, {
+		name: "terminal exit code of a terminating pod",
+		cfg:  cfg,
+		pods: []*corev1.Pod{deleted},
+	}, {
+		name: "no terminal exit codes",
+		cfg:  &deployment.Config{CrashLoopWindow: 5 * time.Minute},
+		pods: []*corev1.Pod{pod(1, 127, time.Second)},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := detectActivationFailure(tt.cfg, tt.pods)
+			if !cmp.Equal(got, tt.want, cmp.AllowUnexported(activationFailure{})) {
+				t.Error("detectActivationFailure (-want, +got):", cmp.Diff(tt.want, got, cmp.AllowUnexported(activationFailure{})))
+			}
+		})
+	}
+}
@@ -40,10 +40,12 @@ import (
 	anames "knative.dev/serving/pkg/reconciler/autoscaling/resources/names"
 	resourceutil "knative.dev/serving/pkg/resources"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/utils/clock"
 )
 
 const (
@@ -70,6 +72,7 @@ type Reconciler struct {
 	podsLister corev1listers.PodLister
 	deciders   resources.Deciders
 	scaler     *scaler
+	clock      clock.PassiveClock
 }
 
 // Check that our Reconciler implements the necessary interfaces.
@@ -115,9 +118,27 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, pa *autoscalingv1alpha1.
 		return fmt.Errorf("error reconciling Metric: %w", err)
 	}
 
+	podCounter := resourceutil.NewPodAccessor(c.podsLister, pa.Namespace, pa.Labels[serving.RevisionLabelKey])
+
+	// Fail the activation of a target that never became ready once its pods
+	// crash-loop, rather than waiting for the progress deadline, and scale it
+	// down.
+	desiredScale := decider.Status.DesiredScale
+	var failure *activationFailure
+	if !pa.Status.IsScaleTargetInitialized() {
+		var pods []*corev1.Pod
+		if err := podCounter.ProcessPods(func(p *corev1.Pod) { pods = append(pods, p) }); err != nil {
+			return fmt.Errorf("error listing pods: %w", err)
+		}
+		if failure = detectActivationFailure(config.FromContext(ctx).Deployment, pods); failure != nil {
+			logger.Infof("Activation failed: %s: %s", failure.reason, failure.message)
+			desiredScale = 0
+		}
+	}
+
 	// Get the appropriate current scale from the metric, and right size
 	// the scaleTargetRef based on it.
-	want, err := c.scaler.scale(ctx, pa, sks, decider.Status.DesiredScale)
+	want, err := c.scaler.scale(ctx, pa, sks, desiredScale)
 	if err != nil {
 		return fmt.Errorf("error scaling target: %w", err)
 	}
@@ -144,8 +165,10 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, pa *autoscalingv1alpha1.
 		mode = nv1alpha1.SKSOperationModeServe
 	}
 
+	if err := reconcileColdStart(pa, decider, want, podCounter, c.clock.Now()); err != nil {
+		return fmt.Errorf("error tracking cold start: %w", err)
+	}
 	// Compare the desired and observed resources to determine our situation.
-	podCounter := resourceutil.NewPodAccessor(c.podsLister, pa.Namespace, pa.Labels[serving.RevisionLabelKey])
 	ready, notReady, pending, terminating, err := podCounter.PodCountsByState()
 	if err != nil {
 		return fmt.Errorf("error getting pod counts: %w", err)
@@ -185,7 +208,11 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, pa *autoscalingv1alpha1.
 		terminating: terminating,
 	}
 	logger.Infof("Observed pod counts=%#v", pc)
+	pa.Status.LastScaleDecision = decider.Status.LastDecision
 	computeStatus(ctx, pa, pc, logger)
+	if failure != nil {
+		pa.Status.MarkActivationFailed(failure.reason, failure.message)
+	}
 	return nil
 }
 
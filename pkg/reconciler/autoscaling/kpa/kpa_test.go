@@ -30,6 +30,7 @@ import (
 	fakenetworkingclient "knative.dev/networking/pkg/client/injection/client/fake"
 	fakesksinformer "knative.dev/networking/pkg/client/injection/informers/networking/v1alpha1/serverlessservice/fake"
 	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
+	_ "knative.dev/pkg/client/injection/kube/informers/core/v1/namespace/fake"
 	fakefilteredpodsinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/pod/filtered/fake"
 	_ "knative.dev/pkg/client/injection/kube/informers/core/v1/service/fake"
 	_ "knative.dev/pkg/client/injection/kube/informers/factory/filtered/fake"
@@ -55,6 +56,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
 	clientgotesting "k8s.io/client-go/testing"
+	clocktest "k8s.io/utils/clock/testing"
 
 	"github.com/google/go-cmp/cmp"
 	"go.opencensus.io/resource"
@@ -180,6 +182,12 @@ func withScales(g, w int32) PodAutoscalerOption {
 	}
 }
 
+func withColdStart(activationTime time.Time) PodAutoscalerOption {
+	return func(pa *autoscalingv1alpha1.PodAutoscaler) {
+		pa.Status.ColdStart = &autoscalingv1alpha1.ColdStartStatus{ActivationTime: metav1.NewTime(activationTime)}
+	}
+}
+
 func metricWithDiffSvc(ns, n string) *autoscalingv1alpha1.Metric {
 	m := metric(ns, n)
 	m.Spec.ScrapeTarget = "something-else"
@@ -250,6 +258,7 @@ func TestReconcile(t *testing.T) {
 		scaledAct  = 4 // 11 or 12 ready pods + 200 TBC
 	)
 	privateSvc := names.PrivateService(testRevision)
+	fc := clocktest.NewFakePassiveClock(time.Now())
 
 	// Set up a default deployment with the appropriate scale so that we don't
 	// see patches to correct that scale.
@@ -303,6 +312,7 @@ func TestReconcile(t *testing.T) {
 
 	defaultSKS := sks(testNamespace, testRevision, WithDeployRef(deployName), WithSKSReady, WithNumActivators(defaultAct))
 	defaultProxySKS := sks(testNamespace, testRevision, WithDeployRef(deployName), WithProxyMode, WithSKSReady, WithNumActivators(defaultAct))
+	minActivatorsProxySKS := sks(testNamespace, testRevision, WithDeployRef(deployName), WithProxyMode, WithSKSReady, WithNumActivators(minActivators))
 	defaultMetric := metric(testNamespace, testRevision)
 
 	underscaledReady := makeReadyPods(underscale, testNamespace, testRevision)
@@ -313,6 +323,35 @@ func TestReconcile(t *testing.T) {
 	type deciderKey struct{}
 	type asConfigKey struct{}
 	type netConfigKey struct{}
+	type deploymentConfigKey struct{}
+
+	crashLoopConfig, _ := deployment.NewConfigFromMap(map[string]string{
+		deployment.QueueSidecarImageKey: "bob",
+		deployment.ProgressDeadlineKey:  progressDeadline.String(),
+		"crash-loop-restart-threshold":  "3",
+	})
+	crashLoopingPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testRevision + "-crashing",
+			Namespace: testNamespace,
+			Labels:    map[string]string{serving.RevisionLabelKey: testRevision},
+		},
+		Status: corev1.PodStatus{
+			Phase:     corev1.PodRunning,
+			StartTime: &metav1.Time{Time: fc.Now().Add(-time.Minute)},
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:         "user-container",
+				RestartCount: 3,
+				LastTerminationState: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						ExitCode:   1,
+						FinishedAt: metav1.Time{Time: fc.Now().Add(-10 * time.Second)},
+					},
+				},
+			}},
+		},
+	}
+	crashLoopMessage := `Container "user-container" restarted 3 times within 5m0s`
 
 	retryAttempted := false
 
@@ -480,7 +519,7 @@ func TestReconcile(t *testing.T) {
 		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
 			Object: kpa(testNamespace, testRevision, WithScaleTargetInitialized, WithBufferedTraffic, withScales(0, defaultScale),
 				WithPASKSReady, WithPAMetricsService(privateSvc),
-				WithPAStatusService(testRevision), WithObservedGeneration(1)),
+				WithPAStatusService(testRevision), WithObservedGeneration(1), withColdStart(fc.Now())),
 		}},
 	}, {
 		Name: "sks is still not ready",
@@ -716,6 +755,35 @@ func TestReconcile(t *testing.T) {
 				d.Spec.Replicas = ptr.Int32(0)
 			}),
 		},
+	}, {
+		Name: "propagate last scale decision",
+		Key:  key,
+		Ctx: context.WithValue(context.Background(), deciderKey{},
+			func() *scaling.Decider {
+				d := decider(testNamespace, testRevision, 0 /* desiredScale */, 0 /* ebc */)
+				d.Status.LastDecision = "desired=0 on concurrency"
+				return d
+			}()),
+		Objects: []runtime.Object{
+			kpa(testNamespace, testRevision, WithScaleTargetInitialized, withScales(0, 0),
+				WithNoTraffic(noTrafficReason, "The target is not receiving traffic."),
+				WithPASKSReady, markOld, WithPAStatusService(testRevision),
+				WithPAMetricsService(privateSvc), WithObservedGeneration(1)),
+			sks(testNamespace, testRevision, WithDeployRef(deployName), WithProxyMode, WithSKSReady),
+			metric(testNamespace, testRevision),
+			deploy(testNamespace, testRevision, func(d *appsv1.Deployment) {
+				d.Spec.Replicas = ptr.Int32(0)
+			}),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: kpa(testNamespace, testRevision, WithScaleTargetInitialized, withScales(0, 0),
+				WithNoTraffic(noTrafficReason, "The target is not receiving traffic."),
+				WithPASKSReady, markOld, WithPAStatusService(testRevision),
+				WithPAMetricsService(privateSvc), WithObservedGeneration(1),
+				func(pa *autoscalingv1alpha1.PodAutoscaler) {
+					pa.Status.LastScaleDecision = "desired=0 on concurrency"
+				}),
+		}},
 	}, {
 		Name: "steady not serving (scale to zero)",
 		Key:  key,
@@ -798,6 +866,51 @@ func TestReconcile(t *testing.T) {
 			Name:  deployName,
 			Patch: []byte(`[{"op":"add","path":"/spec/replicas","value":0}]`),
 		}},
+	}, {
+		Name: "crash-looping pods fail the activation",
+		Key:  key,
+		Ctx: context.WithValue(context.WithValue(context.Background(), deploymentConfigKey{}, crashLoopConfig), deciderKey{},
+			decider(testNamespace, testRevision, 1 /* desiredScale */, 0 /* ebc */)),
+		Objects: []runtime.Object{
+			kpa(testNamespace, testRevision, WithPASKSReady, WithBufferedTraffic,
+				WithPAStatusService(testRevision), withScales(0, 1),
+				WithPAMetricsService(privateSvc)),
+			defaultSKS,
+			metric(testNamespace, testRevision),
+			deploy(testNamespace, testRevision), crashLoopingPod},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: kpa(testNamespace, testRevision, WithPASKSReady, WithPAMetricsService(privateSvc),
+				WithNoTraffic(autoscalingv1alpha1.ReasonCrashLooping, crashLoopMessage), withScales(0, unknownScale),
+				WithPAStatusService(testRevision), WithObservedGeneration(1)),
+		}},
+		WantUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: minActivatorsProxySKS,
+		}},
+	}, {
+		Name: "crash-looping pods are scaled to zero",
+		Key:  key,
+		Ctx: context.WithValue(context.WithValue(context.Background(), deploymentConfigKey{}, crashLoopConfig), deciderKey{},
+			decider(testNamespace, testRevision, 1 /* desiredScale */, 0 /* ebc */)),
+		Objects: []runtime.Object{
+			kpa(testNamespace, testRevision, WithPASKSReady,
+				WithNoTraffic(autoscalingv1alpha1.ReasonCrashLooping, crashLoopMessage), markOld,
+				WithPAStatusService(testRevision), withScales(0, unknownScale),
+				WithPAMetricsService(privateSvc), WithObservedGeneration(1)),
+			minActivatorsProxySKS,
+			metric(testNamespace, testRevision),
+			deploy(testNamespace, testRevision), crashLoopingPod},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: kpa(testNamespace, testRevision, WithPASKSReady, WithPAMetricsService(privateSvc),
+				WithNoTraffic(autoscalingv1alpha1.ReasonCrashLooping, crashLoopMessage), markOld, withScales(0, 0),
+				WithPAStatusService(testRevision), WithObservedGeneration(1)),
+		}},
+		WantPatches: []clientgotesting.PatchActionImpl{{
+			ActionImpl: clientgotesting.ActionImpl{
+				Namespace: testNamespace,
+			},
+			Name:  deployName,
+			Patch: []byte(`[{"op":"add","path":"/spec/replicas","value":0}]`),
+		}},
 	}, {
 		Name: "want=-1, underscaled, PA inactive",
 		// No-op
@@ -1199,6 +1312,9 @@ func TestReconcile(t *testing.T) {
 		if netConfig := ctx.Value(netConfigKey{}); netConfig != nil {
 			testConfigs.Network = netConfig.(*netcfg.Config)
 		}
+		if deploymentConfig := ctx.Value(deploymentConfigKey{}); deploymentConfig != nil {
+			testConfigs.Deployment = deploymentConfig.(*deployment.Config)
+		}
 		psf := podscalable.Get(ctx)
 		scaler := newScaler(ctx, psf, func(interface{}, time.Duration) {})
 		scaler.activatorProbe = func(*autoscalingv1alpha1.PodAutoscaler, http.RoundTripper) (bool, error) { return true, nil }
@@ -1212,6 +1328,7 @@ func TestReconcile(t *testing.T) {
 			podsLister: listers.GetPodsLister(),
 			deciders:   fakeDeciders,
 			scaler:     scaler,
+			clock:      fc,
 		}
 		return pareconciler.NewReconciler(ctx, logging.FromContext(ctx),
 			servingclient.Get(ctx), listers.GetPodAutoscalerLister(),
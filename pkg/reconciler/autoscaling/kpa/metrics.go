@@ -17,10 +17,12 @@ limitations under the License.
 package kpa
 
 import (
-	pkgmetrics "knative.dev/pkg/metrics"
-
 	"go.opencensus.io/stats"
 	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	pkgmetrics "knative.dev/pkg/metrics"
+	"knative.dev/serving/pkg/metrics"
 )
 
 var (
@@ -44,6 +46,14 @@ var (
 		"terminating_pods",
 		"Number of pods that are terminating currently",
 		stats.UnitDimensionless)
+	coldStartLatencyM = stats.Float64(
+		"cold_start_latencies",
+		"The duration of the phases of activations from zero in milliseconds",
+		stats.UnitMilliseconds)
+
+	// NOTE: 0 should not be used as boundary. See
+	// https://github.com/census-ecosystem/opencensus-go-exporter-stackdriver/issues/98
+	coldStartLatencyDistribution = view.Distribution(10, 50, 100, 250, 500, 1000, 2000, 3000, 5000, 10000, 20000, 30000, 60000, 120000, 300000, 600000)
 )
 
 func init() {
@@ -80,6 +90,12 @@ func register() {
 			Measure:     terminatingPodCountM,
 			Aggregation: view.LastValue(),
 		},
+		&view.View{
+			Description: "The duration of the phases of activations from zero in milliseconds",
+			Measure:     coldStartLatencyM,
+			Aggregation: coldStartLatencyDistribution,
+			TagKeys:     []tag.Key{metrics.ColdStartPhaseKey},
+		},
 	); err != nil {
 		panic(err)
 	}
@@ -87,6 +87,8 @@ func MakeDecider(pa *autoscalingv1alpha1.PodAutoscaler, config *autoscalerconfig
 			InitialScale:        GetInitialScale(config, pa),
 			Reachable:           pa.Spec.Reachability != autoscalingv1alpha1.ReachabilityUnreachable,
 			ActivationScale:     activationScale,
+			ScaleUpRules:        pa.ScaleUpRules(),
+			ScaleDownRules:      pa.ScaleDownRules(),
 		},
 	}
 }
@@ -171,6 +171,25 @@ func TestMakeDecider(t *testing.T) {
 				d.Spec.ActivationScale = 3
 				d.Annotations[autoscaling.ActivationScaleKey] = "3"
 			}),
+	}, {
+		name: "with scaling rules",
+		pa: pa(func(pa *autoscalingv1alpha1.PodAutoscaler) {
+			pa.Annotations[autoscaling.ScaleDownPoliciesAnnotationKey] = "Pods=2/1m"
+			pa.Annotations[autoscaling.ScaleUpStabilizationWindowAnnotationKey] = "30s"
+		}),
+		want: decider(withTarget(100.0), withPanicThreshold(2.0), withTotal(100),
+			func(d *scaling.Decider) {
+				d.Spec.ScaleUpRules = &autoscaling.ScalingRules{StabilizationWindow: 30 * time.Second}
+				d.Spec.ScaleDownRules = &autoscaling.ScalingRules{
+					Policies: []autoscaling.ScalingPolicy{{
+						Type:   autoscaling.PodsScalingPolicy,
+						Value:  2,
+						Period: time.Minute,
+					}},
+				}
+				d.Annotations[autoscaling.ScaleDownPoliciesAnnotationKey] = "Pods=2/1m"
+				d.Annotations[autoscaling.ScaleUpStabilizationWindowAnnotationKey] = "30s"
+			}),
 	}}
 
 	for _, tc := range cases {
@@ -91,6 +91,9 @@ type scaler struct {
 	// For async probes.
 	probeManager asyncProber
 	enqueueCB    func(interface{}, time.Duration)
+
+	// budget limits the scale to the pod budget of the namespace, if set.
+	budget *podBudget
 }
 
 // newScaler creates a scaler.
@@ -349,6 +352,13 @@ func (ks *scaler) scale(ctx context.Context, pa *autoscalingv1alpha1.PodAutoscal
 		desiredScale = newScale
 	}
 
+	if ks.budget != nil {
+		desiredScale = ks.budget.apply(ctx, pa, desiredScale)
+		if pa.Status.IsThrottled() {
+			ks.enqueueCB(pa, budgetResyncPeriod)
+		}
+	}
+
 	desiredScale, shouldApplyScale := ks.handleScaleToZero(ctx, pa, sks, desiredScale)
 	if !shouldApplyScale {
 		return desiredScale, nil
@@ -100,5 +100,11 @@ func (c *Base) ReconcileMetric(ctx context.Context, pa *autoscalingv1alpha1.PodA
 		}
 	}
 
+	// Propagate the resource requests recommended by the collector.
+	pa.Status.RecommendedResources = nil
+	if metric != nil && config.FromContext(ctx).Autoscaler.EnableResourceRecommendations {
+		pa.Status.RecommendedResources = metric.Status.RecommendedResources.DeepCopy()
+	}
+
 	return nil
 }
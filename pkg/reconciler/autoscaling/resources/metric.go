@@ -62,6 +62,7 @@ func MakeMetric(pa *autoscalingv1alpha1.PodAutoscaler, metricSvc string, config
 			StableWindow: stableWindow,
 			PanicWindow:  panicWindow,
 			ScrapeTarget: metricSvc,
+			Source:       pa.ExternalMetricSource(),
 		},
 	}
 }
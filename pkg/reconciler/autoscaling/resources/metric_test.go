@@ -72,6 +72,25 @@ func TestMakeMetric(t *testing.T) {
 			withScrapeTarget("dansen"),
 			withStableWindow(time.Minute), withPanicWindow(31*time.Second),
 			withPanicWindowPercentageAnnotation("51")),
+	}, {
+		name: "with external metric source",
+		pa: pa(WithMetricAnnotation(autoscaling.External), func(pa *autoscalingv1alpha1.PodAutoscaler) {
+			pa.Annotations[autoscaling.MetricSourceTypeAnnotationKey] = autoscaling.MetricSourcePrometheus
+			pa.Annotations[autoscaling.MetricSourceURLAnnotationKey] = "http://prometheus.monitoring:9090"
+			pa.Annotations[autoscaling.MetricSourceQueryAnnotationKey] = "sum(queue_length)"
+		}),
+		msn: "ik",
+		want: metric(withScrapeTarget("ik"), func(m *autoscalingv1alpha1.Metric) {
+			m.Annotations[autoscaling.MetricAnnotationKey] = autoscaling.External
+			m.Annotations[autoscaling.MetricSourceTypeAnnotationKey] = autoscaling.MetricSourcePrometheus
+			m.Annotations[autoscaling.MetricSourceURLAnnotationKey] = "http://prometheus.monitoring:9090"
+			m.Annotations[autoscaling.MetricSourceQueryAnnotationKey] = "sum(queue_length)"
+			m.Spec.Source = &autoscalingv1alpha1.ExternalMetricSource{
+				Type:  autoscaling.MetricSourcePrometheus,
+				URL:   "http://prometheus.monitoring:9090",
+				Query: "sum(queue_length)",
+			}
+		}),
 	}}
 
 	for _, tc := range cases {
@@ -35,6 +35,21 @@ func ResolveMetricTarget(pa *autoscalingv1alpha1.PodAutoscaler, config *autoscal
 	case autoscaling.RPS:
 		total = config.RPSTargetDefault
 		tu = config.TargetUtilization
+	case autoscaling.CPU, autoscaling.Memory:
+		// The utilization of a resource is a percentage of the requests of the
+		// pods. Like for the HPA, the target annotation is the percentage to aim
+		// for, rather than the maximum to apply the target utilization to.
+		total = 100
+		if annotationTarget, ok := pa.Target(); ok {
+			return math.Max(autoscaling.TargetMin, annotationTarget), total
+		}
+		tu = config.TargetUtilization
+	case autoscaling.External:
+		// An external metric, such as the length of a queue, is shared among
+		// the pods as is, so the target annotation, which is required for it,
+		// is the value each pod should handle.
+		annotationTarget, _ := pa.Target()
+		return math.Max(autoscaling.TargetMin, annotationTarget), annotationTarget
 	default:
 		// Concurrency is used by default
 		total = float64(pa.Spec.ContainerConcurrency)
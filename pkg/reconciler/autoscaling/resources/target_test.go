@@ -170,6 +170,26 @@ func TestResolveMetricTarget(t *testing.T) {
 		pa:         pa(WithMetricAnnotation(autoscaling.RPS), WithTargetAnnotation("300")),
 		wantTarget: 210,
 		wantTotal:  300,
+	}, {
+		name:       "CPU: defaults",
+		pa:         pa(WithMetricAnnotation(autoscaling.CPU)),
+		wantTarget: 70,
+		wantTotal:  100,
+	}, {
+		name:       "CPU: with TU annotation 80%",
+		pa:         pa(WithMetricAnnotation(autoscaling.CPU), WithTUAnnotation("80")),
+		wantTarget: 80,
+		wantTotal:  100,
+	}, {
+		name:       "Memory: with target annotation 60",
+		pa:         pa(WithMetricAnnotation(autoscaling.Memory), WithTUAnnotation("80"), WithTargetAnnotation("60")),
+		wantTarget: 60,
+		wantTotal:  100,
+	}, {
+		name:       "External: with target annotation 50",
+		pa:         pa(WithMetricAnnotation(autoscaling.External), WithTUAnnotation("80"), WithTargetAnnotation("50")),
+		wantTarget: 50,
+		wantTotal:  50,
 	}}
 
 	for _, tc := range cases {
@@ -28,12 +28,14 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/utils/clock"
 
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/kmp"
 	"knative.dev/pkg/logging"
 	pkgreconciler "knative.dev/pkg/reconciler"
+	"knative.dev/pkg/tracker"
 	"knative.dev/serving/pkg/apis/serving"
 	v1 "knative.dev/serving/pkg/apis/serving/v1"
 	clientset "knative.dev/serving/pkg/client/clientset/versioned"
@@ -47,7 +49,12 @@ type Reconciler struct {
 	client clientset.Interface
 
 	// listers index properties about resources
-	revisionLister listers.RevisionLister
+	revisionLister  listers.RevisionLister
+	configMapLister corev1listers.ConfigMapLister
+	secretLister    corev1listers.SecretLister
+
+	// tracker tracks the ConfigMaps and Secrets the Configurations refer to.
+	tracker tracker.Interface
 
 	clock clock.PassiveClock
 }
@@ -63,10 +70,20 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, config *v1.Configuration
 	logger := logging.FromContext(ctx)
 	recorder := controller.GetEventRecorder(ctx)
 
+	// Hash the referenced ConfigMaps and Secrets, if their changes are to be
+	// rolled out.
+	var configHash string
+	if rolloutOnConfigChange(config) {
+		var err error
+		if configHash, err = c.referencedConfigHash(config); err != nil {
+			return fmt.Errorf("failed to hash the referenced ConfigMaps and Secrets: %w", err)
+		}
+	}
+
 	// First, fetch the revision that should exist for the current generation.
 	lcr, isBYOName, err := c.latestCreatedRevision(ctx, config)
 	if errors.IsNotFound(err) {
-		lcr, err = c.createRevision(ctx, config)
+		lcr, err = c.createRevision(ctx, config, configHash)
 		if errors.IsAlreadyExists(err) {
 			// Newer revisions with a consistent naming scheme can theoretically hit this
 			// path during normal operation so we don't actually report any failures to
@@ -90,6 +107,14 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, config *v1.Configuration
 		return fmt.Errorf("failed to get Revision: %w", err)
 	}
 
+	// Roll out a new revision if the referenced ConfigMaps and Secrets changed
+	// since the latest one was created.
+	if configHash != "" && lcr.Annotations[serving.ReferencedConfigHashAnnotationKey] != configHash {
+		if err := c.rolloutConfigChange(ctx, config, configHash); err != nil {
+			return err
+		}
+	}
+
 	revName := lcr.Name
 
 	// Second, set this to be the latest revision that we have created.
@@ -146,6 +171,7 @@ func (c *Reconciler) findAndSetLatestReadyRevision(ctx context.Context, config *
 		if rev.IsReady() {
 			old, new := config.Status.LatestReadyRevisionName, rev.Name
 			config.Status.SetLatestReadyRevisionName(rev.Name)
+			config.Status.RecommendedResources = rev.Status.RecommendedResources.DeepCopy()
 			if old != new {
 				controller.GetEventRecorder(ctx).Eventf(
 					config, corev1.EventTypeNormal, "LatestReadyUpdate",
@@ -297,10 +323,13 @@ func (c *Reconciler) latestCreatedRevision(ctx context.Context, config *v1.Confi
 	return nil, false, errors.NewNotFound(v1.Resource("revisions"), "revision for "+config.Name)
 }
 
-func (c *Reconciler) createRevision(ctx context.Context, config *v1.Configuration) (*v1.Revision, error) {
+func (c *Reconciler) createRevision(ctx context.Context, config *v1.Configuration, configHash string) (*v1.Revision, error) {
 	logger := logging.FromContext(ctx)
 
 	rev := resources.MakeRevision(ctx, config, c.clock.Now())
+	if configHash != "" {
+		rev.Annotations[serving.ReferencedConfigHashAnnotationKey] = configHash
+	}
 	created, err := c.client.ServingV1().Revisions(config.Namespace).Create(ctx, rev, metav1.CreateOptions{})
 	if err != nil {
 		return nil, err
@@ -23,6 +23,9 @@ import (
 	"time"
 
 	// Inject the fake informers we need.
+	_ "knative.dev/pkg/client/injection/kube/informers/core/v1/configmap/filtered/fake"
+	_ "knative.dev/pkg/client/injection/kube/informers/core/v1/secret/filtered/fake"
+	_ "knative.dev/pkg/client/injection/kube/informers/factory/filtered/fake"
 	_ "knative.dev/serving/pkg/client/injection/informers/serving/v1/configuration/fake"
 	_ "knative.dev/serving/pkg/client/injection/informers/serving/v1/revision/fake"
 
@@ -38,6 +41,8 @@ import (
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
 	"knative.dev/pkg/ptr"
+	"knative.dev/pkg/tracker"
+	"knative.dev/serving/pkg/apis/serving"
 	v1 "knative.dev/serving/pkg/apis/serving/v1"
 	servingclient "knative.dev/serving/pkg/client/injection/client/fake"
 	configreconciler "knative.dev/serving/pkg/client/injection/reconciler/serving/v1/configuration"
@@ -71,6 +76,29 @@ func TestReconcile(t *testing.T) {
 
 	now := testClock.Now()
 
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "foo",
+			Name:      "settings",
+			Labels:    map[string]string{serving.RolloutOnConfigChangeLabelKey: "true"},
+		},
+		Data: map[string]string{"LEVEL": "debug"},
+	}
+	rolloutOnConfigChange := func(cfg *v1.Configuration) {
+		cfg.Annotations = map[string]string{serving.RolloutOnConfigChangeAnnotationKey: "true"}
+		cfg.Spec.Template.Spec.Containers[0].EnvFrom = []corev1.EnvFromSource{{
+			ConfigMapRef: &corev1.ConfigMapEnvSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "settings"},
+			},
+		}}
+	}
+	configHash := referencedConfigHashOf(t, cfg("hash", "foo", 1, rolloutOnConfigChange), configMap)
+	withConfigHash := func(h string) RevisionOption {
+		return func(rev *v1.Revision) {
+			rev.Annotations[serving.ReferencedConfigHashAnnotationKey] = h
+		}
+	}
+
 	table := TableTest{{
 		Name: "bad workqueue key",
 		Key:  "too/many/parts",
@@ -117,6 +145,69 @@ func TestReconcile(t *testing.T) {
 			Eventf(corev1.EventTypeNormal, "Created", "Created Revision %q", "no-revisions-yet-01234"),
 		},
 		Key: "foo/no-revisions-yet",
+	}, {
+		Name: "create revision with the hash of the referenced config",
+		Ctx:  config.ToContext(context.Background(), config.FromContext(testCtx)),
+		Objects: []runtime.Object{
+			cfg("referenced-config", "foo", 1, rolloutOnConfigChange),
+			configMap,
+		},
+		WantCreates: []runtime.Object{
+			rev("referenced-config", "foo", 1, func(rev *v1.Revision) {
+				rev.Spec.Containers[0].EnvFrom = []corev1.EnvFromSource{{
+					ConfigMapRef: &corev1.ConfigMapEnvSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "settings"},
+					},
+				}}
+			}, withConfigHash(configHash)),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: cfg("referenced-config", "foo", 1, rolloutOnConfigChange,
+				WithLatestCreated("referenced-config-00001"), WithConfigObservedGen),
+		}},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "Created", "Created Revision %q", "referenced-config-00001"),
+		},
+		PostConditions: []func(*testing.T, *TableRow){
+			AssertTrackingObject(corev1.SchemeGroupVersion.WithKind("ConfigMap"), "foo", "settings"),
+		},
+		Key: "foo/referenced-config",
+	}, {
+		Name: "roll out the changed referenced config",
+		Ctx:  config.ToContext(context.Background(), config.FromContext(testCtx)),
+		Objects: []runtime.Object{
+			cfg("changed-config", "foo", 1, rolloutOnConfigChange,
+				WithLatestCreated("changed-config-00001"), WithConfigObservedGen),
+			rev("changed-config", "foo", 1, WithCreationTimestamp(now), withConfigHash("stale")),
+			configMap,
+		},
+		WantUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: cfg("changed-config", "foo", 1, rolloutOnConfigChange,
+				WithLatestCreated("changed-config-00001"), WithConfigObservedGen,
+				func(cfg *v1.Configuration) {
+					cfg.Spec.Template.Annotations = map[string]string{
+						serving.ReferencedConfigHashAnnotationKey: configHash,
+					}
+				}),
+		}},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "ConfigChanged", "Rolling out the changed ConfigMaps and Secrets"),
+		},
+		Key: "foo/changed-config",
+	}, {
+		Name: "don't roll out the changed referenced config of a byo name",
+		Ctx:  config.ToContext(context.Background(), config.FromContext(testCtx)),
+		Objects: []runtime.Object{
+			cfg("byo-changed-config", "foo", 1, rolloutOnConfigChange, func(cfg *v1.Configuration) {
+				cfg.Spec.GetTemplate().Name = "byo-changed-config-foo"
+			}, WithLatestCreated("byo-changed-config-foo"), WithConfigObservedGen),
+			rev("byo-changed-config", "foo", 1, WithCreationTimestamp(now), withConfigHash("stale"), func(rev *v1.Revision) {
+				rev.Name = "byo-changed-config-foo"
+				rev.GenerateName = ""
+			}),
+			configMap,
+		},
+		Key: "foo/byo-changed-config",
 	}, {
 		Name: "create revision byo name",
 		Ctx:  config.ToContext(context.Background(), config.FromContext(testCtx)),
@@ -570,9 +661,12 @@ func TestReconcile(t *testing.T) {
 	table.Test(t, MakeFactory(func(ctx context.Context, listers *Listers, cmw configmap.Watcher) controller.Reconciler {
 		retryAttempted = false
 		r := &Reconciler{
-			client:         servingclient.Get(ctx),
-			revisionLister: listers.GetRevisionLister(),
-			clock:          testClock,
+			client:          servingclient.Get(ctx),
+			revisionLister:  listers.GetRevisionLister(),
+			configMapLister: listers.GetConfigMapLister(),
+			secretLister:    listers.GetSecretLister(),
+			tracker:         ctx.Value(TrackerKey).(tracker.Interface),
+			clock:           testClock,
 		}
 
 		return configreconciler.NewReconciler(ctx, logging.FromContext(ctx),
@@ -582,6 +676,23 @@ func TestReconcile(t *testing.T) {
 	}))
 }
 
+// referencedConfigHashOf returns the hash of the ConfigMaps and Secrets the
+// Configuration refers to, given the objects.
+func referencedConfigHashOf(t *testing.T, config *v1.Configuration, objs ...runtime.Object) string {
+	t.Helper()
+	listers := NewListers(objs)
+	r := &Reconciler{
+		configMapLister: listers.GetConfigMapLister(),
+		secretLister:    listers.GetSecretLister(),
+		tracker:         &FakeTracker{},
+	}
+	h, err := r.referencedConfigHash(config)
+	if err != nil {
+		t.Fatal("referencedConfigHash() =", err)
+	}
+	return h
+}
+
 func cfg(name, namespace string, generation int64, co ...ConfigOption) *v1.Configuration {
 	c := &v1.Configuration{
 		ObjectMeta: metav1.ObjectMeta{
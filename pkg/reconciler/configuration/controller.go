@@ -19,11 +19,15 @@ package configuration
 import (
 	"context"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/utils/clock"
+	configmapinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/configmap/filtered"
+	secretinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/secret/filtered"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
+	"knative.dev/serving/pkg/apis/serving"
 	v1 "knative.dev/serving/pkg/apis/serving/v1"
 	servingclient "knative.dev/serving/pkg/client/injection/client"
 	configurationinformer "knative.dev/serving/pkg/client/injection/informers/serving/v1/configuration"
@@ -40,14 +44,19 @@ func NewController(
 	logger := logging.FromContext(ctx)
 	configurationInformer := configurationinformer.Get(ctx)
 	revisionInformer := revisioninformer.Get(ctx)
+	// Only the ConfigMaps and Secrets labeled to be rolled out are cached.
+	configMapInformer := configmapinformer.Get(ctx, serving.RolloutOnConfigChangeLabelKey)
+	secretInformer := secretinformer.Get(ctx, serving.RolloutOnConfigChangeLabelKey)
 
 	configStore := config.NewStore(logger.Named("config-store"))
 	configStore.WatchConfigs(cmw)
 
 	c := &Reconciler{
-		client:         servingclient.Get(ctx),
-		revisionLister: revisionInformer.Lister(),
-		clock:          &clock.RealClock{},
+		client:          servingclient.Get(ctx),
+		revisionLister:  revisionInformer.Lister(),
+		configMapLister: configMapInformer.Lister(),
+		secretLister:    secretInformer.Lister(),
+		clock:           &clock.RealClock{},
 	}
 	impl := configreconciler.NewImpl(ctx, c, func(*controller.Impl) controller.Options {
 		return controller.Options{ConfigStore: configStore}
@@ -60,5 +69,27 @@ func NewController(
 		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
 	})
 
+	c.tracker = impl.Tracker
+
+	// Make sure trackers are deleted once the observers are removed.
+	configurationInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		DeleteFunc: c.tracker.OnDeletedObserver,
+	})
+
+	// Reconcile the Configurations rolling out the changes of the ConfigMaps
+	// and Secrets they refer to.
+	configMapInformer.Informer().AddEventHandler(controller.HandleAll(
+		controller.EnsureTypeMeta(
+			c.tracker.OnChanged,
+			corev1.SchemeGroupVersion.WithKind("ConfigMap"),
+		),
+	))
+	secretInformer.Informer().AddEventHandler(controller.HandleAll(
+		controller.EnsureTypeMeta(
+			c.tracker.OnChanged,
+			corev1.SchemeGroupVersion.WithKind("Secret"),
+		),
+	))
+
 	return impl
 }
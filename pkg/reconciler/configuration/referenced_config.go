@@ -0,0 +1,158 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configuration
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/tracker"
+	"knative.dev/serving/pkg/apis/serving"
+	v1 "knative.dev/serving/pkg/apis/serving/v1"
+)
+
+// rolloutOnConfigChange returns true if the Configuration asks for its
+// referenced ConfigMaps and Secrets to be rolled out when they change.
+func rolloutOnConfigChange(config *v1.Configuration) bool {
+	_, v, _ := serving.RolloutOnConfigChangeAnnotation.Get(config.Annotations)
+	b, _ := strconv.ParseBool(v)
+	return b
+}
+
+// referencedConfigHash returns the hash of the versions of the ConfigMaps and
+// Secrets the template of the Configuration refers to through env or envFrom,
+// and tracks them so that the Configuration is reconciled when they change.
+// The hash is published in the template, so it covers the identity and the
+// version of the objects rather than their data, which it would reveal.
+// The objects without the RolloutOnConfigChangeLabelKey label aren't in the
+// listers, so they are hashed as missing.
+func (c *Reconciler) referencedConfigHash(config *v1.Configuration) (string, error) {
+	h := sha256.New()
+	for _, ref := range referencedConfig(config.Namespace, &config.Spec.Template.Spec) {
+		if err := c.tracker.TrackReference(ref, config); err != nil {
+			return "", err
+		}
+
+		var (
+			obj metav1.Object
+			err error
+		)
+		switch ref.Kind {
+		case "ConfigMap":
+			obj, err = c.configMapLister.ConfigMaps(ref.Namespace).Get(ref.Name)
+		case "Secret":
+			obj, err = c.secretLister.Secrets(ref.Namespace).Get(ref.Name)
+		}
+		switch {
+		case apierrs.IsNotFound(err):
+			// The missing objects are hashed too, so that creating them
+			// changes the hash.
+			fmt.Fprintf(h, "%s/%s missing\n", ref.Kind, ref.Name)
+		case err != nil:
+			return "", err
+		default:
+			fmt.Fprintf(h, "%s/%s %s %s\n", ref.Kind, ref.Name, obj.GetUID(), obj.GetResourceVersion())
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// referencedConfig returns the references to the ConfigMaps and Secrets the
+// containers of the revision spec refer to through env or envFrom, sorted.
+func referencedConfig(namespace string, spec *v1.RevisionSpec) []tracker.Reference {
+	refs := make(map[tracker.Reference]struct{})
+	add := func(kind, name string) {
+		refs[tracker.Reference{
+			APIVersion: "v1",
+			Kind:       kind,
+			Namespace:  namespace,
+			Name:       name,
+		}] = struct{}{}
+	}
+	for _, containers := range [][]corev1.Container{spec.InitContainers, spec.Containers} {
+		for _, container := range containers {
+			for _, env := range container.Env {
+				if env.ValueFrom == nil {
+					continue
+				}
+				if ref := env.ValueFrom.ConfigMapKeyRef; ref != nil {
+					add("ConfigMap", ref.Name)
+				}
+				if ref := env.ValueFrom.SecretKeyRef; ref != nil {
+					add("Secret", ref.Name)
+				}
+			}
+			for _, envFrom := range container.EnvFrom {
+				if ref := envFrom.ConfigMapRef; ref != nil {
+					add("ConfigMap", ref.Name)
+				}
+				if ref := envFrom.SecretRef; ref != nil {
+					add("Secret", ref.Name)
+				}
+			}
+		}
+	}
+
+	ret := make([]tracker.Reference, 0, len(refs))
+	for ref := range refs {
+		ret = append(ret, ref)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Kind != ret[j].Kind {
+			return ret[i].Kind < ret[j].Kind
+		}
+		return ret[i].Name < ret[j].Name
+	})
+	return ret
+}
+
+// rolloutConfigChange stamps the hash of the referenced ConfigMaps and Secrets
+// into the template of the Configuration, so that a new Revision picking up
+// their changes is created and rolled out.
+func (c *Reconciler) rolloutConfigChange(ctx context.Context, config *v1.Configuration, configHash string) error {
+	logger := logging.FromContext(ctx)
+	if config.Spec.Template.Name != "" {
+		// The template can't change without a new revision name.
+		logger.Warnf("Not rolling out the changed ConfigMaps and Secrets, as the template names its Revision %q",
+			config.Spec.Template.Name)
+		return nil
+	}
+
+	// The Configuration passed to ReconcileKind is only for status updates.
+	desired := config.DeepCopy()
+	if desired.Spec.Template.Annotations == nil {
+		desired.Spec.Template.Annotations = make(map[string]string, 1)
+	}
+	desired.Spec.Template.Annotations[serving.ReferencedConfigHashAnnotationKey] = configHash
+	if _, err := c.client.ServingV1().Configurations(config.Namespace).Update(ctx, desired, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to roll out the changed ConfigMaps and Secrets: %w", err)
+	}
+	logger.Info("Rolling out the changed ConfigMaps and Secrets")
+	controller.GetEventRecorder(ctx).Event(config, corev1.EventTypeNormal, "ConfigChanged",
+		"Rolling out the changed ConfigMaps and Secrets")
+	return nil
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configuration
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"knative.dev/pkg/tracker"
+	v1 "knative.dev/serving/pkg/apis/serving/v1"
+)
+
+func TestReferencedConfig(t *testing.T) {
+	local := func(name string) corev1.LocalObjectReference {
+		return corev1.LocalObjectReference{Name: name}
+	}
+	spec := &v1.RevisionSpec{
+		PodSpec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{
+				EnvFrom: []corev1.EnvFromSource{{
+					SecretRef: &corev1.SecretEnvSource{LocalObjectReference: local("init")},
+				}},
+			}},
+			Containers: []corev1.Container{{
+				Env: []corev1.EnvVar{{
+					Name:  "PLAIN",
+					Value: "value",
+				}, {
+					Name: "FROM_CONFIG_MAP",
+					ValueFrom: &corev1.EnvVarSource{
+						ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: local("b"), Key: "k"},
+					},
+				}, {
+					Name: "FROM_SECRET",
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: local("a"), Key: "k"},
+					},
+				}, {
+					Name: "FROM_FIELD",
+					ValueFrom: &corev1.EnvVarSource{
+						FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"},
+					},
+				}},
+				EnvFrom: []corev1.EnvFromSource{{
+					ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: local("a")},
+				}, {
+					ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: local("b")},
+				}},
+			}},
+		},
+	}
+
+	ref := func(kind, name string) tracker.Reference {
+		return tracker.Reference{APIVersion: "v1", Kind: kind, Namespace: "ns", Name: name}
+	}
+	want := []tracker.Reference{
+		ref("ConfigMap", "a"),
+		ref("ConfigMap", "b"),
+		ref("Secret", "a"),
+		ref("Secret", "init"),
+	}
+	if got := referencedConfig("ns", spec); !cmp.Equal(got, want) {
+		t.Error("referencedConfig (-want, +got):", cmp.Diff(want, got))
+	}
+}
+
+func TestReferencedConfigHash(t *testing.T) {
+	config := cfg("hash", "foo", 1, func(cfg *v1.Configuration) {
+		cfg.Spec.Template.Spec.Containers[0].EnvFrom = []corev1.EnvFromSource{{
+			ConfigMapRef: &corev1.ConfigMapEnvSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "settings"},
+			},
+		}, {
+			SecretRef: &corev1.SecretEnvSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "credentials"},
+			},
+		}}
+	})
+	configMap := func(uid types.UID, resourceVersion string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:       "foo",
+				Name:            "settings",
+				UID:             uid,
+				ResourceVersion: resourceVersion,
+			},
+			Data: map[string]string{"A": "1"},
+		}
+	}
+	secret := func(data string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:       "foo",
+				Name:            "credentials",
+				UID:             "secret-uid",
+				ResourceVersion: "7",
+			},
+			Data: map[string][]byte{"PASSWORD": []byte(data)},
+		}
+	}
+	hash := func(objs ...runtime.Object) string {
+		return referencedConfigHashOf(t, config, objs...)
+	}
+
+	base := hash(configMap("cm-uid", "1"), secret("hunter2"))
+	// The hash doesn't depend on the data, so it doesn't reveal it.
+	if got := hash(configMap("cm-uid", "1"), secret("password")); got != base {
+		t.Errorf("Hash with other data of the same version = %s, want: %s", got, base)
+	}
+	for name, objs := range map[string][]runtime.Object{
+		"updated config map":   {configMap("cm-uid", "2"), secret("hunter2")},
+		"recreated config map": {configMap("other-uid", "1"), secret("hunter2")},
+		"missing secret":       {configMap("cm-uid", "1")},
+		"missing config map":   {secret("hunter2")},
+	} {
+		if got := hash(objs...); got == base {
+			t.Errorf("Hash with %s = %s, want a different one", name, got)
+		}
+	}
+}
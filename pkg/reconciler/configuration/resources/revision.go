@@ -19,10 +19,13 @@ package resources
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/kmeta"
+	"knative.dev/serving/pkg/apis/autoscaling"
 	"knative.dev/serving/pkg/apis/serving"
 	v1 "knative.dev/serving/pkg/apis/serving/v1"
 )
@@ -46,6 +49,7 @@ func MakeRevision(ctx context.Context, configuration *v1.Configuration, tm time.
 
 	updateRevisionLabels(rev, configuration)
 	updateRevisionAnnotations(rev, configuration, tm)
+	applyRecommendedResources(rev, configuration)
 
 	// Populate OwnerReferences so that deletes cascade.
 	rev.OwnerReferences = append(rev.OwnerReferences, *kmeta.NewControllerRef(configuration))
@@ -96,6 +100,35 @@ func updateRevisionAnnotations(rev *v1.Revision, config metav1.Object, tm time.T
 	rev.SetAnnotations(annotations)
 }
 
+// applyRecommendedResources sets the resource requests of the container of the
+// revision to the ones recommended for the Configuration, if its template asks
+// for it. The requests are capped by the limits of the container.
+func applyRecommendedResources(rev *v1.Revision, config *v1.Configuration) {
+	_, v, _ := autoscaling.ApplyRecommendedResourcesAnnotation.Get(rev.Annotations)
+	if apply, _ := strconv.ParseBool(v); !apply || len(config.Status.RecommendedResources) == 0 {
+		return
+	}
+	// The recommendations are for the containers of the revision together,
+	// without the queue-proxy, so only apply them to revisions with a single
+	// container.
+	if len(rev.Spec.Containers) != 1 {
+		return
+	}
+
+	// The spec shares its containers with the template of the Configuration.
+	rev.Spec = *rev.Spec.DeepCopy()
+	resources := &rev.Spec.Containers[0].Resources
+	if resources.Requests == nil {
+		resources.Requests = make(corev1.ResourceList, len(config.Status.RecommendedResources))
+	}
+	for name, q := range config.Status.RecommendedResources {
+		if limit, ok := resources.Limits[name]; ok && q.Cmp(limit) > 0 {
+			q = limit
+		}
+		resources.Requests[name] = q
+	}
+}
+
 // RevisionLabelValueForKey returns the label value for the given key.
 func RevisionLabelValueForKey(key string, config metav1.Object) string {
 	switch key {
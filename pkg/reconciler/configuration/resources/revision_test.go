@@ -23,9 +23,11 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"knative.dev/pkg/ptr"
+	"knative.dev/serving/pkg/apis/autoscaling"
 	"knative.dev/serving/pkg/apis/serving"
 	v1 "knative.dev/serving/pkg/apis/serving/v1"
 )
@@ -353,6 +355,88 @@ func TestMakeRevisions(t *testing.T) {
 				},
 			},
 		},
+	}, {
+		name: "with recommended resources",
+		configuration: &v1.Configuration{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:  "with",
+				Name:       "recommendations",
+				Generation: 3,
+				UID:        "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa",
+			},
+			Spec: v1.ConfigurationSpec{
+				Template: v1.RevisionTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							autoscaling.ApplyRecommendedResourcesAnnotationKey: "true",
+						},
+					},
+					Spec: v1.RevisionSpec{
+						PodSpec: corev1.PodSpec{
+							Containers: []corev1.Container{{
+								Image: "busybox",
+								Resources: corev1.ResourceRequirements{
+									Requests: corev1.ResourceList{
+										corev1.ResourceCPU: resource.MustParse("1"),
+									},
+									Limits: corev1.ResourceList{
+										corev1.ResourceMemory: resource.MustParse("256Mi"),
+									},
+								},
+							}},
+						},
+					},
+				},
+			},
+			Status: v1.ConfigurationStatus{
+				ConfigurationStatusFields: v1.ConfigurationStatusFields{
+					RecommendedResources: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("250m"),
+						corev1.ResourceMemory: resource.MustParse("512Mi"),
+					},
+				},
+			},
+		},
+		want: &v1.Revision{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "with",
+				Name:      "recommendations-00003",
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion:         v1.SchemeGroupVersion.String(),
+					Kind:               "Configuration",
+					Name:               "recommendations",
+					Controller:         ptr.Bool(true),
+					BlockOwnerDeletion: ptr.Bool(true),
+					UID:                "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa",
+				}},
+				Labels: map[string]string{
+					serving.ConfigurationLabelKey:           "recommendations",
+					serving.ConfigurationGenerationLabelKey: "3",
+					serving.ConfigurationUIDLabelKey:        "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa",
+					serving.RoutingStateLabelKey:            "pending",
+				},
+				Annotations: map[string]string{
+					autoscaling.ApplyRecommendedResourcesAnnotationKey: "true",
+					serving.RoutingStateModifiedAnnotationKey:          v1.RoutingStateModifiedString(fakeCurTime),
+				},
+			},
+			Spec: v1.RevisionSpec{
+				PodSpec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Image: "busybox",
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("250m"),
+								corev1.ResourceMemory: resource.MustParse("256Mi"),
+							},
+							Limits: corev1.ResourceList{
+								corev1.ResourceMemory: resource.MustParse("256Mi"),
+							},
+						},
+					}},
+				},
+			},
+		},
 	}}
 
 	for _, test := range tests {
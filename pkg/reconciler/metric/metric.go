@@ -47,6 +47,10 @@ func (r *reconciler) ReconcileKind(_ context.Context, metric *autoscalingv1alpha
 			metric.Status.MarkMetricNotReady("NoEndpoints", err.Error())
 		case errors.Is(err, metrics.ErrDidNotReceiveStat):
 			metric.Status.MarkMetricFailed("DidNotReceiveStat", err.Error())
+		case errors.Is(err, metrics.ErrMetricSourceNotAllowed):
+			metric.Status.MarkMetricFailed("MetricSourceNotAllowed", err.Error())
+		case errors.Is(err, metrics.ErrFailedReadSource):
+			metric.Status.MarkMetricFailed("MetricSourceFailed", err.Error())
 		default:
 			metric.Status.MarkMetricFailed("CollectionFailed",
 				"Failed to reconcile metric collection: "+err.Error())
@@ -57,6 +61,12 @@ func (r *reconciler) ReconcileKind(_ context.Context, metric *autoscalingv1alpha
 	}
 
 	metric.Status.MarkMetricReady()
+	// The recommendation is kept until a new one is computed, e.g. after a
+	// restart of the autoscaler.
+	key := types.NamespacedName{Namespace: metric.Namespace, Name: metric.Name}
+	if r := r.collector.RecommendedResources(key); r != nil {
+		metric.Status.RecommendedResources = r
+	}
 	return nil
 }
 
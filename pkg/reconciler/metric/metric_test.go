@@ -26,6 +26,7 @@ import (
 	"golang.org/x/sync/errgroup"
 	corev1 "k8s.io/api/core/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -60,6 +61,10 @@ func TestNewController(t *testing.T) {
 
 func TestReconcile(t *testing.T) {
 	retryAttempted := false
+	recommendation := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("100m"),
+		corev1.ResourceMemory: resource.MustParse("128Mi"),
+	}
 	table := TableTest{{
 		Name: "bad workqueue key, Part I",
 		Key:  "too/many/parts",
@@ -116,6 +121,18 @@ func TestReconcile(t *testing.T) {
 				`Failed to update status for "update-failed": inducing failure for update metrics`),
 		},
 		WantErr: true,
+	}, {
+		Name: "recommended resources",
+		Ctx: context.WithValue(context.Background(), collectorKey{},
+			&testCollector{recommendation: recommendation},
+		),
+		Key: "status/recommended",
+		Objects: []runtime.Object{
+			metric("status", "recommended"),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: metric("status", "recommended", ready, recommended(recommendation)),
+		}},
 	}, {
 		Name: "cannot create collection-part I",
 		Ctx: context.WithValue(context.Background(), collectorKey{},
@@ -165,6 +182,19 @@ func TestReconcile(t *testing.T) {
 			Object: metric("bad", "collector", failed("DidNotReceiveStat",
 				metrics.ErrDidNotReceiveStat.Error())),
 		}},
+	}, {
+		Name: "metric source not allowed",
+		Ctx: context.WithValue(context.Background(), collectorKey{},
+			&testCollector{createOrUpdateError: metrics.ErrMetricSourceNotAllowed},
+		),
+		Key: "bad/collector",
+		Objects: []runtime.Object{
+			metric("bad", "collector"),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: metric("bad", "collector", failed("MetricSourceNotAllowed",
+				metrics.ErrMetricSourceNotAllowed.Error())),
+		}},
 	}}
 
 	table.Test(t, MakeFactory(func(ctx context.Context, listers *Listers, cmw configmap.Watcher) controller.Reconciler {
@@ -239,6 +269,12 @@ func unknown(r, m string) metricOption {
 	}
 }
 
+func recommended(r corev1.ResourceList) metricOption {
+	return func(metric *autoscalingv1alpha1.Metric) {
+		metric.Status.RecommendedResources = r
+	}
+}
+
 func ready(m *autoscalingv1alpha1.Metric) {
 	m.Status.MarkMetricReady()
 }
@@ -267,6 +303,8 @@ type testCollector struct {
 	createOrUpdateError error
 
 	deleteCalls atomic.Int32
+
+	recommendation corev1.ResourceList
 }
 
 func (c *testCollector) CreateOrUpdate(metric *autoscalingv1alpha1.Metric) error {
@@ -279,3 +317,7 @@ func (c *testCollector) Delete(namespace, name string) {
 }
 
 func (c *testCollector) Watch(func(types.NamespacedName)) {}
+
+func (c *testCollector) RecommendedResources(types.NamespacedName) corev1.ResourceList {
+	return c.recommendation
+}
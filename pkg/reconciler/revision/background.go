@@ -18,6 +18,7 @@ package revision
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -27,7 +28,9 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
 	v1 "knative.dev/serving/pkg/apis/serving/v1"
+	"knative.dev/serving/pkg/deployment"
 )
 
 // imageResolver is an interface used mostly to mock digestResolver for tests.
@@ -40,6 +43,8 @@ type backgroundResolver struct {
 	logger *zap.SugaredLogger
 
 	resolver imageResolver
+	verifier imageVerifier
+	cache    *digestCache
 	enqueue  func(types.NamespacedName)
 
 	queue workqueue.RateLimitingInterface
@@ -54,6 +59,9 @@ type resolveResult struct {
 	// these fields are immutable afer creation, so can be accessed without a lock.
 	opt                k8schain.Options
 	registriesToSkip   sets.String
+	verification       *deployment.ImageVerificationPolicy
+	cacheTTL           time.Duration
+	cacheErrorTTL      time.Duration
 	completionCallback func()
 	workItems          []workItem
 
@@ -78,11 +86,13 @@ type workItem struct {
 	image string
 }
 
-func newBackgroundResolver(logger *zap.SugaredLogger, resolver imageResolver, queue workqueue.RateLimitingInterface, enqueue func(types.NamespacedName)) *backgroundResolver {
+func newBackgroundResolver(logger *zap.SugaredLogger, resolver imageResolver, verifier imageVerifier, queue workqueue.RateLimitingInterface, enqueue func(types.NamespacedName)) *backgroundResolver {
 	r := &backgroundResolver{
 		logger: logger,
 
 		resolver: resolver,
+		verifier: verifier,
+		cache:    newDigestCache(digestCacheSize, clock.RealClock{}),
 		enqueue:  enqueue,
 
 		results: make(map[types.NamespacedName]*resolveResult),
@@ -143,7 +153,11 @@ func (r *backgroundResolver) Start(stop <-chan struct{}, maxInFlight int) (done
 // If this method returns `nil, nil` this implies a resolve was triggered or is
 // already in progress, so the reconciler should exit and wait for the revision
 // to be re-enqueued when the result is ready.
-func (r *backgroundResolver) Resolve(logger *zap.SugaredLogger, rev *v1.Revision, opt k8schain.Options, registriesToSkip sets.String, timeout time.Duration) (initContainerStatuses []v1.ContainerStatus, statuses []v1.ContainerStatus, error error) {
+// The resolution follows the digest resolution settings of the deployment
+// config. If it has an image verification policy for the namespace of the
+// revision, the resolved digests are verified with it, and the images failing
+// it are reported with an imageVerificationError.
+func (r *backgroundResolver) Resolve(logger *zap.SugaredLogger, rev *v1.Revision, opt k8schain.Options, cfg *deployment.Config) (initContainerStatuses []v1.ContainerStatus, statuses []v1.ContainerStatus, error error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -155,7 +169,7 @@ func (r *backgroundResolver) Resolve(logger *zap.SugaredLogger, rev *v1.Revision
 	result, inFlight := r.results[name]
 	if !inFlight {
 		logger.Debugf("Adding Resolve request to queue (depth: %d)", r.queue.Len())
-		r.addWorkItems(rev, name, opt, registriesToSkip, timeout)
+		r.addWorkItems(rev, name, opt, cfg)
 		return nil, nil, nil
 	}
 
@@ -194,11 +208,14 @@ func (r *backgroundResolver) Resolve(logger *zap.SugaredLogger, rev *v1.Revision
 
 // addWorkItems adds a digest resolve item to the queue for each container in the revision.
 // This is expected to be called with the mutex locked.
-func (r *backgroundResolver) addWorkItems(rev *v1.Revision, name types.NamespacedName, opt k8schain.Options, registriesToSkip sets.String, timeout time.Duration) {
+func (r *backgroundResolver) addWorkItems(rev *v1.Revision, name types.NamespacedName, opt k8schain.Options, cfg *deployment.Config) {
 	totalNumOfContainers := len(rev.Spec.Containers) + len(rev.Spec.InitContainers)
 	r.results[name] = &resolveResult{
 		opt:                opt,
-		registriesToSkip:   registriesToSkip,
+		registriesToSkip:   cfg.RegistriesSkippingTagResolving,
+		verification:       cfg.ImageVerificationFor(rev.Namespace),
+		cacheTTL:           cfg.DigestResolutionCacheTTL,
+		cacheErrorTTL:      cfg.DigestResolutionCacheErrorTTL,
 		imagesResolved:     make(map[string]string),
 		imagesToBeResolved: sets.String{},
 		workItems:          make([]workItem, 0, totalNumOfContainers),
@@ -213,7 +230,7 @@ func (r *backgroundResolver) addWorkItems(rev *v1.Revision, name types.Namespace
 		}
 		item := workItem{
 			revision: name,
-			timeout:  timeout,
+			timeout:  cfg.DigestResolutionTimeout,
 			image:    container.Image,
 		}
 		r.results[name].workItems = append(r.results[name].workItems, item)
@@ -242,9 +259,13 @@ func (r *backgroundResolver) processWorkItem(item workItem) {
 	ctx, cancel := context.WithTimeout(context.Background(), item.timeout)
 	defer cancel()
 
-	r.logger.Debugf("Resolving image %q from revision %q to digest", item.image, item.revision)
-	resolvedDigest, resolveErr := r.resolver.Resolve(ctx, item.image, result.opt, result.registriesToSkip)
-	r.logger.Debugf("Resolved image %q from revision %q to digest %q, %v", item.image, item.revision, resolvedDigest, resolveErr)
+	resolvedDigest, resolveErr := r.resolve(ctx, item, result)
+
+	var verifyErr error
+	if resolveErr == nil && result.verification != nil {
+		verifyErr = r.verify(ctx, item.image, resolvedDigest, result)
+		r.logger.Debugf("Verified image %q from revision %q, %v", item.image, item.revision, verifyErr)
+	}
 
 	// lock after the resolve because we don't want to block parallel resolves,
 	// just storing the result.
@@ -269,6 +290,12 @@ func (r *backgroundResolver) processWorkItem(item workItem) {
 		return
 	}
 
+	if verifyErr != nil {
+		result.err = verifyErr
+		result.completionCallback()
+		return
+	}
+
 	result.imagesResolved[item.image] = resolvedDigest
 
 	if result.ready() {
@@ -276,6 +303,59 @@ func (r *backgroundResolver) processWorkItem(item workItem) {
 	}
 }
 
+// resolve resolves the image of the work item to a digest, through the digest
+// cache if the result enables it.
+func (r *backgroundResolver) resolve(ctx context.Context, item workItem, result *resolveResult) (string, error) {
+	useCache := result.cacheTTL > 0 || result.cacheErrorTTL > 0
+	key := newDigestCacheKey(item.image, result.opt)
+	if useCache {
+		if entry, ok := r.cache.get(key); ok {
+			r.logger.Debugf("Resolved image %q from revision %q to digest %q from the cache, %v", item.image, item.revision, entry.digest, entry.err)
+			return entry.digest, entry.err
+		}
+	}
+
+	r.logger.Debugf("Resolving image %q from revision %q to digest", item.image, item.revision)
+	digest, err := r.resolver.Resolve(ctx, item.image, result.opt, result.registriesToSkip)
+	r.logger.Debugf("Resolved image %q from revision %q to digest %q, %v", item.image, item.revision, digest, err)
+	if useCache {
+		r.cache.add(key, digest, err, result.cacheTTL, result.cacheErrorTTL)
+	}
+	return digest, err
+}
+
+// verify verifies the resolved digest of the image with the verification
+// policy of the result.
+func (r *backgroundResolver) verify(ctx context.Context, image, resolvedDigest string, result *resolveResult) error {
+	if resolvedDigest == "" {
+		// The images of the registries skipping tag resolution can't be verified,
+		// as there is no digest to verify.
+		return &imageVerificationError{
+			image: image,
+			err:   errors.New("the registry of the image skips tag resolution"),
+		}
+	}
+	if err := r.verifier.Verify(ctx, resolvedDigest, result.opt, result.verification); err != nil {
+		return &imageVerificationError{image: image, err: err}
+	}
+	return nil
+}
+
+// imageVerificationError is returned when an image doesn't satisfy the image
+// verification policy, as opposed to failing to be resolved.
+type imageVerificationError struct {
+	image string
+	err   error
+}
+
+func (e *imageVerificationError) Error() string {
+	return v1.RevisionImageVerificationFailedMessage(e.image, e.err.Error())
+}
+
+func (e *imageVerificationError) Unwrap() error {
+	return e.err
+}
+
 // Clear removes any cached results for the revision. This should be called
 // once the revision's ContainerStatus has been set.
 func (r *backgroundResolver) Clear(name types.NamespacedName) {
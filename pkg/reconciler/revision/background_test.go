@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"math"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -35,6 +36,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/util/workqueue"
 	v1 "knative.dev/serving/pkg/apis/serving/v1"
+	"knative.dev/serving/pkg/deployment"
 )
 
 var (
@@ -148,7 +150,7 @@ func TestResolveInBackground(t *testing.T) {
 			}
 
 			logger := logtesting.TestLogger(t)
-			subject := newBackgroundResolver(logger, tt.resolver, workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()), cb)
+			subject := newBackgroundResolver(logger, tt.resolver, nil, workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()), cb)
 
 			stop := make(chan struct{})
 			done := subject.Start(stop, 10)
@@ -161,7 +163,7 @@ func TestResolveInBackground(t *testing.T) {
 			for i := 0; i < 2; i++ {
 				t.Run(fmt.Sprint("iteration", i), func(t *testing.T) {
 					logger := logtesting.TestLogger(t)
-					initContainerStatuses, statuses, err := subject.Resolve(logger, fakeRevision, k8schain.Options{ServiceAccountName: "san"}, sets.NewString("skip"), timeout)
+					initContainerStatuses, statuses, err := subject.Resolve(logger, fakeRevision, k8schain.Options{ServiceAccountName: "san"}, &deployment.Config{RegistriesSkippingTagResolving: sets.NewString("skip"), DigestResolutionTimeout: timeout})
 					if err != nil || statuses != nil || initContainerStatuses != nil {
 						// Initial result should be nil, nil, nil since we have nothing in cache.
 						t.Errorf("Resolve() = %v, %v %v, wanted nil, nil, nil", statuses, initContainerStatuses, err)
@@ -176,7 +178,7 @@ func TestResolveInBackground(t *testing.T) {
 						t.Fatalf("Resolver did not report ready")
 					}
 
-					initContainerStatuses, statuses, err = subject.Resolve(logger, fakeRevision, k8schain.Options{}, nil, timeout)
+					initContainerStatuses, statuses, err = subject.Resolve(logger, fakeRevision, k8schain.Options{}, &deployment.Config{DigestResolutionTimeout: timeout})
 					if got, want := err, tt.wantError; !errors.Is(got, want) {
 						t.Errorf("Resolve() = _, %q, wanted %q", got, want)
 					}
@@ -198,6 +200,100 @@ func TestResolveInBackground(t *testing.T) {
 	}
 }
 
+func TestVerifyInBackground(t *testing.T) {
+	policy := &deployment.ImageVerificationPolicy{Mode: deployment.ImageVerificationSignature}
+	tests := []struct {
+		name         string
+		resolver     resolveFunc
+		verifier     verifyFunc
+		wantStatuses []v1.ContainerStatus
+		wantError    string
+	}{{
+		name: "verified",
+		resolver: func(_ context.Context, img string, _ k8schain.Options, _ sets.String) (string, error) {
+			return img + "-digest", nil
+		},
+		verifier: func(_ context.Context, img string, opt k8schain.Options, p *deployment.ImageVerificationPolicy) error {
+			if !strings.HasSuffix(img, "-digest") || opt.ServiceAccountName != "san" || p != policy {
+				return fmt.Errorf("unexpected Verify(%q, %v, %v)", img, opt, p)
+			}
+			return nil
+		},
+		wantStatuses: []v1.ContainerStatus{{
+			Name:        "first",
+			ImageDigest: "first-image-digest",
+		}, {
+			Name:        "second",
+			ImageDigest: "second-image-digest",
+		}},
+	}, {
+		name: "one image fails verification",
+		resolver: func(_ context.Context, img string, _ k8schain.Options, _ sets.String) (string, error) {
+			return img + "-digest", nil
+		},
+		verifier: func(_ context.Context, img string, _ k8schain.Options, _ *deployment.ImageVerificationPolicy) error {
+			if img == "second-image-digest" {
+				return errDigest
+			}
+			return nil
+		},
+		wantError: `Unable to verify image "second-image": digest error`,
+	}, {
+		name: "registry skipping tag resolution",
+		resolver: func(_ context.Context, img string, _ k8schain.Options, _ sets.String) (string, error) {
+			return "", nil
+		},
+		verifier: func(context.Context, string, k8schain.Options, *deployment.ImageVerificationPolicy) error {
+			return nil
+		},
+		wantError: "the registry of the image skips tag resolution",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ready := make(chan types.NamespacedName, 1)
+			logger := logtesting.TestLogger(t)
+			subject := newBackgroundResolver(logger, tt.resolver, tt.verifier, workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()), func(rev types.NamespacedName) {
+				ready <- rev
+			})
+
+			stop := make(chan struct{})
+			done := subject.Start(stop, 10)
+			defer func() {
+				close(stop)
+				<-done
+			}()
+
+			revision := rev("rev", "first-image", "second-image")
+			revision.Spec.InitContainers = nil
+			opt := k8schain.Options{ServiceAccountName: "san"}
+			cfg := &deployment.Config{ImageVerification: policy, DigestResolutionTimeout: 5 * time.Second}
+			if _, _, err := subject.Resolve(logger, revision, opt, cfg); err != nil {
+				t.Fatal("Resolve() =", err)
+			}
+			select {
+			case <-ready:
+			case <-time.After(2 * time.Second):
+				t.Fatal("Resolver did not report ready")
+			}
+
+			_, statuses, err := subject.Resolve(logger, revision, opt, cfg)
+			if tt.wantError == "" && err != nil {
+				t.Fatal("Resolve() =", err)
+			}
+			if tt.wantError != "" {
+				var verr *imageVerificationError
+				if !errors.As(err, &verr) || !strings.Contains(err.Error(), tt.wantError) {
+					t.Fatalf("Resolve() = %v, want an imageVerificationError containing %q", err, tt.wantError)
+				}
+			}
+			if !reflect.DeepEqual(statuses, tt.wantStatuses) {
+				t.Errorf("Resolve() = %v, wanted %v", statuses, tt.wantStatuses)
+			}
+		})
+	}
+}
+
 func TestRateLimitPerItem(t *testing.T) {
 	logger := logtesting.TestLogger(t)
 
@@ -213,7 +309,7 @@ func TestRateLimitPerItem(t *testing.T) {
 	queue := workqueue.NewRateLimitingQueue(newItemExponentialFailureRateLimiter(baseDelay, 5*time.Second))
 
 	enqueue := make(chan struct{})
-	subject := newBackgroundResolver(logger, resolver, queue, func(types.NamespacedName) {
+	subject := newBackgroundResolver(logger, resolver, nil, queue, func(types.NamespacedName) {
 		enqueue <- struct{}{}
 	})
 
@@ -229,14 +325,14 @@ func TestRateLimitPerItem(t *testing.T) {
 	for i := 0; i < 3; i++ {
 		subject.Clear(types.NamespacedName{Name: revision.Name, Namespace: revision.Namespace})
 		start := time.Now()
-		initResolution, resolution, err := subject.Resolve(logger, revision, k8schain.Options{ServiceAccountName: "san"}, sets.NewString("skip"), 0)
+		initResolution, resolution, err := subject.Resolve(logger, revision, k8schain.Options{ServiceAccountName: "san"}, &deployment.Config{RegistriesSkippingTagResolving: sets.NewString("skip")})
 		if err != nil || resolution != nil || initResolution != nil {
 			t.Fatalf("Expected Resolve to be nil, nil, nil but got %v, %v, %v", resolution, initResolution, err)
 		}
 
 		<-enqueue
 
-		_, _, err = subject.Resolve(logger, revision, k8schain.Options{ServiceAccountName: "san"}, sets.NewString("skip"), 0)
+		_, _, err = subject.Resolve(logger, revision, k8schain.Options{ServiceAccountName: "san"}, &deployment.Config{RegistriesSkippingTagResolving: sets.NewString("skip")})
 		if err == nil {
 			t.Fatalf("Expected Resolve to fail")
 		}
@@ -251,7 +347,7 @@ func TestRateLimitPerItem(t *testing.T) {
 
 	t.Run("Does not affect other revisions", func(t *testing.T) {
 		start := time.Now()
-		_, resolution, err := subject.Resolve(logger, rev("another-revision", "img1", "img2"), k8schain.Options{ServiceAccountName: "san"}, sets.NewString("skip"), 0)
+		_, resolution, err := subject.Resolve(logger, rev("another-revision", "img1", "img2"), k8schain.Options{ServiceAccountName: "san"}, &deployment.Config{RegistriesSkippingTagResolving: sets.NewString("skip")})
 		if err != nil || resolution != nil {
 			t.Fatalf("Expected Resolve to be nil, nil but got %v, %v", resolution, err)
 		}
@@ -266,7 +362,7 @@ func TestRateLimitPerItem(t *testing.T) {
 		subject.Forget(types.NamespacedName{Name: revision.Name, Namespace: revision.Namespace})
 
 		start := time.Now()
-		_, resolution, err := subject.Resolve(logger, revision, k8schain.Options{ServiceAccountName: "san"}, sets.NewString("skip"), 0)
+		_, resolution, err := subject.Resolve(logger, revision, k8schain.Options{ServiceAccountName: "san"}, &deployment.Config{RegistriesSkippingTagResolving: sets.NewString("skip")})
 		if err != nil || resolution != nil {
 			t.Fatalf("Expected Resolve to be nil, nil but got %v, %v", resolution, err)
 		}
@@ -285,6 +381,12 @@ func (r resolveFunc) Resolve(c context.Context, s string, o k8schain.Options, t
 	return r(c, s, o, t)
 }
 
+type verifyFunc func(context.Context, string, k8schain.Options, *deployment.ImageVerificationPolicy) error
+
+func (v verifyFunc) Verify(c context.Context, s string, o k8schain.Options, p *deployment.ImageVerificationPolicy) error {
+	return v(c, s, o, p)
+}
+
 func rev(name, firstImage, secondImage string) *v1.Revision {
 	return &v1.Revision{
 		ObjectMeta: metav1.ObjectMeta{
@@ -49,9 +49,11 @@ func TestStoreLoadWithContext(t *testing.T) {
 	defaultConfig := ConfigMapFromTestFile(t, apiconfig.DefaultsConfigName)
 	autoscalerConfig := ConfigMapFromTestFile(t, autoscalerconfig.ConfigName)
 	featuresConfig := ConfigMapFromTestFile(t, apiconfig.FeaturesConfigName)
+	policyConfig := ConfigMapFromTestFile(t, apiconfig.PolicyConfigName)
 
 	watcher := configmap.NewStaticWatcher(
 		featuresConfig,
+		policyConfig,
 		deploymentConfig,
 		networkConfig,
 		observabilityConfig,
@@ -146,6 +148,13 @@ func TestStoreLoadWithContext(t *testing.T) {
 		}
 	})
 
+	t.Run("policy", func(t *testing.T) {
+		expected, _ := apiconfig.NewPolicyConfigFromConfigMap(policyConfig)
+		if diff := cmp.Diff(expected, config.Policy); diff != "" {
+			t.Error("Unexpected policy config (-want, +got):", diff)
+		}
+	})
+
 	t.Run("features", func(t *testing.T) {
 		expected, _ := apiconfig.NewFeaturesConfigFromConfigMap(featuresConfig)
 		if diff := cmp.Diff(expected, config.Features); diff != "" {
@@ -165,6 +174,7 @@ func TestStoreImmutableConfig(t *testing.T) {
 		ConfigMapFromTestFile(t, apiconfig.DefaultsConfigName),
 		ConfigMapFromTestFile(t, autoscalerconfig.ConfigName),
 		ConfigMapFromTestFile(t, apiconfig.FeaturesConfigName),
+		ConfigMapFromTestFile(t, apiconfig.PolicyConfigName),
 	)
 
 	store.WatchConfigs(watcher)
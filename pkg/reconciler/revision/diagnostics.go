@@ -0,0 +1,158 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package revision
+
+import (
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	v1 "knative.dev/serving/pkg/apis/serving/v1"
+)
+
+const (
+	// probeFailureReason is the reason of the events the kubelet records
+	// when a probe of a container fails.
+	probeFailureReason = "Unhealthy"
+
+	// oomKilledReason is the termination reason of the containers killed for
+	// exceeding their memory limit.
+	oomKilledReason = "OOMKilled"
+)
+
+// containerDiagnostics gathers the diagnostics of the containers of the pod,
+// keyed by container name, with the probe failures from the pod's events.
+func containerDiagnostics(pod *corev1.Pod, events []corev1.Event) map[string]v1.ContainerStatus {
+	failures := probeFailures(pod, events)
+	statuses := make([]corev1.ContainerStatus, 0, len(pod.Status.InitContainerStatuses)+len(pod.Status.ContainerStatuses))
+	statuses = append(statuses, pod.Status.InitContainerStatuses...)
+	statuses = append(statuses, pod.Status.ContainerStatuses...)
+
+	diagnostics := make(map[string]v1.ContainerStatus, len(statuses))
+	for i := range statuses {
+		status := &statuses[i]
+		d := v1.ContainerStatus{
+			Name:         status.Name,
+			RestartCount: status.RestartCount,
+			ProbeFailure: failures[status.Name],
+		}
+		if w := status.State.Waiting; w != nil {
+			d.Waiting = &v1.ContainerWaiting{Reason: w.Reason, Message: w.Message}
+		}
+		t := status.LastTerminationState.Terminated
+		if t == nil {
+			t = status.State.Terminated
+		}
+		if t != nil {
+			d.LastTermination = &v1.ContainerTermination{ExitCode: t.ExitCode, Reason: t.Reason, Message: t.Message}
+		}
+		diagnostics[status.Name] = d
+	}
+	return diagnostics
+}
+
+// mayFailProbes returns whether a container of the pod shows signs of failing
+// its probes, i.e. it was restarted, which a failing liveness probe does, or
+// it runs without being ready, which a failing readiness or startup probe
+// keeps it in.
+func mayFailProbes(pod *corev1.Pod) bool {
+	for _, statuses := range [][]corev1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+		for _, status := range statuses {
+			if status.RestartCount > 0 || (status.State.Running != nil && !status.Ready) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// probeFailures returns the message of the latest probe failure recorded for
+// each container of the pod.
+func probeFailures(pod *corev1.Pod, events []corev1.Event) map[string]string {
+	failures := make(map[string]string)
+	latest := make(map[string]time.Time)
+	for _, e := range events {
+		if e.Reason != probeFailureReason || e.InvolvedObject.Kind != "Pod" || e.InvolvedObject.Name != pod.Name {
+			continue
+		}
+		name := containerFromFieldPath(e.InvolvedObject.FieldPath)
+		if name == "" {
+			continue
+		}
+		ts := e.LastTimestamp.Time
+		if ts.IsZero() {
+			ts = e.EventTime.Time
+		}
+		if _, ok := failures[name]; !ok || ts.After(latest[name]) {
+			failures[name], latest[name] = e.Message, ts
+		}
+	}
+	return failures
+}
+
+// containerFromFieldPath returns the name of the container the field path of
+// an event refers to, e.g. "spec.containers{user-container}".
+func containerFromFieldPath(fieldPath string) string {
+	_, name, ok := strings.Cut(fieldPath, "{")
+	if !ok || !strings.HasSuffix(name, "}") {
+		return ""
+	}
+	return strings.TrimSuffix(name, "}")
+}
+
+// setContainerDiagnostics records the diagnostics of the containers in their
+// statuses, and clears the diagnostics of the containers without any.
+func setContainerDiagnostics(statuses []v1.ContainerStatus, diagnostics map[string]v1.ContainerStatus) {
+	for i := range statuses {
+		d := diagnostics[statuses[i].Name]
+		statuses[i].RestartCount = d.RestartCount
+		statuses[i].Waiting = d.Waiting
+		statuses[i].LastTermination = d.LastTermination
+		statuses[i].ProbeFailure = d.ProbeFailure
+	}
+}
+
+// terminationMessage describes the termination of a container for the
+// status of the revision.
+func terminationMessage(t *v1.ContainerTermination) string {
+	switch {
+	case t.Reason == oomKilledReason:
+		return "container was OOMKilled, it exceeded its memory limit"
+	case t.Message != "":
+		return t.Message
+	case t.ExitCode == 0:
+		// In cases where there is no error message, we should still provide some exit message in the status
+		return "container exited with no error"
+	case t.Reason != "":
+		return t.Reason
+	}
+	return ""
+}
+
+// withDiagnostics appends the summary of the diagnostics of the container to
+// the status message.
+func withDiagnostics(message string, diagnostics v1.ContainerStatus) string {
+	summary := v1.RevisionContainerDiagnosticsMessage(diagnostics)
+	switch {
+	case summary == "":
+		return message
+	case message == "":
+		return summary
+	}
+	return message + " (" + summary + ")"
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package revision
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "knative.dev/serving/pkg/apis/serving/v1"
+)
+
+func TestContainerDiagnostics(t *testing.T) {
+	now := time.Now()
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "bar-pod"},
+		Status: corev1.PodStatus{
+			InitContainerStatuses: []corev1.ContainerStatus{{
+				Name: "setup",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Reason: "Error", Message: "no config"},
+				},
+			}},
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:         "user-container",
+				RestartCount: 2,
+				State: corev1.ContainerState{
+					Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+				},
+				LastTerminationState: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{ExitCode: 137, Reason: "OOMKilled"},
+				},
+			}, {
+				Name: "sidecar",
+				State: corev1.ContainerState{
+					Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff", Message: "not found"},
+				},
+			}},
+		},
+	}
+	event := func(pod, fieldPath, reason, message string, ts time.Time) corev1.Event {
+		return corev1.Event{
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: pod, FieldPath: fieldPath},
+			Reason:         reason,
+			Message:        message,
+			LastTimestamp:  metav1.NewTime(ts),
+		}
+	}
+	events := []corev1.Event{
+		event("bar-pod", "spec.containers{user-container}", "Unhealthy", "Readiness probe failed: latest", now),
+		event("bar-pod", "spec.containers{user-container}", "Unhealthy", "Readiness probe failed: earlier", now.Add(-time.Minute)),
+		event("bar-pod", "spec.containers{sidecar}", "Pulling", "Pulling image", now),
+		event("bar-pod", "", "Unhealthy", "no container", now),
+		event("other-pod", "spec.containers{sidecar}", "Unhealthy", "Liveness probe failed: other pod", now),
+	}
+
+	got := containerDiagnostics(pod, events)
+	want := map[string]v1.ContainerStatus{
+		"setup": {
+			Name:            "setup",
+			LastTermination: &v1.ContainerTermination{ExitCode: 1, Reason: "Error", Message: "no config"},
+		},
+		"user-container": {
+			Name:            "user-container",
+			RestartCount:    2,
+			Waiting:         &v1.ContainerWaiting{Reason: "CrashLoopBackOff"},
+			LastTermination: &v1.ContainerTermination{ExitCode: 137, Reason: "OOMKilled"},
+			ProbeFailure:    "Readiness probe failed: latest",
+		},
+		"sidecar": {
+			Name:    "sidecar",
+			Waiting: &v1.ContainerWaiting{Reason: "ImagePullBackOff", Message: "not found"},
+		},
+	}
+	if !cmp.Equal(got, want) {
+		t.Error("containerDiagnostics (-want, +got):", cmp.Diff(want, got))
+	}
+
+	statuses := []v1.ContainerStatus{{Name: "user-container", ImageDigest: "busybox@sha256:deadbeef"}, {Name: "gone"}}
+	setContainerDiagnostics(statuses, got)
+	wantStatuses := []v1.ContainerStatus{want["user-container"], {Name: "gone"}}
+	wantStatuses[0].ImageDigest = "busybox@sha256:deadbeef"
+	if !cmp.Equal(statuses, wantStatuses) {
+		t.Error("setContainerDiagnostics (-want, +got):", cmp.Diff(wantStatuses, statuses))
+	}
+
+	setContainerDiagnostics(statuses, nil)
+	wantStatuses = []v1.ContainerStatus{{Name: "user-container", ImageDigest: "busybox@sha256:deadbeef"}, {Name: "gone"}}
+	if !cmp.Equal(statuses, wantStatuses) {
+		t.Error("setContainerDiagnostics(nil) (-want, +got):", cmp.Diff(wantStatuses, statuses))
+	}
+}
+
+func TestTerminationMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		t    v1.ContainerTermination
+		want string
+	}{{
+		name: "oom killed",
+		t:    v1.ContainerTermination{ExitCode: 137, Reason: "OOMKilled"},
+		want: "container was OOMKilled, it exceeded its memory limit",
+	}, {
+		name: "message",
+		t:    v1.ContainerTermination{ExitCode: 1, Reason: "Error", Message: "I failed man!"},
+		want: "I failed man!",
+	}, {
+		name: "no error",
+		t:    v1.ContainerTermination{Reason: "Completed"},
+		want: "container exited with no error",
+	}, {
+		name: "reason only",
+		t:    v1.ContainerTermination{ExitCode: 2, Reason: "Error"},
+		want: "Error",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := terminationMessage(&tt.t); got != tt.want {
+				t.Errorf("terminationMessage() = %q, want: %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMayFailProbes(t *testing.T) {
+	running := corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+	waiting := corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}}
+
+	tests := []struct {
+		name   string
+		status corev1.PodStatus
+		want   bool
+	}{{
+		name: "no containers",
+	}, {
+		name: "creating",
+		status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{Name: "user-container", State: waiting}},
+		},
+	}, {
+		name: "ready",
+		status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{Name: "user-container", State: running, Ready: true}},
+		},
+	}, {
+		name: "running without being ready",
+		status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{Name: "user-container", State: running}},
+		},
+		want: true,
+	}, {
+		name: "restarted",
+		status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{Name: "user-container", State: waiting, RestartCount: 1}},
+		},
+		want: true,
+	}, {
+		name: "init container restarted",
+		status: corev1.PodStatus{
+			InitContainerStatuses: []corev1.ContainerStatus{{Name: "setup", State: running, Ready: true, RestartCount: 2}},
+			ContainerStatuses:     []corev1.ContainerStatus{{Name: "user-container", State: waiting}},
+		},
+		want: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mayFailProbes(&corev1.Pod{Status: tt.status}); got != tt.want {
+				t.Errorf("mayFailProbes() = %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package revision
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn/k8schain"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	lru "github.com/hashicorp/golang-lru"
+	"go.opencensus.io/tag"
+	"k8s.io/utils/clock"
+
+	pkgmetrics "knative.dev/pkg/metrics"
+	"knative.dev/serving/pkg/metrics"
+)
+
+// digestCacheSize is the maximum number of digests and errors the digest
+// cache holds. The entries are small, so we can err on the high side.
+const digestCacheSize = 10000
+
+// digestCacheKey identifies a digest resolution: the same image may resolve
+// differently, or fail, with other pull credentials.
+type digestCacheKey struct {
+	image            string
+	namespace        string
+	serviceAccount   string
+	imagePullSecrets string
+}
+
+func newDigestCacheKey(image string, opt k8schain.Options) digestCacheKey {
+	return digestCacheKey{
+		image:            image,
+		namespace:        opt.Namespace,
+		serviceAccount:   opt.ServiceAccountName,
+		imagePullSecrets: strings.Join(opt.ImagePullSecrets, ","),
+	}
+}
+
+type digestCacheEntry struct {
+	digest  string
+	err     error
+	expires time.Time
+}
+
+// digestCache is a bounded cache of the resolved image digests, shared by the
+// revisions, so that the revisions deployed together don't resolve the same
+// images over and over. The authentication and authorization errors are
+// cached too, so that the registries don't rate limit the failing credentials.
+// The least recently used entries are evicted first.
+type digestCache struct {
+	clock   clock.PassiveClock
+	entries *lru.Cache
+}
+
+func newDigestCache(size int, clock clock.PassiveClock) *digestCache {
+	// The only possible error is when cache size is not positive.
+	entries, _ := lru.New(size)
+	return &digestCache{
+		clock:   clock,
+		entries: entries,
+	}
+}
+
+// get returns the cached entry of the key, with the digest or the error of its
+// resolution, and whether there was an unexpired one.
+func (c *digestCache) get(key digestCacheKey) (digestCacheEntry, bool) {
+	v, ok := c.entries.Get(key)
+	if !ok {
+		recordDigestCacheLookup(digestCacheMiss)
+		return digestCacheEntry{}, false
+	}
+	entry := v.(digestCacheEntry)
+	if !c.clock.Now().Before(entry.expires) {
+		c.entries.Remove(key)
+		recordDigestCacheLookup(digestCacheMiss)
+		pkgmetrics.Record(context.Background(), digestCacheEntriesM.M(int64(c.entries.Len())))
+		return digestCacheEntry{}, false
+	}
+	if entry.err != nil {
+		recordDigestCacheLookup(digestCacheErrorHit)
+	} else {
+		recordDigestCacheLookup(digestCacheHit)
+	}
+	return entry, true
+}
+
+// add caches the result of the resolution of the key: the digest for the ttl,
+// or the error for the errorTTL if it is an authentication or authorization
+// error. The other errors, and the images whose tags aren't resolved, aren't
+// cached.
+func (c *digestCache) add(key digestCacheKey, digest string, err error, ttl, errorTTL time.Duration) {
+	entry := digestCacheEntry{digest: digest, err: err}
+	switch {
+	case err == nil && digest != "" && ttl > 0:
+		entry.expires = c.clock.Now().Add(ttl)
+	case isAuthError(err) && errorTTL > 0:
+		entry.expires = c.clock.Now().Add(errorTTL)
+	default:
+		return
+	}
+
+	ctx := context.Background()
+	if evicted := c.entries.Add(key, entry); evicted {
+		pkgmetrics.Record(ctx, digestCacheEvictionsM.M(1))
+	}
+	pkgmetrics.Record(ctx, digestCacheEntriesM.M(int64(c.entries.Len())))
+}
+
+// isAuthError returns true if the registry refused the credentials.
+func isAuthError(err error) bool {
+	var terr *transport.Error
+	return errors.As(err, &terr) &&
+		(terr.StatusCode == http.StatusUnauthorized || terr.StatusCode == http.StatusForbidden)
+}
+
+func recordDigestCacheLookup(result string) {
+	ctx, _ := tag.New(context.Background(), tag.Upsert(metrics.CacheResultKey, result))
+	pkgmetrics.Record(ctx, digestCacheLookupsM.M(1))
+}
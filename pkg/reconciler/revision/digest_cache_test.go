@@ -0,0 +1,168 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package revision
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn/k8schain"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"go.uber.org/atomic"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/workqueue"
+	clocktest "k8s.io/utils/clock/testing"
+
+	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/metrics/metricstest"
+	_ "knative.dev/pkg/metrics/testing"
+	"knative.dev/serving/pkg/deployment"
+	"knative.dev/serving/pkg/metrics"
+)
+
+func TestDigestCache(t *testing.T) {
+	resetDigestCacheMetrics()
+	fc := clocktest.NewFakePassiveClock(time.Now())
+	c := newDigestCache(2, fc)
+
+	opt := k8schain.Options{Namespace: "ns", ServiceAccountName: "san"}
+	key := newDigestCacheKey("img", opt)
+	authErr := &transport.Error{StatusCode: http.StatusUnauthorized}
+
+	c.add(key, "img@sha256:1", nil, time.Minute, time.Second)
+	if entry, ok := c.get(key); !ok || entry.digest != "img@sha256:1" {
+		t.Errorf("get() = %v, %v, want: img@sha256:1, true", entry, ok)
+	}
+
+	// The other pull credentials don't share the entry.
+	for _, other := range []k8schain.Options{
+		{Namespace: "other", ServiceAccountName: "san"},
+		{Namespace: "ns", ServiceAccountName: "other"},
+		{Namespace: "ns", ServiceAccountName: "san", ImagePullSecrets: []string{"secret"}},
+	} {
+		if entry, ok := c.get(newDigestCacheKey("img", other)); ok {
+			t.Errorf("get() with %v = %v, want a miss", other, entry)
+		}
+	}
+
+	fc.SetTime(fc.Now().Add(time.Minute))
+	if entry, ok := c.get(key); ok {
+		t.Errorf("get() after the TTL = %v, want a miss", entry)
+	}
+
+	// Only the auth errors are cached, for the error TTL.
+	c.add(key, "", errors.New("connection refused"), time.Minute, time.Minute)
+	if entry, ok := c.get(key); ok {
+		t.Errorf("get() after another error = %v, want a miss", entry)
+	}
+	c.add(key, "", authErr, time.Minute, time.Second)
+	if entry, ok := c.get(key); !ok || !errors.Is(entry.err, authErr) {
+		t.Errorf("get() after an auth error = %v, %v, want: %v, true", entry, ok, authErr)
+	}
+	fc.SetTime(fc.Now().Add(time.Second))
+	if entry, ok := c.get(key); ok {
+		t.Errorf("get() after the error TTL = %v, want a miss", entry)
+	}
+	c.add(key, "", authErr, time.Minute, 0)
+	if entry, ok := c.get(key); ok {
+		t.Errorf("get() with the error caching disabled = %v, want a miss", entry)
+	}
+
+	// The images whose tags aren't resolved aren't cached.
+	c.add(key, "", nil, time.Minute, time.Minute)
+	if entry, ok := c.get(key); ok {
+		t.Errorf("get() after skipping the resolution = %v, want a miss", entry)
+	}
+
+	// The cache is bounded.
+	for i := 0; i < 3; i++ {
+		c.add(newDigestCacheKey(fmt.Sprint("img", i), opt), fmt.Sprint("digest", i), nil, time.Minute, 0)
+	}
+	if got, want := c.entries.Len(), 2; got != want {
+		t.Errorf("Len() = %d, want: %d", got, want)
+	}
+	if entry, ok := c.get(newDigestCacheKey("img0", opt)); ok {
+		t.Errorf("get() of the evicted entry = %v, want a miss", entry)
+	}
+
+	lookups := metricstest.IntMetric("digest_cache_lookups", 1, map[string]string{metrics.LabelCacheResult: digestCacheHit})
+	lookups.Values = append(lookups.Values,
+		metricstest.IntMetric("", 1, map[string]string{metrics.LabelCacheResult: digestCacheErrorHit}).Values[0],
+		metricstest.IntMetric("", 9, map[string]string{metrics.LabelCacheResult: digestCacheMiss}).Values[0])
+	metricstest.AssertMetric(t,
+		lookups,
+		metricstest.IntMetric("digest_cache_evictions", 1, nil),
+		metricstest.IntMetric("digest_cache_entries", 2, nil))
+}
+
+func TestResolveThroughDigestCache(t *testing.T) {
+	var calls atomic.Int32
+	var resolver resolveFunc = func(_ context.Context, img string, _ k8schain.Options, _ sets.String) (string, error) {
+		calls.Inc()
+		return img + "-digest", nil
+	}
+
+	ready := make(chan types.NamespacedName, 1)
+	logger := logtesting.TestLogger(t)
+	subject := newBackgroundResolver(logger, resolver, nil, workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()), func(rev types.NamespacedName) {
+		ready <- rev
+	})
+	stop := make(chan struct{})
+	done := subject.Start(stop, 10)
+	defer func() {
+		close(stop)
+		<-done
+	}()
+
+	resolve := func(cfg *deployment.Config, revisions ...string) {
+		t.Helper()
+		for _, name := range revisions {
+			revision := rev(name, "first-image", "second-image")
+			if _, _, err := subject.Resolve(logger, revision, k8schain.Options{Namespace: "ns"}, cfg); err != nil {
+				t.Fatal("Resolve() =", err)
+			}
+			select {
+			case <-ready:
+			case <-time.After(2 * time.Second):
+				t.Fatal("Resolver did not report ready")
+			}
+		}
+	}
+
+	// Without a TTL, every revision resolves its images.
+	resolve(&deployment.Config{DigestResolutionTimeout: 5 * time.Second}, "rev1", "rev2")
+	if got, want := calls.Load(), int32(6); got != want {
+		t.Errorf("Resolutions = %d, want: %d", got, want)
+	}
+
+	// With one, the revisions share the digests.
+	calls.Store(0)
+	resolve(&deployment.Config{DigestResolutionTimeout: 5 * time.Second, DigestResolutionCacheTTL: time.Minute}, "rev3", "rev4", "rev5")
+	if got, want := calls.Load(), int32(3); got != want {
+		t.Errorf("Resolutions = %d, want: %d", got, want)
+	}
+}
+
+func resetDigestCacheMetrics() {
+	metricstest.Unregister("digest_cache_lookups", "digest_cache_evictions", "digest_cache_entries")
+	register()
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package revision
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	pkgmetrics "knative.dev/pkg/metrics"
+	"knative.dev/serving/pkg/metrics"
+)
+
+// The results of the digest cache lookups, reported with the
+// metrics.CacheResultKey tag.
+const (
+	digestCacheHit      = "hit"
+	digestCacheErrorHit = "error_hit"
+	digestCacheMiss     = "miss"
+)
+
+var (
+	digestCacheLookupsM = stats.Int64(
+		"digest_cache_lookups",
+		"Number of image digest cache lookups",
+		stats.UnitDimensionless)
+	digestCacheEvictionsM = stats.Int64(
+		"digest_cache_evictions",
+		"Number of image digests evicted from the cache to bound its size",
+		stats.UnitDimensionless)
+	digestCacheEntriesM = stats.Int64(
+		"digest_cache_entries",
+		"Number of image digests and errors in the cache",
+		stats.UnitDimensionless)
+)
+
+func init() {
+	register()
+}
+
+func register() {
+	if err := pkgmetrics.RegisterResourceView(
+		&view.View{
+			Description: "Number of image digest cache lookups",
+			Measure:     digestCacheLookupsM,
+			Aggregation: view.Count(),
+			TagKeys:     []tag.Key{metrics.CacheResultKey},
+		},
+		&view.View{
+			Description: "Number of image digests evicted from the cache to bound its size",
+			Measure:     digestCacheEvictionsM,
+			Aggregation: view.Count(),
+		},
+		&view.View{
+			Description: "Number of image digests and errors in the cache",
+			Measure:     digestCacheEntriesM,
+			Aggregation: view.LastValue(),
+		},
+	); err != nil {
+		panic(err)
+	}
+}
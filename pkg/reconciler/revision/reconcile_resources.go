@@ -27,6 +27,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 
 	"knative.dev/control-protocol/pkg/certificates"
 	"knative.dev/pkg/kmeta"
@@ -35,6 +36,7 @@ import (
 	"knative.dev/pkg/logging/logkey"
 	v1 "knative.dev/serving/pkg/apis/serving/v1"
 	"knative.dev/serving/pkg/networking"
+	"knative.dev/serving/pkg/reconciler/revision/config"
 	"knative.dev/serving/pkg/reconciler/revision/resources"
 	resourcenames "knative.dev/serving/pkg/reconciler/revision/resources/names"
 )
@@ -99,25 +101,45 @@ func (c *Reconciler) reconcileDeployment(ctx context.Context, rev *v1.Revision)
 				}
 			}
 
-			for _, status := range pod.Status.ContainerStatuses {
-				if status.Name == rev.Spec.GetContainer().Name {
-					if t := status.LastTerminationState.Terminated; t != nil {
-						logger.Infof("marking exiting with: %d/%s", t.ExitCode, t.Message)
-						if t.ExitCode == 0 && t.Message == "" {
-							// In cases where there is no error message, we should still provide some exit message in the status
-							rev.Status.MarkContainerHealthyFalse(v1.ExitCodeReason(t.ExitCode),
-								v1.RevisionContainerExitingMessage("container exited with no error"))
-						} else {
-							rev.Status.MarkContainerHealthyFalse(v1.ExitCodeReason(t.ExitCode), v1.RevisionContainerExitingMessage(t.Message))
-						}
-					} else if w := status.State.Waiting; w != nil && hasDeploymentTimedOut(deployment) {
-						logger.Infof("marking resources unavailable with: %s: %s", w.Reason, w.Message)
-						rev.Status.MarkResourcesAvailableFalse(w.Reason, w.Message)
-					}
-					break
+			// Surface the diagnostics of the containers, so that they can be
+			// debugged without access to the pods. The events are only listed
+			// when a container may be failing its probes, to not query the API
+			// server on every reconcile of a revision whose pods are starting.
+			var events []corev1.Event
+			if mayFailProbes(&pod) {
+				list, err := c.kubeclient.CoreV1().Events(ns).List(ctx, metav1.ListOptions{
+					FieldSelector: fields.Set{
+						"involvedObject.kind": "Pod",
+						"involvedObject.name": pod.Name,
+						"reason":              probeFailureReason,
+					}.AsSelector().String(),
+				})
+				if err != nil {
+					logger.Warnw("Error getting pod events", zap.Error(err))
+				} else {
+					events = list.Items
+				}
+			}
+			diagnostics := containerDiagnostics(&pod, events)
+			setContainerDiagnostics(rev.Status.ContainerStatuses, diagnostics)
+			setContainerDiagnostics(rev.Status.InitContainerStatuses, diagnostics)
+
+			if d, ok := diagnostics[rev.Spec.GetContainer().Name]; ok {
+				if t := d.LastTermination; t != nil {
+					logger.Infof("marking exiting with: %d/%s", t.ExitCode, t.Message)
+					rev.Status.MarkContainerHealthyFalse(v1.ExitCodeReason(t.ExitCode),
+						withDiagnostics(v1.RevisionContainerExitingMessage(terminationMessage(t)), d))
+				} else if w := d.Waiting; w != nil && hasDeploymentTimedOut(deployment) {
+					logger.Infof("marking resources unavailable with: %s: %s", w.Reason, w.Message)
+					rev.Status.MarkResourcesAvailableFalse(w.Reason, withDiagnostics(w.Message, d))
 				}
 			}
 		}
+	} else {
+		// The pods are available, or there are none, so the diagnostics of
+		// the containers no longer apply.
+		setContainerDiagnostics(rev.Status.ContainerStatuses, nil)
+		setContainerDiagnostics(rev.Status.InitContainerStatuses, nil)
 	}
 
 	return nil
@@ -185,6 +207,60 @@ func (c *Reconciler) reconcilePA(ctx context.Context, rev *v1.Revision) error {
 	return nil
 }
 
+func (c *Reconciler) reconcilePDB(ctx context.Context, rev *v1.Revision) error {
+	ns := rev.Namespace
+	pdbName := resourcenames.PDB(rev)
+	logger := logging.FromContext(ctx)
+	cfgs := config.FromContext(ctx)
+
+	// The PodDisruptionBudget is only desired as long as the revision keeps
+	// at least 2 pods around.
+	tmpl := resources.MakePDB(rev, cfgs.Autoscaler)
+
+	pdb, err := c.pdbLister.PodDisruptionBudgets(ns).Get(pdbName)
+	if apierrs.IsNotFound(err) {
+		if tmpl == nil {
+			return nil
+		}
+		// PDB does not exist. Create it.
+		if _, err := c.kubeclient.PolicyV1().PodDisruptionBudgets(ns).Create(ctx, tmpl, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("failed to create PDB %q: %w", pdbName, err)
+		}
+		logger.Info("Created PDB: ", pdbName)
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get PDB %q: %w", pdbName, err)
+	} else if !metav1.IsControlledBy(pdb, rev) {
+		if tmpl == nil {
+			return nil
+		}
+		// Surface an error in the revision's status, and return an error.
+		rev.Status.MarkResourcesAvailableFalse(v1.ReasonNotOwned, v1.ResourceNotOwnedMessage("PodDisruptionBudget", pdbName))
+		return fmt.Errorf("revision: %q does not own PodDisruptionBudget: %q", rev.Name, pdbName)
+	}
+
+	if tmpl == nil {
+		// The revision may scale to zero or below 2 pods: let them go.
+		if err := c.kubeclient.PolicyV1().PodDisruptionBudgets(ns).Delete(ctx, pdbName, metav1.DeleteOptions{}); err != nil && !apierrs.IsNotFound(err) {
+			return fmt.Errorf("failed to delete PDB %q: %w", pdbName, err)
+		}
+		logger.Info("Deleted PDB: ", pdbName)
+		return nil
+	}
+
+	if !equality.Semantic.DeepEqual(tmpl.Spec, pdb.Spec) {
+		diff, _ := kmp.SafeDiff(tmpl.Spec, pdb.Spec) // Can't realistically fail on PodDisruptionBudgetSpec.
+		logger.Infof("PDB %s needs reconciliation, diff(-want,+got):\n%s", pdbName, diff)
+
+		want := pdb.DeepCopy()
+		want.Spec = tmpl.Spec
+		if _, err := c.kubeclient.PolicyV1().PodDisruptionBudgets(ns).Update(ctx, want, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("failed to update PDB %q: %w", pdbName, err)
+		}
+	}
+	return nil
+}
+
 func hasDeploymentTimedOut(deployment *appsv1.Deployment) bool {
 	// as per https://kubernetes.io/docs/concepts/workloads/controllers/deployment
 	for _, cond := range deployment.Status.Conditions {
@@ -201,6 +201,10 @@ func makePodSpec(rev *v1.Revision, cfg *config.Config) (*corev1.PodSpec, error)
 	podSpec := BuildPodSpec(rev, append(BuildUserContainers(rev), *queueContainer), cfg)
 	podSpec.Volumes = append(podSpec.Volumes, extraVolumes...)
 
+	if sharesProcessNamespace(rev, cfg) {
+		podSpec.ShareProcessNamespace = ptr.Bool(true)
+	}
+
 	if cfg.Observability.EnableVarLogCollection {
 		podSpec.Volumes = append(podSpec.Volumes, varLogVolume)
 
@@ -360,7 +364,7 @@ func MakeDeployment(rev *v1.Revision, cfg *config.Config) (*appsv1.Deployment, e
 
 	// Slowly but steadily roll the deployment out, to have the least possible impact.
 	maxUnavailable := intstr.FromInt(0)
-	return &appsv1.Deployment{
+	deployment := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:            names.Deployment(rev),
 			Namespace:       rev.Namespace,
@@ -386,5 +390,13 @@ func MakeDeployment(rev *v1.Revision, cfg *config.Config) (*appsv1.Deployment, e
 				Spec: *podSpec,
 			},
 		},
-	}, nil
+	}
+
+	// Apply the cluster-wide customizations last, so they can change anything.
+	for _, patch := range cfg.Deployment.PatchesFor(rev.Namespace, rev.Labels) {
+		if deployment, err = patch.Apply(deployment); err != nil {
+			return nil, fmt.Errorf("failed to apply %s patch: %w", patch.Type, err)
+		}
+	}
+	return deployment, nil
 }
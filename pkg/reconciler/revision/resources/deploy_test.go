@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -177,6 +178,9 @@ var (
 		}, {
 			Name:  "METRICS_COLLECTOR_ADDRESS",
 			Value: "",
+		}, {
+			Name:  "STAT_PUSH_ENDPOINT",
+			Value: "",
 		}, {
 			Name: "HOST_IP",
 			ValueFrom: &corev1.EnvVarSource{
@@ -711,6 +715,83 @@ func TestMakePodSpec(t *testing.T) {
 					withEnvVar("SERVING_REQUEST_METRICS_BACKEND", "opencensus"),
 				),
 			}),
+	}, {
+		name: "cpu utilization metric",
+		rev: revision("bar", "foo",
+			withContainers([]corev1.Container{{
+				Name:           servingContainerName,
+				Image:          "busybox",
+				ReadinessProbe: withTCPReadinessProbe(v1.DefaultUserPort),
+			}}),
+			WithContainerStatuses([]v1.ContainerStatus{{
+				ImageDigest: "busybox@sha256:deadbeef",
+			}}),
+			WithRevisionAnnotations(map[string]string{
+				autoscaling.ClassAnnotationKey:  autoscaling.KPA,
+				autoscaling.MetricAnnotationKey: autoscaling.CPU,
+			}),
+		),
+		want: podSpec(
+			[]corev1.Container{
+				servingContainer(func(container *corev1.Container) {
+					container.Image = "busybox@sha256:deadbeef"
+				}),
+				queueContainer(
+					withEnvVar("SERVING_CPU_REQUEST", "0"),
+				),
+			}),
+	}, {
+		name: "cpu utilization metric opting into sharing the process namespace",
+		rev: revision("bar", "foo",
+			withContainers([]corev1.Container{{
+				Name:           servingContainerName,
+				Image:          "busybox",
+				ReadinessProbe: withTCPReadinessProbe(v1.DefaultUserPort),
+			}}),
+			WithContainerStatuses([]v1.ContainerStatus{{
+				ImageDigest: "busybox@sha256:deadbeef",
+			}}),
+			WithRevisionAnnotations(map[string]string{
+				autoscaling.ClassAnnotationKey:                 autoscaling.KPA,
+				autoscaling.MetricAnnotationKey:                autoscaling.CPU,
+				autoscaling.ShareProcessNamespaceAnnotationKey: "true",
+			}),
+		),
+		want: podSpec(
+			[]corev1.Container{
+				servingContainer(func(container *corev1.Container) {
+					container.Image = "busybox@sha256:deadbeef"
+				}),
+				queueContainer(
+					withEnvVar("SERVING_CPU_REQUEST", "0"),
+					withEnvVar("SERVING_SHARE_PROCESS_NAMESPACE", "true"),
+				),
+			},
+			func(p *corev1.PodSpec) {
+				p.ShareProcessNamespace = ptr.Bool(true)
+			}),
+	}, {
+		name: "concurrency metric ignores sharing the process namespace",
+		rev: revision("bar", "foo",
+			withContainers([]corev1.Container{{
+				Name:           servingContainerName,
+				Image:          "busybox",
+				ReadinessProbe: withTCPReadinessProbe(v1.DefaultUserPort),
+			}}),
+			WithContainerStatuses([]v1.ContainerStatus{{
+				ImageDigest: "busybox@sha256:deadbeef",
+			}}),
+			WithRevisionAnnotations(map[string]string{
+				autoscaling.ShareProcessNamespaceAnnotationKey: "true",
+			}),
+		),
+		want: podSpec(
+			[]corev1.Container{
+				servingContainer(func(container *corev1.Container) {
+					container.Image = "busybox@sha256:deadbeef"
+				}),
+				queueContainer(),
+			}),
 	}, {
 		name: "podInfoFeature Enabled",
 		fc: apicfg.Features{
@@ -1519,6 +1600,35 @@ func TestMakeDeployment(t *testing.T) {
 			deploy.Spec.Template.Annotations = map[string]string{autoscaling.InitialScaleAnnotationKey: "20"}
 			deploy.Annotations = map[string]string{autoscaling.InitialScaleAnnotationKey: "20"}
 		}),
+	}, {
+		name: "with deployment patches",
+		dc: deployment.Config{
+			DeploymentPatches: []deployment.DeploymentPatch{{
+				Type:  deployment.DeploymentPatchStrategic,
+				Patch: []byte(`{"spec":{"strategy":{"rollingUpdate":{"maxSurge":"50%"}},"template":{"metadata":{"annotations":{"cluster-autoscaler.kubernetes.io/safe-to-evict":"false"}}}}}`),
+			}, {
+				Namespaces: []string{"other"},
+				Type:       deployment.DeploymentPatchStrategic,
+				Patch:      []byte(`{"spec":{"paused":true}}`),
+			}, {
+				Type:  deployment.DeploymentPatchJSON,
+				Patch: []byte(`[{"op":"add","path":"/metadata/labels","value":{"team":"payments"}}]`),
+			}},
+		},
+		rev: revision("bar", "foo",
+			withoutLabels,
+			withContainers([]corev1.Container{{
+				Name:           servingContainerName,
+				Image:          "ubuntu",
+				ReadinessProbe: withTCPReadinessProbe(12345),
+			}}),
+		),
+		want: appsv1deployment(func(deploy *appsv1.Deployment) {
+			maxSurge := intstr.FromString("50%")
+			deploy.Spec.Strategy.RollingUpdate.MaxSurge = &maxSurge
+			deploy.Spec.Template.Annotations = map[string]string{"cluster-autoscaler.kubernetes.io/safe-to-evict": "false"}
+			deploy.Labels = map[string]string{"team": "payments"}
+		}),
 	}}
 
 	for _, test := range tests {
@@ -1546,7 +1656,13 @@ func TestMakeDeployment(t *testing.T) {
 			if err != nil {
 				t.Fatal("Got unexpected error:", err)
 			}
-			if diff := cmp.Diff(test.want, got, quantityComparer); diff != "" {
+			opts := []cmp.Option{quantityComparer}
+			if len(test.dc.DeploymentPatches) > 0 {
+				// The patches round trip the Deployment through JSON, which drops
+				// the empty fields.
+				opts = append(opts, cmpopts.EquateEmpty())
+			}
+			if diff := cmp.Diff(test.want, got, opts...); diff != "" {
 				t.Errorf("MakeDeployment (-want, +got) =\n%s", diff)
 			}
 		})
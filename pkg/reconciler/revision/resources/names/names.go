@@ -32,3 +32,8 @@ func ImageCache(rev kmeta.Accessor) string {
 func PA(rev kmeta.Accessor) string {
 	return rev.GetName()
 }
+
+// PDB returns the PodDisruptionBudget name for the revision.
+func PDB(rev kmeta.Accessor) string {
+	return kmeta.ChildName(rev.GetName(), "-pdb")
+}
@@ -95,6 +95,15 @@ func TestNamer(t *testing.T) {
 		},
 		f:    PA,
 		want: "baz",
+	}, {
+		name: "PDB",
+		rev: &v1.Revision{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "baz",
+			},
+		},
+		f:    PDB,
+		want: "baz-pdb",
 	}}
 
 	for _, test := range tests {
@@ -0,0 +1,67 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"knative.dev/pkg/kmeta"
+	"knative.dev/serving/pkg/apis/autoscaling"
+	v1 "knative.dev/serving/pkg/apis/serving/v1"
+	"knative.dev/serving/pkg/autoscaler/config/autoscalerconfig"
+	"knative.dev/serving/pkg/reconciler/revision/resources/names"
+)
+
+// MakePDB makes a PodDisruptionBudget for the Pods of a revision, or returns
+// nil if the revision shouldn't have one. Only the revisions with an effective
+// min-scale of at least 2 have one, so the unreachable revisions, which may
+// scale to zero, don't. The minAvailable is the min-available annotation of
+// the revision if it leaves one of its min-scale Pods evictable, or all but
+// one of them.
+func MakePDB(rev *v1.Revision, asConfig *autoscalerconfig.Config) *policyv1.PodDisruptionBudget {
+	min, _ := MakePA(rev).ScaleBounds(asConfig)
+	if min < 2 {
+		return nil
+	}
+
+	minAvailable := intstr.FromInt(int(min - 1))
+	if _, v, ok := autoscaling.MinAvailableAnnotation.Get(rev.Annotations); ok {
+		// The annotation is ignored when it keeps all the min-scale Pods
+		// available, e.g. once the default min-scale was lowered, as that
+		// would block draining their nodes. Percentages are rounded up.
+		a := intstr.Parse(v)
+		if n, err := intstr.GetScaledValueFromIntOrPercent(&a, int(min), true); err == nil && n < int(min) {
+			minAvailable = a
+		}
+	}
+
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            names.PDB(rev),
+			Namespace:       rev.Namespace,
+			Labels:          makeLabels(rev),
+			Annotations:     makeAnnotations(rev),
+			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(rev)},
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MinAvailable: &minAvailable,
+			Selector:     makeSelector(rev),
+		},
+	}
+}
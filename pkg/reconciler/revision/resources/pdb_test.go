@@ -0,0 +1,163 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"knative.dev/pkg/ptr"
+	"knative.dev/serving/pkg/apis/autoscaling"
+	"knative.dev/serving/pkg/apis/serving"
+	v1 "knative.dev/serving/pkg/apis/serving/v1"
+	"knative.dev/serving/pkg/autoscaler/config/autoscalerconfig"
+)
+
+func TestMakePDB(t *testing.T) {
+	pdb := func(annotations map[string]string, minAvailable intstr.IntOrString) *policyv1.PodDisruptionBudget {
+		return &policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "foo",
+				Name:      "bar-pdb",
+				Labels: map[string]string{
+					serving.RevisionLabelKey: "bar",
+					serving.RevisionUID:      "1234",
+					AppLabelKey:              "bar",
+				},
+				Annotations: annotations,
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion:         v1.SchemeGroupVersion.String(),
+					Kind:               "Revision",
+					Name:               "bar",
+					UID:                "1234",
+					Controller:         ptr.Bool(true),
+					BlockOwnerDeletion: ptr.Bool(true),
+				}},
+			},
+			Spec: policyv1.PodDisruptionBudgetSpec{
+				MinAvailable: &minAvailable,
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{serving.RevisionUID: "1234"},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		reachable   bool
+		minScale    int32
+		want        *policyv1.PodDisruptionBudget
+	}{{
+		name:      "no min-scale",
+		reachable: true,
+	}, {
+		name:        "min-scale of 1",
+		annotations: map[string]string{autoscaling.MinScaleAnnotationKey: "1"},
+		reachable:   true,
+	}, {
+		name:        "min-scale of 3",
+		annotations: map[string]string{autoscaling.MinScaleAnnotationKey: "3"},
+		reachable:   true,
+		want:        pdb(map[string]string{autoscaling.MinScaleAnnotationKey: "3"}, intstr.FromInt(2)),
+	}, {
+		name:      "min-scale of the config",
+		reachable: true,
+		minScale:  2,
+		want:      pdb(map[string]string{}, intstr.FromInt(1)),
+	}, {
+		name: "min-available",
+		annotations: map[string]string{
+			autoscaling.MinScaleAnnotationKey:     "4",
+			autoscaling.MinAvailableAnnotationKey: "3",
+		},
+		reachable: true,
+		want: pdb(map[string]string{
+			autoscaling.MinScaleAnnotationKey:     "4",
+			autoscaling.MinAvailableAnnotationKey: "3",
+		}, intstr.FromInt(3)),
+	}, {
+		name: "min-available percentage",
+		annotations: map[string]string{
+			autoscaling.MinScaleAnnotationKey:     "4",
+			autoscaling.MinAvailableAnnotationKey: "50%",
+		},
+		reachable: true,
+		want: pdb(map[string]string{
+			autoscaling.MinScaleAnnotationKey:     "4",
+			autoscaling.MinAvailableAnnotationKey: "50%",
+		}, intstr.FromString("50%")),
+	}, {
+		name: "min-available of all the min-scale pods",
+		annotations: map[string]string{
+			autoscaling.MinScaleAnnotationKey:     "3",
+			autoscaling.MinAvailableAnnotationKey: "3",
+		},
+		reachable: true,
+		want: pdb(map[string]string{
+			autoscaling.MinScaleAnnotationKey:     "3",
+			autoscaling.MinAvailableAnnotationKey: "3",
+		}, intstr.FromInt(2)),
+	}, {
+		name:        "min-available above the min-scale of the config",
+		annotations: map[string]string{autoscaling.MinAvailableAnnotationKey: "5"},
+		reachable:   true,
+		minScale:    2,
+		want:        pdb(map[string]string{autoscaling.MinAvailableAnnotationKey: "5"}, intstr.FromInt(1)),
+	}, {
+		name: "min-available percentage rounded up to all the min-scale pods",
+		annotations: map[string]string{
+			autoscaling.MinScaleAnnotationKey:     "2",
+			autoscaling.MinAvailableAnnotationKey: "60%",
+		},
+		reachable: true,
+		want: pdb(map[string]string{
+			autoscaling.MinScaleAnnotationKey:     "2",
+			autoscaling.MinAvailableAnnotationKey: "60%",
+		}, intstr.FromInt(1)),
+	}, {
+		name:        "unreachable",
+		annotations: map[string]string{autoscaling.MinScaleAnnotationKey: "3"},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rev := &v1.Revision{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "foo",
+					Name:        "bar",
+					UID:         "1234",
+					Annotations: test.annotations,
+				},
+			}
+			rev.Status.MarkActiveTrue()
+			if test.reachable {
+				rev.Labels = map[string]string{serving.RoutingStateLabelKey: string(v1.RoutingStateActive)}
+			}
+
+			got := MakePDB(rev, &autoscalerconfig.Config{MinScale: test.minScale})
+			if !cmp.Equal(got, test.want) {
+				t.Error("MakePDB (-want, +got):", cmp.Diff(test.want, got))
+			}
+		})
+	}
+}
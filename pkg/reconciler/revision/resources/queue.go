@@ -29,9 +29,11 @@ import (
 	netheader "knative.dev/networking/pkg/http/header"
 	"knative.dev/pkg/kmap"
 	"knative.dev/pkg/metrics"
+	"knative.dev/pkg/network"
 	"knative.dev/pkg/profiling"
 	"knative.dev/pkg/ptr"
 	"knative.dev/pkg/system"
+	"knative.dev/serving/pkg/apis/autoscaling"
 	apicfg "knative.dev/serving/pkg/apis/config"
 	"knative.dev/serving/pkg/apis/serving"
 	v1 "knative.dev/serving/pkg/apis/serving/v1"
@@ -47,6 +49,11 @@ const (
 	requestQueueHTTPPortName  = "queue-port"
 	requestQueueHTTPSPortName = "https-port" // must be no more than 15 characters.
 	profilingPortName         = "profiling-port"
+
+	// autoscalerService and autoscalerStatsPort address the stats server of
+	// the autoscaler, which the queue-proxies push their stats to.
+	autoscalerService   = "autoscaler"
+	autoscalerStatsPort = 8080
 )
 
 var (
@@ -216,6 +223,14 @@ func makeQueueContainer(rev *v1.Revision, cfg *config.Config) (*corev1.Container
 	}
 	ports = append(ports, servingPort, queueHTTPSPort)
 
+	// The autoscaler forwards the pushed stats to the bucket owning the
+	// revision, so any autoscaler replica may receive them.
+	var statPushEndpoint string
+	if cfg.Autoscaler.EnableStatPush {
+		statPushEndpoint = fmt.Sprintf("ws://%s:%d",
+			network.GetServiceHostname(autoscalerService, system.Namespace()), autoscalerStatsPort)
+	}
+
 	container := rev.Spec.GetContainer()
 
 	var httpProbe, execProbe *corev1.Probe
@@ -358,6 +373,9 @@ func makeQueueContainer(rev *v1.Revision, cfg *config.Config) (*corev1.Container
 		}, {
 			Name:  "METRICS_COLLECTOR_ADDRESS",
 			Value: cfg.Observability.MetricsCollectorAddress,
+		}, {
+			Name:  "STAT_PUSH_ENDPOINT",
+			Value: statPushEndpoint,
 		}, {
 			Name: "HOST_IP",
 			ValueFrom: &corev1.EnvVarSource{
@@ -375,9 +393,68 @@ func makeQueueContainer(rev *v1.Revision, cfg *config.Config) (*corev1.Container
 		}},
 	}
 
+	// The queue-proxy reports the utilization of the resource the KPA scales
+	// the revision on, relative to the requests of the containers of the
+	// revision, whose usage it measures.
+	switch utilizationMetric(rev, cfg) {
+	case autoscaling.CPU:
+		c.Env = append(c.Env, corev1.EnvVar{
+			Name:  "SERVING_CPU_REQUEST",
+			Value: strconv.FormatInt(containersRequest(rev, corev1.ResourceCPU).MilliValue(), 10),
+		})
+	case autoscaling.Memory:
+		c.Env = append(c.Env, corev1.EnvVar{
+			Name:  "SERVING_MEMORY_REQUEST",
+			Value: strconv.FormatInt(containersRequest(rev, corev1.ResourceMemory).Value(), 10),
+		})
+	}
+	if sharesProcessNamespace(rev, cfg) {
+		c.Env = append(c.Env, corev1.EnvVar{
+			Name:  "SERVING_SHARE_PROCESS_NAMESPACE",
+			Value: "true",
+		})
+	}
+
 	return c, nil
 }
 
+// utilizationMetric returns the resource metric the KPA scales the revision
+// on, or "" if the revision is not scaled on the utilization of a resource.
+func utilizationMetric(rev *v1.Revision, cfg *config.Config) string {
+	class := cfg.Autoscaler.PodAutoscalerClass
+	if _, c, ok := autoscaling.ClassAnnotation.Get(rev.Annotations); ok {
+		class = c
+	}
+	_, metric, _ := autoscaling.MetricAnnotation.Get(rev.Annotations)
+	if class != autoscaling.KPA || (metric != autoscaling.CPU && metric != autoscaling.Memory) {
+		return ""
+	}
+	return metric
+}
+
+// sharesProcessNamespace returns whether the pods of the revision share their
+// process namespace, for the queue-proxy to measure the usage of the
+// containers from their processes rather than from the cgroup of the pod.
+// Revisions only do when they opt in.
+func sharesProcessNamespace(rev *v1.Revision, cfg *config.Config) bool {
+	_, v, _ := autoscaling.ShareProcessNamespaceAnnotation.Get(rev.Annotations)
+	share, _ := strconv.ParseBool(v)
+	return share && utilizationMetric(rev, cfg) != ""
+}
+
+// containersRequest returns the sum of the requests of the given resource of
+// the containers of the revision. The queue-proxy is not measured, so its
+// requests are left out.
+func containersRequest(rev *v1.Revision, name corev1.ResourceName) *resource.Quantity {
+	total := resource.Quantity{}
+	for _, c := range rev.Spec.Containers {
+		if q, ok := c.Resources.Requests[name]; ok {
+			total.Add(q)
+		}
+	}
+	return &total
+}
+
 func applyReadinessProbeDefaultsForExec(p *corev1.Probe, port int32) {
 	switch {
 	case p == nil:
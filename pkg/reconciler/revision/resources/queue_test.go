@@ -38,9 +38,11 @@ import (
 	"knative.dev/pkg/kmeta"
 	"knative.dev/pkg/logging"
 	"knative.dev/pkg/metrics"
+	"knative.dev/pkg/network"
 	"knative.dev/pkg/ptr"
 	"knative.dev/pkg/system"
 	tracingconfig "knative.dev/pkg/tracing/config"
+	"knative.dev/serving/pkg/apis/autoscaling"
 	apicfg "knative.dev/serving/pkg/apis/config"
 	"knative.dev/serving/pkg/apis/serving"
 	v1 "knative.dev/serving/pkg/apis/serving/v1"
@@ -91,6 +93,7 @@ func TestMakeQueueContainer(t *testing.T) {
 		oc   metrics.ObservabilityConfig
 		dc   deployment.Config
 		fc   apicfg.Features
+		ac   autoscalerconfig.Config
 		want corev1.Container
 	}{{
 		name: "autoscaler single",
@@ -358,6 +361,95 @@ func TestMakeQueueContainer(t *testing.T) {
 				"METRICS_COLLECTOR_ADDRESS":       "otel:55678",
 			})
 		}),
+	}, {
+		name: "stat push enabled",
+		rev: revision("bar", "foo",
+			withContainers(containers)),
+		ac: autoscalerconfig.Config{
+			EnableStatPush: true,
+		},
+		want: queueContainer(func(c *corev1.Container) {
+			c.Env = env(map[string]string{
+				"STAT_PUSH_ENDPOINT": "ws://autoscaler." + system.Namespace() + ".svc." + network.GetClusterDomainName() + ":8080",
+			})
+		}),
+	}, {
+		name: "resource recommendations enabled",
+		rev: revision("bar", "foo",
+			withContainers(containers)),
+		ac: autoscalerconfig.Config{
+			EnableResourceRecommendations: true,
+		},
+		want: queueContainer(func(c *corev1.Container) {
+			c.Env = env(map[string]string{})
+		}),
+	}, {
+		name: "cpu utilization metric",
+		rev: revision("bar", "foo",
+			func(revision *v1.Revision) {
+				revision.Annotations = map[string]string{
+					autoscaling.ClassAnnotationKey:  autoscaling.KPA,
+					autoscaling.MetricAnnotationKey: autoscaling.CPU,
+				}
+				revision.Spec.PodSpec.Containers = []corev1.Container{{
+					Name:           servingContainerName,
+					ReadinessProbe: testProbe,
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("500m"),
+						},
+					},
+				}}
+			}),
+		dc: deployment.Config{
+			QueueSidecarCPURequest: resourcePtr(resource.MustParse("25m")),
+		},
+		want: queueContainer(func(c *corev1.Container) {
+			c.Env = env(map[string]string{
+				"SERVING_CPU_REQUEST": "500",
+			})
+			c.Resources.Requests = corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("25m"),
+			}
+		}),
+	}, {
+		name: "memory utilization metric, default class",
+		rev: revision("bar", "foo",
+			func(revision *v1.Revision) {
+				revision.Annotations = map[string]string{
+					autoscaling.MetricAnnotationKey: autoscaling.Memory,
+				}
+				revision.Spec.PodSpec.Containers = []corev1.Container{{
+					Name:           servingContainerName,
+					ReadinessProbe: testProbe,
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceMemory: resource.MustParse("256Mi"),
+						},
+					},
+				}}
+			}),
+		ac: autoscalerconfig.Config{
+			PodAutoscalerClass: autoscaling.KPA,
+		},
+		want: queueContainer(func(c *corev1.Container) {
+			c.Env = env(map[string]string{
+				"SERVING_MEMORY_REQUEST": "268435456",
+			})
+		}),
+	}, {
+		name: "cpu metric of the HPA",
+		rev: revision("bar", "foo",
+			withContainers(containers),
+			func(revision *v1.Revision) {
+				revision.Annotations = map[string]string{
+					autoscaling.ClassAnnotationKey:  autoscaling.HPA,
+					autoscaling.MetricAnnotationKey: autoscaling.CPU,
+				}
+			}),
+		want: queueContainer(func(c *corev1.Container) {
+			c.Env = env(map[string]string{})
+		}),
 	}, {
 		name: "HTTP2 autodetection enabled",
 		rev: revision("bar", "foo",
@@ -415,7 +507,8 @@ func TestMakeQueueContainer(t *testing.T) {
 				Observability: &test.oc,
 				Deployment:    &test.dc,
 				Config: &apicfg.Config{
-					Features: &test.fc,
+					Features:   &test.fc,
+					Autoscaler: &test.ac,
 				},
 			}
 			got, err := makeQueueContainer(test.rev, cfg)
@@ -908,6 +1001,7 @@ var defaultEnv = map[string]string{
 	"ENABLE_PROFILING":                        "false",
 	"METRICS_DOMAIN":                          metrics.Domain(),
 	"METRICS_COLLECTOR_ADDRESS":               "",
+	"STAT_PUSH_ENDPOINT":                      "",
 	"QUEUE_SERVING_PORT":                      "8012",
 	"QUEUE_SERVING_TLS_PORT":                  "8112",
 	"REVISION_TIMEOUT_SECONDS":                "45",
@@ -32,6 +32,7 @@ import (
 	fakedeploymentinformer "knative.dev/pkg/client/injection/kube/informers/apps/v1/deployment/fake"
 	_ "knative.dev/pkg/client/injection/kube/informers/core/v1/configmap/fake"
 	_ "knative.dev/pkg/client/injection/kube/informers/core/v1/service/fake"
+	_ "knative.dev/pkg/client/injection/kube/informers/policy/v1/poddisruptionbudget/fake"
 	"knative.dev/pkg/ptr"
 	fakeservingclient "knative.dev/serving/pkg/client/injection/client/fake"
 	fakepainformer "knative.dev/serving/pkg/client/injection/informers/autoscaling/v1alpha1/podautoscaler/fake"
@@ -46,7 +47,6 @@ import (
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 
 	netcfg "knative.dev/networking/pkg/config"
@@ -115,6 +115,12 @@ func newTestController(t *testing.T, configs []*corev1.ConfigMap, opts ...reconc
 			Name:      config.FeaturesConfigName,
 		},
 		Data: map[string]string{},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: system.Namespace(),
+			Name:      config.PolicyConfigName,
+		},
+		Data: map[string]string{},
 	}, {
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: system.Namespace(),
@@ -229,7 +235,7 @@ func addResourcesToInformers(t *testing.T, ctx context.Context, rev *v1.Revision
 
 type nopResolver struct{}
 
-func (r *nopResolver) Resolve(_ *zap.SugaredLogger, rev *v1.Revision, _ k8schain.Options, _ sets.String, _ time.Duration) ([]v1.ContainerStatus, []v1.ContainerStatus, error) {
+func (r *nopResolver) Resolve(_ *zap.SugaredLogger, rev *v1.Revision, _ k8schain.Options, _ *deployment.Config) ([]v1.ContainerStatus, []v1.ContainerStatus, error) {
 	status := []v1.ContainerStatus{{
 		Name: rev.Spec.Containers[0].Name,
 	}}
@@ -340,7 +346,7 @@ func testDefaultsCM() *corev1.ConfigMap {
 
 type notResolvedYetResolver struct{}
 
-func (r *notResolvedYetResolver) Resolve(_ *zap.SugaredLogger, _ *v1.Revision, _ k8schain.Options, _ sets.String, _ time.Duration) ([]v1.ContainerStatus, []v1.ContainerStatus, error) {
+func (r *notResolvedYetResolver) Resolve(_ *zap.SugaredLogger, _ *v1.Revision, _ k8schain.Options, _ *deployment.Config) ([]v1.ContainerStatus, []v1.ContainerStatus, error) {
 	return nil, nil, nil
 }
 
@@ -352,7 +358,7 @@ type errorResolver struct {
 	cleared bool
 }
 
-func (r *errorResolver) Resolve(_ *zap.SugaredLogger, _ *v1.Revision, _ k8schain.Options, _ sets.String, _ time.Duration) ([]v1.ContainerStatus, []v1.ContainerStatus, error) {
+func (r *errorResolver) Resolve(_ *zap.SugaredLogger, _ *v1.Revision, _ k8schain.Options, _ *deployment.Config) ([]v1.ContainerStatus, []v1.ContainerStatus, error) {
 	return nil, nil, r.err
 }
 
@@ -399,6 +405,35 @@ func TestResolutionFailed(t *testing.T) {
 	}
 }
 
+func TestImageVerificationFailed(t *testing.T) {
+	verifyErr := &imageVerificationError{image: "busybox", err: errors.New("no signature found")}
+	resolver := &errorResolver{err: verifyErr}
+	ctx, _, _, controller, _ := newTestController(t, nil /*additional CMs*/, func(r *Reconciler) {
+		r.resolver = resolver
+	})
+
+	rev := testRevision(testPodSpec())
+	createRevision(t, ctx, controller, rev)
+
+	rev, err := fakeservingclient.Get(ctx).ServingV1().Revisions(testNamespace).Get(ctx, rev.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal("Couldn't get revision:", err)
+	}
+
+	got := rev.Status.GetCondition(v1.RevisionConditionContainerHealthy)
+	want := &apis.Condition{
+		Type:               v1.RevisionConditionContainerHealthy,
+		Status:             corev1.ConditionFalse,
+		Reason:             v1.ReasonImageVerificationFailed,
+		Message:            `Unable to verify image "busybox": no signature found`,
+		LastTransitionTime: got.LastTransitionTime,
+		Severity:           apis.ConditionSeverityError,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected revision conditions diff (-want +got):\n%s", diff)
+	}
+}
+
 func TestUpdateRevWithWithUpdatedLoggingURL(t *testing.T) {
 	ctx, _, _, controller, watcher := newTestController(t, []*corev1.ConfigMap{{
 		ObjectMeta: metav1.ObjectMeta{
@@ -23,8 +23,10 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	clientgotesting "k8s.io/client-go/testing"
 	clocktest "k8s.io/utils/clock/testing"
 
@@ -39,6 +41,7 @@ import (
 	"knative.dev/pkg/metrics"
 	pkgreconciler "knative.dev/pkg/reconciler"
 	tracingconfig "knative.dev/pkg/tracing/config"
+	"knative.dev/serving/pkg/apis/autoscaling"
 	autoscalingv1alpha1 "knative.dev/serving/pkg/apis/autoscaling/v1alpha1"
 	defaultconfig "knative.dev/serving/pkg/apis/config"
 	v1 "knative.dev/serving/pkg/apis/serving/v1"
@@ -194,6 +197,67 @@ func TestReconcile(t *testing.T) {
 		},
 		// No changes are made to any objects.
 		Key: "foo/stable-reconcile",
+	}, {
+		Name: "pdb created for min-scale",
+		// Test that a revision keeping at least 2 pods around gets a
+		// PodDisruptionBudget next to its Deployment.
+		Objects: []runtime.Object{
+			Revision("foo", "pdb-create", WithLogURL, allUnknownConditions,
+				withDefaultContainerStatuses(), WithRevisionObservedGeneration(1),
+				WithRevisionAnn(autoscaling.MinScaleAnnotationKey, "3")),
+			pa("foo", "pdb-create", WithReachabilityUnknown),
+			deploy(t, "foo", "pdb-create", WithRevisionAnn(autoscaling.MinScaleAnnotationKey, "3")),
+			image("foo", "pdb-create"),
+		},
+		WantCreates: []runtime.Object{
+			pdb("foo", "pdb-create", WithRevisionAnn(autoscaling.MinScaleAnnotationKey, "3")),
+		},
+		Key: "foo/pdb-create",
+	}, {
+		Name: "pdb updated for min-available",
+		// Test that the PodDisruptionBudget follows the min-available of the revision.
+		Objects: []runtime.Object{
+			Revision("foo", "pdb-update", WithLogURL, allUnknownConditions,
+				withDefaultContainerStatuses(), WithRevisionObservedGeneration(1),
+				WithRevisionAnn(autoscaling.MinScaleAnnotationKey, "3"),
+				WithRevisionAnn(autoscaling.MinAvailableAnnotationKey, "50%")),
+			pa("foo", "pdb-update", WithReachabilityUnknown),
+			deploy(t, "foo", "pdb-update",
+				WithRevisionAnn(autoscaling.MinScaleAnnotationKey, "3"),
+				WithRevisionAnn(autoscaling.MinAvailableAnnotationKey, "50%")),
+			image("foo", "pdb-update"),
+			pdb("foo", "pdb-update", WithRevisionAnn(autoscaling.MinScaleAnnotationKey, "3")),
+		},
+		WantUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: func() runtime.Object {
+				want := pdb("foo", "pdb-update", WithRevisionAnn(autoscaling.MinScaleAnnotationKey, "3"))
+				minAvailable := intstr.FromString("50%")
+				want.Spec.MinAvailable = &minAvailable
+				return want
+			}(),
+		}},
+		Key: "foo/pdb-update",
+	}, {
+		Name: "pdb deleted when min-scale drops",
+		// Test that the PodDisruptionBudget is removed once the revision may
+		// keep fewer than 2 pods around.
+		Objects: []runtime.Object{
+			Revision("foo", "pdb-delete", WithLogURL, allUnknownConditions,
+				withDefaultContainerStatuses(), WithRevisionObservedGeneration(1)),
+			pa("foo", "pdb-delete", WithReachabilityUnknown),
+			deploy(t, "foo", "pdb-delete"),
+			image("foo", "pdb-delete"),
+			pdb("foo", "pdb-delete", WithRevisionAnn(autoscaling.MinScaleAnnotationKey, "3")),
+		},
+		WantDeletes: []clientgotesting.DeleteActionImpl{{
+			ActionImpl: clientgotesting.ActionImpl{
+				Namespace: "foo",
+				Verb:      "delete",
+				Resource:  policyv1.SchemeGroupVersion.WithResource("poddisruptionbudgets"),
+			},
+			Name: "pdb-delete-pdb",
+		}},
+		Key: "foo/pdb-delete",
 	}, {
 		Name: "update deployment containers",
 		// Test that we update a deployment with new containers when they disagree
@@ -496,13 +560,18 @@ func TestReconcile(t *testing.T) {
 				WithLogURL, MarkActivating("Deploying", "")),
 			pa("foo", "pull-backoff"), // pa can't be ready since deployment times out.
 			pod(t, "foo", "pull-backoff", WithWaitingContainer("pull-backoff", "ImagePullBackoff", "can't pull it")),
+			// The events of a pod whose containers never ran are not listed.
+			probeFailureEvent("foo", "pull-backoff", "pull-backoff", "Readiness probe failed"),
 			timeoutDeploy(deploy(t, "foo", "pull-backoff"), "Timed out!"),
 			image("foo", "pull-backoff"),
 		},
 		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
 			Object: Revision("foo", "pull-backoff",
 				WithLogURL, allUnknownConditions,
-				MarkResourcesUnavailable("ImagePullBackoff", "can't pull it"), withDefaultContainerStatuses(), WithRevisionObservedGeneration(1)),
+				MarkResourcesUnavailable("ImagePullBackoff", "can't pull it"),
+				withContainerDiagnostics(v1.ContainerStatus{
+					Waiting: &v1.ContainerWaiting{Reason: "ImagePullBackoff", Message: "can't pull it"},
+				}), WithRevisionObservedGeneration(1)),
 		}},
 		WantUpdates: []clientgotesting.UpdateActionImpl{{
 			Object: pa("foo", "pull-backoff", WithReachabilityUnreachable),
@@ -525,12 +594,70 @@ func TestReconcile(t *testing.T) {
 		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
 			Object: Revision("foo", "pod-error",
 				WithLogURL, allUnknownConditions, MarkContainerExiting(5,
-					v1.RevisionContainerExitingMessage("I failed man!")), withDefaultContainerStatuses(), WithRevisionObservedGeneration(1)),
+					v1.RevisionContainerExitingMessage("I failed man!")),
+				withContainerDiagnostics(v1.ContainerStatus{
+					LastTermination: &v1.ContainerTermination{ExitCode: 5, Message: "I failed man!"},
+				}), WithRevisionObservedGeneration(1)),
 		}},
 		WantUpdates: []clientgotesting.UpdateActionImpl{{
 			Object: pa("foo", "pod-error", WithReachabilityUnreachable),
 		}},
 		Key: "foo/pod-error",
+	}, {
+		Name: "surface container diagnostics",
+		// Test the propagation of the restarts, the OOM kills and the probe
+		// failures of the containers of a crash-looping Pod into the revision.
+		Objects: []runtime.Object{
+			Revision("foo", "pod-oom",
+				WithLogURL, allUnknownConditions, MarkActive),
+			pa("foo", "pod-oom"), // PA can't be ready, since no traffic.
+			pod(t, "foo", "pod-oom", withOOMKilledContainer("pod-oom", 3)),
+			probeFailureEvent("foo", "pod-oom", "pod-oom", "Liveness probe failed: HTTP probe failed with statuscode: 500"),
+			deploy(t, "foo", "pod-oom"),
+			image("foo", "pod-oom"),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: Revision("foo", "pod-oom",
+				WithLogURL, allUnknownConditions, MarkContainerExiting(137,
+					v1.RevisionContainerExitingMessage("container was OOMKilled, it exceeded its memory limit")+
+						" (restarts: 3; last probe failure: Liveness probe failed: HTTP probe failed with statuscode: 500)"),
+				withContainerDiagnostics(v1.ContainerStatus{
+					RestartCount: 3,
+					Waiting:      &v1.ContainerWaiting{Reason: "CrashLoopBackOff", Message: "back-off restarting failed container"},
+					LastTermination: &v1.ContainerTermination{
+						ExitCode: 137,
+						Reason:   "OOMKilled",
+					},
+					ProbeFailure: "Liveness probe failed: HTTP probe failed with statuscode: 500",
+				}), WithRevisionObservedGeneration(1)),
+		}},
+		WantUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: pa("foo", "pod-oom", WithReachabilityUnreachable),
+		}},
+		Key: "foo/pod-oom",
+	}, {
+		Name: "clear container diagnostics",
+		// Test that the diagnostics of the containers are cleared once the
+		// Deployment has available pods.
+		Objects: []runtime.Object{
+			Revision("foo", "recovered", WithLogURL,
+				withContainerDiagnostics(v1.ContainerStatus{
+					RestartCount:    1,
+					LastTermination: &v1.ContainerTermination{ExitCode: 5, Message: "I failed man!"},
+				})),
+			pa("foo", "recovered", WithPASKSReady, WithTraffic,
+				WithScaleTargetInitialized, WithPAStatusService("recovered")),
+			availableDeploy(deploy(t, "foo", "recovered")),
+			image("foo", "recovered"),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: Revision("foo", "recovered", WithLogURL,
+				MarkRevisionReady, withDefaultContainerStatuses(), WithRevisionObservedGeneration(1)),
+		}},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "RevisionReady", "Revision becomes ready upon all resources being ready"),
+		},
+		Key: "foo/recovered",
 	}, {
 		Name: "surface pod schedule errors",
 		// Test the propagation of the scheduling errors of Pod into the revision.
@@ -691,6 +818,7 @@ func TestReconcile(t *testing.T) {
 			podAutoscalerLister: listers.GetPodAutoscalerLister(),
 			imageLister:         listers.GetImageLister(),
 			deploymentLister:    listers.GetDeploymentLister(),
+			pdbLister:           listers.GetPodDisruptionBudgetLister(),
 			resolver:            &nopResolver{},
 		}
 
@@ -751,6 +879,13 @@ func imagePullSecrets(Revision *caching.Image, secretName string) *caching.Image
 	return Revision
 }
 
+func availableDeploy(deploy *appsv1.Deployment) *appsv1.Deployment {
+	deploy.Status.Replicas = *deploy.Spec.Replicas
+	deploy.Status.ReadyReplicas = *deploy.Spec.Replicas
+	deploy.Status.AvailableReplicas = *deploy.Spec.Replicas
+	return deploy
+}
+
 func changeContainers(deploy *appsv1.Deployment) *appsv1.Deployment {
 	podSpec := deploy.Spec.Template.Spec
 	for i := range podSpec.Containers {
@@ -768,6 +903,13 @@ func withDefaultContainerStatuses() RevisionOption {
 	}
 }
 
+func withContainerDiagnostics(diagnostics v1.ContainerStatus) RevisionOption {
+	return func(r *v1.Revision) {
+		diagnostics.Name = r.Name
+		r.Status.ContainerStatuses = []v1.ContainerStatus{diagnostics}
+	}
+}
+
 func withInitContainerStatuses() RevisionOption {
 	return func(r *v1.Revision) {
 		r.Status.InitContainerStatuses = []v1.ContainerStatus{{
@@ -849,6 +991,10 @@ func pa(namespace, name string, ko ...PodAutoscalerOption) *autoscalingv1alpha1.
 	return k
 }
 
+func pdb(namespace, name string, ro ...RevisionOption) *policyv1.PodDisruptionBudget {
+	return resources.MakePDB(Revision(namespace, name, ro...), reconcilerTestConfig().Autoscaler)
+}
+
 func pod(t *testing.T, namespace, name string, po ...PodOption) *corev1.Pod {
 	t.Helper()
 	deploy := deploy(t, namespace, name)
@@ -867,6 +1013,45 @@ func pod(t *testing.T, namespace, name string, po ...PodOption) *corev1.Pod {
 	return pod
 }
 
+func withOOMKilledContainer(name string, restarts int32) PodOption {
+	return func(pod *corev1.Pod) {
+		pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+			Name:         name,
+			RestartCount: restarts,
+			State: corev1.ContainerState{
+				Waiting: &corev1.ContainerStateWaiting{
+					Reason:  "CrashLoopBackOff",
+					Message: "back-off restarting failed container",
+				},
+			},
+			LastTerminationState: corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{
+					ExitCode: 137,
+					Reason:   "OOMKilled",
+				},
+			},
+		}}
+	}
+}
+
+func probeFailureEvent(namespace, pod, container, message string) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      pod + ".unhealthy",
+		},
+		InvolvedObject: corev1.ObjectReference{
+			Kind:      "Pod",
+			Namespace: namespace,
+			Name:      pod,
+			FieldPath: "spec.containers{" + container + "}",
+		},
+		Reason:  "Unhealthy",
+		Message: message,
+		Type:    corev1.EventTypeWarning,
+	}
+}
+
 type testConfigStore struct {
 	config *config.Config
 }
@@ -0,0 +1,260 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package revision
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn/k8schain"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+
+	"knative.dev/serving/pkg/deployment"
+)
+
+const (
+	// cosignSignatureAnnotation is the annotation of the layers of a cosign
+	// signature image holding the signature of the layer.
+	cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+
+	// inTotoPayloadType is the DSSE payload type of the in-toto statements
+	// cosign attests images with.
+	inTotoPayloadType = "application/vnd.in-toto+json"
+
+	// maxEvidenceSize is the maximum size of a signature or attestation layer
+	// read from the registry.
+	maxEvidenceSize = 4 << 20
+)
+
+// imageVerifier is an interface used mostly to mock digestResolver for tests.
+type imageVerifier interface {
+	Verify(ctx context.Context, image string, opt k8schain.Options, policy *deployment.ImageVerificationPolicy) error
+}
+
+// Verify verifies that the image, referenced by digest, has a cosign
+// signature or attestation, as the policy requires, signed by one of the
+// public keys of the policy. The signatures and attestations are looked up
+// with the cosign tag conventions, in the repository of the image.
+func (r *digestResolver) Verify(ctx context.Context, image string, opt k8schain.Options, policy *deployment.ImageVerificationPolicy) error {
+	digest, err := name.NewDigest(image, name.WeakValidation)
+	if err != nil {
+		return fmt.Errorf("failed to parse image name %q into a digest: %w", image, err)
+	}
+	hash, err := v1.NewHash(digest.DigestStr())
+	if err != nil {
+		return fmt.Errorf("failed to parse digest of image %q: %w", image, err)
+	}
+	keys, err := deployment.ParsePublicKeys([]byte(strings.Join(policy.PublicKeys, "")))
+	if err != nil {
+		return fmt.Errorf("failed to parse public keys: %w", err)
+	}
+
+	kc, err := k8schain.New(ctx, r.client, opt)
+	if err != nil {
+		return fmt.Errorf("failed to initialize authentication: %w", err)
+	}
+
+	suffix := "sig"
+	if policy.Mode == deployment.ImageVerificationAttestation {
+		suffix = "att"
+	}
+	tag := digest.Context().Tag(fmt.Sprintf("%s-%s.%s", hash.Algorithm, hash.Hex, suffix))
+	img, err := remote.Image(tag, remote.WithContext(ctx), remote.WithTransport(r.transport), remote.WithAuthFromKeychain(kc), remote.WithUserAgent(r.userAgent))
+	var terr *transport.Error
+	if errors.As(err, &terr) && terr.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("no %s found at %s", policy.Mode, tag)
+	} else if err != nil {
+		return err
+	}
+
+	manifest, err := img.Manifest()
+	if err != nil {
+		return err
+	}
+	var reason error
+	for _, desc := range manifest.Layers {
+		layer, err := img.LayerByDigest(desc.Digest)
+		if err != nil {
+			return err
+		}
+		payload, err := readLayer(layer)
+		if err != nil {
+			return err
+		}
+		if policy.Mode == deployment.ImageVerificationAttestation {
+			reason = verifyAttestation(keys, payload, hash)
+		} else {
+			reason = verifySignature(keys, payload, desc.Annotations[cosignSignatureAnnotation], hash)
+		}
+		if reason == nil {
+			return nil
+		}
+	}
+	if reason == nil {
+		return fmt.Errorf("no %s found at %s", policy.Mode, tag)
+	}
+	return fmt.Errorf("no %s verified with the public keys: %w", policy.Mode, reason)
+}
+
+func readLayer(layer v1.Layer) ([]byte, error) {
+	rc, err := layer.Compressed()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(io.LimitReader(rc, maxEvidenceSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxEvidenceSize {
+		return nil, fmt.Errorf("layer is larger than %d bytes", maxEvidenceSize)
+	}
+	return data, nil
+}
+
+// simpleSigning is the part of the simple signing payload cosign signs that
+// the verification needs.
+type simpleSigning struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+// verifySignature verifies that the base64-encoded signature of the simple
+// signing payload was made with one of the keys, and that the payload is about
+// the image digest.
+func verifySignature(keys []interface{}, payload []byte, signature string, digest v1.Hash) error {
+	if signature == "" {
+		return errors.New("signature layer has no signature")
+	}
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("failed to decode signature: %w", err)
+	}
+	if err := verifyWithAny(keys, payload, sig); err != nil {
+		return err
+	}
+
+	var ss simpleSigning
+	if err := json.Unmarshal(payload, &ss); err != nil {
+		return fmt.Errorf("failed to parse signature payload: %w", err)
+	}
+	if got := ss.Critical.Image.DockerManifestDigest; got != digest.String() {
+		return fmt.Errorf("signature is for digest %q", got)
+	}
+	return nil
+}
+
+// dsseEnvelope is a DSSE envelope, as cosign stores the attestations in.
+type dsseEnvelope struct {
+	PayloadType string `json:"payloadType"`
+	Payload     string `json:"payload"`
+	Signatures  []struct {
+		Sig string `json:"sig"`
+	} `json:"signatures"`
+}
+
+// inTotoStatement is the part of an in-toto statement the verification needs.
+type inTotoStatement struct {
+	Subject []struct {
+		Digest map[string]string `json:"digest"`
+	} `json:"subject"`
+}
+
+// verifyAttestation verifies that the DSSE envelope holds an in-toto
+// statement about the image digest, signed with one of the keys.
+func verifyAttestation(keys []interface{}, envelope []byte, digest v1.Hash) error {
+	var env dsseEnvelope
+	if err := json.Unmarshal(envelope, &env); err != nil {
+		return fmt.Errorf("failed to parse attestation envelope: %w", err)
+	}
+	if env.PayloadType != inTotoPayloadType {
+		return fmt.Errorf("attestation payload type is %q, want: %q", env.PayloadType, inTotoPayloadType)
+	}
+	payload, err := base64.StdEncoding.DecodeString(env.Payload)
+	if err != nil {
+		return fmt.Errorf("failed to decode attestation payload: %w", err)
+	}
+
+	// The signatures are over the pre-authentication encoding of the payload.
+	pae := []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(env.PayloadType), env.PayloadType, len(payload), payload))
+	err = errors.New("attestation has no signature")
+	for _, s := range env.Signatures {
+		var sig []byte
+		if sig, err = base64.StdEncoding.DecodeString(s.Sig); err != nil {
+			err = fmt.Errorf("failed to decode attestation signature: %w", err)
+			continue
+		}
+		if err = verifyWithAny(keys, pae, sig); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	var statement inTotoStatement
+	if err := json.Unmarshal(payload, &statement); err != nil {
+		return fmt.Errorf("failed to parse attestation statement: %w", err)
+	}
+	for _, subject := range statement.Subject {
+		if subject.Digest[digest.Algorithm] == digest.Hex {
+			return nil
+		}
+	}
+	return errors.New("attestation isn't about the image digest")
+}
+
+// verifyWithAny verifies that the signature of the message was made with one
+// of the keys. The ECDSA and RSA signatures are over the SHA-256 digest of the
+// message, like cosign makes them.
+func verifyWithAny(keys []interface{}, message, sig []byte) error {
+	sum := sha256.Sum256(message)
+	for _, key := range keys {
+		switch k := key.(type) {
+		case *ecdsa.PublicKey:
+			if ecdsa.VerifyASN1(k, sum[:], sig) {
+				return nil
+			}
+		case *rsa.PublicKey:
+			if rsa.VerifyPKCS1v15(k, crypto.SHA256, sum[:], sig) == nil {
+				return nil
+			}
+		case ed25519.PublicKey:
+			if ed25519.Verify(k, message, sig) {
+				return nil
+			}
+		}
+	}
+	return errors.New("signature doesn't match any of the public keys")
+}
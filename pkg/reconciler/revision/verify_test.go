@@ -0,0 +1,260 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package revision
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn/k8schain"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	fakeclient "k8s.io/client-go/kubernetes/fake"
+
+	"knative.dev/serving/pkg/deployment"
+)
+
+func TestVerify(t *testing.T) {
+	server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal("GenerateKey() =", err)
+	}
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal("GenerateKey() =", err)
+	}
+	edPublic, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal("GenerateKey() =", err)
+	}
+
+	tests := []struct {
+		name    string
+		mode    deployment.ImageVerificationMode
+		keys    []crypto.PublicKey
+		publish func(t *testing.T, digest name.Digest)
+		wantErr string
+	}{{
+		name: "signature",
+		mode: deployment.ImageVerificationSignature,
+		keys: []crypto.PublicKey{&otherKey.PublicKey, &ecKey.PublicKey},
+		publish: func(t *testing.T, digest name.Digest) {
+			pushSignature(t, digest, ecKey, digest.DigestStr())
+		},
+	}, {
+		name: "ed25519 signature",
+		mode: deployment.ImageVerificationSignature,
+		keys: []crypto.PublicKey{edPublic},
+		publish: func(t *testing.T, digest name.Digest) {
+			pushSignature(t, digest, edKey, digest.DigestStr())
+		},
+	}, {
+		name:    "no signature",
+		mode:    deployment.ImageVerificationSignature,
+		keys:    []crypto.PublicKey{&ecKey.PublicKey},
+		publish: func(*testing.T, name.Digest) {},
+		wantErr: "no signature found",
+	}, {
+		name: "signature with another key",
+		mode: deployment.ImageVerificationSignature,
+		keys: []crypto.PublicKey{&ecKey.PublicKey},
+		publish: func(t *testing.T, digest name.Digest) {
+			pushSignature(t, digest, otherKey, digest.DigestStr())
+		},
+		wantErr: "doesn't match any of the public keys",
+	}, {
+		name: "signature of another digest",
+		mode: deployment.ImageVerificationSignature,
+		keys: []crypto.PublicKey{&ecKey.PublicKey},
+		publish: func(t *testing.T, digest name.Digest) {
+			pushSignature(t, digest, ecKey, "sha256:"+strings.Repeat("0", 64))
+		},
+		wantErr: "signature is for digest",
+	}, {
+		name: "attestation",
+		mode: deployment.ImageVerificationAttestation,
+		keys: []crypto.PublicKey{&ecKey.PublicKey},
+		publish: func(t *testing.T, digest name.Digest) {
+			pushAttestation(t, digest, ecKey, digest.DigestStr())
+		},
+	}, {
+		name: "signature, but no attestation",
+		mode: deployment.ImageVerificationAttestation,
+		keys: []crypto.PublicKey{&ecKey.PublicKey},
+		publish: func(t *testing.T, digest name.Digest) {
+			pushSignature(t, digest, ecKey, digest.DigestStr())
+		},
+		wantErr: "no attestation found",
+	}, {
+		name: "attestation with another key",
+		mode: deployment.ImageVerificationAttestation,
+		keys: []crypto.PublicKey{&ecKey.PublicKey},
+		publish: func(t *testing.T, digest name.Digest) {
+			pushAttestation(t, digest, otherKey, digest.DigestStr())
+		},
+		wantErr: "doesn't match any of the public keys",
+	}, {
+		name: "attestation of another digest",
+		mode: deployment.ImageVerificationAttestation,
+		keys: []crypto.PublicKey{&ecKey.PublicKey},
+		publish: func(t *testing.T, digest name.Digest) {
+			pushAttestation(t, digest, ecKey, "sha256:"+strings.Repeat("0", 64))
+		},
+		wantErr: "attestation isn't about the image digest",
+	}}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img, err := random.Image(1024, 1)
+			if err != nil {
+				t.Fatal("random.Image() =", err)
+			}
+			digest, err := name.NewDigest(fmt.Sprintf("%s/verify/%d@%s", host, i, mustDigest(t, img)))
+			if err != nil {
+				t.Fatal("NewDigest() =", err)
+			}
+			if err := remote.Write(digest, img); err != nil {
+				t.Fatal("Write() =", err)
+			}
+			tt.publish(t, digest)
+
+			dr := &digestResolver{client: fakeclient.NewSimpleClientset(), transport: http.DefaultTransport}
+			policy := &deployment.ImageVerificationPolicy{Mode: tt.mode}
+			for _, key := range tt.keys {
+				policy.PublicKeys = append(policy.PublicKeys, publicKeyPEM(t, key))
+			}
+			err = dr.Verify(context.Background(), digest.String(), k8schain.Options{Namespace: "ns"}, policy)
+			if tt.wantErr == "" && err != nil {
+				t.Fatal("Verify() =", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("Verify() = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// pushSignature pushes a cosign signature of the digest to the repository of
+// the image, as cosign does.
+func pushSignature(t *testing.T, image name.Digest, key crypto.Signer, digest string) {
+	t.Helper()
+	payload := []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":%q},"image":{"docker-manifest-digest":%q},"type":"cosign container image signature"},"optional":null}`,
+		image.Context().String(), digest))
+	pushEvidence(t, image, "sig", mutate.Addendum{
+		Layer: static.NewLayer(payload, "application/vnd.dev.cosign.simplesigning.v1+json"),
+		Annotations: map[string]string{
+			cosignSignatureAnnotation: base64.StdEncoding.EncodeToString(sign(t, key, payload)),
+		},
+	})
+}
+
+// pushAttestation pushes a cosign attestation of the digest to the repository
+// of the image, as cosign does.
+func pushAttestation(t *testing.T, image name.Digest, key crypto.Signer, digest string) {
+	t.Helper()
+	h, err := v1.NewHash(digest)
+	if err != nil {
+		t.Fatal("NewHash() =", err)
+	}
+	statement := []byte(fmt.Sprintf(`{"_type":"https://in-toto.io/Statement/v0.1","predicateType":"https://slsa.dev/provenance/v0.2","subject":[{"name":%q,"digest":{%q:%q}}],"predicate":{}}`,
+		image.Context().String(), h.Algorithm, h.Hex))
+	pae := []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(inTotoPayloadType), inTotoPayloadType, len(statement), statement))
+	envelope, err := json.Marshal(map[string]interface{}{
+		"payloadType": inTotoPayloadType,
+		"payload":     base64.StdEncoding.EncodeToString(statement),
+		"signatures": []map[string]string{{
+			"keyid": "",
+			"sig":   base64.StdEncoding.EncodeToString(sign(t, key, pae)),
+		}},
+	})
+	if err != nil {
+		t.Fatal("Marshal() =", err)
+	}
+	pushEvidence(t, image, "att", mutate.Addendum{
+		Layer: static.NewLayer(envelope, "application/vnd.dsse.envelope.v1+json"),
+	})
+}
+
+func pushEvidence(t *testing.T, image name.Digest, suffix string, add mutate.Addendum) {
+	t.Helper()
+	h, err := v1.NewHash(image.DigestStr())
+	if err != nil {
+		t.Fatal("NewHash() =", err)
+	}
+	img, err := mutate.Append(mutate.MediaType(empty.Image, types.OCIManifestSchema1), add)
+	if err != nil {
+		t.Fatal("Append() =", err)
+	}
+	tag := image.Context().Tag(fmt.Sprintf("%s-%s.%s", h.Algorithm, h.Hex, suffix))
+	if err := remote.Write(tag, img); err != nil {
+		t.Fatal("Write() =", err)
+	}
+}
+
+func sign(t *testing.T, key crypto.Signer, message []byte) []byte {
+	t.Helper()
+	var (
+		sig []byte
+		err error
+	)
+	if _, ok := key.(ed25519.PrivateKey); ok {
+		sig, err = key.Sign(rand.Reader, message, crypto.Hash(0))
+	} else {
+		sum := sha256.Sum256(message)
+		sig, err = key.Sign(rand.Reader, sum[:], crypto.SHA256)
+	}
+	if err != nil {
+		t.Fatal("Sign() =", err)
+	}
+	return sig
+}
+
+func publicKeyPEM(t *testing.T, key crypto.PublicKey) string {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		t.Fatal("MarshalPKIXPublicKey() =", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
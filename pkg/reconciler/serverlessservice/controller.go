@@ -19,6 +19,7 @@ package serverlessservice
 import (
 	"context"
 
+	discoveryv1 "k8s.io/api/discovery/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/tools/cache"
 
@@ -28,6 +29,7 @@ import (
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	endpointsinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/endpoints"
 	serviceinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/service"
+	endpointsliceinformer "knative.dev/pkg/client/injection/kube/informers/discovery/v1/endpointslice"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
@@ -47,14 +49,16 @@ func NewController(
 	logger := logging.FromContext(ctx)
 	serviceInformer := serviceinformer.Get(ctx)
 	endpointsInformer := endpointsinformer.Get(ctx)
+	endpointSliceInformer := endpointsliceinformer.Get(ctx)
 	psInformerFactory := podscalable.Get(ctx)
 	sksInformer := sksinformer.Get(ctx)
 
 	c := &reconciler{
 		kubeclient: kubeclient.Get(ctx),
 
-		endpointsLister: endpointsInformer.Lister(),
-		serviceLister:   serviceInformer.Lister(),
+		endpointsLister:     endpointsInformer.Lister(),
+		endpointSliceLister: endpointSliceInformer.Lister(),
+		serviceLister:       serviceInformer.Lister(),
 
 		// We wrap the PodScalable Informer Factory here so Get() uses the outer context.
 		// As the returned Informer is shared across reconciles, passing the context from
@@ -69,8 +73,9 @@ func NewController(
 	// Watch all the SKS objects.
 	sksInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
 
-	// Watch all the endpoints that we have attached our label to.
-	endpointsInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+	// Watch all the endpoint slices of the private services, which carry the
+	// labels of their service.
+	endpointSliceInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 		FilterFunc: pkgreconciler.ChainFilterFuncs(
 			pkgreconciler.LabelExistsFilterFunc(networking.SKSLabelKey),
 			pkgreconciler.LabelFilterFunc(networking.ServiceTypeKey, string(networking.ServiceTypePrivate), false),
@@ -84,18 +89,18 @@ func NewController(
 		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
 	})
 
-	// Watch activator-service endpoints.
+	// Watch activator-service endpoint slices.
 	grCb := func(obj interface{}) {
 		// Since changes in the Activator Service endpoints affect all the SKS objects,
 		// do a global resync.
 		logger.Info("Doing a global resync due to activator endpoint changes")
 		impl.GlobalResync(sksInformer.Informer())
 	}
-	endpointsInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
-		// Accept only ActivatorService K8s service objects.
+	endpointSliceInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		// Accept only the slices of the ActivatorService K8s service.
 		FilterFunc: pkgreconciler.ChainFilterFuncs(
 			pkgreconciler.NamespaceFilterFunc(system.Namespace()),
-			pkgreconciler.NameFilterFunc(networking.ActivatorServiceName)),
+			pkgreconciler.LabelFilterFunc(discoveryv1.LabelServiceName, networking.ActivatorServiceName, false)),
 		Handler: controller.HandleAll(grCb),
 	})
 
@@ -28,10 +28,12 @@ import (
 	fakenetworkingclient "knative.dev/networking/pkg/client/injection/client/fake"
 	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
 	fakeendpointsinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/endpoints/fake"
+	_ "knative.dev/pkg/client/injection/kube/informers/discovery/v1/endpointslice/fake"
 	"knative.dev/pkg/configmap"
 	fakedynamicclient "knative.dev/pkg/injection/clients/dynamicclient/fake"
 	"knative.dev/serving/pkg/client/injection/ducks/autoscaling/v1alpha1/podscalable"
 
+	discoveryv1 "k8s.io/api/discovery/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -64,17 +66,17 @@ func TestGlobalResyncOnActivatorChange(t *testing.T) {
 	kubeClnt := fakekubeclient.Get(ctx)
 	epsInformer := fakeendpointsinformer.Get(ctx)
 
-	// Create activator endpoints.
-	aEps := activatorEndpoints(WithSubsets)
-	kubeClnt.CoreV1().Endpoints(aEps.Namespace).Create(ctx, aEps, metav1.CreateOptions{})
+	// Create activator endpoint slice.
+	aSlice := activatorSlice(withEndpoints)
+	kubeClnt.DiscoveryV1().EndpointSlices(aSlice.Namespace).Create(ctx, aSlice, metav1.CreateOptions{})
 
-	// Private endpoints are supposed to exist, since we're using selector mode for the service.
-	privEps := endpointspriv(ns1, sks1)
-	kubeClnt.CoreV1().Endpoints(privEps.Namespace).Create(ctx, privEps, metav1.CreateOptions{})
+	// Private endpoint slices are supposed to exist, since we're using selector mode for the service.
+	privSlice := slicepriv(ns1, sks1)
+	kubeClnt.DiscoveryV1().EndpointSlices(privSlice.Namespace).Create(ctx, privSlice, metav1.CreateOptions{})
 
 	// This is passive, so no endpoints.
-	privEps = endpointspriv(ns2, sks2, withOtherSubsets)
-	kubeClnt.CoreV1().Endpoints(privEps.Namespace).Create(ctx, privEps, metav1.CreateOptions{})
+	privSlice = slicepriv(ns2, sks2, withOtherEndpoints)
+	kubeClnt.DiscoveryV1().EndpointSlices(privSlice.Namespace).Create(ctx, privSlice, metav1.CreateOptions{})
 
 	waitInformers, err := RunAndSyncInformers(ctx, informers...)
 	if err != nil {
@@ -118,11 +120,12 @@ func TestGlobalResyncOnActivatorChange(t *testing.T) {
 	}
 
 	t.Log("Updating the activator endpoints now...")
-	// Now that we have established the baseline, update the activator endpoints.
-	aEps = activatorEndpoints(withOtherSubsets)
-	if _, err := kubeClnt.CoreV1().Endpoints(aEps.Namespace).Update(ctx, aEps, metav1.UpdateOptions{}); err != nil {
-		t.Fatal("Error creating activator endpoints:", err)
+	// Now that we have established the baseline, update the activator endpoint slice.
+	aSlice = activatorSlice(withOtherEndpoints)
+	if _, err := kubeClnt.DiscoveryV1().EndpointSlices(aSlice.Namespace).Update(ctx, aSlice, metav1.UpdateOptions{}); err != nil {
+		t.Fatal("Error updating activator endpoint slice:", err)
 	}
+	aSubsets := resources.EndpointSubsets([]*discoveryv1.EndpointSlice{aSlice})
 
 	// Actively wait for the endpoints to change their value.
 	if err := wait.PollImmediate(25*time.Millisecond, 5*time.Second, func() (bool, error) {
@@ -132,7 +135,7 @@ func TestGlobalResyncOnActivatorChange(t *testing.T) {
 		} else if err != nil {
 			return false, err
 		}
-		if cmp.Equal(ep.Subsets, resources.FilterSubsetPorts(sksObj1, aEps.Subsets)) {
+		if cmp.Equal(ep.Subsets, resources.FilterSubsetPorts(sksObj1, aSubsets)) {
 			return true, nil
 		}
 		return false, nil
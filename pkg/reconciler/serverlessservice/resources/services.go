@@ -17,16 +17,21 @@ limitations under the License.
 package resources
 
 import (
+	"sort"
+
 	pkgnet "knative.dev/networking/pkg/apis/networking"
 	"knative.dev/networking/pkg/apis/networking/v1alpha1"
 	"knative.dev/pkg/kmeta"
 	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
 	"knative.dev/serving/pkg/networking"
 	"knative.dev/serving/pkg/reconciler/serverlessservice/resources/names"
+	presources "knative.dev/serving/pkg/resources"
 
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 // targetPort chooses the target (pod) port for the public and private service.
@@ -78,7 +83,7 @@ func makePublicServicePorts(sks *v1alpha1.ServerlessService) []corev1.ServicePor
 
 // MakePublicEndpoints constructs a K8s Endpoints that is not backed a selector
 // and will be manually reconciled by the SKS controller.
-func MakePublicEndpoints(sks *v1alpha1.ServerlessService, src *corev1.Endpoints) *corev1.Endpoints {
+func MakePublicEndpoints(sks *v1alpha1.ServerlessService, subsets []corev1.EndpointSubset) *corev1.Endpoints {
 	return &corev1.Endpoints{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      sks.Name, // Name of Endpoints must match that of Service.
@@ -91,8 +96,79 @@ func MakePublicEndpoints(sks *v1alpha1.ServerlessService, src *corev1.Endpoints)
 			Annotations:     kmeta.CopyMap(sks.GetAnnotations()),
 			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(sks)},
 		},
-		Subsets: FilterSubsetPorts(sks, src.Subsets),
+		Subsets: FilterSubsetPorts(sks, subsets),
+	}
+}
+
+// EndpointSubsets converts the endpoints of the EndpointSlices of a service to
+// the subsets of an Endpoints object, one for every slice with endpoints.
+// Terminating endpoints are left out, as the Endpoints controller does, and an
+// endpoint showing up in more than one slice is kept only once. The slices are
+// taken in the order of their names and the addresses are sorted, so that the
+// result does not change as long as the endpoints do not.
+func EndpointSubsets(slices []*discoveryv1.EndpointSlice) []corev1.EndpointSubset {
+	sorted := make([]*discoveryv1.EndpointSlice, len(slices))
+	copy(sorted, slices)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	var (
+		subsets []corev1.EndpointSubset
+		seen    = sets.NewString()
+	)
+	for _, slice := range sorted {
+		var ss corev1.EndpointSubset
+		for _, ep := range slice.Endpoints {
+			// Per the API all the addresses are fungible, so the first one suffices.
+			if len(ep.Addresses) == 0 || presources.EndpointTerminating(ep) || seen.Has(ep.Addresses[0]) {
+				continue
+			}
+			seen.Insert(ep.Addresses[0])
+			addr := corev1.EndpointAddress{
+				IP:        ep.Addresses[0],
+				TargetRef: ep.TargetRef.DeepCopy(),
+			}
+			if ep.Hostname != nil {
+				addr.Hostname = *ep.Hostname
+			}
+			if ep.NodeName != nil {
+				nodeName := *ep.NodeName
+				addr.NodeName = &nodeName
+			}
+			if presources.EndpointReady(ep) {
+				ss.Addresses = append(ss.Addresses, addr)
+			} else {
+				ss.NotReadyAddresses = append(ss.NotReadyAddresses, addr)
+			}
+		}
+		if len(ss.Addresses) == 0 && len(ss.NotReadyAddresses) == 0 {
+			continue
+		}
+		sortAddresses(ss.Addresses)
+		sortAddresses(ss.NotReadyAddresses)
+		for _, p := range slice.Ports {
+			port := corev1.EndpointPort{AppProtocol: p.AppProtocol}
+			if p.Name != nil {
+				port.Name = *p.Name
+			}
+			if p.Port != nil {
+				port.Port = *p.Port
+			}
+			if p.Protocol != nil {
+				port.Protocol = *p.Protocol
+			}
+			ss.Ports = append(ss.Ports, port)
+		}
+		subsets = append(subsets, ss)
 	}
+	return subsets
+}
+
+func sortAddresses(addrs []corev1.EndpointAddress) {
+	sort.Slice(addrs, func(i, j int) bool {
+		return addrs[i].IP < addrs[j].IP
+	})
 }
 
 // FilterSubsetPorts makes a copy of the ep.Subsets, filtering out ports
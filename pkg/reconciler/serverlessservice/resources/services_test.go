@@ -22,6 +22,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
@@ -248,16 +249,15 @@ func TestMakePublicService(t *testing.T) {
 
 func TestMakeEndpoints(t *testing.T) {
 	tests := []struct {
-		name string
-		sks  *v1alpha1.ServerlessService
-		eps  *corev1.Endpoints
-		want *corev1.Endpoints
+		name    string
+		sks     *v1alpha1.ServerlessService
+		subsets []corev1.EndpointSubset
+		want    *corev1.Endpoints
 	}{{
 		name: "empty source",
 		sks: sks(func(s *v1alpha1.ServerlessService) {
 			s.Annotations["tonight"] = "tonight"
 		}),
-		eps: &corev1.Endpoints{},
 		want: eps(func(e *corev1.Endpoints) {
 			e.Annotations = map[string]string{"tonight": "tonight"}
 		}),
@@ -266,28 +266,26 @@ func TestMakeEndpoints(t *testing.T) {
 		sks: sks(func(s *v1alpha1.ServerlessService) {
 			s.Labels["ava"] = "adore"
 		}),
-		eps: &corev1.Endpoints{
-			Subsets: []corev1.EndpointSubset{{
-				Addresses: []corev1.EndpointAddress{{
-					IP: "192.168.1.1",
-				}, {
-					IP: "10.5.6.21",
-				}},
-				Ports: []corev1.EndpointPort{{
-					Name:     "http",
-					Port:     8022,
-					Protocol: "TCP",
-				}, {
-					Name:     "http",
-					Port:     8012,
-					Protocol: "TCP",
-				}, {
-					Name:     "https",
-					Port:     8043,
-					Protocol: "TCP",
-				}},
+		subsets: []corev1.EndpointSubset{{
+			Addresses: []corev1.EndpointAddress{{
+				IP: "192.168.1.1",
+			}, {
+				IP: "10.5.6.21",
 			}},
-		},
+			Ports: []corev1.EndpointPort{{
+				Name:     "http",
+				Port:     8022,
+				Protocol: "TCP",
+			}, {
+				Name:     "http",
+				Port:     8012,
+				Protocol: "TCP",
+			}, {
+				Name:     "https",
+				Port:     8043,
+				Protocol: "TCP",
+			}},
+		}},
 		want: eps(func(e *corev1.Endpoints) {
 			e.Labels["ava"] = "adore"
 			e.Subsets = []corev1.EndpointSubset{{
@@ -307,7 +305,7 @@ func TestMakeEndpoints(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			if got, want := MakePublicEndpoints(test.sks, test.eps), test.want; !cmp.Equal(got, want, cmpopts.EquateEmpty()) {
+			if got, want := MakePublicEndpoints(test.sks, test.subsets), test.want; !cmp.Equal(got, want, cmpopts.EquateEmpty()) {
 				t.Errorf("Public K8s Endpoints mismatch (-want, +got) = %v",
 					cmp.Diff(want, got, cmpopts.EquateEmpty()))
 			}
@@ -315,6 +313,91 @@ func TestMakeEndpoints(t *testing.T) {
 	}
 }
 
+func TestEndpointSubsets(t *testing.T) {
+	tcp := corev1.ProtocolTCP
+	http := []discoveryv1.EndpointPort{{
+		Name:     ptr.String("http"),
+		Port:     ptr.Int32(8012),
+		Protocol: &tcp,
+	}}
+	endpoint := func(ip string, ready, terminating bool) discoveryv1.Endpoint {
+		return discoveryv1.Endpoint{
+			Addresses: []string{ip},
+			Conditions: discoveryv1.EndpointConditions{
+				Ready:       ptr.Bool(ready),
+				Terminating: ptr.Bool(terminating),
+			},
+			NodeName:  ptr.String("node"),
+			TargetRef: &corev1.ObjectReference{Kind: "Pod", Name: "pod-" + ip},
+		}
+	}
+	address := func(ip string) corev1.EndpointAddress {
+		return corev1.EndpointAddress{
+			IP:        ip,
+			NodeName:  ptr.String("node"),
+			TargetRef: &corev1.ObjectReference{Kind: "Pod", Name: "pod-" + ip},
+		}
+	}
+	ports := []corev1.EndpointPort{{Name: "http", Port: 8012, Protocol: corev1.ProtocolTCP}}
+
+	tests := []struct {
+		name   string
+		slices []*discoveryv1.EndpointSlice
+		want   []corev1.EndpointSubset
+	}{{
+		name: "no slices",
+	}, {
+		name: "empty slice",
+		slices: []*discoveryv1.EndpointSlice{{
+			ObjectMeta: metav1.ObjectMeta{Name: "a"},
+			Ports:      http,
+		}},
+	}, {
+		name: "ready, not ready and terminating endpoints",
+		slices: []*discoveryv1.EndpointSlice{{
+			ObjectMeta: metav1.ObjectMeta{Name: "a"},
+			Endpoints: []discoveryv1.Endpoint{
+				endpoint("10.0.0.3", true, false),
+				endpoint("10.0.0.1", true, false),
+				endpoint("10.0.0.2", false, false),
+				endpoint("10.0.0.4", false, true),
+			},
+			Ports: http,
+		}},
+		want: []corev1.EndpointSubset{{
+			Addresses:         []corev1.EndpointAddress{address("10.0.0.1"), address("10.0.0.3")},
+			NotReadyAddresses: []corev1.EndpointAddress{address("10.0.0.2")},
+			Ports:             ports,
+		}},
+	}, {
+		name: "slices in the order of their names",
+		slices: []*discoveryv1.EndpointSlice{{
+			ObjectMeta: metav1.ObjectMeta{Name: "b"},
+			Endpoints:  []discoveryv1.Endpoint{endpoint("10.0.0.1", true, false), endpoint("10.0.0.2", true, false)},
+			Ports:      http,
+		}, {
+			ObjectMeta: metav1.ObjectMeta{Name: "a"},
+			Endpoints:  []discoveryv1.Endpoint{endpoint("10.0.0.2", true, false)},
+			Ports:      http,
+		}},
+		want: []corev1.EndpointSubset{{
+			Addresses: []corev1.EndpointAddress{address("10.0.0.2")},
+			Ports:     ports,
+		}, {
+			Addresses: []corev1.EndpointAddress{address("10.0.0.1")},
+			Ports:     ports,
+		}},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := EndpointSubsets(test.slices); !cmp.Equal(got, test.want) {
+				t.Error("EndpointSubsets (-want, +got):", cmp.Diff(test.want, got))
+			}
+		})
+	}
+}
+
 func TestFilterSubsetPorts(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -25,7 +25,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"go.uber.org/zap"
 
-	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -33,6 +33,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes"
 	corev1listers "k8s.io/client-go/listers/core/v1"
+	discoveryv1listers "k8s.io/client-go/listers/discovery/v1"
 	"k8s.io/client-go/tools/cache"
 	sksreconciler "knative.dev/networking/pkg/client/injection/reconciler/networking/v1alpha1/serverlessservice"
 
@@ -52,8 +53,9 @@ type reconciler struct {
 	kubeclient kubernetes.Interface
 
 	// listers index properties about resources
-	serviceLister   corev1listers.ServiceLister
-	endpointsLister corev1listers.EndpointsLister
+	serviceLister       corev1listers.ServiceLister
+	endpointsLister     corev1listers.EndpointsLister
+	endpointSliceLister discoveryv1listers.EndpointSliceLister
 
 	// Used to get PodScalables from object references.
 	listerFactory func(schema.GroupVersionResource) (cache.GenericLister, error)
@@ -128,62 +130,54 @@ func (r *reconciler) reconcilePublicService(ctx context.Context, sks *netv1alpha
 
 // subsetEndpoints computes a subset of all endpoints of size `n` using a consistent
 // selection algorithm. For non empty input, subsetEndpoints returns a copy of the
-// input with the irrelevant endpoints and empty subsets filtered out, if the input
+// input with the irrelevant endpoints and empty slices filtered out, if the input
 // size is larger than `n`,
 // Otherwise the input is returned as is.
 // `target` is the revision name for which we are computing a subset.
-func subsetEndpoints(eps *corev1.Endpoints, target string, n int) *corev1.Endpoints {
-	// n == 0 means all, and if there are no subsets there's no work to do either.
-	if len(eps.Subsets) == 0 || n == 0 {
-		return eps
+func subsetEndpoints(slices []*discoveryv1.EndpointSlice, target string, n int) []*discoveryv1.EndpointSlice {
+	// n == 0 means all, and if there are no slices there's no work to do either.
+	if len(slices) == 0 || n == 0 {
+		return slices
 	}
 
-	addrs := make(sets.String, len(eps.Subsets[0].Addresses))
-	for _, ss := range eps.Subsets {
-		for _, addr := range ss.Addresses {
-			addrs.Insert(addr.IP)
+	addrs := make(sets.String, len(slices[0].Endpoints))
+	for _, slice := range slices {
+		for _, ep := range slice.Endpoints {
+			if len(ep.Addresses) > 0 && presources.EndpointReady(ep) {
+				addrs.Insert(ep.Addresses[0])
+			}
 		}
 	}
 
 	// The input is not larger than desired.
 	if len(addrs) <= n {
-		return eps
+		return slices
 	}
 
 	selection := hash.ChooseSubset(addrs, n, target)
 
-	// Copy the informer's copy, so we can filter it out.
-	neps := eps.DeepCopy()
-	// Standard in place filter using read and write indices.
-	// This preserves the original object order.
-	r, w := 0, 0
-	for r < len(neps.Subsets) {
-		ss := neps.Subsets[r]
-		// And same algorithm internally.
-		ra, wa := 0, 0
-		for ra < len(ss.Addresses) {
-			if selection.Has(ss.Addresses[ra].IP) {
-				ss.Addresses[wa] = ss.Addresses[ra]
-				wa++
+	ret := make([]*discoveryv1.EndpointSlice, 0, len(slices))
+	for _, slice := range slices {
+		// Copy the informer's copy, so we can filter it out.
+		nslice := slice.DeepCopy()
+		// Standard in place filter using read and write indices.
+		// This preserves the original object order.
+		w := 0
+		for _, ep := range nslice.Endpoints {
+			if len(ep.Addresses) > 0 && presources.EndpointReady(ep) && selection.Has(ep.Addresses[0]) {
+				nslice.Endpoints[w] = ep
+				w++
 			}
-			ra++
 		}
-		// At least one address from the subset was preserved, so keep it.
-		if wa > 0 {
-			ss.Addresses = ss.Addresses[:wa]
-			// At least one address from the subset was preserved, so keep it.
-			neps.Subsets[w] = ss
-			w++
+		// At least one endpoint from the slice was preserved, so keep it.
+		if w > 0 {
+			nslice.Endpoints = nslice.Endpoints[:w]
+			ret = append(ret, nslice)
 		}
-		r++
 	}
-	// We are guaranteed here to have w > 0, because
-	// 0. There's at least one subset (checked above).
-	// 1. A subset cannot be empty (k8s validation).
-	// 2. len(addrs) is at least as big as n
-	// Thus there's at least 1 non empty subset (and for all intents and purposes we'll have 1 always).
-	neps.Subsets = neps.Subsets[:w]
-	return neps
+	// We are guaranteed here to have at least one slice, because
+	// len(addrs) is bigger than n, which is at least 1.
+	return ret
 }
 
 func (r *reconciler) reconcilePublicEndpoints(ctx context.Context, sks *netv1alpha1.ServerlessService) error {
@@ -191,37 +185,37 @@ func (r *reconciler) reconcilePublicEndpoints(ctx context.Context, sks *netv1alp
 	dlogger := logger.Desugar()
 
 	var (
-		srcEps                *corev1.Endpoints
+		srcSlices             []*discoveryv1.EndpointSlice
 		foundServingEndpoints bool
 	)
-	activatorEps, err := r.endpointsLister.Endpoints(system.Namespace()).Get(networking.ActivatorServiceName)
+	activatorSlices, err := presources.EndpointSlicesForService(r.endpointSliceLister, system.Namespace(), networking.ActivatorServiceName)
 	if err != nil {
-		return fmt.Errorf("failed to get activator service endpoints: %w", err)
+		return fmt.Errorf("failed to get activator service endpoint slices: %w", err)
 	}
 	if dlogger.Core().Enabled(zap.DebugLevel) {
 		// Spew is expensive and there might be a lof of activator endpoints.
-		dlogger.Debug("Activator endpoints: " + spew.Sprint(activatorEps.Subsets))
+		dlogger.Debug("Activator endpoint slices: " + spew.Sprint(activatorSlices))
 	}
 
 	psn := sks.Status.PrivateServiceName
-	pvtEps, err := r.endpointsLister.Endpoints(sks.Namespace).Get(psn)
+	pvtSlices, err := presources.EndpointSlicesForService(r.endpointSliceLister, sks.Namespace, psn)
 	if err != nil {
-		return fmt.Errorf("failed to get private K8s Service endpoints: %w", err)
+		return fmt.Errorf("failed to get private K8s Service endpoint slices: %w", err)
 	}
 
 	// We still might be "ready" even if in proxy mode,
 	// if proxy mode is by means of burst capacity handling.
-	pvtReady := presources.ReadyAddressCount(pvtEps)
-	sharedReady := presources.ReadyAddressCount(activatorEps)
+	pvtReady := presources.ReadyEndpointCount(pvtSlices)
+	sharedReady := presources.ReadyEndpointCount(activatorSlices)
 
 	logger.Infof("SKS is in %s mode; has %d endpoints in %s; %d activator endpoints",
 		sks.Spec.Mode, pvtReady, psn, sharedReady)
 
 	// Spew is expensive and there might be a lof of endpoints.
 	if dlogger.Core().Enabled(zap.DebugLevel) {
-		dlogger.Debug("Private endpoints: " + spew.Sprint(pvtEps.Subsets))
+		dlogger.Debug("Private endpoint slices: " + spew.Sprint(pvtSlices))
 		dlogger.Debug(fmt.Sprintf("Subset of activator endpoints (needed %d): %s",
-			sks.Spec.NumActivators, spew.Sprint(activatorEps)))
+			sks.Spec.NumActivators, spew.Sprint(activatorSlices)))
 	}
 
 	// The logic below is as follows:
@@ -235,9 +229,9 @@ func (r *reconciler) reconcilePublicEndpoints(ctx context.Context, sks *netv1alp
 	//    mode = Serve
 	//
 	// if mode == serve:
-	//    srcEps = private_service_endpoints
+	//    srcSlices = private_service_endpoint_slices
 	// else:
-	//    srcEps = subset(activator_endpoints)
+	//    srcSlices = subset(activator_endpoint_slices)
 	// The reason for this is, we don't want to leave the public service endpoints empty,
 	// since those endpoints are the ones programmed into the VirtualService.
 	mode := sks.Spec.Mode
@@ -253,10 +247,11 @@ func (r *reconciler) reconcilePublicEndpoints(ctx context.Context, sks *netv1alp
 	}
 	switch mode {
 	case netv1alpha1.SKSOperationModeServe:
-		srcEps = pvtEps
+		srcSlices = pvtSlices
 	case netv1alpha1.SKSOperationModeProxy:
-		srcEps = subsetEndpoints(activatorEps, sks.Name, int(sks.Spec.NumActivators))
+		srcSlices = subsetEndpoints(activatorSlices, sks.Name, int(sks.Spec.NumActivators))
 	}
+	srcSubsets := resources.EndpointSubsets(srcSlices)
 
 	sn := sks.Name
 	eps, err := r.endpointsLister.Endpoints(sks.Namespace).Get(sn)
@@ -264,7 +259,7 @@ func (r *reconciler) reconcilePublicEndpoints(ctx context.Context, sks *netv1alp
 	if apierrs.IsNotFound(err) {
 		dlogger.Info("Public endpoints object does not exist; creating.")
 		sks.Status.MarkEndpointsNotReady("CreatingPublicEndpoints")
-		if _, err = r.kubeclient.CoreV1().Endpoints(sks.Namespace).Create(ctx, resources.MakePublicEndpoints(sks, srcEps), metav1.CreateOptions{}); err != nil {
+		if _, err = r.kubeclient.CoreV1().Endpoints(sks.Namespace).Create(ctx, resources.MakePublicEndpoints(sks, srcSubsets), metav1.CreateOptions{}); err != nil {
 			return fmt.Errorf("failed to create public K8s Endpoints: %w", err)
 		}
 		dlogger.Info("Created K8s Endpoints: " + sn)
@@ -274,7 +269,7 @@ func (r *reconciler) reconcilePublicEndpoints(ctx context.Context, sks *netv1alp
 		sks.Status.MarkEndpointsNotOwned("Endpoints", sn)
 		return fmt.Errorf("SKS: %s does not own Endpoints: %s", sks.Name, sn)
 	} else {
-		wantSubsets := resources.FilterSubsetPorts(sks, srcEps.Subsets)
+		wantSubsets := resources.FilterSubsetPorts(sks, srcSubsets)
 		if !equality.Semantic.DeepEqual(wantSubsets, eps.Subsets) {
 			want := eps.DeepCopy()
 			want.Subsets = wantSubsets
@@ -29,12 +29,14 @@ import (
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	_ "knative.dev/pkg/client/injection/kube/informers/core/v1/endpoints/fake"
 	_ "knative.dev/pkg/client/injection/kube/informers/core/v1/service/fake"
+	_ "knative.dev/pkg/client/injection/kube/informers/discovery/v1/endpointslice/fake"
 	"knative.dev/pkg/logging"
 	"knative.dev/serving/pkg/client/injection/ducks/autoscaling/v1alpha1/podscalable"
 	_ "knative.dev/serving/pkg/client/injection/ducks/autoscaling/v1alpha1/podscalable/fake"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -88,8 +90,8 @@ func TestReconcile(t *testing.T) {
 			svcpub("steady", "state"),
 			svcpriv("steady", "state"),
 			endpointspub("steady", "state", WithSubsets, withFilteredPorts(networking.BackendHTTPPort)),
-			endpointspriv("steady", "state", WithSubsets),
-			activatorEndpoints(WithSubsets),
+			slicepriv("steady", "state", withEndpoints),
+			activatorSlice(withEndpoints),
 		},
 	}, {
 		Name: "force proxy mode, no endpoints",
@@ -100,8 +102,8 @@ func TestReconcile(t *testing.T) {
 			svcpub("force", "proxy"),
 			svcpriv("force", "proxy"),
 			endpointspub("force", "proxy", withOtherSubsets, withFilteredPorts(networking.BackendHTTPPort)),
-			endpointspriv("force", "proxy" /* revision has no endpoints, force proxy mode */),
-			activatorEndpoints(WithSubsets),
+			slicepriv("force", "proxy" /* revision has no endpoints, force proxy mode */),
+			activatorSlice(withEndpoints),
 		},
 		WantUpdates: []clientgotesting.UpdateActionImpl{{
 			Object: endpointspub("force", "proxy", WithSubsets, withFilteredPorts(networking.BackendHTTPPort)),
@@ -120,8 +122,8 @@ func TestReconcile(t *testing.T) {
 			svcpub("force", "serve"),
 			svcpriv("force", "serve"),
 			endpointspub("force", "serve", WithSubsets, withFilteredPorts(networking.BackendHTTPPort)),
-			endpointspriv("force", "serve", withOtherSubsets),
-			activatorEndpoints( /* activator has no endpoints, force serve mode */ ),
+			slicepriv("force", "serve", withOtherEndpoints),
+			activatorSlice( /* activator has no endpoints, force serve mode */ ),
 		},
 		WantUpdates: []clientgotesting.UpdateActionImpl{{
 			Object: endpointspub("force", "serve", withOtherSubsets, withFilteredPorts(networking.BackendHTTPPort)),
@@ -138,8 +140,8 @@ func TestReconcile(t *testing.T) {
 			svcpub("steady", "to-proxy"),
 			svcpriv("steady", "to-proxy"),
 			endpointspub("steady", "to-proxy", withOtherSubsets, withFilteredPorts(networking.BackendHTTPPort)),
-			endpointspriv("steady", "to-proxy"),
-			activatorEndpoints(WithSubsets),
+			slicepriv("steady", "to-proxy"),
+			activatorSlice(withEndpoints),
 		},
 		WithReactors: []clientgotesting.ReactionFunc{
 			func(action clientgotesting.Action) (handled bool, ret runtime.Object, err error) {
@@ -170,8 +172,8 @@ func TestReconcile(t *testing.T) {
 			svcpub("steady", "to-proxy-with-subset"),
 			svcpriv("steady", "to-proxy-with-subset"),
 			endpointspub("steady", "to-proxy-with-subset", withOtherSubsets, withFilteredPorts(networking.BackendHTTPPort)),
-			endpointspriv("steady", "to-proxy-with-subset"),
-			activatorEndpoints(withNSubsets(2, 4 /*8 in total*/)),
+			slicepriv("steady", "to-proxy-with-subset"),
+			activatorSlice(withNEndpoints(8)),
 		},
 		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
 			Object: SKS("steady", "to-proxy-with-subset", WithDeployRef("bar"), markNoEndpoints,
@@ -179,7 +181,7 @@ func TestReconcile(t *testing.T) {
 		}},
 		WantUpdates: []clientgotesting.UpdateActionImpl{{
 			Object: endpointspub("steady", "to-proxy-with-subset",
-				withPickedSubset(2, 4, 5, "to-proxy-with-subset"),
+				withPickedSubset(8, 5, "to-proxy-with-subset"),
 				withFilteredPorts(networking.BackendHTTPPort)),
 		}},
 	}, {
@@ -192,8 +194,8 @@ func TestReconcile(t *testing.T) {
 			svcpub("steady", "to-proxy-with-subset"),
 			svcpriv("steady", "to-proxy-with-subset"),
 			endpointspub("steady", "to-proxy-with-subset", withOtherSubsets, withFilteredPorts(networking.BackendHTTPPort)),
-			endpointspriv("steady", "to-proxy-with-subset"),
-			activatorEndpoints(withNSubsets(2, 4 /*8 in total*/)),
+			slicepriv("steady", "to-proxy-with-subset"),
+			activatorSlice(withNEndpoints(8)),
 		},
 		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
 			Object: SKS("steady", "to-proxy-with-subset", WithDeployRef("bar"), markNoEndpoints,
@@ -201,7 +203,7 @@ func TestReconcile(t *testing.T) {
 		}},
 		WantUpdates: []clientgotesting.UpdateActionImpl{{
 			Object: endpointspub("steady", "to-proxy-with-subset",
-				withPickedSubset(2, 4, 8, "to-proxy-with-subset"),
+				withPickedSubset(8, 8, "to-proxy-with-subset"),
 				withFilteredPorts(networking.BackendHTTPPort)),
 		}},
 	}, {
@@ -216,8 +218,8 @@ func TestReconcile(t *testing.T) {
 			svcpub("steady", "to-proxy"),
 			svcpriv("steady", "to-proxy"),
 			endpointspub("steady", "to-proxy", withOtherSubsets, withFilteredPorts(networking.BackendHTTPPort)),
-			endpointspriv("steady", "to-proxy", WithSubsets),
-			activatorEndpoints(WithSubsets),
+			slicepriv("steady", "to-proxy", withEndpoints),
+			activatorSlice(withEndpoints),
 		},
 		WantUpdates: []clientgotesting.UpdateActionImpl{{
 			Object: endpointspub("steady", "to-proxy", WithSubsets, withFilteredPorts(networking.BackendHTTPPort)),
@@ -232,8 +234,8 @@ func TestReconcile(t *testing.T) {
 			svcpub("public", "svc-change", withTimeSelector),
 			svcpriv("public", "svc-change"),
 			endpointspub("public", "svc-change", WithSubsets, withFilteredPorts(networking.BackendHTTPPort)),
-			endpointspriv("public", "svc-change", WithSubsets),
-			activatorEndpoints(WithSubsets),
+			slicepriv("public", "svc-change", withEndpoints),
+			activatorSlice(withEndpoints),
 		},
 		WantUpdates: []clientgotesting.UpdateActionImpl{{
 			Object: svcpub("public", "svc-change"),
@@ -248,8 +250,8 @@ func TestReconcile(t *testing.T) {
 			svcpub("private", "svc-change"),
 			svcpriv("private", "svc-change", withTimeSelector),
 			endpointspub("private", "svc-change", withOtherSubsets, withFilteredPorts(networking.BackendHTTPPort)),
-			endpointspriv("private", "svc-change", WithSubsets),
-			activatorEndpoints(WithSubsets),
+			slicepriv("private", "svc-change", withEndpoints),
+			activatorSlice(withEndpoints),
 		},
 		WantUpdates: []clientgotesting.UpdateActionImpl{{
 			Object: svcpriv("private", "svc-change"),
@@ -262,7 +264,7 @@ func TestReconcile(t *testing.T) {
 		Objects: []runtime.Object{
 			SKS("on", "cde", WithDeployRef("blah"), markNoEndpoints),
 			deploy("on", "blah-another"),
-			endpointspriv("on", "cde", WithSubsets),
+			slicepriv("on", "cde", withEndpoints),
 		},
 		WantErr: true,
 		WantEvents: []string{
@@ -275,8 +277,8 @@ func TestReconcile(t *testing.T) {
 			SKS("on", "cde", WithDeployRef("blah")),
 			deploy("on", "blah"),
 			// This "has" to pre-exist, otherwise I can't populate it with subsets.
-			endpointspriv("on", "cde", WithSubsets),
-			activatorEndpoints(WithSubsets),
+			slicepriv("on", "cde", withEndpoints),
+			activatorSlice(withEndpoints),
 		},
 		WantCreates: []runtime.Object{
 			svcpriv("on", "cde"),
@@ -297,8 +299,8 @@ func TestReconcile(t *testing.T) {
 			svcpub("update-eps", "failA"),
 			svcpriv("update-eps", "failA"),
 			endpointspub("update-eps", "failA"),
-			endpointspriv("update-eps", "failA", WithSubsets),
-			activatorEndpoints(WithSubsets),
+			slicepriv("update-eps", "failA", withEndpoints),
+			activatorSlice(withEndpoints),
 		},
 		WithReactors: []clientgotesting.ReactionFunc{
 			InduceFailure("update", "endpoints"),
@@ -319,8 +321,8 @@ func TestReconcile(t *testing.T) {
 			SKS("svc", "fail2", WithDeployRef("blah")),
 			deploy("svc", "blah"),
 			svcpriv("svc", "fail2"),
-			endpointspriv("svc", "fail2"),
-			activatorEndpoints(WithSubsets),
+			slicepriv("svc", "fail2"),
+			activatorSlice(withEndpoints),
 		},
 		WithReactors: []clientgotesting.ReactionFunc{
 			InduceFailure("create", "services"),
@@ -344,8 +346,8 @@ func TestReconcile(t *testing.T) {
 			SKS("eps", "fail3", WithDeployRef("blah")),
 			deploy("eps", "blah"),
 			svcpriv("eps", "fail3"),
-			endpointspriv("eps", "fail3", WithSubsets),
-			activatorEndpoints(withOtherSubsets),
+			slicepriv("eps", "fail3", withEndpoints),
+			activatorSlice(withOtherEndpoints),
 		},
 		WithReactors: []clientgotesting.ReactionFunc{
 			InduceFailure("create", "endpoints"),
@@ -367,8 +369,8 @@ func TestReconcile(t *testing.T) {
 		Objects: []runtime.Object{
 			SKS("on", "cneps", WithDeployRef("blah"), WithPrivateService),
 			deploy("on", "blah"),
-			endpointspriv("on", "cneps"),
-			activatorEndpoints(WithSubsets),
+			slicepriv("on", "cneps"),
+			activatorSlice(withEndpoints),
 		},
 		WantCreates: []runtime.Object{
 			svcpriv("on", "cneps"),
@@ -380,60 +382,55 @@ func TestReconcile(t *testing.T) {
 				markNoEndpoints, WithPubService, WithPrivateService),
 		}},
 	}, {
-		Name: "OnCreate-no-activator-eps-exist",
-		Key:  "on/cnaeps2",
+		Name: "OnCreate-no-activator-slices-exist",
+		// Without any activator endpoint slices the activator has no
+		// endpoints, so the serve mode is forced.
+		Key: "on/cnaeps2",
 		Objects: []runtime.Object{
 			SKS("on", "cnaeps2", WithDeployRef("blah")),
 			deploy("on", "blah"),
-			endpointspriv("on", "cnaeps2", WithSubsets),
+			slicepriv("on", "cnaeps2", withEndpoints),
 			endpointspub("on", "cnaeps2", WithSubsets, withFilteredPorts(networking.BackendHTTPPort)),
 		},
-		WantErr: true,
 		WantCreates: []runtime.Object{
 			svcpriv("on", "cnaeps2"),
 			svcpub("on", "cnaeps2"),
 		},
 		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
-			Object: SKS("on", "cnaeps2", WithDeployRef("blah"), WithPubService,
-				WithPrivateService,
-				markTransitioning("CreatingPublicService")),
+			Object: SKS("on", "cnaeps2", WithDeployRef("blah"),
+				markHappy, WithPubService, WithPrivateService),
 		}},
-		WantEvents: []string{
-			Eventf(corev1.EventTypeWarning, "InternalError",
-				`failed to get activator service endpoints: endpoints "activator-service" not found`),
-		},
 	}, {
-		Name: "OnCreate-no-private-eps-exist",
-		Key:  "on/cnaeps3",
+		Name: "OnCreate-no-private-slices-exist",
+		// Without any endpoint slices the private service has no endpoints,
+		// so the proxy mode is forced.
+		Key: "on/cnaeps3",
 		Objects: []runtime.Object{
 			SKS("on", "cnaeps3", WithDeployRef("blah")),
 			deploy("on", "blah"),
-			endpointspub("on", "cnaeps3", WithSubsets),
-			activatorEndpoints(WithSubsets),
+			endpointspub("on", "cnaeps3", withOtherSubsets),
+			activatorSlice(withEndpoints),
 		},
-		WantErr: true,
 		WantCreates: []runtime.Object{
 			svcpriv("on", "cnaeps3"),
 			svcpub("on", "cnaeps3"),
 		},
+		WantUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: endpointspub("on", "cnaeps3", WithSubsets, withFilteredPorts(networking.BackendHTTPPort)),
+		}},
 		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
-			Object: SKS("on", "cnaeps3", WithDeployRef("blah"), WithPubService,
-				WithPrivateService,
-				markTransitioning("CreatingPublicService")),
+			Object: SKS("on", "cnaeps3", WithDeployRef("blah"),
+				markNoEndpoints, WithPubService, WithPrivateService),
 		}},
-		WantEvents: []string{
-			Eventf(corev1.EventTypeWarning, "InternalError",
-				`failed to get private K8s Service endpoints: endpoints "cnaeps3-private" not found`),
-		},
 	}, {
 		Name: "OnCreate-no-activator-eps-service",
 		Key:  "on/cnaeps",
 		Objects: []runtime.Object{
 			SKS("on", "cnaeps", WithDeployRef("blah")),
 			deploy("on", "blah"),
-			endpointspriv("on", "cnaeps", WithSubsets),
+			slicepriv("on", "cnaeps", withEndpoints),
 			endpointspub("on", "cnaeps", WithSubsets, withFilteredPorts(networking.BackendHTTPPort)),
-			activatorEndpoints(),
+			activatorSlice(),
 		},
 		WantCreates: []runtime.Object{
 			svcpriv("on", "cnaeps"),
@@ -449,8 +446,8 @@ func TestReconcile(t *testing.T) {
 		Objects: []runtime.Object{
 			SKS("on", "cnaeps", WithDeployRef("blah"), withProxyMode),
 			deploy("on", "blah"),
-			endpointspriv("on", "cnaeps"), // This should be ignored.
-			activatorEndpoints(),
+			slicepriv("on", "cnaeps"), // This should be ignored.
+			activatorSlice(),
 		},
 		WantCreates: []runtime.Object{
 			svcpriv("on", "cnaeps"),
@@ -468,8 +465,8 @@ func TestReconcile(t *testing.T) {
 		Objects: []runtime.Object{
 			SKS("svc", "fail", WithDeployRef("blah")),
 			deploy("svc", "blah"),
-			endpointspriv("svc", "fail"),
-			activatorEndpoints(WithSubsets),
+			slicepriv("svc", "fail"),
+			activatorSlice(withEndpoints),
 		},
 		WithReactors: []clientgotesting.ReactionFunc{
 			InduceFailure("create", "services"),
@@ -495,8 +492,8 @@ func TestReconcile(t *testing.T) {
 			svcpub("update-sks", "fail4"),
 			svcpriv("update-sks", "fail4"),
 			endpointspub("update-sks", "fail4", WithSubsets, withFilteredPorts(networking.BackendHTTPPort)),
-			endpointspriv("update-sks", "fail4", WithSubsets),
-			activatorEndpoints(WithSubsets),
+			slicepriv("update-sks", "fail4", withEndpoints),
+			activatorSlice(withEndpoints),
 		},
 		WithReactors: []clientgotesting.ReactionFunc{
 			InduceFailure("update", "serverlessservices"),
@@ -520,8 +517,8 @@ func TestReconcile(t *testing.T) {
 			svcpub("ronin-priv-service", "fail5"),
 			svcpriv("ronin-priv-service", "fail5", WithK8sSvcOwnersRemoved),
 			endpointspub("ronin-priv-service", "fail5", WithSubsets),
-			endpointspriv("ronin-priv-service", "fail5", WithSubsets),
-			activatorEndpoints(WithSubsets),
+			slicepriv("ronin-priv-service", "fail5", withEndpoints),
+			activatorSlice(withEndpoints),
 		},
 		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
 			Object: SKS("ronin-priv-service", "fail5", WithPubService, WithPrivateService,
@@ -541,8 +538,8 @@ func TestReconcile(t *testing.T) {
 			svcpub("ronin-pub-service", "fail6", WithK8sSvcOwnersRemoved),
 			svcpriv("ronin-pub-service", "fail6"),
 			endpointspub("ronin-pub-service", "fail6", WithSubsets),
-			endpointspriv("ronin-pub-service", "fail6", WithSubsets),
-			activatorEndpoints(WithSubsets),
+			slicepriv("ronin-pub-service", "fail6", withEndpoints),
+			activatorSlice(withEndpoints),
 		},
 		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
 			Object: SKS("ronin-pub-service", "fail6", WithPubService, WithPrivateService,
@@ -562,8 +559,8 @@ func TestReconcile(t *testing.T) {
 			svcpub("ronin-pub-eps", "fail7"),
 			svcpriv("ronin-pub-eps", "fail7"),
 			endpointspub("ronin-pub-eps", "fail7", WithSubsets, WithEndpointsOwnersRemoved),
-			endpointspriv("ronin-pub-eps", "fail7", WithSubsets),
-			activatorEndpoints(WithSubsets),
+			slicepriv("ronin-pub-eps", "fail7", withEndpoints),
+			activatorSlice(withEndpoints),
 		},
 		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
 			Object: SKS("ronin-pub-eps", "fail7", WithPubService, WithPrivateService,
@@ -583,8 +580,8 @@ func TestReconcile(t *testing.T) {
 			svcpub("update-svc", "fail9"),
 			svcpriv("update-svc", "fail9", withTimeSelector),
 			endpointspub("update-svc", "fail9"),
-			endpointspriv("update-svc", "fail9"),
-			activatorEndpoints(WithSubsets),
+			slicepriv("update-svc", "fail9"),
+			activatorSlice(withEndpoints),
 		},
 		WithReactors: []clientgotesting.ReactionFunc{
 			InduceFailure("update", "services"),
@@ -610,8 +607,8 @@ func TestReconcile(t *testing.T) {
 			svcpub("update-svc", "fail8", withTimeSelector),
 			svcpriv("update-svc", "fail8"),
 			endpointspub("update-svc", "fail8", WithSubsets),
-			endpointspriv("update-svc", "fail8", WithSubsets),
-			activatorEndpoints(WithSubsets),
+			slicepriv("update-svc", "fail8", withEndpoints),
+			activatorSlice(withEndpoints),
 		},
 		WithReactors: []clientgotesting.ReactionFunc{
 			InduceFailure("update", "services"),
@@ -632,8 +629,8 @@ func TestReconcile(t *testing.T) {
 			svcpub("pod", "change"),
 			svcpriv("pod", "change"),
 			endpointspub("pod", "change", WithSubsets),
-			endpointspriv("pod", "change", withOtherSubsets),
-			activatorEndpoints(WithSubsets),
+			slicepriv("pod", "change", withOtherEndpoints),
+			activatorSlice(withEndpoints),
 		},
 		WantUpdates: []clientgotesting.UpdateActionImpl{{
 			Object: endpointspub("pod", "change", withOtherSubsets, withFilteredPorts(networking.BackendHTTPPort)),
@@ -648,8 +645,8 @@ func TestReconcile(t *testing.T) {
 			svcpub("pod", "change", withHTTP2),
 			svcpriv("pod", "change", withHTTP2Priv),
 			endpointspub("pod", "change", WithSubsets),
-			endpointspriv("pod", "change"),
-			activatorEndpoints(withOtherSubsets),
+			slicepriv("pod", "change"),
+			activatorSlice(withOtherEndpoints),
 		},
 		WantUpdates: []clientgotesting.UpdateActionImpl{{
 			Object: endpointspub("pod", "change", withOtherSubsets, withFilteredPorts(networking.BackendHTTP2Port)),
@@ -664,8 +661,8 @@ func TestReconcile(t *testing.T) {
 			svcpub("pod", "change"),
 			svcpriv("pod", "change"),
 			endpointspub("pod", "change", withOtherSubsets),
-			endpointspriv("pod", "change", WithSubsets),
-			activatorEndpoints(withOtherSubsets),
+			slicepriv("pod", "change", withEndpoints),
+			activatorSlice(withOtherEndpoints),
 		},
 		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
 			Object: SKS("pod", "change",
@@ -684,8 +681,8 @@ func TestReconcile(t *testing.T) {
 			svcpub("pod", "change", withHTTP2),
 			svcpriv("pod", "change", withHTTP2Priv),
 			endpointspub("pod", "change", WithSubsets), // We had endpoints...
-			endpointspriv("pod", "change"),             // but now we don't.
-			activatorEndpoints(withOtherSubsets),
+			slicepriv("pod", "change"),                 // but now we don't.
+			activatorSlice(withOtherEndpoints),
 		},
 		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
 			Object: SKS("pod", "change", withHTTP2Protocol,
@@ -702,9 +699,10 @@ func TestReconcile(t *testing.T) {
 		psInformerFactory := podscalable.Get(ctx)
 
 		r := &reconciler{
-			kubeclient:      kubeclient.Get(ctx),
-			serviceLister:   listers.GetK8sServiceLister(),
-			endpointsLister: listers.GetEndpointsLister(),
+			kubeclient:          kubeclient.Get(ctx),
+			serviceLister:       listers.GetK8sServiceLister(),
+			endpointsLister:     listers.GetEndpointsLister(),
+			endpointSliceLister: listers.GetEndpointSliceLister(),
 			listerFactory: func(gvr schema.GroupVersionResource) (cache.GenericLister, error) {
 				_, l, err := psInformerFactory.Get(ctx, gvr)
 				return l, err
@@ -734,12 +732,11 @@ func withFilteredPorts(port int32) EndpointsOption {
 
 // withPickedSubset simulates the picking of the activator
 // address subset.
-func withPickedSubset(numSS, numAddrs, pickN int, target string) EndpointsOption {
+func withPickedSubset(numAddrs, pickN int, target string) EndpointsOption {
 	return func(ep *corev1.Endpoints) {
-		// Generate the full set.
-		withNSubsets(numSS, numAddrs)(ep)
-		// Now pick and replace.
-		ep.Subsets = subsetEndpoints(ep, target, pickN).Subsets
+		// Generate the full set, then pick and replace.
+		slices := []*discoveryv1.EndpointSlice{activatorSlice(withNEndpoints(numAddrs))}
+		ep.Subsets = resources.EndpointSubsets(subsetEndpoints(slices, target, pickN))
 	}
 }
 
@@ -847,48 +844,71 @@ func svcpriv(namespace, name string, so ...K8sServiceOption) *corev1.Service {
 	return s
 }
 
-func activatorEndpoints(eo ...EndpointsOption) *corev1.Endpoints {
-	ep := &corev1.Endpoints{
-		ObjectMeta: metav1.ObjectMeta{
-			Namespace: system.Namespace(),
-			Name:      networking.ActivatorServiceName,
-		},
-	}
-	for _, opt := range eo {
-		opt(ep)
-	}
-	return ep
+// endpointSliceOption enables further configuration of the EndpointSlices.
+type endpointSliceOption func(*discoveryv1.EndpointSlice)
+
+func activatorSlice(eo ...endpointSliceOption) *discoveryv1.EndpointSlice {
+	return endpointSlice(system.Namespace(), networking.ActivatorServiceName, eo...)
 }
 
-func endpointspriv(namespace, name string, eo ...EndpointsOption) *corev1.Endpoints {
+func slicepriv(namespace, name string, eo ...endpointSliceOption) *discoveryv1.EndpointSlice {
 	service := svcpriv(namespace, name)
-	ep := &corev1.Endpoints{
+	return endpointSlice(service.Namespace, service.Name, eo...)
+}
+
+func endpointSlice(namespace, serviceName string, eo ...endpointSliceOption) *discoveryv1.EndpointSlice {
+	slice := &discoveryv1.EndpointSlice{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: service.Namespace,
-			Name:      service.Name,
+			Namespace: namespace,
+			Name:      serviceName + "-abcde",
+			Labels:    map[string]string{discoveryv1.LabelServiceName: serviceName},
 		},
+		AddressType: discoveryv1.AddressTypeIPv4,
 	}
 	for _, opt := range eo {
-		opt(ep)
+		opt(slice)
 	}
-	return ep
+	return slice
 }
 
-// withNSubsets populates the endpoints object with numSS subsets
-// each having numAddr endpoints.
-func withNSubsets(numSS, numAddr int) EndpointsOption {
-	return func(ep *corev1.Endpoints) {
-		ep.Subsets = make([]corev1.EndpointSubset, numSS)
-		for i := 0; i < numSS; i++ {
-			ep.Subsets[i].Ports = []corev1.EndpointPort{{Port: 8012}, {Port: 8013}}
-			ep.Subsets[i].Addresses = make([]corev1.EndpointAddress, numAddr)
-			for j := 0; j < numAddr; j++ {
-				ep.Subsets[i].Addresses[j].IP = fmt.Sprintf("10.1.%d.%d", i+1, j+1)
-			}
+// withEndpoints adds the endpoint of functional::WithSubsets to the slice.
+func withEndpoints(slice *discoveryv1.EndpointSlice) {
+	slice.Endpoints = []discoveryv1.Endpoint{readyEndpoint("127.0.0.1")}
+	slice.Ports = slicePorts(8012, 8013)
+}
+
+// withOtherEndpoints adds the endpoint of withOtherSubsets to the slice.
+func withOtherEndpoints(slice *discoveryv1.EndpointSlice) {
+	slice.Endpoints = []discoveryv1.Endpoint{readyEndpoint("127.0.0.2")}
+	slice.Ports = slicePorts(8013, 8012)
+}
+
+// withNEndpoints populates the slice with numAddr ready endpoints.
+func withNEndpoints(numAddr int) endpointSliceOption {
+	return func(slice *discoveryv1.EndpointSlice) {
+		slice.Ports = slicePorts(8012, 8013)
+		slice.Endpoints = make([]discoveryv1.Endpoint, numAddr)
+		for i := 0; i < numAddr; i++ {
+			slice.Endpoints[i] = readyEndpoint(fmt.Sprintf("10.1.%d.%d", i/10+1, i%10+1))
 		}
 	}
 }
 
+func readyEndpoint(ip string) discoveryv1.Endpoint {
+	return discoveryv1.Endpoint{
+		Addresses:  []string{ip},
+		Conditions: discoveryv1.EndpointConditions{Ready: ptr.Bool(true)},
+	}
+}
+
+func slicePorts(ports ...int32) []discoveryv1.EndpointPort {
+	ret := make([]discoveryv1.EndpointPort, len(ports))
+	for i, p := range ports {
+		ret[i].Port = ptr.Int32(p)
+	}
+	return ret
+}
+
 func endpointspub(namespace, name string, eo ...EndpointsOption) *corev1.Endpoints {
 	service := svcpub(namespace, name)
 	ep := &corev1.Endpoints{
@@ -907,13 +927,12 @@ func withTimeSelector(svc *corev1.Service) {
 func TestSubsetEndpoints(t *testing.T) {
 	// This just tests the `subsetEndpoints` helper.
 	t.Run("empty", func(t *testing.T) {
-		aeps := activatorEndpoints()
-		if got, want := subsetEndpoints(aeps, "rev", 1), aeps; got != want {
-			t.Errorf("Empty EPS = %p, want: %p", got, want)
+		if got := subsetEndpoints(nil, "rev", 1); got != nil {
+			t.Errorf("Empty slices = %v, want: nil", got)
 		}
-		aeps = activatorEndpoints(withNSubsets(1, 0))
-		if got, want := subsetEndpoints(aeps, "rev", 1), aeps; got != want {
-			t.Errorf("Empty EPS = %p, want: %p", got, want)
+		slices := []*discoveryv1.EndpointSlice{activatorSlice(withNEndpoints(0))}
+		if got, want := subsetEndpoints(slices, "rev", 1), slices; &got[0] != &want[0] {
+			t.Errorf("Empty slices = %p, want: %p", got, want)
 		}
 	})
 	t.Run("over-requested or all", func(t *testing.T) {
@@ -933,9 +952,9 @@ func TestSubsetEndpoints(t *testing.T) {
 		}}
 		for _, tc := range tests {
 			t.Run(tc.name, func(t *testing.T) {
-				aeps := activatorEndpoints(withNSubsets(tc.nss, tc.naddr))
-				if got, want := subsetEndpoints(aeps, "rev", tc.req), aeps; got != want {
-					t.Errorf("Select all: EPS = %p, want: %p", got, want)
+				slices := nSlices(tc.nss, tc.naddr)
+				if got, want := subsetEndpoints(slices, "rev", tc.req), slices; &got[0] != &want[0] {
+					t.Errorf("Select all: slices = %p, want: %p", got, want)
 				}
 			})
 		}
@@ -944,7 +963,7 @@ func TestSubsetEndpoints(t *testing.T) {
 		// We need to verify two things
 		// 1. that exactly N items were returned
 		// 2. they are distinct
-		// 3. No empty subset is returned.
+		// 3. No empty slice is returned.
 		tests := []struct {
 			name            string
 			nss, naddr, req int
@@ -961,17 +980,30 @@ func TestSubsetEndpoints(t *testing.T) {
 		}}
 		for _, tc := range tests {
 			t.Run(tc.name, func(t *testing.T) {
-				aeps := activatorEndpoints(withNSubsets(tc.nss, tc.naddr))
-				subset := subsetEndpoints(aeps, "target", tc.req)
-				if got, want := presources.ReadyAddressCount(subset), tc.req; got != want {
+				subset := subsetEndpoints(nSlices(tc.nss, tc.naddr), "target", tc.req)
+				if got, want := presources.ReadyEndpointCount(subset), tc.req; got != want {
 					t.Errorf("Endpoint count = %d, want: %d", got, want)
 				}
-				for i, ss := range subset.Subsets {
-					if len(ss.Addresses) == 0 {
-						t.Errorf("Size of subset %d is 0", i)
+				for i, slice := range subset {
+					if len(slice.Endpoints) == 0 {
+						t.Errorf("Size of slice %d is 0", i)
 					}
 				}
 			})
 		}
 	})
 }
+
+// nSlices returns numSS slices of the activator service each having numAddr
+// distinct endpoints.
+func nSlices(numSS, numAddr int) []*discoveryv1.EndpointSlice {
+	slices := make([]*discoveryv1.EndpointSlice, numSS)
+	for i := range slices {
+		slices[i] = activatorSlice(withNEndpoints(numAddr))
+		slices[i].Name = fmt.Sprint(networking.ActivatorServiceName, "-", i)
+		for j := range slices[i].Endpoints {
+			slices[i].Endpoints[j].Addresses = []string{fmt.Sprintf("10.1.%d.%d", i+1, j+1)}
+		}
+	}
+	return slices
+}
@@ -50,6 +50,17 @@ func MakeConfigurationFromExisting(service *v1.Service, existing *v1.Configurati
 	set.Insert(routeName)
 	anns[serving.RoutesAnnotationKey] = strings.Join(set.UnsortedList(), ",")
 
+	// Keep the hash of the referenced ConfigMaps and Secrets the Configuration
+	// stamped into its template to roll out their changes.
+	spec := service.Spec.ConfigurationSpec
+	if h, ok := existing.Spec.Template.Annotations[serving.ReferencedConfigHashAnnotationKey]; ok {
+		if _, set := spec.Template.Annotations[serving.ReferencedConfigHashAnnotationKey]; !set {
+			spec = *spec.DeepCopy()
+			spec.Template.Annotations = kmeta.UnionMaps(spec.Template.Annotations,
+				map[string]string{serving.ReferencedConfigHashAnnotationKey: h})
+		}
+	}
+
 	return &v1.Configuration{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      names.Configuration(service),
@@ -60,6 +71,6 @@ func MakeConfigurationFromExisting(service *v1.Service, existing *v1.Configurati
 			Labels:      kmeta.UnionMaps(service.GetLabels(), labels),
 			Annotations: anns,
 		},
-		Spec: service.Spec.ConfigurationSpec,
+		Spec: spec,
 	}
 }
@@ -93,3 +93,19 @@ func TestConfigurationHasNoKubectlAnnotation(t *testing.T) {
 		t.Errorf(`Annotation[%s] = %q, want: ""`, corev1.LastAppliedConfigAnnotation, v)
 	}
 }
+
+func TestConfigurationKeepsReferencedConfigHash(t *testing.T) {
+	s := createService()
+	existing := MakeConfiguration(s)
+	existing.Spec.Template.Annotations = map[string]string{
+		serving.ReferencedConfigHashAnnotationKey: "abc",
+	}
+
+	c := MakeConfigurationFromExisting(s, existing)
+	if got, want := c.Spec.Template.Annotations[serving.ReferencedConfigHashAnnotationKey], "abc"; got != want {
+		t.Errorf("Template annotation %s = %q, want: %q", serving.ReferencedConfigHashAnnotationKey, got, want)
+	}
+	if _, ok := s.Spec.Template.Annotations[serving.ReferencedConfigHashAnnotationKey]; ok {
+		t.Error("The template of the Service was changed")
+	}
+}
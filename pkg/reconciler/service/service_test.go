@@ -808,6 +808,12 @@ func TestNew(t *testing.T) {
 			Namespace: system.Namespace(),
 		},
 		Data: map[string]string{},
+	}, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfgmap.PolicyConfigName,
+			Namespace: system.Namespace(),
+		},
+		Data: map[string]string{},
 	})
 
 	if NewController(ctx, configMapWatcher) == nil {
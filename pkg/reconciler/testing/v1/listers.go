@@ -20,11 +20,15 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	fakekubeclientset "k8s.io/client-go/kubernetes/fake"
 	appsv1listers "k8s.io/client-go/listers/apps/v1"
 	autoscalingv2listers "k8s.io/client-go/listers/autoscaling/v2"
 	corev1listers "k8s.io/client-go/listers/core/v1"
+	discoveryv1listers "k8s.io/client-go/listers/discovery/v1"
+	policyv1listers "k8s.io/client-go/listers/policy/v1"
 	"k8s.io/client-go/tools/cache"
 	cachingv1alpha1 "knative.dev/caching/pkg/apis/caching/v1alpha1"
 	fakecachingclientset "knative.dev/caching/pkg/client/clientset/versioned/fake"
@@ -183,6 +187,11 @@ func (l *Listers) GetDeploymentLister() appsv1listers.DeploymentLister {
 	return appsv1listers.NewDeploymentLister(l.IndexerFor(&appsv1.Deployment{}))
 }
 
+// GetPodDisruptionBudgetLister returns a lister for PodDisruptionBudget objects.
+func (l *Listers) GetPodDisruptionBudgetLister() policyv1listers.PodDisruptionBudgetLister {
+	return policyv1listers.NewPodDisruptionBudgetLister(l.IndexerFor(&policyv1.PodDisruptionBudget{}))
+}
+
 // GetK8sServiceLister returns a lister for K8sService objects.
 func (l *Listers) GetK8sServiceLister() corev1listers.ServiceLister {
 	return corev1listers.NewServiceLister(l.IndexerFor(&corev1.Service{}))
@@ -193,6 +202,11 @@ func (l *Listers) GetEndpointsLister() corev1listers.EndpointsLister {
 	return corev1listers.NewEndpointsLister(l.IndexerFor(&corev1.Endpoints{}))
 }
 
+// GetEndpointSliceLister returns a lister for EndpointSlice objects.
+func (l *Listers) GetEndpointSliceLister() discoveryv1listers.EndpointSliceLister {
+	return discoveryv1listers.NewEndpointSliceLister(l.IndexerFor(&discoveryv1.EndpointSlice{}))
+}
+
 // GetPodsLister gets lister for pods.
 func (l *Listers) GetPodsLister() corev1listers.PodLister {
 	return corev1listers.NewPodLister(l.IndexerFor(&corev1.Pod{}))
@@ -202,3 +216,13 @@ func (l *Listers) GetPodsLister() corev1listers.PodLister {
 func (l *Listers) GetNamespaceLister() corev1listers.NamespaceLister {
 	return corev1listers.NewNamespaceLister(l.IndexerFor(&corev1.Namespace{}))
 }
+
+// GetConfigMapLister gets lister for ConfigMap resource.
+func (l *Listers) GetConfigMapLister() corev1listers.ConfigMapLister {
+	return corev1listers.NewConfigMapLister(l.IndexerFor(&corev1.ConfigMap{}))
+}
+
+// GetSecretLister gets lister for Secret resource.
+func (l *Listers) GetSecretLister() corev1listers.SecretLister {
+	return corev1listers.NewSecretLister(l.IndexerFor(&corev1.Secret{}))
+}
@@ -18,7 +18,10 @@ package resources
 
 import (
 	corev1 "k8s.io/api/core/v1"
-	corev1listers "k8s.io/client-go/listers/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	discoveryv1listers "k8s.io/client-go/listers/discovery/v1"
 )
 
 // ReadyAddressCount returns the total number of addresses ready for the given endpoint.
@@ -39,6 +42,59 @@ func NotReadyAddressCount(endpoints *corev1.Endpoints) int {
 	return notReady
 }
 
+// EndpointReady returns whether the EndpointSlice endpoint is ready.
+// Per the API a nil condition is to be interpreted as ready.
+func EndpointReady(ep discoveryv1.Endpoint) bool {
+	return ep.Conditions.Ready == nil || *ep.Conditions.Ready
+}
+
+// EndpointServing returns whether the EndpointSlice endpoint is serving.
+// Per the API a nil condition is to be interpreted as serving.
+func EndpointServing(ep discoveryv1.Endpoint) bool {
+	return ep.Conditions.Serving == nil || *ep.Conditions.Serving
+}
+
+// EndpointTerminating returns whether the EndpointSlice endpoint is terminating.
+// Per the API a nil condition is to be interpreted as not terminating.
+func EndpointTerminating(ep discoveryv1.Endpoint) bool {
+	return ep.Conditions.Terminating != nil && *ep.Conditions.Terminating
+}
+
+// ReadyEndpointCount returns the number of distinct ready addresses in the given
+// EndpointSlices. The same endpoint may briefly show up in more than one slice.
+func ReadyEndpointCount(slices []*discoveryv1.EndpointSlice) int {
+	return countEndpoints(slices, EndpointReady)
+}
+
+// NotReadyEndpointCount returns the number of distinct not ready addresses in the
+// given EndpointSlices. Like NotReadyAddresses of Endpoints this leaves out the
+// terminating endpoints.
+func NotReadyEndpointCount(slices []*discoveryv1.EndpointSlice) int {
+	return countEndpoints(slices, func(ep discoveryv1.Endpoint) bool {
+		return !EndpointReady(ep) && !EndpointTerminating(ep)
+	})
+}
+
+func countEndpoints(slices []*discoveryv1.EndpointSlice, pred func(discoveryv1.Endpoint) bool) int {
+	addrs := sets.NewString()
+	for _, slice := range slices {
+		for _, ep := range slice.Endpoints {
+			// Per the API all the addresses are fungible, so the first one suffices.
+			if len(ep.Addresses) > 0 && pred(ep) {
+				addrs.Insert(ep.Addresses[0])
+			}
+		}
+	}
+	return addrs.Len()
+}
+
+// EndpointSlicesForService returns all the EndpointSlices of the given service.
+func EndpointSlicesForService(lister discoveryv1listers.EndpointSliceLister, namespace, serviceName string) ([]*discoveryv1.EndpointSlice, error) {
+	return lister.EndpointSlices(namespace).List(labels.SelectorFromSet(labels.Set{
+		discoveryv1.LabelServiceName: serviceName,
+	}))
+}
+
 // EndpointsCounter provides a count of currently ready and notReady pods.
 // This information, among other places, is used by UniScaler implementations
 // to make scaling decisions.
@@ -54,35 +110,37 @@ type EndpointsCounter interface {
 }
 
 type scopedEndpointCounter struct {
-	endpointsLister corev1listers.EndpointsNamespaceLister
-	serviceName     string
+	endpointSliceLister discoveryv1listers.EndpointSliceLister
+	namespace           string
+	serviceName         string
 }
 
 func (eac *scopedEndpointCounter) ReadyCount() (int, error) {
-	endpoints, err := eac.endpointsLister.Get(eac.serviceName)
+	slices, err := EndpointSlicesForService(eac.endpointSliceLister, eac.namespace, eac.serviceName)
 	if err != nil {
 		return 0, err
 	}
-	return ReadyAddressCount(endpoints), nil
+	return ReadyEndpointCount(slices), nil
 }
 
 func (eac *scopedEndpointCounter) NotReadyCount() (int, error) {
-	endpoints, err := eac.endpointsLister.Get(eac.serviceName)
+	slices, err := EndpointSlicesForService(eac.endpointSliceLister, eac.namespace, eac.serviceName)
 	if err != nil {
 		return 0, err
 	}
-	return NotReadyAddressCount(endpoints), nil
+	return NotReadyEndpointCount(slices), nil
 }
 
 // NewScopedEndpointsCounter creates a EndpointsCounter that uses
-// a count of endpoints for a namespace/serviceName as the value
-// of ready pods. The values returned by ReadyCount() will vary
-// over time.
-// lister is used to retrieve endpoints for counting with the
-// scope of namespace/serviceName.
-func NewScopedEndpointsCounter(lister corev1listers.EndpointsLister, namespace, serviceName string) EndpointsCounter {
+// a count of the endpoints in all the EndpointSlices of
+// namespace/serviceName as the value of ready pods. Unlike Endpoints,
+// EndpointSlices are not capped at 1000 addresses. The values returned
+// by ReadyCount() will vary over time.
+// lister is used to retrieve the EndpointSlices for counting.
+func NewScopedEndpointsCounter(lister discoveryv1listers.EndpointSliceLister, namespace, serviceName string) EndpointsCounter {
 	return &scopedEndpointCounter{
-		endpointsLister: lister.Endpoints(namespace),
-		serviceName:     serviceName,
+		endpointSliceLister: lister,
+		namespace:           namespace,
+		serviceName:         serviceName,
 	}
 }
@@ -22,9 +22,11 @@ import (
 	"testing"
 
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kubeinformers "k8s.io/client-go/informers"
 	fakek8s "k8s.io/client-go/kubernetes/fake"
+	"knative.dev/pkg/ptr"
 )
 
 const (
@@ -34,63 +36,56 @@ const (
 
 func TestScopedEndpointsCounter(t *testing.T) {
 	kubeClient := fakek8s.NewSimpleClientset()
-	endpointsClient := kubeinformers.NewSharedInformerFactory(kubeClient, 0).Core().V1().Endpoints()
-	createEndpoints := func(ep *corev1.Endpoints) {
-		kubeClient.CoreV1().Endpoints(testNamespace).Create(context.Background(), ep, metav1.CreateOptions{})
-		endpointsClient.Informer().GetIndexer().Add(ep)
+	slicesClient := kubeinformers.NewSharedInformerFactory(kubeClient, 0).Discovery().V1().EndpointSlices()
+	createSlice := func(slice *discoveryv1.EndpointSlice) {
+		kubeClient.DiscoveryV1().EndpointSlices(testNamespace).Create(context.Background(), slice, metav1.CreateOptions{})
+		slicesClient.Informer().GetIndexer().Add(slice)
 	}
 
-	addressCounter := NewScopedEndpointsCounter(endpointsClient.Lister(), testNamespace, testService)
+	addressCounter := NewScopedEndpointsCounter(slicesClient.Lister(), testNamespace, testService)
 
 	tests := []struct {
 		name         string
-		endpoints    *corev1.Endpoints
+		slice        *discoveryv1.EndpointSlice
 		wantReady    int
 		wantNotReady int
-		wantErr      bool
 	}{{
-		name:         "no endpoints at all",
-		endpoints:    nil,
-		wantReady:    0,
-		wantNotReady: 0,
-		wantErr:      true,
+		name: "no endpoint slices at all",
 	}, {
-		name:         "no ready/not-ready addresses",
-		endpoints:    endpoints(0, 0),
-		wantReady:    0,
-		wantNotReady: 0,
+		name:  "slice of another service",
+		slice: endpointSlice("other-slice", "other-service", 1, 2),
 	}, {
-		name:         "one ready/two not-ready addresses",
-		endpoints:    endpoints(1, 2),
+		name:         "one ready/two not-ready endpoints",
+		slice:        endpointSlice("slice-1", testService, 1, 2),
 		wantReady:    1,
 		wantNotReady: 2,
 	}, {
-		name:         "ten ready/twenty not-ready addresses",
-		endpoints:    endpoints(10, 20),
+		name:         "endpoints in multiple slices",
+		slice:        endpointSlice("slice-2", testService, 10, 20),
 		wantReady:    10,
 		wantNotReady: 20,
 	}}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			if test.endpoints != nil {
-				createEndpoints(test.endpoints)
+			if test.slice != nil {
+				createSlice(test.slice)
 			}
 			got, err := addressCounter.ReadyCount()
+			if err != nil {
+				t.Fatal("ReadyCount() =", err)
+			}
 			if got != test.wantReady {
 				t.Errorf("ReadyCount() = %d, wantReady: %d", got, test.wantReady)
 			}
-			if got, want := (err != nil), test.wantErr; got != want {
-				t.Errorf("ReadyCount() wantErr = %v, want: %v, err: %v", got, want, err)
-			}
 
 			got, err = addressCounter.NotReadyCount()
+			if err != nil {
+				t.Fatal("NotReadyCount() =", err)
+			}
 			if got != test.wantNotReady {
 				t.Errorf("NotReadyCount() = %d, wantNotReady: %d", got, test.wantNotReady)
 			}
-			if got, want := (err != nil), test.wantErr; got != want {
-				t.Errorf("NotReadyCount() wantErr = %v, want: %v, err: %v", got, want, err)
-			}
 		})
 	}
 }
@@ -130,6 +125,60 @@ func TestReadyAddressCount(t *testing.T) {
 	}
 }
 
+func TestReadyEndpointCount(t *testing.T) {
+	terminating := discoveryv1.Endpoint{
+		Addresses: []string{"127.0.0.100"},
+		Conditions: discoveryv1.EndpointConditions{
+			Ready:       ptr.Bool(false),
+			Serving:     ptr.Bool(true),
+			Terminating: ptr.Bool(true),
+		},
+	}
+	withTerminating := endpointSlice("slice", testService, 1, 1)
+	withTerminating.Endpoints = append(withTerminating.Endpoints, terminating)
+
+	tests := []struct {
+		name         string
+		slices       []*discoveryv1.EndpointSlice
+		wantReady    int
+		wantNotReady int
+	}{{
+		name: "no slices",
+	}, {
+		name:   "no ready/not-ready endpoints",
+		slices: []*discoveryv1.EndpointSlice{endpointSlice("slice", testService, 0, 0)},
+	}, {
+		name:         "one ready/two not-ready endpoints",
+		slices:       []*discoveryv1.EndpointSlice{endpointSlice("slice", testService, 1, 2)},
+		wantReady:    1,
+		wantNotReady: 2,
+	}, {
+		name: "duplicate endpoints across slices",
+		slices: []*discoveryv1.EndpointSlice{
+			endpointSlice("slice-1", testService, 10, 20),
+			endpointSlice("slice-2", testService, 10, 20),
+		},
+		wantReady:    10,
+		wantNotReady: 20,
+	}, {
+		name:         "terminating endpoints are not counted",
+		slices:       []*discoveryv1.EndpointSlice{withTerminating},
+		wantReady:    1,
+		wantNotReady: 1,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := ReadyEndpointCount(test.slices); got != test.wantReady {
+				t.Errorf("ReadyEndpointCount() = %d, want: %d", got, test.wantReady)
+			}
+			if got := NotReadyEndpointCount(test.slices); got != test.wantNotReady {
+				t.Errorf("NotReadyEndpointCount() = %d, want: %d", got, test.wantNotReady)
+			}
+		})
+	}
+}
+
 func endpoints(readyIPCount, notReadyIPCount int) *corev1.Endpoints {
 	ep := &corev1.Endpoints{
 		ObjectMeta: metav1.ObjectMeta{
@@ -154,3 +203,27 @@ func endpoints(readyIPCount, notReadyIPCount int) *corev1.Endpoints {
 	}}
 	return ep
 }
+
+func endpointSlice(name, serviceName string, readyIPCount, notReadyIPCount int) *discoveryv1.EndpointSlice {
+	slice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      name,
+			Labels:    map[string]string{discoveryv1.LabelServiceName: serviceName},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+	}
+	for i := 0; i < readyIPCount; i++ {
+		slice.Endpoints = append(slice.Endpoints, discoveryv1.Endpoint{
+			Addresses:  []string{fmt.Sprint("127.0.0.", i*3+1)},
+			Conditions: discoveryv1.EndpointConditions{Ready: ptr.Bool(true)},
+		})
+	}
+	for i := 0; i < notReadyIPCount; i++ {
+		slice.Endpoints = append(slice.Endpoints, discoveryv1.Endpoint{
+			Addresses:  []string{fmt.Sprint("127.0.0.", i*3+2)},
+			Conditions: discoveryv1.EndpointConditions{Ready: ptr.Bool(false)},
+		})
+	}
+	return slice
+}
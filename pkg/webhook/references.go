@@ -0,0 +1,234 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+
+	"knative.dev/pkg/apis"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	"knative.dev/serving/pkg/apis/serving"
+)
+
+// The kinds of the objects a pod spec references.
+const (
+	configMapKind      = "ConfigMap"
+	secretKind         = "Secret"
+	serviceAccountKind = "ServiceAccount"
+)
+
+// Asserts that CheckReferences implements serving.ReferencesChecker.
+var _ serving.ReferencesChecker = CheckReferences
+
+// CheckReferences checks that the ConfigMaps, Secrets, keys and
+// ServiceAccounts the pod spec references exist in the namespace, with the
+// kube client of the context. The optional references aren't required to
+// exist.
+func CheckReferences(ctx context.Context, namespace string, ps *corev1.PodSpec) *apis.FieldError {
+	c := &referencesChecker{
+		ctx:       ctx,
+		client:    kubeclient.Get(ctx),
+		namespace: namespace,
+		objects:   make(map[string]referencedObject),
+	}
+
+	var errs *apis.FieldError
+	if ps.ServiceAccountName != "" {
+		_, err := c.checkObject(serviceAccountKind, ps.ServiceAccountName, nil)
+		errs = errs.Also(err.ViaField("serviceAccountName"))
+	}
+	for i, s := range ps.ImagePullSecrets {
+		_, err := c.checkObject(secretKind, s.Name, nil)
+		errs = errs.Also(err.ViaField("name").ViaFieldIndex("imagePullSecrets", i))
+	}
+	for i := range ps.Volumes {
+		errs = errs.Also(c.checkVolume(&ps.Volumes[i].VolumeSource).ViaFieldIndex("volumes", i))
+	}
+	for i := range ps.InitContainers {
+		errs = errs.Also(c.checkContainer(&ps.InitContainers[i]).ViaFieldIndex("initContainers", i))
+	}
+	for i := range ps.Containers {
+		errs = errs.Also(c.checkContainer(&ps.Containers[i]).ViaFieldIndex("containers", i))
+	}
+	return errs
+}
+
+// referencedObject holds the keys of a referenced object, or the error getting
+// it.
+type referencedObject struct {
+	keys sets.String
+	err  error
+}
+
+// referencesChecker gets each referenced object once.
+type referencesChecker struct {
+	ctx       context.Context
+	client    kubernetes.Interface
+	namespace string
+	objects   map[string]referencedObject
+}
+
+func (c *referencesChecker) get(kind, name string) referencedObject {
+	key := kind + "/" + name
+	if o, ok := c.objects[key]; ok {
+		return o
+	}
+
+	var o referencedObject
+	switch kind {
+	case configMapKind:
+		cm, err := c.client.CoreV1().ConfigMaps(c.namespace).Get(c.ctx, name, metav1.GetOptions{})
+		if err == nil {
+			o.keys = sets.StringKeySet(cm.Data).Union(sets.StringKeySet(cm.BinaryData))
+		}
+		o.err = err
+	case secretKind:
+		s, err := c.client.CoreV1().Secrets(c.namespace).Get(c.ctx, name, metav1.GetOptions{})
+		if err == nil {
+			o.keys = sets.StringKeySet(s.Data)
+		}
+		o.err = err
+	case serviceAccountKind:
+		_, err := c.client.CoreV1().ServiceAccounts(c.namespace).Get(c.ctx, name, metav1.GetOptions{})
+		o.keys, o.err = sets.NewString(), err
+	}
+	c.objects[key] = o
+	return o
+}
+
+// checkObject returns the keys of the object, or nil and the error about it if
+// it can't be read. The optional objects can be missing.
+func (c *referencesChecker) checkObject(kind, name string, optional *bool) (sets.String, *apis.FieldError) {
+	o := c.get(kind, name)
+	switch {
+	case o.err == nil:
+		return o.keys, nil
+	case apierrs.IsNotFound(o.err):
+		if optional != nil && *optional {
+			return nil, nil
+		}
+		return nil, &apis.FieldError{
+			Message: fmt.Sprintf("%s %q not found in namespace %q", kind, name, c.namespace),
+			Paths:   []string{apis.CurrentField},
+		}
+	default:
+		return nil, &apis.FieldError{
+			Message: fmt.Sprintf("%s %q could not be read", kind, name),
+			Paths:   []string{apis.CurrentField},
+			Details: o.err.Error(),
+		}
+	}
+}
+
+// checkKeys checks that the object the keys are read from has them, unless
+// they're optional.
+func checkKeys(kind, name string, keys sets.String, items []corev1.KeyToPath, optional *bool) *apis.FieldError {
+	if keys == nil || (optional != nil && *optional) {
+		return nil
+	}
+	var errs *apis.FieldError
+	for i, item := range items {
+		if !keys.Has(item.Key) {
+			errs = errs.Also(missingKey(kind, name, item.Key).ViaField("key").ViaFieldIndex("items", i))
+		}
+	}
+	return errs
+}
+
+func missingKey(kind, name, key string) *apis.FieldError {
+	return &apis.FieldError{
+		Message: fmt.Sprintf("key %q not found in %s %q", key, kind, name),
+		Paths:   []string{apis.CurrentField},
+	}
+}
+
+func (c *referencesChecker) checkVolume(v *corev1.VolumeSource) *apis.FieldError {
+	var errs *apis.FieldError
+	if cm := v.ConfigMap; cm != nil {
+		keys, err := c.checkObject(configMapKind, cm.Name, cm.Optional)
+		errs = errs.Also(err.ViaField("name").
+			Also(checkKeys(configMapKind, cm.Name, keys, cm.Items, cm.Optional)).ViaField("configMap"))
+	}
+	if s := v.Secret; s != nil {
+		keys, err := c.checkObject(secretKind, s.SecretName, s.Optional)
+		errs = errs.Also(err.ViaField("secretName").
+			Also(checkKeys(secretKind, s.SecretName, keys, s.Items, s.Optional)).ViaField("secret"))
+	}
+	if p := v.Projected; p != nil {
+		for i, source := range p.Sources {
+			var serr *apis.FieldError
+			if cm := source.ConfigMap; cm != nil {
+				keys, err := c.checkObject(configMapKind, cm.Name, cm.Optional)
+				serr = serr.Also(err.ViaField("name").
+					Also(checkKeys(configMapKind, cm.Name, keys, cm.Items, cm.Optional)).ViaField("configMap"))
+			}
+			if s := source.Secret; s != nil {
+				keys, err := c.checkObject(secretKind, s.Name, s.Optional)
+				serr = serr.Also(err.ViaField("name").
+					Also(checkKeys(secretKind, s.Name, keys, s.Items, s.Optional)).ViaField("secret"))
+			}
+			errs = errs.Also(serr.ViaFieldIndex("sources", i).ViaField("projected"))
+		}
+	}
+	return errs
+}
+
+func (c *referencesChecker) checkContainer(container *corev1.Container) *apis.FieldError {
+	var errs *apis.FieldError
+	for i, env := range container.EnvFrom {
+		if ref := env.ConfigMapRef; ref != nil {
+			_, err := c.checkObject(configMapKind, ref.Name, ref.Optional)
+			errs = errs.Also(err.ViaField("name").ViaField("configMapRef").ViaFieldIndex("envFrom", i))
+		}
+		if ref := env.SecretRef; ref != nil {
+			_, err := c.checkObject(secretKind, ref.Name, ref.Optional)
+			errs = errs.Also(err.ViaField("name").ViaField("secretRef").ViaFieldIndex("envFrom", i))
+		}
+	}
+	for i, env := range container.Env {
+		if env.ValueFrom == nil {
+			continue
+		}
+		if ref := env.ValueFrom.ConfigMapKeyRef; ref != nil {
+			errs = errs.Also(c.checkKeyRef(configMapKind, ref.Name, ref.Key, ref.Optional).
+				ViaField("configMapKeyRef").ViaField("valueFrom").ViaFieldIndex("env", i))
+		}
+		if ref := env.ValueFrom.SecretKeyRef; ref != nil {
+			errs = errs.Also(c.checkKeyRef(secretKind, ref.Name, ref.Key, ref.Optional).
+				ViaField("secretKeyRef").ViaField("valueFrom").ViaFieldIndex("env", i))
+		}
+	}
+	return errs
+}
+
+func (c *referencesChecker) checkKeyRef(kind, name, key string, optional *bool) *apis.FieldError {
+	keys, err := c.checkObject(kind, name, optional)
+	if err != nil {
+		return err.ViaField("name")
+	}
+	if keys == nil || keys.Has(key) || (optional != nil && *optional) {
+		return nil
+	}
+	return missingKey(kind, name, key).ViaField("key")
+}
@@ -0,0 +1,274 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ktesting "k8s.io/client-go/testing"
+
+	"knative.dev/pkg/apis"
+	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
+	"knative.dev/pkg/ptr"
+)
+
+func TestCheckReferences(t *testing.T) {
+	objects := []runtime.Object{
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "settings"},
+			Data:       map[string]string{"log-level": "debug"},
+			BinaryData: map[string][]byte{"logo.png": nil},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "creds"},
+			Data:       map[string][]byte{"password": []byte("hunter2")},
+		},
+		&corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "app"},
+		},
+	}
+
+	tests := []struct {
+		name string
+		ps   corev1.PodSpec
+		want *apis.FieldError
+	}{{
+		name: "existing references",
+		ps: corev1.PodSpec{
+			ServiceAccountName: "app",
+			ImagePullSecrets:   []corev1.LocalObjectReference{{Name: "creds"}},
+			Volumes: []corev1.Volume{{
+				Name: "settings",
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "settings"},
+						Items:                []corev1.KeyToPath{{Key: "logo.png", Path: "logo.png"}},
+					},
+				},
+			}, {
+				Name: "creds",
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{SecretName: "creds"},
+				},
+			}},
+			Containers: []corev1.Container{{
+				EnvFrom: []corev1.EnvFromSource{{
+					ConfigMapRef: &corev1.ConfigMapEnvSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "settings"},
+					},
+				}},
+				Env: []corev1.EnvVar{{
+					Name: "PASSWORD",
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "creds"},
+							Key:                  "password",
+						},
+					},
+				}},
+			}},
+		},
+	}, {
+		name: "missing optional references",
+		ps: corev1.PodSpec{
+			Volumes: []corev1.Volume{{
+				Name: "extra",
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{SecretName: "extra", Optional: ptr.Bool(true)},
+				},
+			}},
+			Containers: []corev1.Container{{
+				Env: []corev1.EnvVar{{
+					Name: "LOG_FORMAT",
+					ValueFrom: &corev1.EnvVarSource{
+						ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "settings"},
+							Key:                  "log-format",
+							Optional:             ptr.Bool(true),
+						},
+					},
+				}},
+			}},
+		},
+	}, {
+		name: "missing service account and image pull secret",
+		ps: corev1.PodSpec{
+			ServiceAccountName: "ap",
+			ImagePullSecrets:   []corev1.LocalObjectReference{{Name: "creds"}, {Name: "registry"}},
+		},
+		want: (&apis.FieldError{
+			Message: `ServiceAccount "ap" not found in namespace "foo"`,
+			Paths:   []string{"serviceAccountName"},
+		}).Also(&apis.FieldError{
+			Message: `Secret "registry" not found in namespace "foo"`,
+			Paths:   []string{"imagePullSecrets[1].name"},
+		}),
+	}, {
+		name: "missing volumes and keys",
+		ps: corev1.PodSpec{
+			Volumes: []corev1.Volume{{
+				Name: "settings",
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "settings"},
+						Items:                []corev1.KeyToPath{{Key: "log-format", Path: "format"}},
+					},
+				},
+			}, {
+				Name: "certs",
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{SecretName: "certs"},
+				},
+			}, {
+				Name: "all",
+				VolumeSource: corev1.VolumeSource{
+					Projected: &corev1.ProjectedVolumeSource{
+						Sources: []corev1.VolumeProjection{{
+							ConfigMap: &corev1.ConfigMapProjection{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "settings"},
+							},
+						}, {
+							Secret: &corev1.SecretProjection{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "creds"},
+								Items:                []corev1.KeyToPath{{Key: "token", Path: "token"}},
+							},
+						}},
+					},
+				},
+			}},
+		},
+		want: (&apis.FieldError{
+			Message: `key "log-format" not found in ConfigMap "settings"`,
+			Paths:   []string{"volumes[0].configMap.items[0].key"},
+		}).Also(&apis.FieldError{
+			Message: `Secret "certs" not found in namespace "foo"`,
+			Paths:   []string{"volumes[1].secret.secretName"},
+		}).Also(&apis.FieldError{
+			Message: `key "token" not found in Secret "creds"`,
+			Paths:   []string{"volumes[2].projected.sources[1].secret.items[0].key"},
+		}),
+	}, {
+		name: "missing env sources and keys",
+		ps: corev1.PodSpec{
+			InitContainers: []corev1.Container{{
+				EnvFrom: []corev1.EnvFromSource{{
+					SecretRef: &corev1.SecretEnvSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "setup"},
+					},
+				}},
+			}},
+			Containers: []corev1.Container{{
+				EnvFrom: []corev1.EnvFromSource{{
+					ConfigMapRef: &corev1.ConfigMapEnvSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "setings"},
+					},
+				}},
+				Env: []corev1.EnvVar{{
+					Name:  "USER",
+					Value: "admin",
+				}, {
+					Name: "PASSWORD",
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "creds"},
+							Key:                  "pasword",
+						},
+					},
+				}, {
+					Name: "LOG_LEVEL",
+					ValueFrom: &corev1.EnvVarSource{
+						ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "logging"},
+							Key:                  "log-level",
+						},
+					},
+				}},
+			}},
+		},
+		want: (&apis.FieldError{
+			Message: `Secret "setup" not found in namespace "foo"`,
+			Paths:   []string{"initContainers[0].envFrom[0].secretRef.name"},
+		}).Also(&apis.FieldError{
+			Message: `ConfigMap "setings" not found in namespace "foo"`,
+			Paths:   []string{"containers[0].envFrom[0].configMapRef.name"},
+		}).Also(&apis.FieldError{
+			Message: `key "pasword" not found in Secret "creds"`,
+			Paths:   []string{"containers[0].env[1].valueFrom.secretKeyRef.key"},
+		}).Also(&apis.FieldError{
+			Message: `ConfigMap "logging" not found in namespace "foo"`,
+			Paths:   []string{"containers[0].env[2].valueFrom.configMapKeyRef.name"},
+		}),
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := fakekubeclient.With(context.Background(), objects...)
+			got := CheckReferences(ctx, "foo", &tt.ps)
+			if got.Error() != tt.want.Error() {
+				t.Error("CheckReferences (-want, +got):", cmp.Diff(tt.want.Error(), got.Error()))
+			}
+		})
+	}
+}
+
+func TestCheckReferencesUnreadable(t *testing.T) {
+	ctx, client := fakekubeclient.With(context.Background())
+	gets := 0
+	client.PrependReactor("get", "secrets", func(ktesting.Action) (bool, runtime.Object, error) {
+		gets++
+		return true, nil, apierrs.NewForbidden(schema.GroupResource{Resource: "secrets"}, "creds", nil)
+	})
+
+	ps := &corev1.PodSpec{
+		ImagePullSecrets: []corev1.LocalObjectReference{{Name: "creds"}},
+		Containers: []corev1.Container{{
+			EnvFrom: []corev1.EnvFromSource{{
+				SecretRef: &corev1.SecretEnvSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "creds"},
+					Optional:             ptr.Bool(true),
+				},
+			}},
+		}},
+	}
+	got := CheckReferences(ctx, "foo", ps)
+	want := (&apis.FieldError{
+		Message: `Secret "creds" could not be read`,
+		Paths:   []string{"imagePullSecrets[0].name"},
+	}).Also(&apis.FieldError{
+		Message: `Secret "creds" could not be read`,
+		Paths:   []string{"containers[0].envFrom[0].secretRef.name"},
+	})
+	// The details hold the error of the API server.
+	if got.Filter(apis.ErrorLevel) == nil || len(got.WrappedErrors()) != len(want.WrappedErrors()) {
+		t.Fatalf("CheckReferences() = %v, want: %v", got, want)
+	}
+	for i, err := range got.WrappedErrors() {
+		if w := want.WrappedErrors()[i]; err.Message != w.Message || !cmp.Equal(err.Paths, w.Paths) {
+			t.Errorf("CheckReferences()[%d] = %v, want: %v", i, err, w)
+		}
+	}
+	if gets != 1 {
+		t.Errorf("Secret gets = %d, want: 1", gets)
+	}
+}